@@ -0,0 +1,103 @@
+package gts
+
+// locationSegments returns the minimized segments of the bases covered by the
+// location, excluding the empty segments of locations in between bases.
+func locationSegments(loc Location) []Segment {
+	ss := Minimize(loc.Region())
+	ret := ss[:0]
+	for _, s := range ss {
+		if s[0] < s[1] {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+func intersectSegments(a, b []Segment) []Segment {
+	ret := []Segment{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		head, tail := Max(a[i][0], b[j][0]), Min(a[i][1], b[j][1])
+		if head < tail {
+			ret = append(ret, Segment{head, tail})
+		}
+		if a[i][1] < b[j][1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return ret
+}
+
+func segmentsLen(ss []Segment) int {
+	n := 0
+	for _, s := range ss {
+		n += s.Len()
+	}
+	return n
+}
+
+// segmentsLocation returns the location covering the given segments, which
+// is complemented if reverse is true. A nil location is returned if there are
+// no segments.
+func segmentsLocation(ss []Segment, reverse bool) Location {
+	if len(ss) == 0 {
+		return nil
+	}
+	locs := make([]Location, len(ss))
+	for i, s := range ss {
+		locs[i] = Range(s[0], s[1])
+	}
+	loc := Join(locs...)
+	if reverse {
+		return loc.Complement()
+	}
+	return loc
+}
+
+// bothReverse reports whether both of the locations lie on the reverse
+// strand.
+func bothReverse(a, b Location) bool {
+	return a.Strand() == StrandReverse && b.Strand() == StrandReverse
+}
+
+// Overlaps tests if the locations share at least one base. The locations may
+// be composed of any number of joins and complements, and the strands of the
+// locations are not taken into account.
+func Overlaps(a, b Location) bool {
+	return len(intersectSegments(locationSegments(a), locationSegments(b))) > 0
+}
+
+// Contains tests if all of the bases of location b are also bases of location
+// a. The strands of the locations are not taken into account.
+func Contains(a, b Location) bool {
+	ss := locationSegments(b)
+	if len(ss) == 0 {
+		return false
+	}
+	return segmentsLen(intersectSegments(locationSegments(a), ss)) == segmentsLen(ss)
+}
+
+// Intersect returns the location of the bases shared by both of the
+// locations, or nil if there are none. The location is a Ranged if the bases
+// are contiguous and a Joined of Ranged locations otherwise, complemented if
+// both of the locations lie on the reverse strand. The partiality of the
+// locations is not retained.
+func Intersect(a, b Location) Location {
+	ss := intersectSegments(locationSegments(a), locationSegments(b))
+	return segmentsLocation(ss, bothReverse(a, b))
+}
+
+// Union returns the location of the bases of either of the locations, or nil
+// if there are none. The location is a Ranged if the bases are contiguous and
+// a Joined of Ranged locations otherwise, complemented if both of the
+// locations lie on the reverse strand. The partiality of the locations is not
+// retained.
+func Union(a, b Location) Location {
+	rr := Regions{}
+	for _, s := range append(locationSegments(a), locationSegments(b)...) {
+		rr = append(rr, s)
+	}
+	return segmentsLocation(Minimize(rr), bothReverse(a, b))
+}
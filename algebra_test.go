@@ -0,0 +1,73 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var locationAlgebraTests = []struct {
+	a, b      Location
+	overlaps  bool
+	contains  bool
+	intersect Location
+	union     Location
+}{
+	{
+		Range(0, 10), Range(5, 15),
+		true, false,
+		Range(5, 10), Range(0, 15),
+	},
+	{
+		Range(0, 10), Range(10, 20),
+		false, false,
+		nil, Range(0, 20),
+	},
+	{
+		Range(0, 20), Join(Range(2, 5), Range(8, 12)),
+		true, true,
+		Join(Range(2, 5), Range(8, 12)), Range(0, 20),
+	},
+	{
+		Join(Range(0, 5), Range(10, 15)), Range(3, 12),
+		true, false,
+		Join(Range(3, 5), Range(10, 12)), Range(0, 15),
+	},
+	{
+		Range(0, 10).Complement(), Range(5, 15).Complement(),
+		true, false,
+		Range(5, 10).Complement(), Range(0, 15).Complement(),
+	},
+	{
+		Range(0, 10).Complement(), Range(5, 15),
+		true, false,
+		Range(5, 10), Range(0, 15),
+	},
+	{
+		Join(Range(90, 100), Range(0, 10)), Point(5),
+		true, true,
+		Range(5, 6), Join(Range(0, 10), Range(90, 100)),
+	},
+	{
+		Range(0, 10), Between(5),
+		false, false,
+		nil, Range(0, 10),
+	},
+	{
+		Between(5), Between(5),
+		false, false,
+		nil, nil,
+	},
+}
+
+func TestLocationAlgebra(t *testing.T) {
+	for _, tt := range locationAlgebraTests {
+		testutils.Equals(t, Overlaps(tt.a, tt.b), tt.overlaps)
+		testutils.Equals(t, Overlaps(tt.b, tt.a), tt.overlaps)
+		testutils.Equals(t, Contains(tt.a, tt.b), tt.contains)
+		testutils.Equals(t, Intersect(tt.a, tt.b), tt.intersect)
+		testutils.Equals(t, Intersect(tt.b, tt.a), tt.intersect)
+		testutils.Equals(t, Union(tt.a, tt.b), tt.union)
+		testutils.Equals(t, Union(tt.b, tt.a), tt.union)
+	}
+}
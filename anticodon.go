@@ -0,0 +1,170 @@
+package gts
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Anticodon represents the value of an `/anticodon` qualifier, which
+// describes the location of the anticodon of a tRNA feature along with the
+// amino acid it carries and the anticodon sequence.
+type Anticodon struct {
+	Location  Location
+	AminoAcid string
+	Seq       string
+}
+
+// AnticodonAminoAcids lists the amino acid abbreviations allowed in an
+// `/anticodon` qualifier.
+var AnticodonAminoAcids = []string{
+	"Ala", "Arg", "Asn", "Asp", "Cys", "Gln", "Glu", "Gly", "His", "Ile",
+	"Leu", "Lys", "Met", "Phe", "Pro", "Ser", "Thr", "Trp", "Tyr", "Val",
+	"Sec", "Pyl", "fMet", "TERM", "OTHER",
+}
+
+// AsAnticodon interprets the given string as an Anticodon. The string should
+// be formatted as `(pos:<location>,aa:<amino_acid>,seq:<text>)` where the
+// `seq` field may be omitted.
+func AsAnticodon(s string) (Anticodon, error) {
+	v := strings.TrimSpace(s)
+	if !strings.HasPrefix(v, "(pos:") || !strings.HasSuffix(v, ")") {
+		return Anticodon{}, fmt.Errorf("cannot interpret %q as an anticodon: expected `(pos:<location>,aa:<amino_acid>,seq:<text>)`", s)
+	}
+	v = v[5 : len(v)-1]
+
+	i := strings.LastIndex(v, ",aa:")
+	if i < 0 {
+		return Anticodon{}, fmt.Errorf("cannot interpret %q as an anticodon: missing `aa` field", s)
+	}
+
+	loc, err := AsLocation(v[:i])
+	if err != nil {
+		return Anticodon{}, fmt.Errorf("cannot interpret %q as an anticodon: %v", s, err)
+	}
+
+	aa, seq := v[i+4:], ""
+	if j := strings.Index(aa, ",seq:"); j >= 0 {
+		aa, seq = aa[:j], aa[j+5:]
+	}
+
+	return Anticodon{loc, aa, seq}, nil
+}
+
+// String satisfies the fmt.Stringer interface.
+func (a Anticodon) String() string {
+	if a.Seq == "" {
+		return fmt.Sprintf("(pos:%s,aa:%s)", a.Location, a.AminoAcid)
+	}
+	return fmt.Sprintf("(pos:%s,aa:%s,seq:%s)", a.Location, a.AminoAcid, a.Seq)
+}
+
+func anticodonBytes(p []byte) []byte {
+	return bytes.ReplaceAll(bytes.ToLower(p), []byte("u"), []byte("t"))
+}
+
+// Check the anticodon against the given sequence. The anticodon must be three
+// bases long, be located within the sequence, have a known amino acid, and
+// have a sequence matching the bases at its location.
+func (a Anticodon) Check(seq Sequence) error {
+	if a.Location.Len() != 3 {
+		return fmt.Errorf("anticodon %s is %d bases long", a.Location, a.Location.Len())
+	}
+
+	if HasRemote(a.Location.Region()) {
+		return fmt.Errorf("anticodon %s refers to a remote entry", a.Location)
+	}
+
+	if !LocationWithin(a.Location, 0, Len(seq)) {
+		return fmt.Errorf("anticodon %s is out of bounds for sequence of length %d", a.Location, Len(seq))
+	}
+
+	known := false
+	for _, aa := range AnticodonAminoAcids {
+		if aa == a.AminoAcid {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown anticodon amino acid %q", a.AminoAcid)
+	}
+
+	if a.Seq != "" {
+		p := anticodonBytes(a.Location.Region().Locate(seq).Bytes())
+		if q := anticodonBytes([]byte(a.Seq)); !bytes.Equal(p, q) {
+			return fmt.Errorf("anticodon %s has sequence %s but the sequence is %s", a.Location, a.Seq, p)
+		}
+	}
+
+	return nil
+}
+
+// Regenerate returns the anticodon with the sequence recomputed from the
+// bases at its location in the given sequence.
+func (a Anticodon) Regenerate(seq Sequence) (Anticodon, error) {
+	if a.Location.Len() != 3 || HasRemote(a.Location.Region()) || !LocationWithin(a.Location, 0, Len(seq)) {
+		return a, fmt.Errorf("anticodon %s cannot be located in sequence of length %d", a.Location, Len(seq))
+	}
+	p := anticodonBytes(a.Location.Region().Locate(seq).Bytes())
+	a.Seq = string(p)
+	return a, nil
+}
+
+// CheckAnticodons checks the `/anticodon` qualifiers of the feature against
+// the given sequence. In addition to the checks performed by Anticodon.Check,
+// the anticodon must be contained in the feature.
+func CheckAnticodons(f Feature, seq Sequence) error {
+	for _, value := range f.Props.Get("anticodon") {
+		a, err := AsAnticodon(value)
+		if err != nil {
+			return err
+		}
+		if err := a.Check(seq); err != nil {
+			return err
+		}
+		if _, _, ok := splicedRange(f.Loc, a.Location); !ok {
+			return fmt.Errorf("anticodon %s is not within %s %s", a.Location, f.Key, f.Loc)
+		}
+	}
+	return nil
+}
+
+// RegenerateAnticodons returns the feature with the sequences of its
+// `/anticodon` qualifiers recomputed from the given sequence.
+func RegenerateAnticodons(f Feature, seq Sequence) (Feature, error) {
+	values := f.Props.Get("anticodon")
+	if len(values) == 0 {
+		return f, nil
+	}
+
+	regenerated := make([]string, len(values))
+	for i, value := range values {
+		a, err := AsAnticodon(value)
+		if err != nil {
+			return f, err
+		}
+		if a, err = a.Regenerate(seq); err != nil {
+			return f, err
+		}
+		regenerated[i] = a.String()
+	}
+
+	f.Props = f.Props.Clone()
+	f.Props.Set("anticodon", regenerated...)
+	return f, nil
+}
+
+// LocationQualifiers maps the names of qualifiers whose values contain a
+// location to functions which apply a location transformation to the value.
+// The locations in these qualifiers are updated along with the location of
+// the feature when a sequence is edited.
+var LocationQualifiers = map[string]func(value string, fn func(Location) Location) (string, error){
+	"anticodon": func(value string, fn func(Location) Location) (string, error) {
+		a, err := AsAnticodon(value)
+		if err != nil {
+			return value, err
+		}
+		a.Location = fn(a.Location)
+		return a.String(), nil
+	},
+}
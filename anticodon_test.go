@@ -0,0 +1,133 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var anticodonTests = []struct {
+	in  string
+	out Anticodon
+}{
+	{"(pos:34..36,aa:Phe,seq:gaa)", Anticodon{Range(33, 36), "Phe", "gaa"}},
+	{"(pos:complement(4156..4158),aa:Gln,seq:ttg)", Anticodon{Range(4155, 4158).Complement(), "Gln", "ttg"}},
+	{"(pos:join(5..6,9),aa:Leu,seq:caa)", Anticodon{Join(Range(4, 6), Point(8)), "Leu", "caa"}},
+	{"(pos:34..36,aa:Phe)", Anticodon{Range(33, 36), "Phe", ""}},
+}
+
+func TestAnticodon(t *testing.T) {
+	for _, tt := range anticodonTests {
+		out, err := AsAnticodon(tt.in)
+		if err != nil {
+			t.Errorf("AsAnticodon(%q): %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, out, tt.out)
+		testutils.Equals(t, out.String(), tt.in)
+	}
+
+	for _, in := range []string{"", "pos:34..36,aa:Phe", "(pos:34..36)", "(pos:foo,aa:Phe)"} {
+		if _, err := AsAnticodon(in); err == nil {
+			t.Errorf("AsAnticodon(%q) expected error", in)
+		}
+	}
+}
+
+func TestAnticodonCheck(t *testing.T) {
+	seq := New(nil, nil, []byte("aaaagaattcaaaa"))
+
+	tests := []struct {
+		in Anticodon
+		ok bool
+	}{
+		{Anticodon{Range(4, 7), "Phe", "gaa"}, true},
+		{Anticodon{Range(4, 7), "Phe", "GAA"}, true},
+		{Anticodon{Range(7, 10), "Glu", "ttc"}, true},
+		{Anticodon{Range(4, 7).Complement(), "Phe", "ttc"}, true},
+		{Anticodon{Range(4, 7), "Phe", ""}, true},
+		{Anticodon{Range(4, 8), "Phe", "gaat"}, false},
+		{Anticodon{Range(12, 15), "Phe", "aaa"}, false},
+		{Anticodon{Range(4, 7), "Foo", "gaa"}, false},
+		{Anticodon{Range(4, 7), "Phe", "gaU"}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.in.Check(seq)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s.Check() = %v", tt.in, err)
+		}
+	}
+
+	a, err := Anticodon{Range(7, 10), "Glu", "uuc"}.Regenerate(seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, a.Seq, "ttc")
+
+	if _, err := (Anticodon{Range(12, 15), "Glu", ""}).Regenerate(seq); err == nil {
+		t.Errorf("Regenerate() expected error")
+	}
+}
+
+func TestFeatureAnticodons(t *testing.T) {
+	seq := New(nil, nil, []byte("aaaagaattcaaaa"))
+	f := NewFeature("tRNA", Range(2, 12), Props{[]string{"anticodon", "(pos:5..7,aa:Phe,seq:ttt)"}})
+
+	if err := CheckAnticodons(f, seq); err == nil {
+		t.Errorf("CheckAnticodons() expected error")
+	}
+
+	g, err := RegenerateAnticodons(f, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, g.Props.Get("anticodon"), []string{"(pos:5..7,aa:Phe,seq:gaa)"})
+	testutils.Equals(t, f.Props.Get("anticodon"), []string{"(pos:5..7,aa:Phe,seq:ttt)"})
+
+	if err := CheckAnticodons(g, seq); err != nil {
+		t.Errorf("CheckAnticodons(): %v", err)
+	}
+
+	h := NewFeature("tRNA", Range(8, 12), g.Props)
+	if err := CheckAnticodons(h, seq); err == nil {
+		t.Errorf("CheckAnticodons() expected error for anticodon outside of feature")
+	}
+}
+
+func TestAnticodonEdits(t *testing.T) {
+	f := NewFeature("tRNA", Range(2, 12), Props{
+		[]string{"product", "tRNA-Phe"},
+		[]string{"anticodon", "(pos:5..7,aa:Phe,seq:gaa)"},
+	})
+	seq := New(nil, []Feature{f}, []byte("aaaagaattcaaaa"))
+
+	anticodon := func(seq Sequence) string {
+		return seq.Features()[0].Props.Get("anticodon")[0]
+	}
+
+	tests := []struct {
+		seq Sequence
+		out string
+		ok  bool
+	}{
+		{Insert(seq, 0, New(nil, nil, []byte("cc"))), "(pos:7..9,aa:Phe,seq:gaa)", true},
+		{Embed(seq, 1, New(nil, nil, []byte("cc"))), "(pos:7..9,aa:Phe,seq:gaa)", true},
+		{Delete(seq, 0, 2), "(pos:3..5,aa:Phe,seq:gaa)", true},
+		{Slice(seq, 2, 12), "(pos:3..5,aa:Phe,seq:gaa)", true},
+		{Concat(New(nil, nil, []byte("cc")), seq), "(pos:7..9,aa:Phe,seq:gaa)", true},
+		{Rotate(seq, 3), "(pos:8..10,aa:Phe,seq:gaa)", true},
+		{Reverse(seq), "(pos:8..10,aa:Phe,seq:gaa)", false},
+		{Reverse(Complement(seq)), "(pos:complement(8..10),aa:Phe,seq:gaa)", true},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, anticodon(tt.seq), tt.out)
+		err := CheckAnticodons(tt.seq.Features()[0], tt.seq)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckAnticodons(%s) = %v", tt.out, err)
+		}
+	}
+
+	testutils.Equals(t, anticodon(seq), "(pos:5..7,aa:Phe,seq:gaa)")
+}
@@ -0,0 +1,47 @@
+package gts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func canonicalFeature(f Feature) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "%s\t%s\n", f.Key, f.Loc)
+	for _, item := range f.Props.SortedFor(f.Key).Items() {
+		fmt.Fprintf(&b, "\t%s=%s\n", item.Key, item.Value)
+	}
+	return b.String()
+}
+
+// Canonical returns the canonical representation of the features and the
+// sequence of the given Sequence. Features are ordered independently of the
+// order in the feature table, qualifiers are ordered as in Props.SortedFor,
+// and the sequence is lowercased, so that any two equivalent sequences will
+// have an identical canonical representation. The metadata of the sequence is not included.
+func Canonical(seq Sequence) []byte {
+	ss := make([]string, len(seq.Features()))
+	for i, f := range seq.Features() {
+		ss[i] = canonicalFeature(f)
+	}
+	sort.Strings(ss)
+
+	b := bytes.Buffer{}
+	for _, s := range ss {
+		b.WriteString(s)
+	}
+	b.WriteString("//\n")
+	b.Write(bytes.ToLower(seq.Bytes()))
+	return b.Bytes()
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of the canonical
+// representation of the given Sequence.
+func Checksum(seq Sequence) string {
+	sum := sha256.Sum256(Canonical(seq))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,31 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestChecksum(t *testing.T) {
+	props := Props{}
+	props.Add("note", "foo")
+	props.Add("gene", "bar")
+	ff := []Feature{
+		{"source", Range(0, 6), Props{}},
+		{"gene", Range(0, 3), props},
+		{"CDS", Range(0, 3), Props{}},
+	}
+	seq := New(nil, ff, []byte("atgatg"))
+
+	testutils.Equals(t, string(Canonical(seq)), "CDS\t1..3\ngene\t1..3\n\tgene=bar\n\tnote=foo\nsource\t1..6\n//\natgatg")
+
+	reordered := New("info", []Feature{ff[2], ff[0], {"gene", Range(0, 3), props.Sorted()}}, []byte("ATGATG"))
+	testutils.Equals(t, Checksum(reordered), Checksum(seq))
+
+	modified := New(nil, ff, []byte("atgatc"))
+	if Checksum(modified) == Checksum(seq) {
+		t.Error("expected checksums to differ for different sequences")
+	}
+
+	testutils.Equals(t, len(Checksum(seq)), 64)
+}
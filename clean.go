@@ -0,0 +1,116 @@
+package gts
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Upper returns a Sequence object with the byte representation in upper case.
+func Upper(seq Sequence) Sequence {
+	orig := seq
+	seq = WithBytes(seq, bytes.ToUpper(seq.Bytes()))
+	return mapQualityScores(seq, orig, func(q []byte) []byte {
+		return q
+	})
+}
+
+// Lower returns a Sequence object with the byte representation in lower case.
+func Lower(seq Sequence) Sequence {
+	orig := seq
+	seq = WithBytes(seq, bytes.ToLower(seq.Bytes()))
+	return mapQualityScores(seq, orig, func(q []byte) []byte {
+		return q
+	})
+}
+
+// IsGapChar tests if the character represents a gap in an alignment, which is
+// either `-` or `.`.
+func IsGapChar(c byte) bool {
+	return c == '-' || c == '.'
+}
+
+// RemoveGaps returns a Sequence object with the gap characters removed, along
+// with the number of removed characters. The features are shifted and
+// truncated as with the Delete function.
+func RemoveGaps(seq Sequence) (Sequence, int) {
+	p := seq.Bytes()
+	n := 0
+	for end := len(p); end > 0; {
+		if !IsGapChar(p[end-1]) {
+			end--
+			continue
+		}
+		start := end - 1
+		for start > 0 && IsGapChar(p[start-1]) {
+			start--
+		}
+		seq = Delete(seq, start, end-start)
+		n += end - start
+		end = start
+	}
+	return seq, n
+}
+
+const (
+	iupacNucleotides = "ACGTURYSWKMBDHVN"
+	iupacAminoAcids  = "ACDEFGHIKLMNPQRSTVWYBZJUOX*"
+)
+
+// IsIUPAC tests if the character is one of the IUPAC codes for the given
+// molecule type, ignoring case. Gap characters are considered valid.
+func IsIUPAC(c byte, mol Molecule) bool {
+	if IsGapChar(c) {
+		return true
+	}
+	codes := iupacNucleotides
+	if mol == AA {
+		codes = iupacAminoAcids
+	}
+	return strings.IndexByte(codes, toUpper(c)) >= 0
+}
+
+func toUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// ReplaceInvalid returns a Sequence object with the characters which are not
+// IUPAC codes for the given molecule type replaced with `N` for nucleotides
+// or `X` for amino acids, along with the positions of the replaced
+// characters. The replacement is in lower case if the preceding character is.
+func ReplaceInvalid(seq Sequence, mol Molecule) (Sequence, []int) {
+	r := byte('N')
+	if mol == AA {
+		r = 'X'
+	}
+
+	p := seq.Bytes()
+	var q []byte
+	var indices []int
+	for i, c := range p {
+		if IsIUPAC(c, mol) {
+			continue
+		}
+		if q == nil {
+			q = make([]byte, len(p))
+			copy(q, p)
+		}
+		q[i] = r
+		if i > 0 && 'a' <= q[i-1] && q[i-1] <= 'z' {
+			q[i] = r - 'A' + 'a'
+		}
+		indices = append(indices, i)
+	}
+
+	if q == nil {
+		return seq, nil
+	}
+
+	orig := seq
+	seq = WithBytes(seq, q)
+	return mapQualityScores(seq, orig, func(q []byte) []byte {
+		return q
+	}), indices
+}
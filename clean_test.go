@@ -0,0 +1,58 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestUpperLower(t *testing.T) {
+	in := New(nil, nil, []byte("atgCATgc"))
+	testutils.Equals(t, string(Upper(in).Bytes()), "ATGCATGC")
+	testutils.Equals(t, string(Lower(in).Bytes()), "atgcatgc")
+	testutils.Equals(t, string(in.Bytes()), "atgCATgc")
+}
+
+func TestRemoveGaps(t *testing.T) {
+	ff := []Feature{
+		NewFeature("source", Range(0, 12), Props{}),
+		NewFeature("gene", Range(4, 9), Props{}),
+	}
+	in := New(nil, ff, []byte("at--gc.at-gc"))
+
+	out, n := RemoveGaps(in)
+	testutils.Equals(t, n, 4)
+	testutils.Equals(t, string(out.Bytes()), "atgcatgc")
+
+	gg := []Feature{
+		NewFeature("source", Range(0, 8), Props{}),
+		NewFeature("gene", Range(2, 6), Props{}),
+	}
+	if !featuresEqual(out.Features(), gg) {
+		t.Errorf("RemoveGaps(in).Features() = %v, want %v", out.Features(), gg)
+	}
+
+	out, n = RemoveGaps(New(nil, nil, []byte("atgc")))
+	testutils.Equals(t, n, 0)
+	testutils.Equals(t, string(out.Bytes()), "atgc")
+}
+
+var replaceInvalidTests = []struct {
+	in      string
+	mol     Molecule
+	out     string
+	indices []int
+}{
+	{"atgcnryk", DNA, "atgcnryk", nil},
+	{"at?c-GE1", DNA, "atnc-GNN", []int{2, 6, 7}},
+	{"MKV*", AA, "MKV*", nil},
+	{"MK9v", AA, "MKXv", []int{2}},
+}
+
+func TestReplaceInvalid(t *testing.T) {
+	for _, tt := range replaceInvalidTests {
+		out, indices := ReplaceInvalid(New(nil, nil, []byte(tt.in)), tt.mol)
+		testutils.Equals(t, string(out.Bytes()), tt.out)
+		testutils.Equals(t, indices, tt.indices)
+	}
+}
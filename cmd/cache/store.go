@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ref associates a key with the content stored in a Store.
+type Ref struct {
+	Key     string    `json:"key"`
+	Object  string    `json:"object"`
+	Time    time.Time `json:"time"`
+	Expires time.Time `json:"expires"`
+}
+
+// Expired tests if the reference is expired at the given time. A reference
+// with a zero expiry time never expires.
+func (ref Ref) Expired(t time.Time) bool {
+	return !ref.Expires.IsZero() && !t.Before(ref.Expires)
+}
+
+// Store is a content-addressed store. Contents are kept under the name of
+// their hash sums and looked up through references named after the hash sum
+// of the key, so that identical contents stored under different keys will
+// only be kept once.
+type Store struct {
+	h   hash.Hash
+	dir string
+}
+
+const (
+	storeObjects = "objects"
+	storeRefs    = "refs"
+)
+
+// OpenStore opens the store in the given directory, creating it if necessary.
+func OpenStore(dir string, h hash.Hash) (*Store, error) {
+	for _, name := range []string{storeObjects, storeRefs} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &Store{h, dir}, nil
+}
+
+// Dir returns the directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) sum(p []byte) string {
+	s.h.Reset()
+	s.h.Write(p)
+	return hex.EncodeToString(s.h.Sum(nil))
+}
+
+func (s *Store) refPath(key string) string {
+	return filepath.Join(s.dir, storeRefs, s.sum([]byte(key)))
+}
+
+func (s *Store) objectPath(object string) string {
+	return filepath.Join(s.dir, storeObjects, object)
+}
+
+func readRef(path string) (Ref, error) {
+	p, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Ref{}, err
+	}
+	ref := Ref{}
+	err = json.Unmarshal(p, &ref)
+	return ref, err
+}
+
+// Get returns the reference and content stored for the given key. If the
+// key is not in the store, an error satisfying os.IsNotExist is returned.
+func (s *Store) Get(key string) (Ref, []byte, error) {
+	ref, err := readRef(s.refPath(key))
+	if err != nil {
+		return Ref{}, nil, err
+	}
+	if ref.Key != key {
+		return Ref{}, nil, os.ErrNotExist
+	}
+
+	p, err := ioutil.ReadFile(s.objectPath(ref.Object))
+	if err != nil {
+		return Ref{}, nil, err
+	}
+	if s.sum(p) != ref.Object {
+		return Ref{}, nil, errors.New("object hash sum mismatch")
+	}
+
+	return ref, p, nil
+}
+
+// Put stores the content under the given key, which will expire after the
+// given duration. A non-positive duration will never expire.
+func (s *Store) Put(key string, p []byte, ttl time.Duration) (Ref, error) {
+	now := time.Now()
+	ref := Ref{key, s.sum(p), now, time.Time{}}
+	if ttl > 0 {
+		ref.Expires = now.Add(ttl)
+	}
+
+	path := s.objectPath(ref.Object)
+	if _, err := os.Stat(path); err != nil {
+		if err := writeFileAtomic(path, p); err != nil {
+			return ref, err
+		}
+	}
+
+	q, err := json.Marshal(ref)
+	if err != nil {
+		return ref, err
+	}
+
+	return ref, writeFileAtomic(s.refPath(key), q)
+}
+
+// Remove the reference of the given key. The content will be removed by the
+// next call to Prune if it is no longer referenced.
+func (s *Store) Remove(key string) error {
+	return os.Remove(s.refPath(key))
+}
+
+// Refs returns all of the references in the store sorted by key.
+func (s *Store) Refs() ([]Ref, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(s.dir, storeRefs))
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]Ref, 0, len(infos))
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		ref, err := readRef(filepath.Join(s.dir, storeRefs, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Key < refs[j].Key
+	})
+
+	return refs, nil
+}
+
+// Size returns the size of the content referenced by the given reference.
+func (s *Store) Size(ref Ref) int64 {
+	info, err := os.Stat(s.objectPath(ref.Object))
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// Prune removes the references that are expired at the given time and the
+// contents that are no longer referenced. The number of references removed
+// is returned.
+func (s *Store) Prune(t time.Time) (int, error) {
+	refs, err := s.Refs()
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	live := make(map[string]bool)
+	for _, ref := range refs {
+		if ref.Expired(t) {
+			if err := s.Remove(ref.Key); err != nil {
+				return n, err
+			}
+			n++
+			continue
+		}
+		live[ref.Object] = true
+	}
+
+	infos, err := ioutil.ReadDir(filepath.Join(s.dir, storeObjects))
+	if err != nil {
+		return n, err
+	}
+
+	for _, info := range infos {
+		if !live[info.Name()] {
+			if err := os.Remove(s.objectPath(info.Name())); err != nil {
+				return n, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
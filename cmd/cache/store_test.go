@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenStore(dir, sha1.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, s.Dir(), dir)
+
+	if _, _, err := s.Get("foo"); !os.IsNotExist(err) {
+		t.Errorf("s.Get(%q) = %v, want not exist", "foo", err)
+	}
+
+	foo, err := s.Put("foo", []byte("sumomomomo"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bar, err := s.Put("bar", []byte("sumomomomo"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baz, err := s.Put("baz", []byte("momonouchi"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.Equals(t, foo.Object, bar.Object)
+	testutils.Differs(t, foo.Object, baz.Object)
+	testutils.Equals(t, s.Size(foo), int64(10))
+
+	ref, p, err := s.Get("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, ref.Key, "foo")
+	testutils.Equals(t, string(p), "sumomomomo")
+
+	refs, err := s.Refs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, len(refs), 3)
+	testutils.Equals(t, refs[0].Key, "bar")
+
+	now := time.Now()
+	testutils.Equals(t, foo.Expired(now.Add(time.Hour*24)), false)
+	testutils.Equals(t, bar.Expired(now), false)
+	testutils.Equals(t, bar.Expired(now.Add(time.Hour*2)), true)
+
+	n, err := s.Prune(now.Add(time.Minute * 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, n, 1)
+
+	if _, err := os.Stat(filepath.Join(dir, storeObjects, baz.Object)); !os.IsNotExist(err) {
+		t.Errorf("expected unreferenced object to be pruned")
+	}
+
+	if err := s.Remove("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Get("foo"); !os.IsNotExist(err) {
+		t.Errorf("s.Get(%q) = %v, want not exist", "foo", err)
+	}
+
+	path := filepath.Join(dir, storeObjects, bar.Object)
+	if err := ioutil.WriteFile(path, []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Get("bar"); err == nil {
+		t.Errorf("s.Get(%q) expected error for corrupted object", "bar")
+	}
+}
@@ -0,0 +1,69 @@
+// JavaScript interface to the WebAssembly build of gts.
+//
+// The Go WebAssembly support script (wasm_exec.js), which is distributed with
+// the Go toolchain in $(go env GOROOT)/lib/wasm ($(go env GOROOT)/misc/wasm
+// prior to Go 1.24), must be loaded before this module so that the global
+// `Go` class is available.
+//
+//   import { load } from "./gts.js";
+//   const gts = await load("gts.wasm");
+//   const records = gts.parse(text);
+//   console.log(gts.format(gts.select(records, "CDS"), "fasta"));
+//
+// Sequences are represented as arrays of records following the versioned
+// record schema of the seqio package.
+
+function unwrap(result) {
+  if (result.error !== undefined) {
+    throw new Error(result.error);
+  }
+  return result.value;
+}
+
+class GTS {
+  constructor(api) {
+    this.api = api;
+  }
+
+  // version returns the version of gts the module was built with.
+  version() {
+    return unwrap(this.api.version());
+  }
+
+  // parse reads the sequences in a FASTA, FASTQ, or GenBank string and
+  // returns an array of records.
+  parse(text) {
+    return JSON.parse(unwrap(this.api.parse(text)));
+  }
+
+  // format writes an array of records in the given format ("fasta",
+  // "genbank", ...). The format of each record is detected if omitted.
+  format(records, format = "") {
+    return unwrap(this.api.format(JSON.stringify(records), format));
+  }
+
+  // convert reads the sequences in a string and writes them in the given
+  // format.
+  convert(text, format = "") {
+    return unwrap(this.api.convert(text, format));
+  }
+
+  // select retains the features matching the selector in each record.
+  select(records, selector) {
+    return JSON.parse(unwrap(this.api.select(JSON.stringify(records), selector)));
+  }
+
+  // extract returns the regions referenced by the locator as records.
+  extract(records, locator) {
+    return JSON.parse(unwrap(this.api.extract(JSON.stringify(records), locator)));
+  }
+}
+
+// load instantiates the WebAssembly module at the given URL and resolves to
+// an interface for the gts functions.
+export async function load(url) {
+  const go = new Go();
+  const source = await WebAssembly.instantiateStreaming(fetch(url), go.importObject);
+  go.run(source.instance);
+  return new GTS(globalThis.gts);
+}
@@ -0,0 +1,72 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Command gts-wasm exposes the gts library to JavaScript when compiled to
+// WebAssembly. The functions are registered on the global `gts` object and
+// return an object with either a `value` or an `error` property. Use gts.js
+// for an interface which throws exceptions instead.
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/go-gts/gts/internal/binding"
+)
+
+type handler func(args []string) (interface{}, error)
+
+func wrap(n int, f handler) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) != n {
+			return map[string]interface{}{
+				"error": fmt.Sprintf("expected %d arguments, got %d", n, len(args)),
+			}
+		}
+
+		strs := make([]string, n)
+		for i, arg := range args {
+			if arg.Type() != js.TypeString {
+				return map[string]interface{}{
+					"error": fmt.Sprintf("argument %d must be a string, got %s", i+1, arg.Type()),
+				}
+			}
+			strs[i] = arg.String()
+		}
+
+		v, err := f(strs)
+		if err != nil {
+			return map[string]interface{}{"error": err.Error()}
+		}
+		return map[string]interface{}{"value": v}
+	})
+}
+
+func main() {
+	js.Global().Set("gts", map[string]interface{}{
+		"version": wrap(0, func(args []string) (interface{}, error) {
+			return binding.Version(), nil
+		}),
+		"parse": wrap(1, func(args []string) (interface{}, error) {
+			p, err := binding.Parse(args[0])
+			return string(p), err
+		}),
+		"format": wrap(2, func(args []string) (interface{}, error) {
+			return binding.Format([]byte(args[0]), args[1])
+		}),
+		"convert": wrap(2, func(args []string) (interface{}, error) {
+			return binding.Convert(args[0], args[1])
+		}),
+		"select": wrap(2, func(args []string) (interface{}, error) {
+			p, err := binding.Select([]byte(args[0]), args[1])
+			return string(p), err
+		}),
+		"extract": wrap(2, func(args []string) (interface{}, error) {
+			p, err := binding.Extract([]byte(args[0]), args[1])
+			return string(p), err
+		}),
+	})
+
+	// Keep the runtime alive so that the registered functions remain callable.
+	select {}
+}
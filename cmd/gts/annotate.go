@@ -8,7 +8,6 @@ import (
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 	"github.com/go-pars/pars"
 )
@@ -17,40 +16,63 @@ func init() {
 	flags.Register("annotate", "merge features from a feature list file into a sequence", annotateFunc)
 }
 
+// gff3Directive is the version directive at the head of a GFF3 file.
+const gff3Directive = "##gff-version"
+
 func annotateFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
-	featinPath := pos.String("feature_table", "feature table file containing features to merge")
+	featinPath := pos.String("feature_table", "feature table or GFF3 file containing features to merge")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	merge := opt.String('m', "merge", "keep-both", "policy for merging features with identical keys and locations")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
+	policy, err := gts.AsMergePolicy(*merge)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
 	featinFile, err := os.Open(*featinPath)
 	if err != nil {
 		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *featinPath, err))
 	}
 
 	h.Reset()
-	r := attach(h, featinFile)
-	state := pars.NewState(r)
-	result, err := seqio.INSDCTableParser("").Parse(state)
-	if err != nil {
-		return ctx.Raise(err)
+	r := bufio.NewReader(attach(h, featinFile))
+
+	// Features read from a GFF3 file are only annotated to the sequence with
+	// the matching seqid, while features read from a feature table are
+	// annotated to every sequence.
+	featin := func(seq gts.Sequence) []gts.Feature { return nil }
+	if p, _ := r.Peek(len(gff3Directive)); seqio.Detect(*featinPath) == seqio.GFF3File || string(p) == gff3Directive {
+		features, err := seqio.ReadGFF3(r)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		featin = func(seq gts.Sequence) []gts.Feature {
+			return features[seqio.ID(seq)]
+		}
+	} else {
+		state := pars.NewState(r)
+		result, err := seqio.INSDCTableParser("").Parse(state)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		features := result.Value.([]gts.Feature)
+		featin = func(seq gts.Sequence) []gts.Feature {
+			return features
+		}
 	}
 
-	featin := result.Value.([]gts.Feature)
 	featsum := h.Sum(nil)
 
 	d, err := newIODelegate(*seqinPath, *seqoutPath)
@@ -70,6 +92,7 @@ func annotateFunc(ctx *flags.Context) error {
 			{"version", gts.Version.String()},
 			{"featin", encodeToString(featsum)},
 			{"filetype", filetype},
+			{"merge", policy},
 		})
 
 		ok, err := d.TryCache(h, data)
@@ -78,15 +101,15 @@ func annotateFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
 	for scanner.Scan() {
 		seq := scanner.Value()
 		ff := seq.Features()
-		for _, f := range featin {
-			ff = ff.Insert(f)
+		for _, f := range featin(seq) {
+			ff = ff.Merge(f, policy)
 		}
 		seq = gts.WithFeatures(seq, ff)
 		if _, err := writer.WriteSeq(seq); err != nil {
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-gts/flags"
+)
+
+func init() {
+	flags.Register("batch", "run a pipeline for each input file listed in a manifest", batchFunc)
+}
+
+// batchManifest is a table of the input files and their parameters, where
+// each row is given as a map from the column names to the values.
+type batchManifest struct {
+	Columns []string
+	Rows    []map[string]string
+}
+
+// readManifest reads a manifest file delimited with the given delimiter, or
+// with a comma for a `.csv` file and a tab otherwise if the delimiter is
+// empty. The first line is the header and the `input` and `output` columns
+// are required.
+func readManifest(path, delim string) (batchManifest, error) {
+	m := batchManifest{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return m, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	switch {
+	case delim != "":
+		if len(delim) != 1 {
+			return m, fmt.Errorf("delimiter must be a single character: got %q", delim)
+		}
+		r.Comma = rune(delim[0])
+	case strings.ToLower(filepath.Ext(path)) == ".csv":
+		r.Comma = ','
+	default:
+		r.Comma = '\t'
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return m, err
+	}
+	if len(records) == 0 {
+		return m, errors.New("manifest is empty")
+	}
+
+	m.Columns = records[0]
+	for i, name := range m.Columns {
+		m.Columns[i] = strings.TrimSpace(name)
+	}
+	for _, name := range []string{"input", "output"} {
+		if !containsString(m.Columns, name) {
+			return m, fmt.Errorf("manifest has no %q column", name)
+		}
+	}
+
+	for i, record := range records[1:] {
+		row := make(map[string]string)
+		for j, name := range m.Columns {
+			row[name] = strings.TrimSpace(record[j])
+		}
+		if row["input"] == "" || row["output"] == "" {
+			return m, fmt.Errorf("row %d: input and output must not be empty", i+1)
+		}
+		m.Rows = append(m.Rows, row)
+	}
+
+	return m, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, t := range ss {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Substitute returns the pipeline with each `{column}` in the commands and
+// arguments replaced by the value of the column in the given row.
+func (p pipeline) Substitute(row map[string]string) pipeline {
+	oldnew := []string{}
+	for name, value := range row {
+		oldnew = append(oldnew, "{"+name+"}", value)
+	}
+	r := strings.NewReplacer(oldnew...)
+
+	steps := make([]pipelineStep, len(p.Steps))
+	for i, step := range p.Steps {
+		args := make([]string, len(step.Args))
+		for j, arg := range step.Args {
+			args[j] = r.Replace(arg)
+		}
+		steps[i] = pipelineStep{r.Replace(step.Command), args}
+	}
+	return pipeline{steps}
+}
+
+func batchFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	pipelinePath := pos.String("pipeline", "pipeline file in YAML or JSON format")
+	manifestPath := pos.String("manifest", "table of the input files, output files, and parameters")
+
+	outPath := opt.String('o', "output", "-", "output file of the summary report (specifying `-` will force standard output)")
+	delim := opt.String('d', "delimiter", "", "delimiter of the manifest (defaults to a comma for .csv files and a tab otherwise)")
+	dryrun := opt.Switch('n', "dry-run", "print the commands to be run without running them")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the summary report")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	p, err := readPipeline(*pipelinePath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to read pipeline %q: %v", *pipelinePath, err))
+	}
+
+	m, err := readManifest(*manifestPath, *delim)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to read manifest %q: %v", *manifestPath, err))
+	}
+
+	if *dryrun {
+		for _, row := range m.Rows {
+			q := p.Substitute(row)
+			lines := make([]string, len(q.Steps))
+			for i, step := range q.Steps {
+				lines[i] = fmt.Sprintf("gts %s", shellJoin(step.argv()))
+			}
+			lines[0] += " " + shellJoin([]string{row["input"]})
+			lines[len(lines)-1] += " > " + shellJoin([]string{row["output"]})
+			if _, err := fmt.Fprintln(os.Stdout, strings.Join(lines, " |\n")); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+		return nil
+	}
+
+	w, err := createOutput(*outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer w.Close()
+	buffer := bufio.NewWriter(w)
+
+	if !*noheader {
+		fields := []string{"input", "output", "status", "time", "message"}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	failed := 0
+	for _, row := range m.Rows {
+		start := time.Now()
+		status, message := "ok", ""
+		if err := runAtomic(p.Substitute(row), row["input"], row["output"]); err != nil {
+			status, message = "failed", err.Error()
+			failed++
+		}
+		elapsed := time.Since(start).Round(time.Millisecond)
+
+		fields := []string{row["input"], row["output"], status, elapsed.String(), message}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if failed > 0 {
+		return ctx.Raise(fmt.Errorf("%d of %d row(s) failed", failed, len(m.Rows)))
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/go-gts/flags"
@@ -16,22 +17,69 @@ func init() {
 
 	cacheSet.Register("list", "list the cache files", cacheListFunc)
 	cacheSet.Register("path", "print the cache directory path", cachePathFunc)
+	cacheSet.Register("prune", "delete expired fetch cache entries", cachePruneFunc)
 	cacheSet.Register("purge", "delete all cache files", cachePurgeFunc)
 
 	flags.Register("cache", "manage gts cache files", cacheSet.Compile())
 }
 
+func cacheListFetchFunc(ctx *flags.Context) error {
+	store, err := openFetchCache()
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	refs, err := store.Refs()
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	now := time.Now()
+	total := uint64(0)
+
+	for _, ref := range refs {
+		size := uint64(store.Size(ref))
+		total += size
+
+		expires := "never"
+		switch {
+		case ref.Expired(now):
+			expires = "expired"
+		case !ref.Expires.IsZero():
+			expires = ref.Expires.Format(time.RFC3339)
+		}
+
+		fmt.Printf("%s\t%s\t%s\t%s\n", ref.Key, ref.Time.Format(time.RFC3339), expires, humanize.IBytes(size))
+	}
+
+	fmt.Printf("Total\t%s\n", humanize.IBytes(total))
+
+	return nil
+}
+
 func cacheListFunc(ctx *flags.Context) error {
 	pos, opt := flags.Flags()
+
+	fetch := opt.Switch('f', "fetch", "list the sequences in the fetch cache")
+
 	if err := ctx.Parse(pos, opt); err != nil {
 		return err
 	}
 
+	if *fetch {
+		return cacheListFetchFunc(ctx)
+	}
+
 	dir, err := gtsCacheDir()
 	if err != nil {
 		return nil
 	}
 
+	fetchDir, err := gtsFetchCacheDir()
+	if err != nil {
+		return nil
+	}
+
 	total := uint64(0)
 
 	walker := func(path string, info os.FileInfo, err error) error {
@@ -40,6 +88,9 @@ func cacheListFunc(ctx *flags.Context) error {
 		}
 
 		if info.IsDir() {
+			if path == fetchDir {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
@@ -99,6 +150,29 @@ func cachePathFunc(ctx *flags.Context) error {
 	return nil
 }
 
+func cachePruneFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	store, err := openFetchCache()
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	n, err := store.Prune(time.Now())
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	if n > 0 {
+		fmt.Fprintf(os.Stderr, "removed %d expired fetch cache entries\n", n)
+	}
+
+	return nil
+}
+
 func cachePurgeFunc(ctx *flags.Context) error {
 	pos, opt := flags.Flags()
 	if err := ctx.Parse(pos, opt); err != nil {
@@ -0,0 +1,627 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	cdsSet := flags.CommandSet{}
+
+	cdsSet.Register("check", "check the translations of CDS features against the sequence", cdsCheckFunc)
+	cdsSet.Register("retranslate", "regenerate the translations of CDS features", cdsRetranslateFunc)
+	cdsSet.Register("snap", "snap the boundaries of CDS features to start and stop codons", cdsSnapFunc)
+	cdsSet.Register("starts", "list and apply alternative start codons of CDS features", cdsStartsFunc)
+
+	flags.Register("cds", "validate and manipulate CDS features and their translations", cdsSet.Compile())
+}
+
+func cdsCheckFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+	pseudo := opt.String('p', "pseudo", "skip", "handling of pseudo features (skip, strip, include)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if err := checkPseudoMode(*pseudo, "skip", "strip", "include"); err != nil {
+		return ctx.Raise(err)
+	}
+
+	if _, err := gts.LookupCodonTable(*table); err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "feature", "location", "problem"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	count := 0
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		for _, f := range seq.Features().Filter(gts.Key("CDS")) {
+			problems := []string{}
+
+			switch {
+			case !gts.IsPseudo(f) || *pseudo == "include":
+				codons, err := gts.TranslationTable(f, *table)
+				if err != nil {
+					problems = append(problems, err.Error())
+					break
+				}
+				if problems, err = gts.CheckTranslation(f, seq, codons, seqResolve()); err != nil {
+					problems = []string{err.Error()}
+				}
+			case *pseudo == "strip" && f.Props.Has("translation"):
+				problems = append(problems, gts.TranslationPseudo)
+			}
+
+			for _, problem := range problems {
+				count++
+				fields := []string{id, f.Key, f.Loc.String(), problem}
+				if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if count > 0 {
+		return ctx.Raise(fmt.Errorf("found %d translation issue(s)", count))
+	}
+
+	return nil
+}
+
+func cdsRetranslateFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	pseudo := opt.String('p', "pseudo", "skip", "handling of pseudo features (skip, strip, include)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if err := checkPseudoMode(*pseudo, "skip", "strip", "include"); err != nil {
+		return ctx.Raise(err)
+	}
+
+	if _, err := gts.LookupCodonTable(*table); err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"pseudo", *pseudo},
+			{"table", *table},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		ff := make([]gts.Feature, len(seq.Features()))
+		for j, f := range seq.Features() {
+			ff[j] = f
+			if f.Key != "CDS" {
+				continue
+			}
+
+			if gts.IsPseudo(f) {
+				switch *pseudo {
+				case "skip":
+					continue
+				case "strip":
+					if f.Props.Has("translation") {
+						f.Props = f.Props.Clone()
+						f.Props.Del("translation")
+						ff[j] = f
+					}
+					continue
+				default:
+					warnPseudo(ctx, id, f)
+				}
+			}
+
+			codons, err := gts.TranslationTable(f, *table)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), id, f.Key, f.Loc, err)
+				continue
+			}
+
+			p, err := gts.TranslateCDS(f, seq, codons, seqResolve())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), id, f.Key, f.Loc, err)
+				continue
+			}
+
+			f.Props = f.Props.Clone()
+			f.Props.Set("translation", gts.TranslationValue(p))
+			ff[j] = f
+		}
+
+		seq = gts.WithFeatures(seq, ff)
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
+
+func cdsSnapFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	selector := opt.String(0, "selector", "CDS", "feature selector for the features to snap")
+	start := opt.Int(0, "start", 0, "move the 5' ends to the nearest start codon within the given number of bases")
+	stop := opt.Int(0, "stop", 0, "extend the 3' ends to the nearest stop codon within the given number of bases")
+	starts := opt.StringSlice(0, "start-codon", nil, "codon to regard as a start codon (defaults to those of the translation table)")
+	pseudo := opt.String('p', "pseudo", "skip", "handling of pseudo features (skip, include)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+	reportPath := opt.String('r', "report", "", "output file of a table of the adjusted features")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *start < 0 || *stop < 0 {
+		return ctx.Raise(errors.New("expected a non-negative number of bases"))
+	}
+	if *start == 0 && *stop == 0 {
+		return ctx.Raise(errors.New("either of --start or --stop is required"))
+	}
+
+	if err := checkPseudoMode(*pseudo, "skip", "include"); err != nil {
+		return ctx.Raise(err)
+	}
+
+	if _, err := gts.LookupCodonTable(*table); err != nil {
+		return ctx.Raise(err)
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+	filter = gts.And(gts.Key("CDS"), filter)
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache && *reportPath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"selector", *selector},
+			{"start", *start},
+			{"stop", *stop},
+			{"starts", *starts},
+			{"pseudo", *pseudo},
+			{"table", *table},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	var report *bufio.Writer
+	if *reportPath != "" {
+		w, err := createOutput(*reportPath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		report = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "feature", "end", "before", "after"}
+		if _, err := fmt.Fprintf(report, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		var ff gts.FeatureSlice
+		for _, f := range seq.Features() {
+			if !filter(f) || (gts.IsPseudo(f) && *pseudo == "skip") {
+				ff = ff.Insert(f)
+				continue
+			}
+
+			codons, err := gts.TranslationTable(f, *table)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), id, f.Key, f.Loc, err)
+				ff = ff.Insert(f)
+				continue
+			}
+			if len(*starts) > 0 {
+				codons = codons.WithStarts(*starts...)
+			}
+
+			name := featureName(f)
+			if name == "" {
+				name = f.Key
+			}
+
+			orig := f.Loc
+			changes := [][2]string{}
+			if *start > 0 {
+				loc, ok := gts.SnapStart(f, seq, codons, *start)
+				if ok {
+					changes = append(changes, [2]string{"start", f.Loc.String()})
+					f.Loc = loc
+				}
+			}
+			if *stop > 0 {
+				loc, ok := gts.SnapStop(f, seq, codons, *stop)
+				if ok {
+					changes = append(changes, [2]string{"stop", f.Loc.String()})
+					f.Loc = loc
+				}
+			}
+
+			if f.Loc.String() != orig.String() && f.Props.Has("translation") {
+				p, err := gts.TranslateCDS(f, seq, codons, seqResolve())
+				if err != nil {
+					return ctx.Raise(fmt.Errorf("%s: %s %s: %v", id, f.Key, f.Loc, err))
+				}
+				f.Props = f.Props.Clone()
+				f.Props.Set("translation", gts.TranslationValue(p))
+			}
+
+			if report != nil {
+				for j, change := range changes {
+					after := f.Loc.String()
+					if j+1 < len(changes) {
+						after = changes[j+1][1]
+					}
+					fields := []string{id, name, change[0], change[1], after}
+					if _, err := fmt.Fprintf(report, "%s\n", strings.Join(fields, "\t")); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+			}
+
+			ff = ff.Insert(f)
+		}
+
+		if report != nil {
+			if err := report.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		seq = gts.WithFeatures(seq, ff)
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
+
+// pickStart returns the candidate start codon chosen by the given rule among
+// the candidates with an RBS score of at least min, and whether one was found.
+func pickStart(cc []gts.StartCandidate, rule string, min int) (gts.StartCandidate, bool) {
+	best, ok := gts.StartCandidate{}, false
+	for _, c := range cc {
+		if c.RBS < min {
+			continue
+		}
+		switch {
+		case !ok:
+		case rule == "longest" && c.Shift > best.Shift:
+		case rule == "rbs" && c.RBS > best.RBS:
+		case rule == "rbs" && c.RBS == best.RBS && gts.Abs(c.Shift) < gts.Abs(best.Shift):
+		default:
+			continue
+		}
+		best, ok = c, true
+	}
+	return best, ok
+}
+
+func cdsStartsFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format when applying (defaults to same as input)")
+	selector := opt.String(0, "selector", "CDS", "feature selector for the features to examine")
+	window := opt.Int('w', "window", 150, "number of bases to search upstream and downstream for start codons")
+	starts := opt.StringSlice(0, "start-codon", nil, "codon to regard as a start codon (defaults to those of the translation table)")
+	pseudo := opt.String('p', "pseudo", "skip", "handling of pseudo features (skip, include)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+	apply := opt.String('a', "apply", "", "move the start codons by the given rule (longest, rbs) and output the sequences")
+	minRBS := opt.Int(0, "min-rbs", 0, "minimum RBS score of the candidate start codons")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the candidate table")
+	reportPath := opt.String('r', "report", "", "output file of a table of the moved features when applying")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *window < 0 {
+		return ctx.Raise(fmt.Errorf("window size must not be negative: got %d", *window))
+	}
+
+	switch *apply {
+	case "", "longest", "rbs":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown rule %q: expected one of longest, rbs", *apply))
+	}
+
+	if *reportPath != "" && *apply == "" {
+		return ctx.Raise(errors.New("--report requires --apply"))
+	}
+
+	if err := checkPseudoMode(*pseudo, "skip", "include"); err != nil {
+		return ctx.Raise(err)
+	}
+
+	if _, err := gts.LookupCodonTable(*table); err != nil {
+		return ctx.Raise(err)
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+	filter = gts.And(gts.Key("CDS"), filter)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*outPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache && *reportPath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"selector", *selector},
+			{"window", *window},
+			{"starts", *starts},
+			{"pseudo", *pseudo},
+			{"table", *table},
+			{"apply", *apply},
+			{"minrbs", *minRBS},
+			{"noheader", *noheader},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	var report *bufio.Writer
+	if *reportPath != "" {
+		w, err := createOutput(*reportPath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		report = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "feature", "before", "after", "codon", "shift", "rbs"}
+		if _, err := fmt.Fprintf(report, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	if *apply == "" && !*noheader {
+		fields := []string{"seqid", "feature", "location", "candidate", "codon", "shift", "rbs"}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		var ff gts.FeatureSlice
+		for _, f := range seq.Features() {
+			if !filter(f) || (gts.IsPseudo(f) && *pseudo == "skip") {
+				ff = ff.Insert(f)
+				continue
+			}
+
+			codons, err := gts.TranslationTable(f, *table)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), id, f.Key, f.Loc, err)
+				ff = ff.Insert(f)
+				continue
+			}
+			if len(*starts) > 0 {
+				codons = codons.WithStarts(*starts...)
+			}
+
+			name := featureName(f)
+			if name == "" {
+				name = f.Key
+			}
+
+			cc := gts.StartCandidates(f, seq, codons, *window)
+
+			if *apply == "" {
+				for _, c := range cc {
+					if c.RBS < *minRBS {
+						continue
+					}
+					fields := []string{id, name, f.Loc.String(), c.Loc.String(), c.Codon, strconv.Itoa(c.Shift), strconv.Itoa(c.RBS)}
+					if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, "\t")); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+				continue
+			}
+
+			if c, ok := pickStart(cc, *apply, *minRBS); ok && c.Shift != 0 {
+				if report != nil {
+					fields := []string{id, name, f.Loc.String(), c.Loc.String(), c.Codon, strconv.Itoa(c.Shift), strconv.Itoa(c.RBS)}
+					if _, err := fmt.Fprintf(report, "%s\n", strings.Join(fields, "\t")); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+
+				f.Loc = c.Loc
+				if f.Props.Has("translation") {
+					p, err := gts.TranslateCDS(f, seq, codons, seqResolve())
+					if err != nil {
+						return ctx.Raise(fmt.Errorf("%s: %s %s: %v", id, f.Key, f.Loc, err))
+					}
+					f.Props = f.Props.Clone()
+					f.Props.Set("translation", gts.TranslationValue(p))
+				}
+			}
+
+			ff = ff.Insert(f)
+		}
+
+		if report != nil {
+			if err := report.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if *apply != "" {
+			seq = gts.WithFeatures(seq, ff)
+			if _, err := writer.WriteSeq(seq); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("clean", "remove gaps and replace invalid characters in the sequence(s)", cleanFunc)
+}
+
+func cleanFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	keepGaps := opt.Switch('g', "keep-gaps", "do not remove the gap characters")
+	keepInvalid := opt.Switch('k', "keep-invalid", "do not replace the non-IUPAC characters")
+	reportPath := opt.String('r', "report", "", "output file of a table of the replaced characters")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache && *reportPath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"keepGaps", *keepGaps},
+			{"keepInvalid", *keepInvalid},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	var report *bufio.Writer
+	if *reportPath != "" {
+		w, err := createOutput(*reportPath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		report = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "position", "original", "replacement"}
+		if _, err := fmt.Fprintf(report, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+		mol := seqMolecule(seq)
+
+		if !*keepGaps {
+			seq, _ = gts.RemoveGaps(seq)
+		}
+
+		if !*keepInvalid {
+			orig := seq.Bytes()
+			var indices []int
+			seq, indices = gts.ReplaceInvalid(seq, mol)
+			if report != nil {
+				p := seq.Bytes()
+				for _, j := range indices {
+					fields := []string{id, strconv.Itoa(j + 1), string(orig[j]), string(p[j])}
+					if _, err := fmt.Fprintf(report, "%s\n", strings.Join(fields, "\t")); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+			}
+		}
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if report != nil {
+		if err := report.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
@@ -3,12 +3,10 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -20,17 +18,13 @@ func clearFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -58,14 +52,17 @@ func clearFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
+
+	op := gts.ClearOp()
 
 	for scanner.Scan() {
-		seq := scanner.Value()
-		ff := seq.Features().Filter(gts.Key("source"))
-		seq = gts.WithFeatures(seq, ff)
+		seq, err := op(scanner.Value())
+		if err != nil {
+			return ctx.Raise(err)
+		}
 		if _, err := writer.WriteSeq(seq); err != nil {
 			return ctx.Raise(err)
 		}
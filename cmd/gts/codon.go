@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("codon", "tally the codon usage of the CDS features", codonFunc)
+}
+
+// writeKazusa writes the codon usage in the format of the Kazusa codon usage
+// database, giving the frequency per thousand and the count of each codon.
+func writeKazusa(w *bufio.Writer, usage gts.CodonUsage) error {
+	total := usage.Total()
+	for first := 0; first < 4; first++ {
+		if first > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		for third := 0; third < 4; third++ {
+			cells := make([]string, 4)
+			for second := range cells {
+				i := first*16 + second*4 + third
+				codon := strings.ReplaceAll(usage.Codon(i), "T", "U")
+				perThousand := 0.0
+				if total > 0 {
+					perThousand = usage[i] / total * 1000
+				}
+				cells[second] = fmt.Sprintf("%s %4.1f(%6d)", codon, perThousand, int(usage[i]))
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(cells, "  ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func codonFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	selector := opt.String(0, "selector", "CDS", "feature selector for the features to tally")
+	format := opt.String('f', "format", "tsv", "output format (tsv, kazusa)")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+	pseudo := opt.String('p', "pseudo", "skip", "handling of pseudo features (skip, include)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	switch *format {
+	case "tsv", "kazusa":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown output format %q: expected one of tsv, kazusa", *format))
+	}
+
+	if err := checkPseudoMode(*pseudo, "skip", "include"); err != nil {
+		return ctx.Raise(err)
+	}
+
+	codons, err := gts.LookupCodonTable(*table)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+	filter = gts.And(gts.Key("CDS"), filter)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"selector", *selector},
+			{"format", *format},
+			{"noheader", *noheader},
+			{"pseudo", *pseudo},
+			{"table", *table},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	usage := gts.CodonUsage{}
+	tables := map[int]gts.CodonTable{}
+
+	scanner := newSeqScanner(d)
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+
+		for _, f := range seq.Features().Filter(filter) {
+			if gts.IsPseudo(f) && *pseudo == "skip" {
+				continue
+			}
+
+			t, err := gts.TranslationTable(f, *table)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), seqID(seq, i), f.Key, f.Loc, err)
+				continue
+			}
+
+			sub, err := gts.LocateRegion(f.Loc.Region(), seq, seqResolve())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), seqID(seq, i), f.Key, f.Loc, err)
+				continue
+			}
+			tables[t.ID] = t
+
+			p := sub.Bytes()
+			usage.Add(p[gts.Min(gts.CodonStart(f), len(p)):])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	// The amino acids are given by the translation table of the tallied
+	// features if they all share the same table.
+	if len(tables) == 1 {
+		for _, t := range tables {
+			codons = t
+		}
+	}
+
+	w := bufio.NewWriter(d)
+
+	if *format == "kazusa" {
+		if err := writeKazusa(w, usage); err != nil {
+			return ctx.Raise(err)
+		}
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+		return nil
+	}
+
+	if !*noheader {
+		fields := []string{"codon", "aa", "fraction", "frequency", "count"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	total := usage.Total()
+	fractions := usage.Fractions(codons)
+	for i, n := range usage {
+		frequency := 0.0
+		if total > 0 {
+			frequency = n / total * 1000
+		}
+		fields := []string{
+			usage.Codon(i),
+			string(codons.AAs[i]),
+			strconv.FormatFloat(fractions[i], 'f', 3, 64),
+			strconv.FormatFloat(frequency, 'f', 2, 64),
+			strconv.FormatFloat(n, 'f', -1, 64),
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return ctx.Raise(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("colorize", "assign display colors to features", colorizeFunc)
+}
+
+type colorRule struct {
+	Filter gts.Filter
+	Color  gts.Color
+}
+
+func parseColorRule(s string) (colorRule, error) {
+	i := strings.LastIndexByte(s, '=')
+	if i < 0 {
+		return colorRule{}, fmt.Errorf("cannot interpret %q as a color rule: expected `<selector>=<color>`", s)
+	}
+
+	filter, err := gts.Selector(s[:i])
+	if err != nil {
+		return colorRule{}, fmt.Errorf("invalid selector syntax: %v", err)
+	}
+
+	c, err := gts.AsColor(s[i+1:])
+	if err != nil {
+		return colorRule{}, err
+	}
+
+	return colorRule{filter, c}, nil
+}
+
+func colorizeFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	rules := opt.StringSlice('c', "color", nil, "color rule of the form <selector>=<color>")
+	styleNames := opt.StringSlice('s', "style", nil, "color qualifier style(s) to write (defaults to all styles)")
+	clear := opt.Switch(0, "clear", "remove the color qualifiers of all styles")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	colorRules := make([]colorRule, len(*rules))
+	for i, s := range *rules {
+		rule, err := parseColorRule(s)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		colorRules[i] = rule
+	}
+
+	styles := gts.ColorStyles
+	if len(*styleNames) > 0 {
+		styles = make([]gts.ColorStyle, len(*styleNames))
+		for i, name := range *styleNames {
+			style, err := gts.AsColorStyle(name)
+			if err != nil {
+				return ctx.Raise(err)
+			}
+			styles[i] = style
+		}
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"rules", *rules},
+			{"styles", styles},
+			{"clear", *clear},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	colorOf := func(f gts.Feature) (gts.Color, bool) {
+		for _, rule := range colorRules {
+			if rule.Filter(f) {
+				return rule.Color, true
+			}
+		}
+		return gts.FeatureColor(f)
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+
+		ff := make([]gts.Feature, len(seq.Features()))
+		for i, f := range seq.Features() {
+			switch c, ok := colorOf(f); {
+			case *clear:
+				ff[i] = gts.ClearFeatureColor(f)
+			case ok:
+				ff[i] = gts.SetFeatureColor(f, c, styles...)
+			default:
+				ff[i] = f
+			}
+		}
+
+		seq = gts.WithFeatures(seq, ff)
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("compare-annotations", "compare the annotations of sequences against a reference", compareAnnotationsFunc)
+}
+
+type annotationStats struct {
+	Reference, Query                int
+	Matched, Partial, Missed, Extra int
+}
+
+func (stats *annotationStats) Add(status string) {
+	switch status {
+	case gts.FeatureMatched:
+		stats.Reference++
+		stats.Query++
+		stats.Matched++
+	case gts.FeaturePartial:
+		stats.Reference++
+		stats.Query++
+		stats.Partial++
+	case gts.FeatureMissed:
+		stats.Reference++
+		stats.Missed++
+	case gts.FeatureExtra:
+		stats.Query++
+		stats.Extra++
+	}
+}
+
+func formatRatio(n, d int) string {
+	if d == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(float64(n)/float64(d), 'f', 3, 64)
+}
+
+func (stats annotationStats) Fields() []string {
+	return []string{
+		strconv.Itoa(stats.Reference), strconv.Itoa(stats.Query),
+		strconv.Itoa(stats.Matched), strconv.Itoa(stats.Partial),
+		strconv.Itoa(stats.Missed), strconv.Itoa(stats.Extra),
+		formatRatio(stats.Matched, stats.Query),
+		formatRatio(stats.Matched, stats.Reference),
+	}
+}
+
+func compareAnnotationsFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	refPath := pos.String("reference", "reference sequence file")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	selector := opt.String('s', "selector", "", "feature selector for the features to compare (defaults to all features except source)")
+	tolerance := opt.Int('t', "tolerance", 0, "maximum difference in bases allowed for each boundary of matching features")
+	detail := opt.Switch(0, "detail", "report the status of each feature instead of the summary")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *tolerance < 0 {
+		return ctx.Raise(fmt.Errorf("tolerance must not be negative: got %d", *tolerance))
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+	filter = gts.And(filter, gts.Not(gts.Key("source")))
+
+	f, err := os.Open(*refPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *refPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	refs := []gts.Sequence{}
+	refIndex := map[string]int{}
+	refScanner := newSeqScanner(attach(h, f))
+	for i := 0; refScanner.Scan(); i++ {
+		seq := refScanner.Value()
+		refIndex[seqID(seq, i)] = i
+		refs = append(refs, seq)
+	}
+	if err := refScanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+	refSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"reference", encodeToString(refSum)},
+			{"selector", *selector},
+			{"tolerance", *tolerance},
+			{"detail", *detail},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "key", "reference", "query", "match", "partial", "missed", "extra", "precision", "recall"}
+		if *detail {
+			fields = []string{"seqid", "key", "status", "reference", "query"}
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		j, ok := refIndex[id]
+		if !ok {
+			j = i
+		}
+		if j >= len(refs) {
+			return ctx.Raise(fmt.Errorf("%s: no corresponding reference sequence", id))
+		}
+
+		ref := refs[j].Features().Filter(filter)
+		query := seq.Features().Filter(filter)
+		matches := gts.CompareFeatures(ref, query, *tolerance)
+
+		if *detail {
+			for _, match := range matches {
+				key, refLoc, queryLoc := "", "-", "-"
+				if match.Reference >= 0 {
+					key = ref[match.Reference].Key
+					refLoc = ref[match.Reference].Loc.String()
+				}
+				if match.Query >= 0 {
+					key = query[match.Query].Key
+					queryLoc = query[match.Query].Loc.String()
+				}
+				fields := []string{id, key, match.Status, refLoc, queryLoc}
+				if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		} else {
+			total := annotationStats{}
+			stats := map[string]*annotationStats{}
+			for _, match := range matches {
+				key := ""
+				if match.Reference >= 0 {
+					key = ref[match.Reference].Key
+				} else {
+					key = query[match.Query].Key
+				}
+				if _, ok := stats[key]; !ok {
+					stats[key] = &annotationStats{}
+				}
+				stats[key].Add(match.Status)
+				total.Add(match.Status)
+			}
+
+			keys := make([]string, 0, len(stats))
+			for key := range stats {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				fields := append([]string{id, key}, stats[key].Fields()...)
+				if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+
+			fields := append([]string{id, "*"}, total.Fields()...)
+			if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
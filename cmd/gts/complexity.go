@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("complexity", "annotate or mask homopolymer runs and low-complexity regions", complexityFunc)
+}
+
+func maskSegments(p []byte, segments []gts.Segment, mode string) []byte {
+	q := make([]byte, len(p))
+	copy(q, p)
+	if mode == "soft" {
+		q = bytes.ToUpper(q)
+	}
+	for _, segment := range segments {
+		start, end := gts.Unpack(segment)
+		for i := start; i < end; i++ {
+			switch mode {
+			case "soft":
+				q[i] = bytes.ToLower(q[i : i+1])[0]
+			default:
+				q[i] = 'n'
+			}
+		}
+	}
+	return q
+}
+
+func complexityFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	featureKey := opt.String('k', "key", "misc_feature", "key for the reported region features")
+	homopolymer := opt.Int('p', "homopolymer", 8, "minimum length of a homopolymer run (0 to disable)")
+	window := opt.Int('w', "window", 64, "window size for the low-complexity (DUST) score")
+	threshold := opt.Float('t', "threshold", 20, "DUST score above which a window is low-complexity (0 to disable)")
+	mask := opt.String('m', "mask", "", "mask the regions instead of annotating them (soft, hard)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	switch *mask {
+	case "", "soft", "hard":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown mask mode %q: expected one of soft, hard", *mask))
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"featureKey", *featureKey},
+			{"homopolymer", *homopolymer},
+			{"window", *window},
+			{"threshold", *threshold},
+			{"mask", *mask},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+		p := seq.Bytes()
+
+		runs := gts.Homopolymers(p, *homopolymer)
+		dust := []gts.Segment{}
+		if *threshold > 0 {
+			dust = gts.LowComplexity(p, *window, *threshold)
+		}
+
+		if *mask != "" {
+			segments := append(append([]gts.Segment{}, runs...), dust...)
+			seq = gts.WithBytes(seq, maskSegments(p, segments, *mask))
+		} else {
+			ff := seq.Features()
+			for _, segment := range runs {
+				start, end := gts.Unpack(segment)
+				props := gts.Props{}
+				props.Add("note", fmt.Sprintf("homopolymer run of %d %c", end-start, p[start]))
+				ff = ff.Insert(gts.NewFeature(*featureKey, gts.Range(start, end), props))
+			}
+			for _, segment := range dust {
+				start, end := gts.Unpack(segment)
+				props := gts.Props{}
+				props.Add("note", fmt.Sprintf("low complexity region (DUST score %.1f)", gts.DustScore(p[start:end])))
+				ff = ff.Insert(gts.NewFeature(*featureKey, gts.Range(start, end), props))
+			}
+			seq = gts.WithFeatures(seq, ff)
+		}
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("coordinates", "convert positions between the coordinate systems of the feature(s)", coordinatesFunc)
+}
+
+// coordinateSpaces are the coordinate systems which positions may be given
+// in with the `--from` option.
+var coordinateSpaces = []string{"genomic", "rotated", "local", "transcript", "protein"}
+
+// toGenomic converts a 0-based position in the given coordinate system into
+// the genomic position, reporting false if the position cannot be mapped.
+func toGenomic(fc gts.FeatureCoordinates, space string, pos, rotate, length int) (int, bool) {
+	switch space {
+	case "genomic":
+		return pos, 0 <= pos && pos < length
+	case "rotated":
+		return gts.RotatedPosition(pos, -rotate, length), 0 <= pos && pos < length
+	case "local":
+		return fc.FromLocal(pos)
+	case "transcript":
+		return fc.FromTranscript(pos)
+	default:
+		t, ok := fc.FromProtein(pos)
+		if !ok {
+			return 0, false
+		}
+		return fc.FromTranscript(t)
+	}
+}
+
+// coordinateFields returns the 1-based positions corresponding to the given
+// genomic position in each of the coordinate systems, followed by the
+// position within the codon.
+func coordinateFields(fc gts.FeatureCoordinates, pos, rotate, length int) []string {
+	format := func(n int, ok bool) string {
+		if !ok {
+			return "-"
+		}
+		return strconv.Itoa(n + 1)
+	}
+	local, lok := fc.Local(pos)
+	t, tok := fc.Transcript(pos)
+	residue, phase, pok := 0, 0, false
+	if tok {
+		residue, phase, pok = fc.Protein(t)
+	}
+	return []string{
+		format(pos, true),
+		format(gts.RotatedPosition(pos, rotate, length), true),
+		format(local, lok),
+		format(t, tok),
+		format(residue, pok),
+		format(phase, pok),
+	}
+}
+
+func coordinatesFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	selector := pos.String("selector", "feature selector (syntax: [feature_key][/[qualifier1][=regexp1]][/[qualifier2][=regexp2]]...)")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	positions := opt.IntSlice('p', "position", nil, "1-based position(s) to convert (defaults to every base of the feature)")
+	from := opt.String('f', "from", "genomic", "coordinate system of the given positions (`genomic`, `rotated`, `local`, `transcript`, or `protein`)")
+	rotate := opt.Int('r', "rotate", 0, "amount by which the sequence is rotated in the `rotated` coordinate system")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+
+	valid := false
+	for _, space := range coordinateSpaces {
+		valid = valid || space == *from
+	}
+	if !valid {
+		return ctx.Raise(fmt.Errorf("unknown coordinate system %q: expected one of %s", *from, strings.Join(coordinateSpaces, ", ")))
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"selector", *selector},
+			{"positions", *positions},
+			{"from", *from},
+			{"rotate", *rotate},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "feature", "location", "given", "genomic", "rotated", "local", "transcript", "protein", "codon"}
+		header := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+		if _, err := io.WriteString(w, header); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+		length := gts.Len(seq)
+
+		for _, f := range seq.Features().Filter(filter) {
+			fc := gts.NewFeatureCoordinates(f, length)
+
+			space, given := *from, *positions
+			if len(given) == 0 {
+				space, given = "transcript", make([]int, fc.Len())
+				for j := range given {
+					given[j] = j + 1
+				}
+			}
+
+			for _, p := range given {
+				fields := []string{id, f.Key, f.Loc.String(), strconv.Itoa(p)}
+				if g, ok := toGenomic(fc, space, p-1, *rotate, length); ok {
+					fields = append(fields, coordinateFields(fc, g, *rotate, length)...)
+				} else {
+					fields = append(fields, "-", "-", "-", "-", "-", "-")
+				}
+				line := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+				if _, err := io.WriteString(w, line); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
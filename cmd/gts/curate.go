@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("curate", "normalize the product names of features", curateFunc)
+}
+
+func curateFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	synonymsPath := opt.String('s', "synonyms", "", "tab-delimited table of product names and their replacements")
+	banned := opt.StringSlice('b', "ban", nil, "additional term(s) which render a product name uninformative")
+	nodefault := opt.Switch(0, "no-default-ban", "do not use the default list of banned terms")
+	fallback := opt.String('f', "fallback", seqio.DefaultProductFallback, "product name to replace uninformative names with")
+	keepcase := opt.Switch(0, "keep-case", "do not lowercase the first letter of product names")
+	names := opt.StringSlice('n', "name", []string{"product"}, "qualifier name(s) to curate")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	curator := seqio.NewProductCurator()
+	if *nodefault {
+		curator.Banned = nil
+	}
+	curator.Banned = append(curator.Banned, *banned...)
+	curator.Fallback = *fallback
+	curator.KeepCase = *keepcase
+
+	synonymsSum := []byte{}
+	if *synonymsPath != "" {
+		f, err := os.Open(*synonymsPath)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *synonymsPath, err))
+		}
+		defer f.Close()
+
+		h.Reset()
+		if err := curator.ReadSynonyms(attach(h, f)); err != nil {
+			return ctx.Raise(fmt.Errorf("failed to read synonyms in %q: %v", *synonymsPath, err))
+		}
+		synonymsSum = h.Sum(nil)
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"synonyms", encodeToString(synonymsSum)},
+			{"banned", curator.Banned},
+			{"fallback", *fallback},
+			{"keepcase", *keepcase},
+			{"names", *names},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+		ff := make([]gts.Feature, len(seq.Features()))
+		for i, f := range seq.Features() {
+			props := f.Props.Clone()
+			for _, name := range *names {
+				values := props.Get(name)
+				for j, value := range values {
+					values[j] = curator.Curate(value)
+				}
+			}
+			ff[i] = gts.NewFeature(f.Key, f.Loc, props)
+		}
+		seq = gts.WithFeatures(seq, ff)
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
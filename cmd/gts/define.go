@@ -3,12 +3,10 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -23,18 +21,14 @@ func defineFunc(ctx *flags.Context) error {
 	key := pos.String("key", "feature key")
 	locstr := pos.String("location", "feature location")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	propstrs := opt.StringSlice('q', "qualifier", nil, "qualifier key-value pairs (syntax: key=value))")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -81,9 +75,9 @@ func defineFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
 	for scanner.Scan() {
 		seq := scanner.Value()
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	degenerateSet := flags.CommandSet{}
+
+	degenerateSet.Register("expand", "enumerate the concrete sequences represented by degenerate sequence(s)", degenerateExpandFunc)
+	degenerateSet.Register("consensus", "compress sequences of equal length into a degenerate consensus", degenerateConsensusFunc)
+
+	flags.Register("degenerate", "expand and compress degenerate nucleotide sequences", degenerateSet.Compile())
+}
+
+func degenerateExpandFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	count := opt.Switch('c', "count", "report the number of represented sequences instead of the sequences")
+	max := opt.Int('m', "max", 1024, "maximum number of sequences a single sequence may expand to")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *max < 1 {
+		return ctx.Raise(fmt.Errorf("maximum number of sequences must be positive: got %d", *max))
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+
+	if *count {
+		for i := 0; scanner.Scan(); i++ {
+			seq := scanner.Value()
+			n := gts.DegenerateCount(seq.Bytes())
+			if _, err := fmt.Fprintf(buffer, "%s\t%d\n", seqID(seq, i), n); err != nil {
+				return ctx.Raise(err)
+			}
+			if err := buffer.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+		}
+
+		return nil
+	}
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"max", *max},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		p := seq.Bytes()
+		if n := gts.DegenerateCount(p); n > *max {
+			return ctx.Raise(fmt.Errorf("%s: represents %d sequences, exceeding the maximum of %d", id, n, *max))
+		}
+
+		for j, q := range gts.ExpandDegenerate(p, *max) {
+			out := gts.WithBytes(seq, q)
+			out = seqio.WithID(out, fmt.Sprintf("%s_%d", id, j+1))
+			if _, err := writer.WriteSeq(out); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
+
+func degenerateConsensusFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	id := opt.String(0, "id", "consensus", "identifier of the consensus sequence")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"id", *id},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	// The consensus is computed as the sequences are read so that only the
+	// first sequence is held in memory.
+	var first gts.Sequence
+	builder := gts.ConsensusBuilder{}
+	scanner := newSeqScanner(d)
+	for scanner.Scan() {
+		seq := scanner.Value()
+		if first == nil {
+			first = seq
+		}
+		if err := builder.Add(seq.Bytes()); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if builder.Len() == 0 {
+		return ctx.Raise(errors.New("no sequences to compute a consensus of"))
+	}
+
+	seq := gts.WithFeatures(gts.WithBytes(first, builder.Bytes()), nil)
+	seq = seqio.WithID(seq, *id)
+
+	writer := newSeqWriter(d, filetype)
+
+	if _, err := writer.WriteSeq(seq); err != nil {
+		return ctx.Raise(err)
+	}
+
+	return nil
+}
@@ -3,13 +3,11 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/go-flip/flip"
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -23,18 +21,15 @@ func deleteFunc(ctx *flags.Context) error {
 
 	locstr := pos.String("locator", "a locator string ([modifier|selector|point|range][@modifier])")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	erase := opt.Switch('e', "erase", "remove features contained in the deleted regions")
+	verbose := opt.Switch('v', "verbose", "report the features truncated or removed by the deletion to standard error")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -54,12 +49,12 @@ func deleteFunc(ctx *flags.Context) error {
 		filetype = seqio.ToFileType(*format)
 	}
 
-	delete := gts.Delete
+	remove := gts.DeleteReport
 	if *erase {
-		delete = gts.Erase
+		remove = gts.EraseReport
 	}
 
-	if !*nocache {
+	if !*nocache && !*verbose {
 		data := encodePayload([]tuple{
 			{"command", strings.Join(ctx.Name, "-")},
 			{"version", gts.Version.String()},
@@ -74,18 +69,29 @@ func deleteFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
-	for scanner.Scan() {
+	for i := 0; scanner.Scan(); i++ {
 		seq := scanner.Value()
+		id := seqID(seq, i)
 
 		ss := gts.Minimize(locate(seq))
 		flip.Flip(gts.BySegment(ss))
 		for _, s := range ss {
-			i, n := s.Head(), s.Len()
-			seq = delete(seq, i, n)
+			loc, err := gts.TryRange(gts.Unpack(s))
+			if err != nil {
+				return ctx.Raise(fmt.Errorf("%s: invalid region: %v", id, err))
+			}
+			if s[0] < 0 || gts.Len(seq) < s[1] {
+				return ctx.Raise(fmt.Errorf("%s: region %s is out of bounds for a sequence of length %d", id, loc, gts.Len(seq)))
+			}
+			var invs []gts.Invalidation
+			seq, invs = remove(seq, s.Head(), s.Len())
+			if *verbose {
+				reportInvalidations(ctx, id, invs)
+			}
 		}
 
 		if _, err := writer.WriteSeq(seq); err != nil {
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestDeleteOutOfBounds(t *testing.T) {
+	record := ">foo\nacgtacgtac\n"
+
+	for _, locator := range []string{"5..20", "11", "^-5..^"} {
+		_, err := runCommand(t, record, "delete", locator)
+		if err == nil {
+			t.Errorf("expected error deleting %q from a sequence of length 10", locator)
+			continue
+		}
+		if !strings.Contains(err.Error(), "out of bounds") {
+			t.Errorf("delete %q: unexpected error: %v", locator, err)
+		}
+	}
+
+	out, err := runCommand(t, record, "delete", "3..5")
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	testutils.Equals(t, out, ">foo\naccgtac\n")
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("digest", "digest the given sequence(s) with restriction enzymes", digestFunc)
+}
+
+// lookupEnzymes resolves the given enzyme names case insensitively using the
+// built-in enzyme table, extended or overridden by the given enzymes.
+func lookupEnzymes(names []string, extra []gts.Enzyme) ([]gts.Enzyme, error) {
+	builtin, err := gts.ReadEnzymes(strings.NewReader(gts.RestrictionEnzymes))
+	if err != nil {
+		return nil, err
+	}
+
+	table := make(map[string]gts.Enzyme)
+	for _, e := range append(builtin, extra...) {
+		table[strings.ToLower(e.Name)] = e
+	}
+
+	enzymes := make([]gts.Enzyme, len(names))
+	for i, name := range names {
+		e, ok := table[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown restriction enzyme: %q", name)
+		}
+		enzymes[i] = e
+	}
+	return enzymes, nil
+}
+
+func digestFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	names := opt.StringSlice('e', "enzyme", nil, "name of a restriction enzyme to digest with")
+	databasePath := opt.String('d', "database", "", "file of additional restriction enzymes (syntax: name site)")
+	tablePath := opt.String('t', "table", "", "output file of a table of the cut positions")
+	fragmentsPath := opt.String('f', "fragments", "", "output file of a table of the fragments")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if len(*names) == 0 {
+		return ctx.Raise(errors.New("at least one restriction enzyme is required"))
+	}
+
+	h.Reset()
+	extra := []gts.Enzyme{}
+	if *databasePath != "" {
+		f, err := os.Open(*databasePath)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to open file: %q: %v", *databasePath, err))
+		}
+		defer f.Close()
+
+		extra, err = gts.ReadEnzymes(attach(h, f))
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to read restriction enzymes %q: %v", *databasePath, err))
+		}
+	}
+	databaseSum := h.Sum(nil)
+
+	enzymes, err := lookupEnzymes(*names, extra)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache && *tablePath == "" && *fragmentsPath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"enzymes", *names},
+			{"database", encodeToString(databaseSum)},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	var table *bufio.Writer
+	if *tablePath != "" {
+		w, err := createOutput(*tablePath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		table = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "enzyme", "position"}
+		if _, err := fmt.Fprintf(table, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	var fragments *bufio.Writer
+	if *fragmentsPath != "" {
+		w, err := createOutput(*fragmentsPath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		fragments = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "start", "end", "length", "left", "right"}
+		if _, err := fmt.Fprintf(fragments, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		circular := false
+		if info, ok := seq.Info().(seqio.GenBankFields); ok {
+			circular = info.Topology == gts.Circular
+		}
+
+		cuts := []int{}
+		cutters := make(map[int][]string)
+		for _, e := range enzymes {
+			for _, cut := range e.Cuts(seq, circular) {
+				cuts = append(cuts, cut)
+				cutters[cut] = append(cutters[cut], e.Name)
+				if table != nil {
+					if _, err := fmt.Fprintf(table, "%s\t%s\t%d\n", id, e.Name, cut+1); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+			}
+		}
+
+		if table != nil {
+			if err := table.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if fragments != nil {
+			n := gts.Len(seq)
+			flank := func(cut int) string {
+				if cut >= n {
+					cut -= n
+				}
+				if names, ok := cutters[cut]; ok {
+					return strings.Join(names, ",")
+				}
+				return "."
+			}
+			for _, s := range gts.DigestSegments(n, cuts, circular) {
+				start, end := s[0]+1, s[1]
+				if end > n {
+					end -= n
+				}
+				fields := []string{id, strconv.Itoa(start), strconv.Itoa(end), strconv.Itoa(s.Len()), flank(s[0]), flank(s[1])}
+				if _, err := fmt.Fprintf(fragments, "%s\n", strings.Join(fields, "\t")); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+			if err := fragments.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		for _, out := range gts.Digest(seq, cuts, circular) {
+			if _, err := writer.WriteSeq(out); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestDigestTables(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gts-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tablePath := filepath.Join(dir, "cuts.tsv")
+	fragmentsPath := filepath.Join(dir, "fragments.tsv")
+	record := ">foo\nccgaattcaaaaggatccttttgaattcgg\n"
+	args := []string{"digest", "-e", "EcoRI", "-e", "BamHI", "-t", tablePath, "-f", fragmentsPath}
+	out, err := runCommand(t, record, args...)
+	if err != nil {
+		t.Fatalf("digest failed: %v", err)
+	}
+	testutils.Equals(t, out, ">foo\nccg\n>foo\naattcaaaag\n>foo\ngatccttttg\n>foo\naattcgg\n")
+
+	p, err := ioutil.ReadFile(tablePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "seqid\tenzyme\tposition\n" +
+		"foo\tEcoRI\t4\n" +
+		"foo\tEcoRI\t24\n" +
+		"foo\tBamHI\t14\n"
+	testutils.Equals(t, string(p), exp)
+
+	p, err = ioutil.ReadFile(fragmentsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp = "seqid\tstart\tend\tlength\tleft\tright\n" +
+		"foo\t1\t3\t3\t.\tEcoRI\n" +
+		"foo\t4\t13\t10\tEcoRI\tBamHI\n" +
+		"foo\t14\t23\t10\tBamHI\tEcoRI\n" +
+		"foo\t24\t30\t7\tEcoRI\t.\n"
+	testutils.Equals(t, string(p), exp)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("dist", "estimate the distances between sequences using MinHash sketches", distFunc)
+}
+
+func distFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	refPath := pos.String("reference", "reference sketch or sequence file")
+
+	seqinPath := pos.String("seqin", "query sketch or sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	k := opt.Int('k', "kmer", 21, "k-mer size used when sketching sequence files")
+	size := opt.Int('s', "size", 1000, "number of hashes to keep when sketching sequence files")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+	maxdist := opt.Float('m', "max-distance", 1, "only report pairs with a distance at most this value")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *k < 1 {
+		return ctx.Raise(fmt.Errorf("k-mer size must be positive: got %d", *k))
+	}
+
+	if *size < 1 {
+		return ctx.Raise(fmt.Errorf("sketch size must be positive: got %d", *size))
+	}
+
+	f, err := os.Open(*refPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *refPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	refs, err := readSketches(attach(h, f), *k, *size)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	refSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"reference", encodeToString(refSum)},
+			{"kmer", *k},
+			{"size", *size},
+			{"delim", *delim},
+			{"noheader", *noheader},
+			{"maxdist", *maxdist},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	queries, err := readSketches(d, *k, *size)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"query", "reference", "distance", "jaccard", "shared"}
+		header := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+		if _, err := io.WriteString(w, header); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for _, query := range queries {
+		for _, ref := range refs {
+			if err := query.Compatible(ref.Sketch); err != nil {
+				return ctx.Raise(fmt.Errorf("cannot compare %q and %q: %v", query.ID, ref.ID, err))
+			}
+
+			shared, total := query.Shared(ref.Sketch)
+			jaccard := query.Jaccard(ref.Sketch)
+			dist := gts.MashDistance(jaccard, query.K)
+			if dist > *maxdist {
+				continue
+			}
+
+			fields := []string{
+				query.ID,
+				ref.ID,
+				fmt.Sprintf("%g", dist),
+				fmt.Sprintf("%g", jaccard),
+				fmt.Sprintf("%d/%d", shared, total),
+			}
+			line := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+			if _, err := io.WriteString(w, line); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
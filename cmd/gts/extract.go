@@ -3,13 +3,13 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -26,24 +26,89 @@ func containsRegion(rr []gts.Region, r gts.Region) bool {
 	return false
 }
 
+// definingFeature returns the feature spanning the entirety of the extracted
+// sequence, which is the feature the sequence was extracted from if any.
+func definingFeature(seq gts.Sequence) gts.Feature {
+	n := gts.Len(seq)
+	for _, f := range seq.Features() {
+		if f.Key != "source" && f.Loc.Len() == n {
+			return f
+		}
+	}
+	return gts.Feature{}
+}
+
+// extractStat computes a statistic of an extracted sequence and the feature
+// it was extracted from.
+type extractStat func(seq gts.Sequence, f gts.Feature) (interface{}, error)
+
+// newExtractStat returns the extractStat for the given statistic name, which
+// is one of `gc`, `length`, or `cai=<table>`, along with the codon usage read
+// from the table for the `cai` statistic.
+func newExtractStat(s string, id int) (extractStat, *gts.CodonUsage, error) {
+	name, arg := s, ""
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		name, arg = s[:i], s[i+1:]
+	}
+
+	switch name {
+	case "gc":
+		return func(seq gts.Sequence, f gts.Feature) (interface{}, error) {
+			return gts.GCContent(seq.Bytes()), nil
+		}, nil, nil
+
+	case "length":
+		return func(seq gts.Sequence, f gts.Feature) (interface{}, error) {
+			return float64(gts.Len(seq)), nil
+		}, nil, nil
+
+	case "cai":
+		if arg == "" {
+			return nil, nil, fmt.Errorf("statistic %q requires a codon usage table (syntax: cai=<table>)", s)
+		}
+		file, err := os.Open(arg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open file: %q: %v", arg, err)
+		}
+		defer file.Close()
+		usage, err := gts.ReadCodonUsage(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read codon usage table %q: %v", arg, err)
+		}
+		return func(seq gts.Sequence, f gts.Feature) (interface{}, error) {
+			table, err := gts.TranslationTable(f, id)
+			if err != nil {
+				return nil, err
+			}
+			p := seq.Bytes()
+			p = p[gts.Min(gts.CodonStart(f), len(p)):]
+			return gts.CAI(p, usage, table), nil
+		}, &usage, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown statistic %q: expected one of `gc`, `length`, or `cai=<table>`", s)
+	}
+}
+
 func extractFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
 	locstrs := pos.Extra("locator", "a locator string ([specifier][@modifier])")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	invert := opt.Switch('v', "invert-region", "extract the sequences that are not referenced by the features")
+	exprstrs := opt.StringSlice('e', "expr", nil, "expression to compute for each extracted sequence (reports a table instead of sequences)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns (used with --expr or --stat)")
+	noheader := opt.Switch('H', "no-header", "do not print the header line (used with --expr or --stat)")
+	statstrs := opt.StringSlice('s', "stat", nil, "statistic to compute for each extracted sequence: gc, length, or cai=<table> (reports a table instead of sequences)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier (used with --stat cai)")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -72,11 +137,46 @@ func extractFunc(ctx *flags.Context) error {
 		locators[i] = locator
 	}
 
+	exprs := make([]gts.Expr, len(*exprstrs))
+
+	for i, exprstr := range *exprstrs {
+		expr, err := gts.AsExpr(exprstr)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("invalid expression %q: %v", exprstr, err))
+		}
+		exprs[i] = expr
+	}
+
+	if _, err := gts.LookupCodonTable(*table); err != nil {
+		return ctx.Raise(err)
+	}
+
+	stats := make([]extractStat, len(*statstrs))
+	usages := []*gts.CodonUsage{}
+
+	for i, statstr := range *statstrs {
+		stat, usage, err := newExtractStat(statstr, *table)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		stats[i] = stat
+		usages = append(usages, usage)
+	}
+
+	tabular := len(exprs) > 0 || len(stats) > 0
+
 	if !*nocache {
 		data := encodePayload([]tuple{
 			{"command", strings.Join(ctx.Name, "-")},
 			{"version", gts.Version.String()},
 			{"locators", *locstrs},
+			{"invert", *invert},
+			{"exprs", *exprstrs},
+			{"stats", *statstrs},
+			{"usages", usages},
+			{"table", *table},
+			{"delim", *delim},
+			{"noheader", *noheader},
 			{"filetype", filetype},
 		})
 
@@ -86,11 +186,20 @@ func extractFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
+
+	if tabular && !*noheader {
+		fields := append([]string{"seqid"}, *exprstrs...)
+		fields = append(fields, *statstrs...)
+		header := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+		if _, err := io.WriteString(buffer, header); err != nil {
+			return ctx.Raise(err)
+		}
+	}
 
-	for scanner.Scan() {
+	for i := 0; scanner.Scan(); i++ {
 		seq := scanner.Value()
 
 		rr := make([]gts.Region, 0)
@@ -109,8 +218,28 @@ func extractFunc(ctx *flags.Context) error {
 
 		for _, region := range rr {
 			if len(rr) == 1 || region.Len() != gts.Len(seq) {
-				out := region.Locate(seq)
-				if _, err := writer.WriteSeq(out); err != nil {
+				out, err := gts.LocateRegion(region, seq, seqResolve())
+				if err != nil {
+					return ctx.Raise(err)
+				}
+				if tabular {
+					env := gts.ExprEnv{Seq: out, Feature: definingFeature(out)}
+					fields := []string{seqID(seq, i)}
+					for _, expr := range exprs {
+						fields = append(fields, gts.FormatExprValue(expr(env)))
+					}
+					for _, stat := range stats {
+						v, err := stat(env.Seq, env.Feature)
+						if err != nil {
+							return ctx.Raise(err)
+						}
+						fields = append(fields, gts.FormatExprValue(v))
+					}
+					line := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+					if _, err := io.WriteString(buffer, line); err != nil {
+						return ctx.Raise(err)
+					}
+				} else if _, err := writer.WriteSeq(out); err != nil {
 					return ctx.Raise(err)
 				}
 				if err := buffer.Flush(); err != nil {
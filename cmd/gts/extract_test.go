@@ -0,0 +1,19 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractEmptyRegion(t *testing.T) {
+	out, err := runCommand(t, remoteRecord, "extract", "31")
+	if err != nil {
+		t.Fatalf("extract failed: %v", err)
+	}
+	if !strings.HasPrefix(out, "LOCUS       TEST0001                   0 bp") {
+		t.Errorf("extract %q = %q, want an empty record", "31", out)
+	}
+	if strings.Contains(out, "REGION") {
+		t.Errorf("extract %q = %q, want no REGION for an empty region", "31", out)
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/cmd/cache"
+	"github.com/go-gts/gts/cmd/httpclient"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("fetch", "retrieve sequence(s) by accession", fetchFunc)
+}
+
+func gtsFetchCacheDir() (string, error) {
+	dir, err := gtsCacheDir()
+	if err != nil {
+		return dir, err
+	}
+	return filepath.Join(dir, "fetch"), nil
+}
+
+func openFetchCache() (*cache.Store, error) {
+	dir, err := gtsFetchCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return cache.OpenStore(dir, newHash())
+}
+
+// cachedResolver stores the sequences retrieved by a remote resolver in the
+// fetch cache so that they will not be retrieved again until they expire.
+type cachedResolver struct {
+	r       seqio.Resolver
+	store   *cache.Store
+	ttl     time.Duration
+	offline bool
+}
+
+func (c cachedResolver) Resolve(accession string) (gts.Sequence, error) {
+	ref, p, err := c.store.Get(accession)
+	if err == nil && (c.offline || !ref.Expired(time.Now())) {
+		scanner := newSeqScanner(bytes.NewReader(p))
+		if scanner.Scan() {
+			return scanner.Value(), nil
+		}
+	}
+
+	if c.offline {
+		return nil, fmt.Errorf("%s is not in the fetch cache (running offline)", accession)
+	}
+
+	seq, err := c.r.Resolve(accession)
+	if err != nil {
+		return nil, err
+	}
+
+	filetype := seqio.FastaFile
+	if _, ok := seq.Info().(seqio.GenBankFields); ok {
+		filetype = seqio.GenBankFile
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := seqio.NewWriter(b, filetype).WriteSeq(seq); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.Put(accession, b.Bytes(), c.ttl); err != nil {
+		fmt.Fprintf(os.Stderr, "gts fetch: failed to cache %q: %v\n", accession, err)
+	}
+
+	return seq, nil
+}
+
+func fetchResolver(dirs, urls []string, noentrez bool, store *cache.Store, ttl time.Duration, offline bool) seqio.Resolver {
+	chain := seqio.ChainResolver{}
+	for _, dir := range dirs {
+		chain = append(chain, seqio.DirResolver(dir))
+	}
+
+	client := httpclient.New()
+
+	remote := seqio.ChainResolver{}
+	for _, tmpl := range urls {
+		remote = append(remote, seqio.HTTPResolver{Template: tmpl, Client: client})
+	}
+	if !noentrez {
+		entrez := seqio.NewEntrezResolver()
+		entrez.Client = client
+		remote = append(remote, entrez)
+	}
+
+	if store != nil {
+		return append(chain, cachedResolver{remote, store, ttl, offline})
+	}
+	return append(chain, remote...)
+}
+
+// memoResolver remembers the sequences resolved by a resolver so that each
+// accession is resolved only once.
+type memoResolver struct {
+	r    seqio.Resolver
+	seqs map[string]gts.Sequence
+}
+
+func (m memoResolver) Resolve(accession string) (gts.Sequence, error) {
+	if seq, ok := m.seqs[accession]; ok {
+		return seq, nil
+	}
+	seq, err := m.r.Resolve(accession)
+	if err != nil {
+		return nil, err
+	}
+	m.seqs[accession] = seq
+	return seq, nil
+}
+
+// seqResolver is the resolver given by the `--resolver` flags, which is used
+// to locate the regions in remote entries and to reconstruct the sequences of
+// CONTIG records. It is nil if no `--resolver` flag is given.
+var seqResolver seqio.Resolver
+
+// newSeqResolver creates a resolver from the sources given by the
+// `--resolver` flags. Each source is either `entrez` for the NCBI Entrez
+// E-utilities, a URL template containing `{accession}`, or a directory to
+// search for files named after the accession. The directories are searched
+// before the remote sources, and the sequences retrieved from the remote
+// sources are stored in the fetch cache.
+func newSeqResolver(sources []string) seqio.Resolver {
+	dirs, urls, entrez := []string{}, []string{}, false
+	for _, source := range sources {
+		switch {
+		case source == "entrez":
+			entrez = true
+		case strings.Contains(source, "{accession}"):
+			urls = append(urls, source)
+		default:
+			dirs = append(dirs, source)
+		}
+	}
+
+	store, err := openFetchCache()
+	if err != nil {
+		store = nil
+	}
+
+	r := fetchResolver(dirs, urls, !entrez, store, 168*time.Hour, false)
+	return memoResolver{r, map[string]gts.Sequence{}}
+}
+
+// seqResolve returns the function resolving accessions with the resolver
+// given by the `--resolver` flags, or nil if no such flag is given.
+func seqResolve() gts.ResolveFunc {
+	if seqResolver == nil {
+		return nil
+	}
+	return seqResolver.Resolve
+}
+
+func fetchFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	accessions := pos.Extra("accession", "accession(s) of the sequence(s) to retrieve")
+
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as retrieved)")
+	dirs := opt.StringSlice('D', "directory", nil, "directory to search for files named after the accession")
+	urls := opt.StringSlice('u', "url", nil, "URL template to retrieve from (`{accession}` will be replaced)")
+	noentrez := opt.Switch(0, "no-entrez", "do not retrieve sequences from NCBI Entrez")
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	offline := opt.Switch(0, "offline", "only retrieve remote sequences from the cache")
+	expiry := opt.String('t', "ttl", "168h", "duration before a cached sequence is retrieved again (`0` to never expire)")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	ttl, err := time.ParseDuration(*expiry)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid duration for --ttl: %v", err))
+	}
+
+	if *nocache && *offline {
+		return ctx.Raise(errors.New("--offline cannot be used with --no-cache"))
+	}
+
+	var store *cache.Store
+	if !*nocache {
+		if store, err = openFetchCache(); err != nil {
+			if *offline {
+				return ctx.Raise(fmt.Errorf("failed to open fetch cache: %v", err))
+			}
+			store = nil
+		}
+	}
+
+	r := fetchResolver(*dirs, *urls, *noentrez, store, ttl, *offline)
+
+	d, err := newIODelegate("-", *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for _, accession := range *accessions {
+		seq, err := r.Resolve(accession)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to fetch %q: %v", accession, err))
+		}
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("gaps", "report or flag the features interrupted by assembly gaps", gapsFunc)
+}
+
+// gapNote returns the note flagging a feature interrupted by a gap.
+func gapNote(status string, gap gts.Segment) string {
+	return fmt.Sprintf("interrupted by gap at %s (%s)", gts.Range(gap[0], gap[1]), status)
+}
+
+func gapsFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format for --annotate and --length (defaults to same as input)")
+	annotate := opt.Switch('a', "annotate", "flag the interrupted features with a note instead of reporting them")
+	length := opt.Int('l', "length", -1, "resize every gap to the given length, projecting the features onto the resized gaps")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns of the table")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the table")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*outPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"filetype", filetype},
+			{"annotate", *annotate},
+			{"length", *length},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+	table := !*annotate && *length < 0
+
+	if table && !*noheader {
+		fields := []string{"seqid", "feature", "name", "location", "gap", "status"}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+		gaps := gts.Gaps(seq)
+
+		ff := make(gts.FeatureSlice, len(seq.Features()))
+		for j, f := range seq.Features() {
+			ff[j] = f
+			if f.Key == "source" || gts.IsGap(f) {
+				continue
+			}
+			for _, gap := range gaps {
+				status := gts.GapDisruption(f.Loc, gap)
+				if status == "" {
+					continue
+				}
+				if table {
+					name := featureName(f)
+					if name == "" {
+						name = "-"
+					}
+					fields := []string{id, f.Key, name, f.Loc.String(), gts.Range(gap[0], gap[1]).String(), status}
+					if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+				if *annotate {
+					props := ff[j].Props.Clone()
+					props.Add("note", gapNote(status, gap))
+					ff[j] = gts.NewFeature(f.Key, f.Loc, props)
+				}
+			}
+		}
+
+		if !table {
+			seq = gts.WithFeatures(seq, ff)
+			if *length >= 0 {
+				for k := len(gaps) - 1; k >= 0; k-- {
+					seq = gts.ResizeGap(seq, gaps[k], *length)
+				}
+			}
+			if _, err := writer.WriteSeq(seq); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("gc", "report the GC content and GC skew of the sequence(s)", gcFunc)
+}
+
+func gcFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
+	window := opt.Int('w', "window", 0, "window size in bases (0 reports a single value per sequence)")
+	step := opt.Int(0, "step", 0, "distance between the starts of adjacent windows (defaults to the window size)")
+	format := opt.String('f', "format", "tsv", "output format (tsv, bedgraph)")
+	metric := opt.String('m', "metric", "gc", "metric to write in the bedgraph format (gc, skew, cumskew)")
+	name := opt.String('n', "name", "", "bedgraph track name (defaults to the metric name)")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	switch *format {
+	case "tsv", "bedgraph":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown output format %q: expected one of tsv, bedgraph", *format))
+	}
+
+	switch *metric {
+	case "gc", "skew", "cumskew":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown metric %q: expected one of gc, skew, cumskew", *metric))
+	}
+
+	if *window < 0 {
+		return ctx.Raise(fmt.Errorf("window size must not be negative: got %d", *window))
+	}
+	if *window == 0 && *step != 0 {
+		return ctx.Raise(errors.New("step requires a window size"))
+	}
+	if *step == 0 {
+		*step = *window
+	}
+	if *step < 0 || *step > *window {
+		return ctx.Raise(fmt.Errorf("step must be between 1 and the window size: got %d for window size %d", *step, *window))
+	}
+
+	if *name == "" {
+		*name = *metric
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"window", *window},
+			{"step", *step},
+			{"format", *format},
+			{"metric", *metric},
+			{"name", *name},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		header := fmt.Sprintf("track type=bedGraph name=%q", *name)
+		if *format == "tsv" {
+			fields := []string{"seqid", "start", "end", "gc", "skew", "cumskew"}
+			header = strings.Join(fields, "\t")
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", header); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	formatFloat := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', 6, 64)
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+		p := seq.Bytes()
+		n := len(p)
+
+		windows := []gts.Segment{{0, n}}
+		if *window > 0 {
+			windows = gts.SlidingWindows(n, *window, *step)
+		}
+
+		// The cumulative skew is accumulated over the non-overlapping steps
+		// so that the bases shared by overlapping windows are counted once.
+		cumskew := 0.0
+		for _, segment := range windows {
+			start, end := gts.Unpack(segment)
+			gc := gts.GCContent(p[start:end])
+			skew := gts.GCSkew(p[start:end])
+			if *window > 0 {
+				cumskew += gts.GCSkew(p[start:gts.Min(start+*step, n)])
+			} else {
+				cumskew += skew
+			}
+
+			var err error
+			switch *format {
+			case "bedgraph":
+				value := gc
+				switch *metric {
+				case "skew":
+					value = skew
+				case "cumskew":
+					value = cumskew
+				}
+				if *window > 0 {
+					end = gts.Min(start+*step, n)
+				}
+				_, err = fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", id, start, end, formatFloat(value))
+			default:
+				_, err = fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n", id, start+1, end, formatFloat(gc), formatFloat(skew), formatFloat(cumskew))
+			}
+			if err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
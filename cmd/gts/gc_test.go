@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+)
+
+func TestGCCumulativeSkewOverlapping(t *testing.T) {
+	p := "ggggcccaagcgcgttttgcatgcgggcccggatcgatcg"
+	window, step := 10, 4
+
+	out, err := runCommand(t, ">foo\n"+p+"\n", "gc", "-w", strconv.Itoa(window), "--step", strconv.Itoa(step), "-H")
+	if err != nil {
+		t.Fatalf("gc failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	windows := gts.SlidingWindows(len(p), window, step)
+	if len(lines) != len(windows) {
+		t.Fatalf("gc reported %d windows, want %d", len(lines), len(windows))
+	}
+
+	// The cumulative skew of each window is the skew of the bases from the
+	// start of the sequence up to the end of the step, summed over steps.
+	exp := 0.0
+	for i, segment := range windows {
+		start := segment.Head()
+		exp += gts.GCSkew([]byte(p[start:gts.Min(start+step, len(p))]))
+
+		fields := strings.Split(lines[i], "\t")
+		if fields[1] != strconv.Itoa(start+1) {
+			t.Errorf("start of window %d = %s, want 1-based %d", i, fields[1], start+1)
+		}
+		out, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			t.Fatalf("cumskew of window %d: %v", i, err)
+		}
+		if d := out - exp; d < -1e-5 || 1e-5 < d {
+			t.Errorf("cumskew of window %d = %g, want %g", i, out, exp)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("grep", "select sequences belonging to the given taxonomic clade(s)", grepFunc)
+}
+
+type seqMatcher func(seq gts.Sequence) bool
+
+func sourceOrganism(seq gts.Sequence) (seqio.Organism, bool) {
+	switch info := seq.Info().(type) {
+	case seqio.GenBankFields:
+		return info.Source, true
+	default:
+		return seqio.Organism{}, false
+	}
+}
+
+func cladeMatcher(clade string, tax *seqio.Taxonomy) (seqMatcher, error) {
+	id, err := strconv.Atoi(clade)
+	isID := err == nil
+
+	if tax == nil {
+		if isID {
+			return func(seq gts.Sequence) bool {
+				taxid, ok := seqio.SourceTaxID(seq)
+				return ok && taxid == id
+			}, nil
+		}
+		return func(seq gts.Sequence) bool {
+			org, ok := sourceOrganism(seq)
+			return ok && org.InClade(clade)
+		}, nil
+	}
+
+	if !isID {
+		v, ok := tax.TaxID(clade)
+		if !ok {
+			return nil, fmt.Errorf("clade %q not found in taxonomy", clade)
+		}
+		id = v
+	}
+
+	return func(seq gts.Sequence) bool {
+		taxid, ok := seqio.SourceTaxID(seq)
+		if !ok {
+			org, found := sourceOrganism(seq)
+			if !found {
+				return false
+			}
+			if taxid, ok = tax.TaxID(org.Name); !ok {
+				return org.InClade(tax.Name(id))
+			}
+		}
+		return tax.InClade(taxid, id)
+	}, nil
+}
+
+func grepFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	clades := opt.StringSlice('c', "clade", nil, "taxonomic clade name or taxonomy ID to select")
+	taxdump := opt.String('t', "taxdump", "", "directory containing a NCBI taxdump (nodes.dmp and names.dmp)")
+	invert := opt.Switch('v', "invert-match", "select sequences that do not match the given criteria")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	var tax *seqio.Taxonomy
+	if *taxdump != "" {
+		v, err := seqio.LoadTaxonomy(*taxdump)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to load taxdump: %v", err))
+		}
+		tax = v
+	}
+
+	matchers := make([]seqMatcher, len(*clades))
+	for i, clade := range *clades {
+		match, err := cladeMatcher(clade, tax)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		matchers[i] = match
+	}
+
+	match := func(seq gts.Sequence) bool {
+		if len(matchers) == 0 {
+			return true
+		}
+		for _, m := range matchers {
+			if m(seq) {
+				return true
+			}
+		}
+		return false
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"clades", *clades},
+			{"taxdump", *taxdump},
+			{"invert", *invert},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+		if match(seq) == *invert {
+			continue
+		}
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
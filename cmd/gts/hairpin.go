@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("hairpin", "screen the target region(s) for hairpin structures", hairpinFunc)
+}
+
+func hairpinStructure(hairpin gts.Hairpin) string {
+	return strings.Repeat("(", hairpin.Stem) +
+		strings.Repeat(".", hairpin.Loop) +
+		strings.Repeat(")", hairpin.Stem)
+}
+
+func hairpinFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	locstr := opt.String('r', "region", "^..$", "a locator string specifying the target region(s)")
+	minStem := opt.Int(0, "min-stem", 4, "minimum number of base pairs in the stem")
+	minLoop := opt.Int(0, "min-loop", 3, "minimum number of bases in the loop")
+	maxLoop := opt.Int(0, "max-loop", 30, "maximum number of bases in the loop")
+	threshold := opt.Float('t', "threshold", -2, "free energy in kcal/mol at or below which a hairpin is reported as a problem")
+	all := opt.Switch('a', "all", "report all hairpins instead of the most stable one for each region")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *minStem < 1 {
+		return ctx.Raise(fmt.Errorf("minimum stem length must be positive: got %d", *minStem))
+	}
+	if *minLoop < 3 {
+		return ctx.Raise(fmt.Errorf("minimum loop length must be at least 3: got %d", *minLoop))
+	}
+	if *maxLoop < *minLoop {
+		return ctx.Raise(fmt.Errorf("maximum loop length must not be smaller than the minimum: got %d for minimum %d", *maxLoop, *minLoop))
+	}
+
+	locate, err := gts.AsLocator(*locstr)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"locator", *locstr},
+			{"minStem", *minStem},
+			{"minLoop", *minLoop},
+			{"maxLoop", *maxLoop},
+			{"threshold", *threshold},
+			{"all", *all},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "start", "end", "strand", "stem", "loop", "dg", "sequence", "structure", "status"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		for _, region := range locate(seq) {
+			if rr, ok := region.(gts.Regions); ok && len(rr) == 1 {
+				region = rr[0]
+			}
+			if gts.HasRemote(region) {
+				return ctx.Raise(fmt.Errorf("%s: cannot screen region %v in a remote entry", id, region))
+			}
+			segment, ok := region.(gts.Segment)
+			if !ok {
+				return ctx.Raise(fmt.Errorf("%s: cannot screen non-contiguous region %v", id, region))
+			}
+
+			head, tail := gts.Unpack(segment)
+			p := segment.Locate(seq).Bytes()
+
+			hairpins := gts.FindHairpins(p, *minStem, *minLoop, *maxLoop)
+			if !*all && len(hairpins) > 1 {
+				hairpins = hairpins[:1]
+			}
+
+			if len(hairpins) == 0 {
+				start, end, strand := head, tail, "+"
+				if tail < head {
+					start, end, strand = tail, head, "-"
+				}
+				fields := []string{
+					id, strconv.Itoa(start + 1), strconv.Itoa(end), strand,
+					"0", "0", "-", "-", "-", "ok",
+				}
+				if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+
+			for _, hairpin := range hairpins {
+				s, e := hairpin.Start, hairpin.End
+				start, end, strand := head+s, head+e, "+"
+				if tail < head {
+					start, end, strand = head-e, head-s, "-"
+				}
+
+				status := "ok"
+				if hairpin.DeltaG <= *threshold {
+					status = "hairpin"
+				}
+
+				fields := []string{
+					id, strconv.Itoa(start + 1), strconv.Itoa(end), strand,
+					strconv.Itoa(hairpin.Stem), strconv.Itoa(hairpin.Loop),
+					strconv.FormatFloat(hairpin.DeltaG, 'f', 2, 64),
+					string(p[s:e]), hairpinStructure(hairpin), status,
+				}
+				if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -24,18 +23,19 @@ func infixFunc(ctx *flags.Context) error {
 	locstr := pos.String("locator", "a locator string ([modifier|selector|point|range][@modifier])")
 	hostPath := pos.String("host", "host sequence")
 
-	guestPath := new(string)
-	*guestPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		guestPath = pos.String("guest", "input sequence file (may be omitted if standard input is provided)")
-	}
+	guestPath := pos.String("guest", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	embed := opt.Switch('e', "embed", "extend existing feature locations when inserting instead of splitting them")
+	embeds := opt.StringSlice(0, "embed-into", nil, "selector of the features to extend instead of splitting them")
+	splits := opt.StringSlice(0, "split", nil, "selector of the features to split even if --embed is given")
+	protects := opt.StringSlice(0, "protect", nil, "selector of the features which must not be disrupted by the insertion")
+	force := opt.Switch('f', "force", "insert even if a protected feature would be disrupted")
+	verbose := opt.Switch('v', "verbose", "report the features split by the insertion to standard error")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -54,7 +54,7 @@ func infixFunc(ctx *flags.Context) error {
 
 	h.Reset()
 	r := attach(h, f)
-	scanner := seqio.NewAutoScanner(r)
+	scanner := newSeqScanner(r)
 	for scanner.Scan() {
 		hosts = append(hosts, scanner.Value())
 	}
@@ -74,18 +74,22 @@ func infixFunc(ctx *flags.Context) error {
 		filetype = seqio.ToFileType(*format)
 	}
 
-	insert := gts.Insert
-	if *embed {
-		insert = gts.Embed
+	policy, err := newInsertPolicy(*embed, *embeds, *splits, *protects, *force)
+	if err != nil {
+		return ctx.Raise(err)
 	}
 
-	if !*nocache {
+	if !*nocache && !*verbose {
 		data := encodePayload([]tuple{
 			{"command", strings.Join(ctx.Name, "-")},
 			{"version", gts.Version.String()},
 			{"locator", *locstr},
 			{"host", hostSum},
 			{"embed", *embed},
+			{"embeds", *embeds},
+			{"splits", *splits},
+			{"protects", *protects},
+			{"force", *force},
 			{"filetype", filetype},
 		})
 
@@ -95,14 +99,15 @@ func infixFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner = seqio.NewAutoScanner(d)
+	scanner = newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
 	for scanner.Scan() {
 		seq := scanner.Value()
 
-		for _, host := range hosts {
+		for j, host := range hosts {
+			id := seqID(host, j)
 			rr := locate(host)
 			indices := make([]int, len(rr))
 			for i, r := range rr {
@@ -112,7 +117,14 @@ func infixFunc(ctx *flags.Context) error {
 
 			out := gts.Sequence(gts.Copy(host))
 			for _, index := range indices {
-				out = insert(out, index, seq)
+				var invs []gts.Invalidation
+				out, invs, err = policy.Insert(out, index, seq)
+				if err != nil {
+					return ctx.Raise(fmt.Errorf("%s: %v", id, err))
+				}
+				if *verbose {
+					reportInvalidations(ctx, id, invs)
+				}
 			}
 
 			if _, err := writer.WriteSeq(out); err != nil {
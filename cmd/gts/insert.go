@@ -9,7 +9,6 @@ import (
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -17,6 +16,70 @@ func init() {
 	flags.Register("insert", "insert guest sequence(s) into the input sequence(s)", insertFunc)
 }
 
+// insertPolicy decides how the features of a host sequence covering a point
+// of insertion are treated: the features matching Embed are extended, the
+// other features are split, and an insertion disrupting a feature matching
+// Protect is refused unless Force is true.
+type insertPolicy struct {
+	Embed   gts.Filter
+	Protect gts.Filter
+	Force   bool
+}
+
+// selectorsFilter returns a Filter matching any one of the given selectors,
+// or no features if there are none.
+func selectorsFilter(sels []string) (gts.Filter, error) {
+	if len(sels) == 0 {
+		return gts.FalseFilter, nil
+	}
+	filters := make([]gts.Filter, len(sels))
+	for i, sel := range sels {
+		filter, err := gts.Selector(sel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %v", sel, err)
+		}
+		filters[i] = filter
+	}
+	return gts.Or(filters...), nil
+}
+
+// newInsertPolicy creates an insertPolicy which embeds the features matching
+// the embeds selectors, or all features if embed is true, except for the
+// features matching the splits selectors.
+func newInsertPolicy(embed bool, embeds, splits, protects []string, force bool) (insertPolicy, error) {
+	embedFilter, err := selectorsFilter(embeds)
+	if err != nil {
+		return insertPolicy{}, err
+	}
+	if embed {
+		embedFilter = gts.TrueFilter
+	}
+	splitFilter, err := selectorsFilter(splits)
+	if err != nil {
+		return insertPolicy{}, err
+	}
+	protectFilter, err := selectorsFilter(protects)
+	if err != nil {
+		return insertPolicy{}, err
+	}
+	return insertPolicy{gts.And(embedFilter, gts.Not(splitFilter)), protectFilter, force}, nil
+}
+
+// Insert the guest sequence into the host sequence at the given index
+// following the policy. An error is returned if the insertion would disrupt
+// a protected feature, unless the policy is forced.
+func (p insertPolicy) Insert(host gts.Sequence, index int, guest gts.Sequence) (gts.Sequence, []gts.Invalidation, error) {
+	if !p.Force {
+		for _, f := range host.Features() {
+			if p.Protect(f) && gts.LocationOverlap(f.Loc, index, index) {
+				return nil, nil, fmt.Errorf("inserting before base %d would disrupt %s %s (use --force to insert anyway)", index+1, f.Key, f.Loc)
+			}
+		}
+	}
+	out, invs := gts.InsertEmbed(host, index, guest, p.Embed)
+	return out, invs, nil
+}
+
 func insertFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
@@ -24,18 +87,19 @@ func insertFunc(ctx *flags.Context) error {
 	locstr := pos.String("locator", "a locator string ([specifier][@modifier])")
 	guestPath := pos.String("guest", "guest sequence file (will be interpreted literally if preceded with @)")
 
-	hostPath := new(string)
-	*hostPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		hostPath = pos.String("host", "input sequence file (may be omitted if standard input is provided)")
-	}
+	hostPath := pos.String("host", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	embed := opt.Switch('e', "embed", "extend existing feature locations when inserting instead of splitting them")
+	embeds := opt.StringSlice(0, "embed-into", nil, "selector of the features to extend instead of splitting them")
+	splits := opt.StringSlice(0, "split", nil, "selector of the features to split even if --embed is given")
+	protects := opt.StringSlice(0, "protect", nil, "selector of the features which must not be disrupted by the insertion")
+	force := opt.Switch('f', "force", "insert even if a protected feature would be disrupted")
+	verbose := opt.Switch('v', "verbose", "report the features split by the insertion to standard error")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -62,7 +126,7 @@ func insertFunc(ctx *flags.Context) error {
 		defer f.Close()
 
 		r := attach(h, f)
-		scanner := seqio.NewAutoScanner(r)
+		scanner := newSeqScanner(r)
 		for scanner.Scan() {
 			guests = append(guests, scanner.Value())
 		}
@@ -83,18 +147,22 @@ func insertFunc(ctx *flags.Context) error {
 		filetype = seqio.ToFileType(*format)
 	}
 
-	insert := gts.Insert
-	if *embed {
-		insert = gts.Embed
+	policy, err := newInsertPolicy(*embed, *embeds, *splits, *protects, *force)
+	if err != nil {
+		return ctx.Raise(err)
 	}
 
-	if !*nocache {
+	if !*nocache && !*verbose {
 		data := encodePayload([]tuple{
 			{"command", strings.Join(ctx.Name, "-")},
 			{"version", gts.Version.String()},
 			{"locator", *locstr},
 			{"guest", guestSum},
 			{"embed", *embed},
+			{"embeds", *embeds},
+			{"splits", *splits},
+			{"protects", *protects},
+			{"force", *force},
 			{"filetype", filetype},
 		})
 
@@ -104,12 +172,13 @@ func insertFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
-	for scanner.Scan() {
+	for i := 0; scanner.Scan(); i++ {
 		host := scanner.Value()
+		id := seqID(host, i)
 
 		rr := locate(host)
 		indices := make([]int, len(rr))
@@ -121,7 +190,14 @@ func insertFunc(ctx *flags.Context) error {
 		for _, guest := range guests {
 			out := gts.Sequence(gts.Copy(host))
 			for _, index := range indices {
-				out = insert(out, index, guest)
+				var invs []gts.Invalidation
+				out, invs, err = policy.Insert(out, index, guest)
+				if err != nil {
+					return ctx.Raise(fmt.Errorf("%s: %v", id, err))
+				}
+				if *verbose {
+					reportInvalidations(ctx, id, invs)
+				}
 			}
 
 			if _, err := writer.WriteSeq(out); err != nil {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("intervals", "annotate the sequence(s) with features from an interval list", intervalsFunc)
+}
+
+// seqNames returns the names by which a sequence may be referred to: the
+// identifier, and the accession and locus name if available.
+func seqNames(seq gts.Sequence) []string {
+	names := []string{seqio.ID(seq)}
+	switch info := seq.Info().(type) {
+	case seqio.GenBankFields:
+		names = append(names, info.Accession, info.LocusName)
+	case seqio.FastaHeader:
+		names = append(names, info.Accession)
+	}
+	return names
+}
+
+func intervalsFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	intervalsPath := pos.String("intervals", "BED file or tab separated list of intervals to annotate")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	key := opt.String('k', "key", "misc_feature", "key for the annotated features")
+	merge := opt.String('m', "merge", "keep-both", "policy for merging features with identical keys and locations")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	policy, err := gts.AsMergePolicy(*merge)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	f, err := os.Open(*intervalsPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *intervalsPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	features, err := seqio.ReadBED(attach(h, f), *key)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	intervalsSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"intervals", encodeToString(intervalsSum)},
+			{"key", *key},
+			{"filetype", filetype},
+			{"merge", policy},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		ff := seq.Features()
+
+		seen := make(map[string]bool)
+		for _, name := range seqNames(seq) {
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			for _, f := range features[name] {
+				if r := f.Loc.Region(); gts.Len(seq) < gts.Max(r.Head(), r.Tail()) {
+					return ctx.Raise(fmt.Errorf("%s: interval %s exceeds the sequence length of %d", seqID(seq, i), f.Loc, gts.Len(seq)))
+				}
+				ff = ff.Merge(f, policy)
+			}
+		}
+
+		seq = gts.WithFeatures(seq, ff)
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
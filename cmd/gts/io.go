@@ -2,14 +2,23 @@ package main
 
 import (
 	"compress/flate"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"hash"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/cmd/cache"
+	"github.com/go-gts/gts/seqio"
 )
 
 type attachment struct {
@@ -39,6 +48,76 @@ func encodePayload(tt []tuple) []byte {
 	return p
 }
 
+// seqMolecule returns the molecule type given with the `--molecule` flag, or
+// the molecule type of the sequence as given by seqio.Molecule otherwise.
+func seqMolecule(seq gts.Sequence) gts.Molecule {
+	if molecule != "" {
+		return gts.Molecule(molecule)
+	}
+	return seqio.Molecule(seq)
+}
+
+// reportInvalidations prints the features invalidated by an edit of the
+// sequence with the given ID to standard error.
+func reportInvalidations(ctx *flags.Context, id string, invs []gts.Invalidation) {
+	for _, inv := range invs {
+		fmt.Fprintf(os.Stderr, "%s: %s: %s\n", strings.Join(ctx.Name, " "), id, inv)
+	}
+}
+
+// newFormatWriter creates a seqio.SeqWriter for the given file type which
+// wraps FASTA sequences at the width given with the `--wrap` flag and formats
+// GenBank records following the `--genbank-dialect` flag.
+func newFormatWriter(w io.Writer, filetype seqio.FileType) seqio.SeqWriter {
+	return seqio.NewWriterDialect(w, filetype, fastaWidth, genbankDialect)
+}
+
+// newSeqWriter creates a seqio.SeqWriter which will write sequences in a
+// deterministic manner if the `--deterministic` flag is set, and record the
+// command in the COMMENT field if the `--history` flag is set. If the
+// `--output-template` flag is set, each sequence is written to its own file
+// instead of the given writer. If the `--molecule` flag is set, the molecule
+// type of each sequence is overridden, and if the `--fasta-header` flag is
+// set, the description of each FASTA sequence is parsed with the pattern.
+func newSeqWriter(w io.Writer, filetype seqio.FileType) seqio.SeqWriter {
+	sw := newFormatWriter(w, filetype)
+	if outputPathTemplate != nil {
+		sw = newTemplateWriter(outputPathTemplate, filetype)
+	}
+	if fastaHeaderRegexp != nil {
+		sw = seqio.HeaderWriter{SeqWriter: sw, Regexp: fastaHeaderRegexp}
+	}
+	if molecule != "" {
+		sw = seqio.MoleculeWriter{SeqWriter: sw, Molecule: gts.Molecule(molecule)}
+	}
+	if history {
+		sw = seqio.HistoryWriter{SeqWriter: sw, Entry: newHistoryEntry()}
+	}
+	if deterministic {
+		sw = seqio.DeterministicWriter{SeqWriter: sw}
+	}
+	return sw
+}
+
+// newSeqScanner creates a seqio.Scanner which will report the warnings
+// encountered while reading the sequences to the standard error if the
+// `--warnings` flag is set. The records read are subject to the limits given
+// by the `--max-*` flags, and the sequences of CONTIG records are
+// reconstructed if the `--resolver` flag is set.
+func newSeqScanner(r io.Reader) *seqio.Scanner {
+	scanner := seqio.NewAutoScanner(r)
+	scanner.SetLimits(recordLimits)
+	if seqResolver != nil {
+		scanner.SetResolver(seqResolver)
+	}
+	if warnings {
+		scanner.SetWarningHandler(func(w seqio.Warning) {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+		})
+	}
+	return scanner
+}
+
 func gtsCacheDir() (string, error) {
 	dir, err := os.UserCacheDir()
 	if err != nil {
@@ -48,30 +127,169 @@ func gtsCacheDir() (string, error) {
 	return dir, os.MkdirAll(dir, 0755)
 }
 
+// seqinRequired reports whether the input sequence file must be given as a
+// positional argument: that is, if the standard input is a terminal and no
+// input was given with the `--input` flag.
+func seqinRequired() bool {
+	return inputPath == "" && cmd.IsTerminal(os.Stdin.Fd())
+}
+
+// trialValue returns a new value of the same type as the given value.
+func trialValue(v flags.Value) flags.Value {
+	switch v.(type) {
+	case *flags.BoolValue:
+		return flags.NewBoolValue(false)
+	case *flags.IntValue:
+		return flags.NewIntValue(0)
+	case *flags.FloatValue:
+		return flags.NewFloatValue(0)
+	case *flags.IntSliceValue:
+		return flags.NewIntSliceValue(nil)
+	case *flags.FloatSliceValue:
+		return flags.NewFloatSliceValue(nil)
+	case *flags.StringSliceValue:
+		return flags.NewStringSliceValue(nil)
+	default:
+		return flags.NewStringValue("")
+	}
+}
+
+// seqinOmitted reports whether the arguments of the context leave out the
+// last positional argument. The arguments are parsed with fresh values so
+// that the values of the given arguments are left untouched.
+func seqinOmitted(ctx *flags.Context, pos *flags.Positional, opt *flags.Optional) bool {
+	trialPos := &flags.Positional{Order: pos.Order[:len(pos.Order)-1], Args: flags.Arguments{}}
+	for _, name := range trialPos.Order {
+		arg := pos.Args[name]
+		trialPos.Args[name] = flags.Argument{Value: trialValue(arg.Value), Usage: arg.Usage}
+	}
+	trialOpt := &flags.Optional{Args: flags.Arguments{}, Alias: opt.Alias}
+	for name, arg := range opt.Args {
+		trialOpt.Args[name] = flags.Argument{Value: trialValue(arg.Value), Usage: arg.Usage}
+	}
+	extra, err := flags.Parse(trialPos, trialOpt, ctx.Args)
+	return err == nil && len(extra) == 0
+}
+
+// parseWithSeqin parses the arguments of a command whose last positional
+// argument is the input sequence file. The input file may always be given as
+// a positional argument. If it is omitted, the input is read from the file
+// given with the `--input` flag or the standard input instead, unless the
+// standard input is a terminal, in which case the usage is reported. Commands
+// taking a variable number of positional arguments cannot tell the input
+// file apart from the other arguments, so the input file is only taken from
+// the positional arguments if the standard input is a terminal and the
+// `--input` flag is not given.
+func parseWithSeqin(ctx *flags.Context, pos *flags.Positional, opt *flags.Optional) error {
+	omitted := !seqinRequired()
+	if !pos.HasExtra() {
+		omitted = omitted && seqinOmitted(ctx, pos, opt)
+	}
+	if omitted {
+		name := pos.Order[len(pos.Order)-1]
+		pos.Args[name].Value.Set("-")
+		pos.Order = pos.Order[:len(pos.Order)-1]
+		delete(pos.Args, name)
+	}
+	return ctx.Parse(pos, opt)
+}
+
+// openInput opens the named input file. If the name is `-`, the file given
+// with the `--input` flag or the standard input is returned instead. The
+// standard input will not be read if it is a terminal, as the command would
+// otherwise wait for input indefinitely.
+func openInput(path string) (*os.File, error) {
+	if path == "-" && inputPath != "" {
+		path = inputPath
+	}
+	if path != "-" {
+		return os.Open(path)
+	}
+	if cmd.IsTerminal(os.Stdin.Fd()) {
+		return nil, errors.New("refusing to read sequences from a terminal: give an input file or redirect the standard input")
+	}
+	return os.Stdin, nil
+}
+
+// createFile creates the named file, or opens it for appending if the
+// `--append` flag is set.
+func createFile(path string) (*os.File, error) {
+	if appendOutput {
+		return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	}
+	return os.Create(path)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// createOutput creates the named output file as with createFile. If the name
+// is `-`, the standard output is returned instead, which is left open when
+// the returned writer is closed.
+func createOutput(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return createFile(path)
+}
+
+// compressPath reports whether the output written to the named file should
+// be gzip compressed: that is, if the `--compress` flag is set or the name
+// has the `.gz` extension.
+func compressPath(path string) bool {
+	return compressOutput || seqio.DetectCompression(path) == seqio.GzipCompression
+}
+
 type ioDelegate struct {
 	infile  *os.File
 	outfile *os.File
+	teefile *os.File
+	zipper  *gzip.Writer
 	cache   *cache.File
 	tmpin   bool
+	zip     bool
 }
 
 func newIODelegate(inpath, outpath string) (*ioDelegate, error) {
-	input, output := os.Stdin, os.Stdout
-	var err error
+	output := os.Stdout
+	var tee *os.File
 
-	if inpath != "-" {
-		if input, err = os.Open(inpath); err != nil {
+	input, err := openInput(inpath)
+	if err != nil {
+		return nil, err
+	}
+
+	if outpath != "-" {
+		if output, err = createFile(outpath); err != nil {
 			return nil, err
 		}
 	}
 
-	if outpath != "-" {
-		if output, err = os.Create(outpath); err != nil {
+	if teePath != "" {
+		if tee, err = createFile(teePath); err != nil {
 			return nil, err
 		}
 	}
 
-	return &ioDelegate{input, output, nil, false}, nil
+	return &ioDelegate{input, output, tee, nil, nil, false, compressPath(outpath)}, nil
+}
+
+// output returns the writer for the output file, which compresses the output
+// if necessary. The compressor is created on the first use so that nothing is
+// written if the command has no output.
+func (d *ioDelegate) output() io.Writer {
+	if !d.zip {
+		return d.outfile
+	}
+	if d.zipper == nil {
+		d.zipper = gzip.NewWriter(d.outfile)
+	}
+	return d.zipper
 }
 
 func (d *ioDelegate) Read(p []byte) (int, error) {
@@ -85,11 +303,32 @@ func (d *ioDelegate) Write(p []byte) (int, error) {
 			return n, err
 		}
 	}
-	n, err := d.outfile.Write(p)
+	if d.teefile != nil {
+		n, err := d.teefile.Write(p)
+		if err != nil {
+			return n, err
+		}
+	}
+	n, err := d.output().Write(p)
 	return n, err
 }
 
 func (d *ioDelegate) TryCache(h hash.Hash, data []byte) (bool, error) {
+	if history && !deterministic {
+		// Cached outputs would carry the timestamps of previous runs.
+		return false, nil
+	}
+
+	if warnings {
+		// The input must be read to report the warnings.
+		return false, nil
+	}
+
+	if outputPathTemplate != nil {
+		// The sequences are written to files outside of the cache.
+		return false, nil
+	}
+
 	dir, err := gtsCacheDir()
 	if err != nil {
 		return false, nil
@@ -128,6 +367,27 @@ func (d *ioDelegate) TryCache(h hash.Hash, data []byte) (bool, error) {
 
 	h.Reset()
 	h.Write(data)
+	if deterministic {
+		h.Write([]byte("deterministic"))
+	}
+	if history {
+		h.Write([]byte("history"))
+	}
+	if molecule != "" {
+		h.Write([]byte("molecule=" + molecule))
+	}
+	if fastaHeader != "" {
+		h.Write([]byte("fasta-header=" + fastaHeader))
+	}
+	if wrap != "" {
+		h.Write([]byte("wrap=" + strconv.Itoa(fastaWidth)))
+	}
+	if genbankDialectFlag != "" {
+		h.Write([]byte(fmt.Sprintf("genbank-dialect=%+v", genbankDialect)))
+	}
+	for _, source := range resolverSources {
+		h.Write([]byte("resolver=" + source))
+	}
 	dsum := h.Sum(nil)
 
 	if _, err := d.infile.Seek(0, io.SeekStart); err != nil {
@@ -147,7 +407,11 @@ func (d *ioDelegate) TryCache(h hash.Hash, data []byte) (bool, error) {
 
 	defer f.Close()
 
-	if _, err := io.Copy(d.outfile, f); err != nil {
+	w := d.output()
+	if d.teefile != nil {
+		w = io.MultiWriter(d.teefile, w)
+	}
+	if _, err := io.Copy(w, f); err != nil {
 		return false, nil
 	}
 
@@ -165,6 +429,12 @@ func (d *ioDelegate) Close() error {
 
 	defer d.infile.Close()
 	defer d.outfile.Close()
+	if d.zipper != nil {
+		defer d.zipper.Close()
+	}
+	if d.teefile != nil {
+		defer d.teefile.Close()
+	}
 
 	if d.cache != nil {
 		if err := d.cache.Close(); err != nil {
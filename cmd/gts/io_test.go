@@ -0,0 +1,47 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestParseWithSeqin(t *testing.T) {
+	f, err := ioutil.TempFile("", "gts-test-*.fasta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(">bar\nggggcc\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	record := ">foo\nacgtacgtac\n"
+
+	out, err := runCommand(t, record, "reverse")
+	if err != nil {
+		t.Fatalf("reverse failed: %v", err)
+	}
+	testutils.Equals(t, out, ">foo\ncatgcatgca\n")
+
+	out, err = runCommand(t, record, "reverse", f.Name())
+	if err != nil {
+		t.Fatalf("reverse failed: %v", err)
+	}
+	testutils.Equals(t, out, ">bar\nccgggg\n")
+
+	out, err = runCommand(t, record, "delete", "-e", "1..2", f.Name())
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	testutils.Equals(t, out, ">bar\nggcc\n")
+
+	out, err = runCommand(t, record, "delete", "1..2")
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	testutils.Equals(t, out, ">foo\ngtacgtac\n")
+}
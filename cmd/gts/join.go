@@ -1,13 +1,13 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
-	"os"
+	"strconv"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -15,22 +15,52 @@ func init() {
 	flags.Register("join", "join the sequences contained in the files", joinFunc)
 }
 
+// joinSpan is the identifier and length of a sequence in the joined sequence.
+type joinSpan struct {
+	id     string
+	length int
+}
+
+// writeJoinMap writes the coordinate map of the joined sequences, giving the
+// identifier of each sequence and its range in the joined sequence.
+func writeJoinMap(path string, spans []joinSpan) error {
+	f, err := createOutput(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	fields := []string{"seqid", "start", "end"}
+	if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, "\t")); err != nil {
+		return err
+	}
+
+	offset := 0
+	for _, span := range spans {
+		fields := []string{span.id, strconv.Itoa(offset + 1), strconv.Itoa(offset + span.length)}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+		offset += span.length
+	}
+
+	return w.Flush()
+}
+
 func joinFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	circular := opt.Switch('c', "circular", "output the sequence as circular if possible")
+	mapPath := opt.String('m', "map", "", "output file of the coordinate map of the joined sequences")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -45,7 +75,7 @@ func joinFunc(ctx *flags.Context) error {
 		filetype = seqio.ToFileType(*format)
 	}
 
-	if !*nocache {
+	if !*nocache && *mapPath == "" {
 		data := encodePayload([]tuple{
 			{"command", strings.Join(ctx.Name, "-")},
 			{"version", gts.Version.String()},
@@ -59,20 +89,30 @@ func joinFunc(ctx *flags.Context) error {
 		}
 	}
 
-	seqs := []gts.Sequence{}
-	scanner := seqio.NewAutoScanner(d)
+	// The sequences are joined as they are read so that only the joined
+	// sequence is held in memory.
+	joiner := gts.Joiner{}
+	spans := []joinSpan{}
+	scanner := newSeqScanner(d)
 	for scanner.Scan() {
 		seq := scanner.Value()
-		seqs = append(seqs, seq)
+		spans = append(spans, joinSpan{seqID(seq, len(spans)), gts.Len(seq)})
+		joiner.Add(seq)
+	}
+
+	if *mapPath != "" {
+		if err := writeJoinMap(*mapPath, spans); err != nil {
+			return ctx.Raise(err)
+		}
 	}
 
-	seq := gts.Concat(seqs...)
+	seq := joiner.Sequence()
 
 	if *circular {
 		seq = gts.WithTopology(seq, gts.Circular)
 	}
 
-	writer := seqio.NewWriter(d, filetype)
+	writer := newSeqWriter(d, filetype)
 
 	if _, err := writer.WriteSeq(seq); err != nil {
 		return ctx.Raise(err)
@@ -4,12 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
-	"github.com/go-gts/gts/seqio"
 )
 
 func init() {
@@ -19,40 +16,23 @@ func init() {
 func lengthFunc(ctx *flags.Context) error {
 	pos, opt := flags.Flags()
 
-	var seqinPath *string
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
-	seqinFile := os.Stdin
-	if seqinPath != nil && *seqinPath != "-" {
-		f, err := os.Open(*seqinPath)
-		if err != nil {
-			return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *seqinPath, err))
-		}
-		seqinFile = f
-		defer seqinFile.Close()
-	}
-
-	outFile := os.Stdout
-	if *outPath != "-" {
-		f, err := os.Create(*outPath)
-		if err != nil {
-			return ctx.Raise(fmt.Errorf("failed to create file %q: %v", *outPath, err))
-		}
-		outFile = f
-		defer outFile.Close()
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
 	}
+	defer d.Close()
 
-	w := bufio.NewWriter(outFile)
+	w := bufio.NewWriter(d)
 
-	scanner := seqio.NewAutoScanner(seqinFile)
+	scanner := newSeqScanner(d)
 	for scanner.Scan() {
 		seq := scanner.Value()
 		_, err := io.WriteString(w, fmt.Sprintf("%d\n", gts.Len(seq)))
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+// recordLimits are the limits imposed on every sequence record read, as given
+// by the `--max-record-size`, `--max-features`, and `--max-qualifier-length`
+// flags. If the `--max-memory` flag is set and the `--max-record-size` flag is
+// not, no single record may take up more than a quarter of the memory budget.
+var recordLimits seqio.Limits
+
+// memoryBudget is the number of bytes given by the `--max-memory` flag, or
+// zero if no budget is set.
+var memoryBudget = 0
+
+// parseSize interprets the given string as a number of bytes, which may be
+// followed by a unit such as `K`, `MB`, or `GiB`.
+func parseSize(s string) (int, error) {
+	n, err := humanize.ParseBytes(s)
+	if err != nil || n > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int(n), nil
+}
+
+// parseLimits interprets the values of the limit flags given in the command
+// line and sets the record limits and the memory budget.
+func parseLimits() error {
+	var err error
+	if maxRecordSize != "" {
+		if recordLimits.RecordSize, err = parseSize(maxRecordSize); err != nil {
+			return fmt.Errorf("invalid maximum record size: %v", err)
+		}
+	}
+	if maxFeatures != "" {
+		n, err := strconv.Atoi(maxFeatures)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid maximum feature count %q", maxFeatures)
+		}
+		recordLimits.FeatureCount = n
+	}
+	if maxQualifierLength != "" {
+		if recordLimits.QualifierLength, err = parseSize(maxQualifierLength); err != nil {
+			return fmt.Errorf("invalid maximum qualifier length: %v", err)
+		}
+	}
+	if maxMemory != "" {
+		if memoryBudget, err = parseSize(maxMemory); err != nil {
+			return fmt.Errorf("invalid memory budget: %v", err)
+		}
+		if recordLimits.RecordSize == 0 {
+			recordLimits.RecordSize = memoryBudget / 4
+		}
+	}
+	return nil
+}
+
+// seqFootprint estimates the number of bytes of memory occupied by the given
+// sequence, accounting for the sequence itself and the features.
+func seqFootprint(seq gts.Sequence) int {
+	n := gts.Len(seq)
+	for _, f := range seq.Features() {
+		n += 64 + len(f.Key)
+		for _, item := range f.Props.Items() {
+			n += 32 + len(item.Key) + len(item.Value)
+		}
+	}
+	return n
+}
+
+// memoryMeter accumulates the estimated memory footprint of the sequences
+// held in memory by a command.
+type memoryMeter struct {
+	used int
+}
+
+// add accounts for the given sequence and reports whether the memory budget
+// given by the `--max-memory` flag is still respected.
+func (m *memoryMeter) add(seq gts.Sequence) bool {
+	m.used += seqFootprint(seq)
+	return memoryBudget == 0 || m.used <= memoryBudget
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-gts/gts/cmd"
+)
+
+// completer returns the candidates for the word being typed at the end of the
+// given line, along with the offset in the line at which the word begins. A
+// candidate includes any separator which should follow the word.
+type completer func(line string) (int, []string)
+
+// lineReader reads the lines of input given to an interactive session.
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+	Close() error
+}
+
+// newLineReader returns a lineReader with line editing, history, and tab
+// completion if the standard input is a terminal. Otherwise, the lines are
+// read verbatim without printing the prompt, so that a session can be
+// scripted.
+func newLineReader(complete completer) lineReader {
+	if cmd.IsTerminal(os.Stdin.Fd()) && cmd.IsTerminal(os.Stdout.Fd()) {
+		if restore, err := makeRaw(int(os.Stdin.Fd())); err == nil {
+			return &termLineReader{
+				in:       bufio.NewReader(os.Stdin),
+				out:      os.Stdout,
+				restore:  restore,
+				complete: complete,
+			}
+		}
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1<<20)
+	return plainLineReader{scanner}
+}
+
+type plainLineReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r plainLineReader) ReadLine(prompt string) (string, error) {
+	if r.scanner.Scan() {
+		return r.scanner.Text(), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
+}
+
+func (r plainLineReader) Close() error {
+	return nil
+}
+
+// termLineReader is a minimal line editor for a terminal in raw mode.
+type termLineReader struct {
+	in       *bufio.Reader
+	out      io.Writer
+	restore  func() error
+	complete completer
+	history  []string
+}
+
+// Control characters recognized by termLineReader.
+const (
+	keyCtrlA     = 0x01
+	keyCtrlB     = 0x02
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyCtrlE     = 0x05
+	keyCtrlF     = 0x06
+	keyCtrlK     = 0x0b
+	keyCtrlL     = 0x0c
+	keyCtrlN     = 0x0e
+	keyCtrlP     = 0x10
+	keyCtrlU     = 0x15
+	keyCtrlW     = 0x17
+	keyTab       = 0x09
+	keyEnter     = 0x0d
+	keyNewline   = 0x0a
+	keyEscape    = 0x1b
+	keyBackspace = 0x7f
+	keyCtrlH     = 0x08
+)
+
+func (r *termLineReader) refresh(prompt string, line []rune, cursor int) {
+	fmt.Fprintf(r.out, "\r\x1b[K%s%s", prompt, string(line))
+	if n := len(line) - cursor; n > 0 {
+		fmt.Fprintf(r.out, "\x1b[%dD", n)
+	}
+}
+
+func commonPrefix(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func (r *termLineReader) ReadLine(prompt string) (string, error) {
+	line, cursor := []rune{}, 0
+	index := len(r.history)
+	pending := ""
+
+	r.refresh(prompt, line, cursor)
+
+	for {
+		c, _, err := r.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch c {
+		case keyEnter, keyNewline:
+			fmt.Fprint(r.out, "\r\n")
+			s := string(line)
+			if strings.TrimSpace(s) != "" {
+				r.history = append(r.history, s)
+			}
+			return s, nil
+
+		case keyCtrlC:
+			fmt.Fprint(r.out, "^C\r\n")
+			line, cursor, index = line[:0], 0, len(r.history)
+
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(r.out, "\r\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+			}
+
+		case keyBackspace, keyCtrlH:
+			if cursor > 0 {
+				line = append(line[:cursor-1], line[cursor:]...)
+				cursor--
+			}
+
+		case keyCtrlA:
+			cursor = 0
+
+		case keyCtrlE:
+			cursor = len(line)
+
+		case keyCtrlB:
+			if cursor > 0 {
+				cursor--
+			}
+
+		case keyCtrlF:
+			if cursor < len(line) {
+				cursor++
+			}
+
+		case keyCtrlK:
+			line = line[:cursor]
+
+		case keyCtrlU:
+			line, cursor = line[cursor:], 0
+
+		case keyCtrlW:
+			i := cursor
+			for i > 0 && line[i-1] == ' ' {
+				i--
+			}
+			for i > 0 && line[i-1] != ' ' {
+				i--
+			}
+			line, cursor = append(line[:i], line[cursor:]...), i
+
+		case keyCtrlL:
+			fmt.Fprint(r.out, "\x1b[H\x1b[2J")
+
+		case keyCtrlP, keyCtrlN:
+			line, cursor, index, pending = r.browse(c == keyCtrlP, line, index, pending)
+
+		case keyEscape:
+			if b, _ := r.in.ReadByte(); b != '[' && b != 'O' {
+				break
+			}
+			b, _ := r.in.ReadByte()
+			switch b {
+			case 'A', 'B':
+				line, cursor, index, pending = r.browse(b == 'A', line, index, pending)
+			case 'C':
+				if cursor < len(line) {
+					cursor++
+				}
+			case 'D':
+				if cursor > 0 {
+					cursor--
+				}
+			case 'H':
+				cursor = 0
+			case 'F':
+				cursor = len(line)
+			case '3':
+				if b, _ := r.in.ReadByte(); b == '~' && cursor < len(line) {
+					line = append(line[:cursor], line[cursor+1:]...)
+				}
+			}
+
+		case keyTab:
+			if r.complete == nil {
+				break
+			}
+			head := string(line[:cursor])
+			start, candidates := r.complete(head)
+			if len(candidates) == 0 {
+				break
+			}
+			word := head[start:]
+			prefix := commonPrefix(candidates)
+			if prefix != word && strings.HasPrefix(prefix, word) {
+				insert := []rune(prefix[len(word):])
+				line = append(line[:cursor], append(insert, line[cursor:]...)...)
+				cursor += len(insert)
+				break
+			}
+			for i := range candidates {
+				candidates[i] = strings.TrimSpace(candidates[i])
+			}
+			fmt.Fprintf(r.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+
+		default:
+			if c >= ' ' {
+				line = append(line[:cursor], append([]rune{c}, line[cursor:]...)...)
+				cursor++
+			}
+		}
+
+		r.refresh(prompt, line, cursor)
+	}
+}
+
+// browse moves through the history, retaining the line being edited as the
+// entry after the last.
+func (r *termLineReader) browse(back bool, line []rune, index int, pending string) ([]rune, int, int, string) {
+	if index == len(r.history) {
+		pending = string(line)
+	}
+	switch {
+	case back && index > 0:
+		index--
+	case !back && index < len(r.history):
+		index++
+	default:
+		return line, len(line), index, pending
+	}
+	s := pending
+	if index < len(r.history) {
+		s = r.history[index]
+	}
+	line = []rune(s)
+	return line, len(line), index, pending
+}
+
+func (r *termLineReader) Close() error {
+	return r.restore()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("locate", "print the sequence(s) at the given location", locateFunc)
+}
+
+func locateFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	locstr := pos.String("location", "an INSDC location string (e.g. `complement(join(1..10,21..30))`)")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	translate := opt.Switch('T', "translate", "also print the translation of the located sequence")
+	table := opt.Int('t', "table", 1, "translation table to use with --translate")
+	codonStart := opt.Int('c', "codon-start", 1, "position of the first complete codon with --translate (1, 2, or 3)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	loc, err := gts.AsLocation(*locstr)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid location %q: %v", *locstr, err))
+	}
+
+	codons, err := gts.LookupCodonTable(*table)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	if *codonStart < 1 || 3 < *codonStart {
+		return ctx.Raise(fmt.Errorf("codon start must be 1, 2, or 3: got %d", *codonStart))
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"location", loc.String()},
+			{"translate", *translate},
+			{"table", *table},
+			{"codonStart", *codonStart},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	cds := gts.NewFeature("CDS", loc, gts.Props{})
+	cds.Props.Set("codon_start", strconv.Itoa(*codonStart))
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, seqio.FastaFile)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		if n := gts.Len(seq); !gts.LocationWithin(loc, 0, n) {
+			return ctx.Raise(fmt.Errorf("%s: location %s extends beyond the sequence of length %d", id, loc, n))
+		}
+
+		header := fmt.Sprintf("%s:%s", id, loc)
+		sub, err := gts.LocateRegion(loc.Region(), seq, seqResolve())
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("%s: %v", id, err))
+		}
+		p := sub.Bytes()
+		if _, err := writer.WriteSeq(gts.New(header, nil, p)); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if *translate {
+			if seqMolecule(seq) == gts.AA {
+				return ctx.Raise(fmt.Errorf("%s: cannot translate an amino acid sequence", id))
+			}
+			q, err := gts.TranslateCDS(cds, seq, codons, seqResolve())
+			if err != nil {
+				return ctx.Raise(fmt.Errorf("%s: %v", id, err))
+			}
+			header := fmt.Sprintf("%s translation", header)
+			if _, err := writer.WriteSeq(gts.New(header, nil, q)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -1,13 +1,196 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
 )
 
+// Global flags which may be given anywhere in the command line and apply to
+// every command.
+var (
+	deterministic = false
+	history       = false
+	historyFile   = ""
+	warnings      = false
+	molecule      = ""
+
+	fastaHeader       = ""
+	fastaHeaderRegexp *regexp.Regexp
+
+	wrap       = ""
+	fastaWidth = seqio.DefaultFastaWidth
+
+	genbankDialectFlag = ""
+	genbankDialect     seqio.GenBankDialect
+
+	outputTemplate     = ""
+	outputPathTemplate pathTemplate
+
+	appendOutput   = false
+	compressOutput = false
+	teePath        = ""
+
+	inputPath = ""
+
+	maxRecordSize      = ""
+	maxFeatures        = ""
+	maxQualifierLength = ""
+	maxMemory          = ""
+
+	resolverSources = []string{}
+)
+
+// commandLine is the command line of the running command excluding the
+// global flags.
+var commandLine = ""
+
+func shellJoin(argv []string) string {
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$*?[]|&;<>(){}") {
+			arg = fmt.Sprintf("'%s'", strings.ReplaceAll(arg, "'", `'\''`))
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// extractGlobalFlags removes the global flags preceding the first "--" from
+// the given arguments. Global flags taking a value are only recognized in the
+// --flag=value form, and none of them have a short form, so that they do not
+// collide with the options of the subcommands.
+func extractGlobalFlags(args []string) []string {
+	ret := make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return append(ret, args[i:]...)
+		case arg == "--deterministic":
+			deterministic = true
+		case arg == "--history":
+			history = true
+		case arg == "--warnings":
+			warnings = true
+		case arg == "--append":
+			appendOutput = true
+		case arg == "--compress":
+			compressOutput = true
+		case strings.HasPrefix(arg, "--history-file="):
+			historyFile = strings.TrimPrefix(arg, "--history-file=")
+		case strings.HasPrefix(arg, "--molecule="):
+			molecule = strings.TrimPrefix(arg, "--molecule=")
+		case arg == "--no-wrap":
+			wrap = "0"
+		case strings.HasPrefix(arg, "--wrap="):
+			wrap = strings.TrimPrefix(arg, "--wrap=")
+		case strings.HasPrefix(arg, "--genbank-dialect="):
+			genbankDialectFlag = strings.TrimPrefix(arg, "--genbank-dialect=")
+		case strings.HasPrefix(arg, "--fasta-header="):
+			fastaHeader = strings.TrimPrefix(arg, "--fasta-header=")
+		case strings.HasPrefix(arg, "--output-template="):
+			outputTemplate = strings.TrimPrefix(arg, "--output-template=")
+		case strings.HasPrefix(arg, "--tee="):
+			teePath = strings.TrimPrefix(arg, "--tee=")
+		case strings.HasPrefix(arg, "--input="):
+			inputPath = strings.TrimPrefix(arg, "--input=")
+		case strings.HasPrefix(arg, "--max-record-size="):
+			maxRecordSize = strings.TrimPrefix(arg, "--max-record-size=")
+		case strings.HasPrefix(arg, "--max-features="):
+			maxFeatures = strings.TrimPrefix(arg, "--max-features=")
+		case strings.HasPrefix(arg, "--max-qualifier-length="):
+			maxQualifierLength = strings.TrimPrefix(arg, "--max-qualifier-length=")
+		case strings.HasPrefix(arg, "--max-memory="):
+			maxMemory = strings.TrimPrefix(arg, "--max-memory=")
+		case strings.HasPrefix(arg, "--resolver="):
+			resolverSources = append(resolverSources, strings.TrimPrefix(arg, "--resolver="))
+		default:
+			ret = append(ret, arg)
+		}
+	}
+	return ret
+}
+
+func newHistoryEntry() seqio.HistoryEntry {
+	e := seqio.HistoryEntry{Command: commandLine, Version: gts.Version.String()}
+	if !deterministic {
+		e.Timestamp = time.Now()
+	}
+	return e
+}
+
+func appendHistoryFile(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(newHistoryEntry())
+}
+
 func main() {
+	os.Args = extractGlobalFlags(os.Args)
+	if molecule != "" {
+		if _, err := gts.AsMolecule(molecule); err != nil {
+			fmt.Fprintf(os.Stderr, "gts: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	if fastaHeader != "" {
+		re, err := regexp.Compile(fastaHeader)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gts: invalid FASTA header pattern: %v\n", err)
+			os.Exit(2)
+		}
+		fastaHeaderRegexp = re
+	}
+	if wrap != "" {
+		n, err := strconv.Atoi(wrap)
+		if err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "gts: invalid FASTA line width %q\n", wrap)
+			os.Exit(2)
+		}
+		fastaWidth = n
+	}
+	if genbankDialectFlag != "" {
+		dialect, err := seqio.AsGenBankDialect(genbankDialectFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gts: %v\n", err)
+			os.Exit(2)
+		}
+		genbankDialect = dialect
+	}
+	if err := parseLimits(); err != nil {
+		fmt.Fprintf(os.Stderr, "gts: %v\n", err)
+		os.Exit(2)
+	}
+	if len(resolverSources) > 0 {
+		seqResolver = newSeqResolver(resolverSources)
+	}
+	if outputTemplate != "" {
+		t, err := parsePathTemplate(outputTemplate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gts: %v\n", err)
+			os.Exit(2)
+		}
+		outputPathTemplate = t
+	}
+	commandLine = shellJoin(append([]string{"gts"}, os.Args[1:]...))
 	name, desc := "gts", "the genome transformation subprograms command line tool"
-	os.Exit(flags.Run(name, desc, gts.Version, flags.Compile()))
+	code := flags.Run(name, desc, gts.Version, flags.Compile())
+	if code == 0 && historyFile != "" {
+		if err := appendHistoryFile(historyFile); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write history file %q: %v\n", historyFile, err)
+			code = 1
+		}
+	}
+	os.Exit(code)
 }
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+// runCommand runs the gts command with the given arguments, reading the given
+// input in place of the standard input, and returns the standard output and
+// the error returned by the command.
+func runCommand(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "gts-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	in, err := ioutil.TempFile(dir, "input-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	in.Close()
+
+	out, err := ioutil.TempFile(dir, "output-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = out, out
+	inputPath = in.Name()
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		inputPath = ""
+	}()
+
+	ctx := &flags.Context{Name: []string{"gts"}, Args: append(args, "--no-cache")}
+	err = flags.Compile()(ctx)
+
+	p, rerr := ioutil.ReadFile(out.Name())
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	return string(p), err
+}
+
+func TestExtractGlobalFlags(t *testing.T) {
+	defer func() { compressOutput, appendOutput, teePath = false, false, "" }()
+
+	args := []string{"gts", "grep", "-z", "--compress", "--tee=out.gb", "--tee", "x", "--", "--append"}
+	testutils.Equals(t, extractGlobalFlags(args), []string{"gts", "grep", "-z", "--tee", "x", "--", "--append"})
+	testutils.Equals(t, compressOutput, true)
+	testutils.Equals(t, appendOutput, false)
+	testutils.Equals(t, teePath, "out.gb")
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("map", "transform features using expressions", mapFunc)
+}
+
+type mapAssignment struct {
+	Name string
+	Expr gts.Expr
+}
+
+func parseMapAssignment(s string) (mapAssignment, error) {
+	i := strings.IndexByte(s, '=')
+	if i <= 0 {
+		return mapAssignment{}, fmt.Errorf("cannot interpret %q as an assignment: expected `<qualifier>=<expression>`", s)
+	}
+	expr, err := gts.AsExpr(s[i+1:])
+	if err != nil {
+		return mapAssignment{}, fmt.Errorf("invalid expression in %q: %v", s, err)
+	}
+	return mapAssignment{s[:i], expr}, nil
+}
+
+func mapFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	wherestr := opt.String('w', "where", "", "expression selecting the features to transform (defaults to all features)")
+	keystr := opt.String('k', "key", "", "expression computing the new feature key")
+	setstrs := opt.StringSlice('s', "set", nil, "assignment of the form <qualifier>=<expression>")
+	drop := opt.Switch(0, "drop", "remove the selected features instead of transforming them")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	where := gts.Expr(func(env gts.ExprEnv) interface{} { return true })
+	if *wherestr != "" {
+		expr, err := gts.AsExpr(*wherestr)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("invalid expression %q: %v", *wherestr, err))
+		}
+		where = expr
+	}
+
+	var key gts.Expr
+	if *keystr != "" {
+		expr, err := gts.AsExpr(*keystr)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("invalid expression %q: %v", *keystr, err))
+		}
+		key = expr
+	}
+
+	assignments := make([]mapAssignment, len(*setstrs))
+	for i, s := range *setstrs {
+		assignment, err := parseMapAssignment(s)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		assignments[i] = assignment
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"where", *wherestr},
+			{"key", *keystr},
+			{"set", *setstrs},
+			{"drop", *drop},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+
+		ff := gts.FeatureSlice{}
+		for _, f := range seq.Features() {
+			if !gts.ExprTruthy(where(gts.ExprEnv{Seq: seq, Feature: f})) {
+				ff = append(ff, f)
+				continue
+			}
+			if *drop {
+				continue
+			}
+
+			f = gts.Feature{Key: f.Key, Loc: f.Loc, Props: f.Props.Clone()}
+			for _, assignment := range assignments {
+				if v := assignment.Expr(gts.ExprEnv{Seq: seq, Feature: f}); v != nil {
+					f.Props.Set(assignment.Name, gts.FormatExprValue(v))
+				}
+			}
+			if key != nil {
+				if v := gts.FormatExprValue(key(gts.ExprEnv{Seq: seq, Feature: f})); v != "" {
+					f.Key = v
+				}
+			}
+			ff = append(ff, f)
+		}
+		seq = gts.WithFeatures(seq, ff)
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
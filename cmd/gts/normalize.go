@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("normalize", "apply common fixes to the sequence records", normalizeFunc)
+}
+
+// normalizeFixers lists the fixes applied by normalize in the order they are
+// applied.
+var normalizeFixers = []string{"strip", "dedup", "wrap", "order", "translation", "sort", "case", "dblink", "locus"}
+
+// normalizeStripPrefixes lists the prefixes of the qualifier names added by
+// sequence editors which are removed by the strip fixer.
+var normalizeStripPrefixes = []string{"ApEinfo_"}
+
+// normalizeStrip reports whether the qualifier is removed by the strip fixer.
+func normalizeStrip(name string, names []string) bool {
+	for _, prefix := range normalizeStripPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, s := range names {
+		if name == s {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDedupKey returns the key by which the values of a qualifier are
+// compared by the dedup fixer. Whitespace is collapsed, and cross-references
+// are compared with the database name case folded.
+func normalizeDedupKey(name, value string) string {
+	if name == "db_xref" {
+		if x, err := seqio.AsXref(value); err == nil {
+			return strings.ToLower(x.DB) + ":" + x.ID
+		}
+	}
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func normalizeFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	skips := opt.StringSlice('s', "skip", nil, "fixer(s) to skip (strip, dedup, wrap, order, translation, sort, case, dblink, locus)")
+	names := opt.StringSlice('n', "name", nil, "additional qualifier name(s) to strip")
+	keeps := opt.StringSlice(0, "keep-duplicates", nil, "qualifier name(s) to exempt from deduplication")
+	order := opt.StringSlice(0, "qualifier-order", nil, "qualifier name(s) in the order to place them (defaults to the INSDC order)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	enabled := make(map[string]bool)
+	for _, fixer := range normalizeFixers {
+		enabled[fixer] = true
+	}
+	for _, skip := range *skips {
+		if _, ok := enabled[skip]; !ok {
+			return ctx.Raise(fmt.Errorf("unknown fixer %q: expected one of %s", skip, strings.Join(normalizeFixers, ", ")))
+		}
+		enabled[skip] = false
+	}
+
+	if _, err := gts.LookupCodonTable(*table); err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"skips", *skips},
+			{"names", *names},
+			{"keeps", *keeps},
+			{"order", *order},
+			{"table", *table},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	keep := make(map[string]bool)
+	for _, name := range *keeps {
+		keep[name] = true
+	}
+	dedupKey := func(name, value string) string {
+		if keep[name] {
+			return ""
+		}
+		return normalizeDedupKey(name, value)
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		ff := make([]gts.Feature, len(seq.Features()))
+		for j, f := range seq.Features() {
+			props := f.Props.Clone()
+			if enabled["dedup"] {
+				props = props.Dedup(dedupKey)
+				if !keep["db_xref"] {
+					values := props.Get("db_xref")
+					for k, value := range values {
+						if x, err := seqio.AsXref(value); err == nil {
+							values[k] = x.String()
+						}
+					}
+				}
+			}
+			for _, name := range props.Keys() {
+				if enabled["strip"] && normalizeStrip(name, *names) {
+					props.Del(name)
+					continue
+				}
+				if enabled["wrap"] {
+					values := props.Get(name)
+					for k, value := range values {
+						values[k] = seqio.WrapQualifier(name, value)
+					}
+				}
+			}
+			if enabled["order"] {
+				switch {
+				case len(*order) > 0:
+					props = props.Ordered(*order)
+				default:
+					props = props.Ordered(gts.QualifierOrder(f.Key))
+				}
+			}
+			f = gts.NewFeature(f.Key, f.Loc, props)
+
+			if enabled["translation"] && f.Key == "CDS" && f.Props.Has("translation") && !gts.IsPseudo(f) {
+				codons, err := gts.TranslationTable(f, *table)
+				var p []byte
+				if err == nil {
+					p, err = gts.TranslateCDS(f, seq, codons, seqResolve())
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %s: %s %s: %v\n", strings.Join(ctx.Name, " "), id, f.Key, f.Loc, err)
+				} else {
+					f.Props.Set("translation", gts.TranslationValue(p))
+				}
+			}
+
+			ff[j] = f
+		}
+
+		if enabled["sort"] {
+			gts.SortFeatures(ff)
+		}
+		seq = gts.WithFeatures(seq, ff)
+
+		if enabled["case"] {
+			seq = gts.WithBytes(seq, bytes.ToLower(seq.Bytes()))
+		}
+
+		if info, ok := seq.Info().(seqio.GenBankFields); ok {
+			if enabled["dblink"] {
+				dblink := append(seqio.Dictionary{}, info.DBLink...)
+				sort.SliceStable(dblink, func(i, j int) bool {
+					return dblink[i].Key < dblink[j].Key
+				})
+				info.DBLink = dblink
+			}
+			if enabled["locus"] {
+				info.LocusName = info.ValidLocusName()
+			}
+			seq = gts.WithInfo(seq, info)
+		}
+
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
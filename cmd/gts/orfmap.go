@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("orfmap", "draw a map of the start codons, stop codons, and ORFs in six frames", orfmapFunc)
+}
+
+// orfMapPanel represents the map of the six reading frames of a region.
+type orfMapPanel struct {
+	title   string
+	segment gts.Segment
+	maps    []gts.FrameMap
+}
+
+// labels returns the one-based coordinates of the first and last bases of
+// the region in the order they are read.
+func (panel orfMapPanel) labels() (string, string) {
+	head, tail := gts.Unpack(panel.segment)
+	if tail < head {
+		return fmt.Sprint(head), fmt.Sprint(tail + 1)
+	}
+	return fmt.Sprint(head + 1), fmt.Sprint(tail)
+}
+
+// orfMapRow draws a reading frame as a line of the given number of columns,
+// where start codons are drawn with `>` or `<` depending on the strand, stop
+// codons with `|`, and ORFs with `=` between their start and stop codons.
+// The ORFs are drawn over the codons in the same columns so that they remain
+// visible when each column covers many codons.
+func orfMapRow(m gts.FrameMap, n, width int) []byte {
+	row := []byte(strings.Repeat(".", width))
+	column := func(i int) int {
+		return gts.Min(width-1, i*width/n)
+	}
+	fill := func(start, end int, c byte) {
+		for i := column(start); i <= column(end-1); i++ {
+			row[i] = c
+		}
+	}
+
+	start := byte('>')
+	if m.Frame < 0 {
+		start = '<'
+	}
+
+	for _, i := range m.Starts {
+		fill(i, i+3, start)
+	}
+	for _, i := range m.Stops {
+		fill(i, i+3, '|')
+	}
+	for _, t := range m.ORFs {
+		fill(t.Start, t.End, '=')
+		head, tail := t.Start, t.End-3
+		if m.Frame < 0 {
+			head, tail = tail, head
+		}
+		row[column(head)] = start
+		if len(t.Protein) < (t.End-t.Start)/3 {
+			row[column(tail)] = '|'
+		}
+	}
+	return row
+}
+
+func writeORFMapText(w io.Writer, panel orfMapPanel, width int) error {
+	n := panel.segment.Len()
+	width = gts.Min(width, n)
+
+	left, right := panel.labels()
+	pad := gts.Max(1, width-len(left)-len(right))
+	lines := []string{
+		panel.title,
+		fmt.Sprintf("   %s%s%s", left, strings.Repeat(" ", pad), right),
+	}
+	for _, m := range panel.maps {
+		lines = append(lines, fmt.Sprintf("%s %s", m.Frame, orfMapRow(m, n, width)))
+	}
+
+	_, err := fmt.Fprintf(w, "%s\n\n", strings.Join(lines, "\n"))
+	return err
+}
+
+const (
+	orfMapSVGMargin = 40
+	orfMapSVGTrack  = 20
+	orfMapSVGPanel  = 9 * orfMapSVGTrack
+)
+
+// writeORFMapSVG draws the panels stacked vertically in a single SVG
+// document. Each ORF carries a tooltip with its location and length.
+func writeORFMapSVG(w io.Writer, panels []orfMapPanel, width int) error {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"12\">\n", width+2*orfMapSVGMargin, len(panels)*orfMapSVGPanel)
+
+	for k, panel := range panels {
+		n := panel.segment.Len()
+		x := func(i int) float64 {
+			return float64(orfMapSVGMargin) + float64(i*width)/float64(n)
+		}
+
+		top := k * orfMapSVGPanel
+		fmt.Fprintf(&b, "<g transform=\"translate(0,%d)\">\n", top)
+		fmt.Fprintf(&b, "<text x=\"0\" y=\"14\">%s</text>\n", html.EscapeString(panel.title))
+
+		for j, m := range panel.maps {
+			y := (j+1)*orfMapSVGTrack + orfMapSVGTrack/2
+			fmt.Fprintf(&b, "<text x=\"0\" y=\"%d\">%s</text>\n", y+4, m.Frame)
+			fmt.Fprintf(&b, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#bbbbbb\"/>\n", orfMapSVGMargin, y, orfMapSVGMargin+width, y)
+			for _, t := range m.ORFs {
+				loc := sixFrameLocation(panel.segment, t)
+				fmt.Fprintf(&b, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"8\" fill=\"#4477aa\"><title>%s (%d aa)</title></rect>\n", x(t.Start), y-4, x(t.End)-x(t.Start), loc, len(t.Protein))
+			}
+			for _, i := range m.Starts {
+				fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\" stroke=\"#228833\"/>\n", x(i), y-6, x(i), y+6)
+			}
+			for _, i := range m.Stops {
+				fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\" stroke=\"#cc3311\"/>\n", x(i), y-8, x(i), y+8)
+			}
+		}
+
+		left, right := panel.labels()
+		y := 8 * orfMapSVGTrack
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\">%s</text>\n", orfMapSVGMargin, y, left)
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" text-anchor=\"end\">%s</text>\n", orfMapSVGMargin+width, y, right)
+		b.WriteString("</g>\n")
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func orfmapFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
+	locstr := opt.String('r', "region", "^..$", "a locator string specifying the region(s) to map")
+	table := opt.Int('t', "table", 1, "translation table to use for the start and stop codons")
+	minLength := opt.Int('m', "min-length", 50, "minimum number of residues encoded by an ORF")
+	width := opt.Int('w', "width", 0, "width of the map (defaults to 80 columns, or 800 pixels with --svg)")
+	svg := opt.Switch(0, "svg", "draw the map as an SVG image instead of text")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	codons, err := gts.LookupCodonTable(*table)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	locate, err := gts.AsLocator(*locstr)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	if *width < 0 {
+		return ctx.Raise(fmt.Errorf("invalid map width %d", *width))
+	}
+	if *width == 0 {
+		*width = 80
+		if *svg {
+			*width = 800
+		}
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"locator", *locstr},
+			{"table", *table},
+			{"minLength", *minLength},
+			{"width", *width},
+			{"svg", *svg},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	panels := []orfMapPanel{}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		if seqMolecule(seq) == gts.AA {
+			return ctx.Raise(fmt.Errorf("%s: cannot map the reading frames of an amino acid sequence", id))
+		}
+
+		for _, region := range locate(seq) {
+			if rr, ok := region.(gts.Regions); ok && len(rr) == 1 {
+				region = rr[0]
+			}
+			if gts.HasRemote(region) {
+				return ctx.Raise(fmt.Errorf("%s: cannot map region %v in a remote entry", id, region))
+			}
+			segment, ok := region.(gts.Segment)
+			if !ok {
+				return ctx.Raise(fmt.Errorf("%s: cannot map non-contiguous region %v", id, region))
+			}
+			if segment.Len() == 0 {
+				continue
+			}
+
+			maps := gts.MapFrames(segment.Locate(seq), codons, *minLength)
+			var loc gts.Location
+			switch head, tail := gts.Unpack(segment); {
+			case tail < head:
+				loc = gts.Range(tail, head).Complement()
+			default:
+				loc = gts.Range(head, tail)
+			}
+			title := fmt.Sprintf("%s:%s", id, loc)
+			panel := orfMapPanel{title, segment, maps}
+
+			if *svg {
+				panels = append(panels, panel)
+				continue
+			}
+			if err := writeORFMapText(buffer, panel, *width); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if *svg {
+		if err := writeORFMapSVG(buffer, panels, *width); err != nil {
+			return ctx.Raise(err)
+		}
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
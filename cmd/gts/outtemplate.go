@@ -0,0 +1,186 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+// outputTemplateFields are the fields which may be used in the template given
+// with the `--output-template` flag.
+var outputTemplateFields = map[string]func(seq gts.Sequence, i int, ext string) string{
+	"id": func(seq gts.Sequence, i int, ext string) string {
+		return seqID(seq, i)
+	},
+	"accession": func(seq gts.Sequence, i int, ext string) string {
+		if info, ok := seq.Info().(seqio.GenBankFields); ok && info.Accession != "" {
+			return info.Accession
+		}
+		return seqID(seq, i)
+	},
+	"index": func(seq gts.Sequence, i int, ext string) string {
+		return strconv.Itoa(i + 1)
+	},
+	"format": func(seq gts.Sequence, i int, ext string) string {
+		return ext
+	},
+}
+
+// pathTemplate is a parsed output template: the odd elements are field names
+// and the even elements are literal strings.
+type pathTemplate []string
+
+func parsePathTemplate(s string) (pathTemplate, error) {
+	t := pathTemplate{}
+	for {
+		i := strings.IndexByte(s, '{')
+		if i < 0 {
+			if strings.IndexByte(s, '}') >= 0 {
+				return nil, fmt.Errorf("unexpected `}` in output template")
+			}
+			return append(t, s), nil
+		}
+		j := strings.IndexByte(s[i:], '}')
+		if j < 0 {
+			return nil, fmt.Errorf("unterminated field in output template")
+		}
+		name := s[i+1 : i+j]
+		if _, ok := outputTemplateFields[name]; !ok {
+			return nil, fmt.Errorf("unknown field {%s} in output template", name)
+		}
+		t = append(t, s[:i], name)
+		s = s[i+j+1:]
+	}
+}
+
+// sanitizePathElement replaces the characters which may not appear in a
+// filename, so that a field value cannot change the directory of the path.
+func sanitizePathElement(s string) string {
+	if s == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', 0:
+			return '_'
+		default:
+			return r
+		}
+	}, s)
+}
+
+// Path returns the path for the i-th sequence written with the given file
+// extension.
+func (t pathTemplate) Path(seq gts.Sequence, i int, ext string) string {
+	b := strings.Builder{}
+	for k, s := range t {
+		switch k % 2 {
+		case 0:
+			b.WriteString(s)
+		default:
+			b.WriteString(sanitizePathElement(outputTemplateFields[s](seq, i, ext)))
+		}
+	}
+	return b.String()
+}
+
+func fileTypeExt(filetype seqio.FileType) string {
+	switch filetype {
+	case seqio.FastaFile, seqio.EMBOSSFile:
+		return "fasta"
+	case seqio.FastqFile:
+		return "fastq"
+	case seqio.GenBankFile:
+		return "gb"
+	case seqio.EMBLFile:
+		return "embl"
+	case seqio.GFF3File:
+		return "gff3"
+	case seqio.BEDFile, seqio.BED6File:
+		return "bed"
+	case seqio.GTFFile:
+		return "gtf"
+	default:
+		return "seq"
+	}
+}
+
+// templateWriter writes each sequence to its own file at the path given by
+// the output template, creating the directories as necessary. If multiple
+// sequences map to the same path, they are written to the file in order.
+// Existing files are truncated unless the `--append` flag is set.
+type templateWriter struct {
+	tmpl     pathTemplate
+	filetype seqio.FileType
+
+	mu      sync.Mutex
+	index   int
+	written map[string]bool
+}
+
+func newTemplateWriter(tmpl pathTemplate, filetype seqio.FileType) *templateWriter {
+	return &templateWriter{tmpl: tmpl, filetype: filetype, written: make(map[string]bool)}
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface.
+func (w *templateWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	filetype := w.filetype
+	if filetype == seqio.DefaultFile {
+		switch sw, _ := seqio.DetectWriter(seq, nil); sw.(type) {
+		case seqio.GenBankWriter:
+			filetype = seqio.GenBankFile
+		case seqio.EMBLWriter:
+			filetype = seqio.EMBLFile
+		case seqio.FastaWriter:
+			filetype = seqio.FastaFile
+		}
+	}
+
+	path := w.tmpl.Path(seq, w.index, fileTypeExt(filetype))
+	w.index++
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return 0, err
+		}
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendOutput || w.written[path] {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		return 0, err
+	}
+	w.written[path] = true
+
+	if !compressPath(path) {
+		n, err := newFormatWriter(f, filetype).WriteSeq(seq)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return n, err
+	}
+
+	// Each sequence appended to the file is written as a gzip member.
+	zipper := gzip.NewWriter(f)
+	n, err := newFormatWriter(zipper, filetype).WriteSeq(seq)
+	if cerr := zipper.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+// mateID returns the identifier of a paired-end read without the `/1` or
+// `/2` suffix marking the mate, so that the mates of a pair share the same
+// identifier.
+func mateID(id string) string {
+	if strings.HasSuffix(id, "/1") || strings.HasSuffix(id, "/2") {
+		return id[:len(id)-2]
+	}
+	return id
+}
+
+// pairScanner scans the reads of a pair of paired-end files in sync, and
+// reports an error if the reads are not paired.
+type pairScanner struct {
+	r1, r2 *seqio.Scanner
+	n      int
+	err    error
+}
+
+func newPairScanner(r1, r2 io.Reader) *pairScanner {
+	return &pairScanner{r1: newSeqScanner(r1), r2: newSeqScanner(r2)}
+}
+
+// Scan advances both scanners to the next pair of reads.
+func (ps *pairScanner) Scan() bool {
+	if ps.err != nil {
+		return false
+	}
+	ok1, ok2 := ps.r1.Scan(), ps.r2.Scan()
+	if !ok1 || !ok2 {
+		switch {
+		case ps.r1.Err() != nil || ps.r2.Err() != nil:
+		case ok1:
+			ps.err = fmt.Errorf("the mate file has fewer reads than the input (%d)", ps.n)
+		case ok2:
+			ps.err = fmt.Errorf("the mate file has more reads than the input (%d)", ps.n)
+		}
+		return false
+	}
+	ps.n++
+	id1, id2 := seqio.ID(ps.r1.Value()), seqio.ID(ps.r2.Value())
+	if mateID(id1) != mateID(id2) {
+		ps.err = fmt.Errorf("read %d of the input %q and the mate file %q are not a pair", ps.n, id1, id2)
+		return false
+	}
+	return true
+}
+
+// Values returns the most recently scanned pair of reads.
+func (ps *pairScanner) Values() (gts.Sequence, gts.Sequence) {
+	return ps.r1.Value(), ps.r2.Value()
+}
+
+// Err returns the first error encountered by either of the scanners, or the
+// error reported for reads which are not paired.
+func (ps *pairScanner) Err() error {
+	if err := ps.r1.Err(); err != nil {
+		return fmt.Errorf("encountered error in scanner: %v", err)
+	}
+	if err := ps.r2.Err(); err != nil {
+		return fmt.Errorf("encountered error in mate scanner: %v", err)
+	}
+	return ps.err
+}
+
+// seqOutput is a sequence output file other than the main output of a
+// command, such as the output for the mates of paired-end reads. Sequences
+// written to a nil seqOutput are discarded.
+type seqOutput struct {
+	f      io.WriteCloser
+	zipper *gzip.Writer
+	buffer *bufio.Writer
+	writer seqio.SeqWriter
+}
+
+// createSeqOutput creates the sequence output file at the given path, or
+// returns nil if the path is empty.
+func createSeqOutput(path string, filetype seqio.FileType) (*seqOutput, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := createOutput(path)
+	if err != nil {
+		return nil, err
+	}
+	var zipper *gzip.Writer
+	buffer := bufio.NewWriter(f)
+	if compressPath(path) {
+		zipper = gzip.NewWriter(f)
+		buffer = bufio.NewWriter(zipper)
+	}
+	return &seqOutput{f, zipper, buffer, newFormatWriter(buffer, filetype)}, nil
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface.
+func (o *seqOutput) WriteSeq(seq gts.Sequence) (int, error) {
+	if o == nil {
+		return 0, nil
+	}
+	return o.writer.WriteSeq(seq)
+}
+
+// Close flushes and closes the output file.
+func (o *seqOutput) Close() error {
+	if o == nil {
+		return nil
+	}
+	if err := o.buffer.Flush(); err != nil {
+		o.f.Close()
+		return err
+	}
+	if o.zipper != nil {
+		if err := o.zipper.Close(); err != nil {
+			o.f.Close()
+			return err
+		}
+	}
+	return o.f.Close()
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	peptideSet := flags.CommandSet{}
+
+	peptideSet.Register("add", "add peptide features to CDS features from protein coordinates", peptideAddFunc)
+	peptideSet.Register("check", "check peptide features for consistency with their CDS features", peptideCheckFunc)
+	peptideSet.Register("fix", "fix the peptide features to be consistent with their CDS features", peptideFixFunc)
+
+	flags.Register("peptide", "manipulate peptide features of CDS features", peptideSet.Compile())
+}
+
+func cdsIdentifiers(f gts.Feature) []string {
+	ids := []string{}
+	for _, id := range f.Props.Get("protein_id") {
+		ids = append(ids, id)
+		if i := strings.LastIndexByte(id, '.'); i > 0 {
+			ids = append(ids, id[:i])
+		}
+	}
+	ids = append(ids, f.Props.Get("locus_tag")...)
+	ids = append(ids, f.Props.Get("gene")...)
+	return ids
+}
+
+func hasFeature(ff gts.FeatureSlice, f gts.Feature) bool {
+	for _, g := range ff {
+		if g.Key == f.Key && g.Loc.String() == f.Loc.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func peptideAddFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	tablePath := pos.String("table", "peptide table file containing protein coordinates")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	tableFile, err := os.Open(*tablePath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *tablePath, err))
+	}
+	defer tableFile.Close()
+
+	h.Reset()
+	records, err := seqio.ReadPeptideTable(attach(h, tableFile))
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	tablesum := h.Sum(nil)
+
+	byID := make(map[string][]int)
+	for i, record := range records {
+		byID[record.CDS] = append(byID[record.CDS], i)
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"table", encodeToString(tablesum)},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	matched := make([]bool, len(records))
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+		ff := seq.Features()
+
+		for _, cds := range ff.Filter(gts.Key("CDS")) {
+			seen := make(map[int]bool)
+			for _, id := range cdsIdentifiers(cds) {
+				for _, i := range byID[id] {
+					if seen[i] {
+						continue
+					}
+					seen[i] = true
+					matched[i] = true
+
+					record := records[i]
+					f, err := gts.NewPeptide(record.Key, cds, record.Start, record.End, record.Props)
+					if err != nil {
+						return ctx.Raise(fmt.Errorf("%s for %q: %v", record.Key, record.CDS, err))
+					}
+					if !hasFeature(ff, f) {
+						ff = ff.Insert(f)
+					}
+				}
+			}
+		}
+
+		seq = gts.WithFeatures(seq, ff)
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	warned := make(map[string]bool)
+	for i, ok := range matched {
+		if id := records[i].CDS; !ok && !warned[id] {
+			fmt.Fprintf(os.Stderr, "gts peptide add: no CDS feature found for %q\n", id)
+			warned[id] = true
+		}
+	}
+
+	return nil
+}
+
+func formatPeptideIssue(id string, issue gts.PeptideIssue, delim string) string {
+	cds := ""
+	if issue.CDS.Loc != nil {
+		cds = issue.CDS.Loc.String()
+	}
+	fields := []string{id, issue.Peptide.Key, issue.Peptide.Loc.String(), cds, issue.Problem}
+	return strings.Join(fields, delim)
+}
+
+func peptideCheckFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "feature", "location", "cds", "problem"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	count := 0
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		for _, issue := range gts.CheckPeptides(seq.Features()) {
+			count++
+			if _, err := fmt.Fprintf(w, "%s\n", formatPeptideIssue(id, issue, *delim)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if count > 0 {
+		return ctx.Raise(fmt.Errorf("found %d peptide feature issue(s)", count))
+	}
+
+	return nil
+}
+
+func peptideFixFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		ff, issues := gts.FixPeptides(seq.Features())
+
+		for _, issue := range issues {
+			fmt.Fprintf(os.Stderr, "gts peptide fix: %s\n", formatPeptideIssue(seqID(seq, i), issue, ": "))
+		}
+
+		seq = gts.WithFeatures(seq, ff)
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
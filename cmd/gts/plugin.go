@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/cmd"
+)
+
+// Plugins are registered while initializing the package level variables,
+// which happens before any init function is run. The built-in commands are
+// registered in init functions and thus take precedence over plugins of the
+// same name.
+var _ = registerPlugins()
+
+// commandName returns the name of the command given on the command line, or
+// an empty string if gts was invoked without a command or to show the help.
+// Global flags are skipped, which is possible as they all start with "--"
+// and never take a separate value.
+func commandName() string {
+	for _, arg := range os.Args[1:] {
+		switch {
+		case arg == "--", arg == "--help":
+			return ""
+		case strings.HasPrefix(arg, "--"):
+		case strings.HasPrefix(arg, "-"):
+			return ""
+		default:
+			return arg
+		}
+	}
+	return ""
+}
+
+// generatingFiles tests if gts was invoked to generate the manpage templates
+// or the shell completions, which should only cover the built-in commands.
+func generatingFiles() bool {
+	for _, arg := range os.Args[1:] {
+		switch arg {
+		case "generate-ronn-templates", "generate-completions":
+			return true
+		}
+	}
+	return false
+}
+
+// pluginDirs returns the directories to search for Go plugins.
+func pluginDirs() []string {
+	if path, ok := os.LookupEnv("GTS_PLUGIN_PATH"); ok {
+		return filepath.SplitList(path)
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil
+	}
+	return []string{filepath.Join(dir, "gts", "plugins")}
+}
+
+func registerPlugins() bool {
+	if generatingFiles() {
+		return false
+	}
+
+	// The directories in PATH are only searched for every plugin when the
+	// commands are listed. Otherwise the named command is registered as a
+	// plugin which is looked up when invoked, which only happens if it is
+	// not overridden by a built-in command.
+	if name := commandName(); name != "" {
+		flags.Register(name, "plugin command", lookPluginFunc(name))
+	} else {
+		for name, path := range cmd.FindPlugins(os.Getenv("PATH")) {
+			flags.Register(name, fmt.Sprintf("plugin command (%s)", path), execPluginFunc(path))
+		}
+	}
+
+	for _, dir := range pluginDirs() {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+		if err != nil {
+			continue
+		}
+		for _, path := range paths {
+			if err := loadPlugin(path); err != nil {
+				fmt.Fprintf(os.Stderr, "gts: failed to load plugin %q: %v\n", path, err)
+			}
+		}
+	}
+
+	return true
+}
+
+// pluginEnviron returns the environment variables passed to plugin
+// executables so that they may share the configuration of gts.
+func pluginEnviron() []string {
+	env := os.Environ()
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, "GTS_EXECUTABLE="+exe)
+	}
+	env = append(env, "GTS_VERSION="+gts.Version.String())
+	if deterministic {
+		env = append(env, "GTS_DETERMINISTIC=1")
+	}
+	if history {
+		env = append(env, "GTS_HISTORY=1")
+	}
+	if warnings {
+		env = append(env, "GTS_WARNINGS=1")
+	}
+	if molecule != "" {
+		env = append(env, "GTS_MOLECULE="+molecule)
+	}
+	if fastaHeader != "" {
+		env = append(env, "GTS_FASTA_HEADER="+fastaHeader)
+	}
+	if wrap != "" {
+		env = append(env, "GTS_WRAP="+strconv.Itoa(fastaWidth))
+	}
+	if genbankDialectFlag != "" {
+		env = append(env, "GTS_GENBANK_DIALECT="+genbankDialectFlag)
+	}
+	if outputTemplate != "" {
+		env = append(env, "GTS_OUTPUT_TEMPLATE="+outputTemplate)
+	}
+	if appendOutput {
+		env = append(env, "GTS_APPEND=1")
+	}
+	if compressOutput {
+		env = append(env, "GTS_COMPRESS=1")
+	}
+	if teePath != "" {
+		env = append(env, "GTS_TEE="+teePath)
+	}
+	if maxRecordSize != "" {
+		env = append(env, "GTS_MAX_RECORD_SIZE="+strconv.Itoa(recordLimits.RecordSize))
+	}
+	if maxFeatures != "" {
+		env = append(env, "GTS_MAX_FEATURES="+strconv.Itoa(recordLimits.FeatureCount))
+	}
+	if maxQualifierLength != "" {
+		env = append(env, "GTS_MAX_QUALIFIER_LENGTH="+strconv.Itoa(recordLimits.QualifierLength))
+	}
+	if maxMemory != "" {
+		env = append(env, "GTS_MAX_MEMORY="+strconv.Itoa(memoryBudget))
+	}
+	if len(resolverSources) > 0 {
+		env = append(env, "GTS_RESOLVER="+strings.Join(resolverSources, "\n"))
+	}
+	return env
+}
+
+func execPluginFunc(path string) flags.Function {
+	return func(ctx *flags.Context) error {
+		c := exec.Command(path, ctx.Args...)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if inputPath != "" && inputPath != "-" {
+			f, err := os.Open(inputPath)
+			if err != nil {
+				return ctx.Raise(err)
+			}
+			defer f.Close()
+			c.Stdin = f
+		}
+		c.Env = pluginEnviron()
+		if err := c.Run(); err != nil {
+			return ctx.Raise(err)
+		}
+		return nil
+	}
+}
+
+func lookPluginFunc(name string) flags.Function {
+	return func(ctx *flags.Context) error {
+		path, ok := findPlugin(name)
+		if !ok {
+			return fmt.Errorf("unknown command name `%s`", name)
+		}
+		return execPluginFunc(path)(ctx)
+	}
+}
+
+// findPlugin returns the path of the plugin executable providing the named
+// command, if any.
+func findPlugin(name string) (string, bool) {
+	return cmd.LookPlugin(os.Getenv("PATH"), name)
+}
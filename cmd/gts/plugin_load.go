@@ -0,0 +1,13 @@
+//go:build (linux && cgo) || (darwin && cgo)
+// +build linux,cgo darwin,cgo
+
+package main
+
+import "plugin"
+
+// loadPlugin opens the Go plugin at the given path. The plugin is expected to
+// register its commands with flags.Register in its init functions.
+func loadPlugin(path string) error {
+	_, err := plugin.Open(path)
+	return err
+}
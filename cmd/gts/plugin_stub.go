@@ -0,0 +1,10 @@
+//go:build (!linux && !darwin) || !cgo
+// +build !linux,!darwin !cgo
+
+package main
+
+import "errors"
+
+func loadPlugin(path string) error {
+	return errors.New("Go plugins are not supported on this platform")
+}
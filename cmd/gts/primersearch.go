@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("primersearch", "search for the binding sites of a primer library", primersearchFunc)
+}
+
+type primerHit struct {
+	Primer     seqio.Primer
+	Start, End int
+	Strand     string
+	Mismatches int
+}
+
+func (hit primerHit) Location() gts.Location {
+	loc := gts.Range(hit.Start, hit.End)
+	if hit.Strand == "-" {
+		return loc.Complement()
+	}
+	return loc
+}
+
+func searchPrimers(seq gts.Sequence, primers []seqio.Primer, mismatches int, nocomplement bool) []primerHit {
+	n := gts.Len(seq)
+	cmp := gts.Reverse(gts.Complement(gts.New(nil, nil, seq.Bytes())))
+
+	hits := []primerHit{}
+	for _, primer := range primers {
+		query := gts.New(nil, nil, []byte(primer.Seq))
+		for _, hit := range gts.MatchApprox(seq, query, mismatches) {
+			start, end := gts.Unpack(hit.Segment)
+			hits = append(hits, primerHit{primer, start, end, "+", hit.Mismatches})
+		}
+		if !nocomplement {
+			for _, hit := range gts.MatchApprox(cmp, query, mismatches) {
+				start, end := gts.Unpack(hit.Segment)
+				hits = append(hits, primerHit{primer, n - end, n - start, "-", hit.Mismatches})
+			}
+		}
+	}
+	return hits
+}
+
+func primersearchFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	libraryPath := pos.String("library", "primer library file in FASTA or tab-separated format")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	featureKey := opt.String('k', "key", "primer_bind", "key for the reported primer binding site features")
+	mismatches := opt.Int('m', "mismatches", 0, "maximum number of mismatches allowed for a binding site")
+	nocomplement := opt.Switch(0, "no-complement", "do not match the complement strand")
+	table := opt.Switch('T', "table", "report the binding sites as a table instead of features")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns of the table")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the table")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *mismatches < 0 {
+		return ctx.Raise(fmt.Errorf("number of mismatches must not be negative: got %d", *mismatches))
+	}
+
+	libraryFile, err := os.Open(*libraryPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer libraryFile.Close()
+
+	h.Reset()
+	primers, err := seqio.ReadPrimerLibrary(attach(h, libraryFile))
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	if len(primers) == 0 {
+		return ctx.Raise(fmt.Errorf("primer library %q does not contain a primer", *libraryPath))
+	}
+	librarySum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"library", encodeToString(librarySum)},
+			{"featureKey", *featureKey},
+			{"mismatches", *mismatches},
+			{"nocomplement", *nocomplement},
+			{"table", *table},
+			{"delim", *delim},
+			{"noheader", *noheader},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	if *table && !*noheader {
+		fields := []string{"seqid", "primer", "start", "end", "strand", "mismatches", "site"}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		hits := searchPrimers(seq, primers, *mismatches, *nocomplement)
+
+		if *table {
+			id := seqID(seq, i)
+			for _, hit := range hits {
+				site := string(hit.Location().Region().Locate(seq).Bytes())
+				fields := []string{
+					id, hit.Primer.Name,
+					strconv.Itoa(hit.Start + 1), strconv.Itoa(hit.End),
+					hit.Strand, strconv.Itoa(hit.Mismatches), site,
+				}
+				if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		} else {
+			ff := seq.Features()
+			for _, hit := range hits {
+				props := gts.Props{}
+				props.Add("note", hit.Primer.Name)
+				if hit.Mismatches > 0 {
+					props.Add("note", fmt.Sprintf("%d mismatch(es)", hit.Mismatches))
+				}
+				ff = ff.Insert(gts.NewFeature(*featureKey, hit.Location(), props))
+			}
+			seq = gts.WithFeatures(seq, ff)
+			if _, err := writer.WriteSeq(seq); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -5,14 +5,11 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
-	"github.com/go-gts/gts/seqio"
 )
 
 func init() {
@@ -39,11 +36,7 @@ func queryFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
@@ -57,7 +50,7 @@ func queryFunc(ctx *flags.Context) error {
 	noloc := opt.Switch('L', "no-location", "do not report the feature location")
 	empty := opt.Switch(0, "empty", "allow missing qualifiers to be reported")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -101,7 +94,7 @@ func queryFunc(ctx *flags.Context) error {
 
 	w := bufio.NewWriter(d)
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	for scanner.Scan() {
 		seq := scanner.Value()
 		switch info := seq.Info().(type) {
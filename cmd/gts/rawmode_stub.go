@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package main
+
+import "errors"
+
+func makeRaw(fd int) (func() error, error) {
+	return nil, errors.New("raw terminal input is not supported on this platform")
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/cmd/registry"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	registrySet := flags.CommandSet{}
+
+	registrySet.Register("add", "add sequence(s) to the registry", registryAddFunc)
+	registrySet.Register("get", "retrieve sequence(s) from the registry", registryGetFunc)
+	registrySet.Register("list", "list the sequences in the registry", registryListFunc)
+	registrySet.Register("remove", "remove sequence(s) from the registry", registryRemoveFunc)
+	registrySet.Register("search", "search the registry for sequences", registrySearchFunc)
+
+	flags.Register("registry", "manage a local collection of sequences", registrySet.Compile())
+}
+
+func gtsRegistryDir(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	if path := os.Getenv("GTS_REGISTRY"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return dir, err
+	}
+	return filepath.Join(dir, "gts-registry"), nil
+}
+
+func openRegistry(path string) (*registry.Registry, error) {
+	dir, err := gtsRegistryDir(path)
+	if err != nil {
+		return nil, err
+	}
+	return registry.Open(dir)
+}
+
+func registryEntry(seq gts.Sequence) registry.Entry {
+	e := registry.Entry{
+		Length:   gts.Len(seq),
+		Checksum: gts.Checksum(seq),
+		Ext:      ".fasta",
+	}
+	switch info := seq.Info().(type) {
+	case seqio.GenBankFields:
+		e.Definition = info.Definition
+		e.Accession = info.Accession
+		e.Organism = info.Source.Species
+		e.Ext = ".gb"
+	case string:
+		e.Definition = info
+	case fmt.Stringer:
+		e.Definition = info.String()
+	}
+	return e
+}
+
+func writeEntries(w io.Writer, entries []registry.Entry, delim string, noheader bool) error {
+	if !noheader {
+		fields := []string{"id", "accession", "length", "tags", "definition"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, delim)); err != nil {
+			return err
+		}
+	}
+	for _, e := range entries {
+		fields := []string{
+			e.ID,
+			e.Accession,
+			strconv.Itoa(e.Length),
+			strings.Join(e.Tags, ","),
+			e.Definition,
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, delim)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func registryAddFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	regPath := opt.String('R', "registry", "", "registry directory (defaults to $GTS_REGISTRY or the user config directory)")
+	id := opt.String('i', "id", "", "registry ID to assign (only allowed for a single sequence)")
+	tags := opt.StringSlice('t', "tag", nil, "tag(s) to attach to the sequence(s)")
+	replace := opt.Switch(0, "replace", "replace the sequence if the registry ID already exists")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	reg, err := openRegistry(*regPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, "-")
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	seqs := []gts.Sequence{}
+	scanner := newSeqScanner(d)
+	for scanner.Scan() {
+		seqs = append(seqs, scanner.Value())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if *id != "" && len(seqs) != 1 {
+		return ctx.Raise(fmt.Errorf("--id can only be used with a single sequence: got %d sequences", len(seqs)))
+	}
+
+	for _, seq := range seqs {
+		e := registryEntry(seq)
+		e.ID = *id
+		e.Tags = *tags
+
+		filetype := seqio.FastaFile
+		if e.Ext == ".gb" {
+			filetype = seqio.GenBankFile
+		}
+
+		b := bytes.Buffer{}
+		if _, err := seqio.NewWriter(&b, filetype).WriteSeq(seq); err != nil {
+			return ctx.Raise(err)
+		}
+
+		e, err := reg.Add(e, b.Bytes(), *replace)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+
+		fmt.Println(e.ID)
+	}
+
+	return nil
+}
+
+func registryGetFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	ids := pos.Extra("id", "registry ID(s) of the sequence(s) to retrieve")
+
+	regPath := opt.String('R', "registry", "", "registry directory (defaults to $GTS_REGISTRY or the user config directory)")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as stored)")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	reg, err := openRegistry(*regPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	entries := make([]registry.Entry, len(*ids))
+	for i, id := range *ids {
+		e, ok := reg.Get(id)
+		if !ok {
+			return ctx.Raise(fmt.Errorf("registry ID %q does not exist", id))
+		}
+		entries[i] = e
+	}
+
+	d, err := newIODelegate("-", *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for _, e := range entries {
+		f, err := os.Open(reg.Path(e))
+		if err != nil {
+			return ctx.Raise(err)
+		}
+
+		scanner := newSeqScanner(f)
+		for scanner.Scan() {
+			if _, err := writer.WriteSeq(scanner.Value()); err != nil {
+				f.Close()
+				return ctx.Raise(err)
+			}
+		}
+		f.Close()
+
+		if err := scanner.Err(); err != nil {
+			return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
+
+func registryListFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	regPath := opt.String('R', "registry", "", "registry directory (defaults to $GTS_REGISTRY or the user config directory)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	reg, err := openRegistry(*regPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	return ctx.Raise(writeEntries(os.Stdout, reg.Entries(), *delim, *noheader))
+}
+
+func registryRemoveFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	ids := pos.Extra("id", "registry ID(s) of the sequence(s) to remove")
+
+	regPath := opt.String('R', "registry", "", "registry directory (defaults to $GTS_REGISTRY or the user config directory)")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	reg, err := openRegistry(*regPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	for _, id := range *ids {
+		if err := reg.Remove(id); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
+
+func registrySearchFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	terms := pos.Extra("term", "search term(s) to match against the ID, definition, accession, organism, and tags")
+
+	regPath := opt.String('R', "registry", "", "registry directory (defaults to $GTS_REGISTRY or the user config directory)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	reg, err := openRegistry(*regPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	return ctx.Raise(writeEntries(os.Stdout, reg.Search(*terms...), *delim, *noheader))
+}
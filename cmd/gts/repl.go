@@ -0,0 +1,686 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-flip/flip"
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("repl", "interactively inspect and edit the given sequence(s)", replFunc)
+}
+
+// replCommand is a command available in the interactive session.
+type replCommand struct {
+	Usage string
+	Desc  string
+	Run   func(s *replSession, args []string) error
+}
+
+// replCommands are the commands available in the interactive session. The
+// `help` and `preview` commands are added in init to avoid an initialization
+// cycle.
+var replCommands = map[string]replCommand{
+	"records": {"records", "list the loaded records", replRecords},
+	"use":     {"use <index|id|all>", "restrict the following commands to a single record, or all records", replUse},
+	"features": {"features [selector]...", "list the features matching any of the selectors", func(s *replSession, args []string) error {
+		return s.listFeatures(args, false)
+	}},
+	"count": {"count [selector]...", "count the features matching any of the selectors", func(s *replSession, args []string) error {
+		return s.listFeatures(args, true)
+	}},
+	"keys":       {"keys", "list the feature keys and the number of features for each key", replKeys},
+	"qualifiers": {"qualifiers [selector]...", "list the qualifier values of the matching features", replQualifiers},
+	"show":       {"show", "print the records in the output format", replShow},
+	"select": {"select <selector>...", "retain only the features matching any of the selectors", func(s *replSession, args []string) error {
+		return s.filterFeatures("select", args, false)
+	}},
+	"remove": {"remove <selector>...", "remove the features matching any of the selectors", func(s *replSession, args []string) error {
+		return s.filterFeatures("remove", args, true)
+	}},
+	"set":     {"set <selector> <qualifier>=<value>", "set the qualifier value of the matching features", replSet},
+	"unset":   {"unset <selector> <qualifier>", "remove the qualifier from the matching features", replUnset},
+	"delete":  {"delete <locator>", "delete the regions of the sequence given by the locator", replDelete},
+	"rotate":  {"rotate <amount>", "shift the origin of circular sequences by the given amount", replRotate},
+	"reverse": {"reverse", "reverse complement the sequences", replReverse},
+	"undo":    {"undo [count]", "revert the last edit(s)", replUndo},
+	"history": {"history", "list the edits which may be undone", replHistory},
+	"write":   {"write <file>", "write the records to the given file (specifying `-` will write to standard output)", replWrite},
+	"quit":    {"quit", "leave the session (unwritten edits are discarded)", nil},
+	"exit":    {"exit", "leave the session (unwritten edits are discarded)", nil},
+}
+
+// replEditCommands are the commands which edit the records.
+var replEditCommands = map[string]bool{
+	"select":  true,
+	"remove":  true,
+	"set":     true,
+	"unset":   true,
+	"delete":  true,
+	"rotate":  true,
+	"reverse": true,
+}
+
+func init() {
+	replCommands["help"] = replCommand{"help [command]", "describe the available commands", replHelp}
+	replCommands["preview"] = replCommand{"preview <command> [args]...", "report the effects of an edit without applying it", replPreview}
+}
+
+// replEdit is an edit which may be undone, recorded as the number of
+// operations applied to the transaction of each record.
+type replEdit struct {
+	Line string
+	Ops  map[int]int
+}
+
+type replSession struct {
+	txns     []*gts.Txn
+	current  int // The index of the record in use, or -1 for all records.
+	edits    []replEdit
+	preview  bool
+	filetype seqio.FileType
+	out      io.Writer
+}
+
+func (s *replSession) seq(i int) gts.Sequence {
+	return s.txns[i].Sequence()
+}
+
+// targets returns the indices of the records the commands apply to.
+func (s *replSession) targets() []int {
+	if s.current >= 0 {
+		return []int{s.current}
+	}
+	ii := make([]int, len(s.txns))
+	for i := range ii {
+		ii[i] = i
+	}
+	return ii
+}
+
+func (s *replSession) prompt() string {
+	if s.current >= 0 {
+		return fmt.Sprintf("gts [%s]> ", seqID(s.seq(s.current), s.current))
+	}
+	return "gts> "
+}
+
+// undoOps reverts the given number of operations of each record.
+func (s *replSession) undoOps(ops map[int]int) {
+	for i, n := range ops {
+		for ; n > 0; n-- {
+			s.txns[i].Undo()
+		}
+	}
+}
+
+// edit applies the given function to the transaction of each of the target
+// records and reports the effects of the edit. The edit is recorded so that
+// it may be undone, or reverted immediately if the session is previewing the
+// edit.
+func (s *replSession) edit(line string, f func(txn *gts.Txn) error) error {
+	ops := make(map[int]int)
+	w := bufio.NewWriter(s.out)
+	for _, i := range s.targets() {
+		txn := s.txns[i]
+		n := txn.Len()
+		if err := f(txn); err != nil {
+			ops[i] = txn.Len() - n
+			s.undoOps(ops)
+			return err
+		}
+		ops[i] = txn.Len() - n
+		for _, effect := range txn.Effects()[n:] {
+			fmt.Fprintf(w, "%s: %s\n", seqID(txn.Sequence(), i), effect)
+		}
+	}
+
+	if s.preview {
+		s.undoOps(ops)
+	} else {
+		s.edits = append(s.edits, replEdit{line, ops})
+	}
+	return w.Flush()
+}
+
+func compileSelectors(selectors []string) (gts.Filter, error) {
+	if len(selectors) == 0 {
+		return gts.TrueFilter, nil
+	}
+	filters := make([]gts.Filter, len(selectors))
+	for i, selector := range selectors {
+		filter, err := gts.Selector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector syntax: %v", err)
+		}
+		filters[i] = filter
+	}
+	return gts.Or(filters...), nil
+}
+
+// mapFeatures replaces the matching features of the sequence in the
+// transaction with the result of the given function.
+func mapFeatures(txn *gts.Txn, filter gts.Filter, f func(gts.Feature) gts.Feature) {
+	ff := make(gts.FeatureSlice, len(txn.Sequence().Features()))
+	for i, feature := range txn.Sequence().Features() {
+		if filter(feature) {
+			feature = f(gts.Feature{Key: feature.Key, Loc: feature.Loc, Props: feature.Props.Clone()})
+		}
+		ff[i] = feature
+	}
+	txn.WithFeatures(ff)
+}
+
+func (s *replSession) listFeatures(selectors []string, count bool) error {
+	filter, err := compileSelectors(selectors)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(s.out)
+	for _, i := range s.targets() {
+		id := seqID(s.seq(i), i)
+		ff := s.seq(i).Features().Filter(filter)
+		if count {
+			fmt.Fprintf(w, "%s\t%d\n", id, len(ff))
+			continue
+		}
+		for _, f := range ff {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, f.Key, f.Loc, featureName(f))
+		}
+	}
+	return w.Flush()
+}
+
+func (s *replSession) filterFeatures(name string, selectors []string, invert bool) error {
+	if len(selectors) == 0 {
+		return fmt.Errorf("%s: expected at least one selector", name)
+	}
+	match, err := compileSelectors(selectors)
+	if err != nil {
+		return err
+	}
+	if invert {
+		match = gts.Not(match)
+	}
+	filter := gts.Or(gts.Key("source"), match)
+	line := shellJoin(append([]string{name}, selectors...))
+	return s.edit(line, func(txn *gts.Txn) error {
+		txn.WithFeatures(txn.Sequence().Features().Filter(filter))
+		return nil
+	})
+}
+
+func replHelp(s *replSession, args []string) error {
+	names := make([]string, 0, len(replCommands))
+	for name := range replCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(args) > 0 {
+		names = args
+	}
+
+	w := bufio.NewWriter(s.out)
+	for _, name := range names {
+		c, ok := replCommands[name]
+		if !ok {
+			return fmt.Errorf("unknown command %q", name)
+		}
+		fmt.Fprintf(w, "  %-36s %s\n", c.Usage, c.Desc)
+	}
+	return w.Flush()
+}
+
+func replRecords(s *replSession, args []string) error {
+	w := bufio.NewWriter(s.out)
+	for i, txn := range s.txns {
+		seq := txn.Sequence()
+		mark := " "
+		if i == s.current {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %d\t%s\t%d\t%d\n", mark, i+1, seqID(seq, i), gts.Len(seq), len(seq.Features()))
+	}
+	return w.Flush()
+}
+
+func replUse(s *replSession, args []string) error {
+	if len(args) != 1 {
+		return errors.New("use: expected a record index, identifier, or `all`")
+	}
+	arg := args[0]
+	if arg == "all" {
+		s.current = -1
+		return nil
+	}
+	for i, txn := range s.txns {
+		if seqID(txn.Sequence(), i) == arg {
+			s.current = i
+			return nil
+		}
+	}
+	if i, err := strconv.Atoi(arg); err == nil && 0 < i && i <= len(s.txns) {
+		s.current = i - 1
+		return nil
+	}
+	return fmt.Errorf("use: no record %q", arg)
+}
+
+func replKeys(s *replSession, args []string) error {
+	counts := make(map[string]int)
+	for _, i := range s.targets() {
+		for _, f := range s.seq(i).Features() {
+			counts[f.Key]++
+		}
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w := bufio.NewWriter(s.out)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%d\n", key, counts[key])
+	}
+	return w.Flush()
+}
+
+func replQualifiers(s *replSession, args []string) error {
+	filter, err := compileSelectors(args)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(s.out)
+	for _, i := range s.targets() {
+		for _, f := range s.seq(i).Features().Filter(filter) {
+			fmt.Fprintf(w, "%s %s\n", f.Key, f.Loc)
+			for _, item := range f.Props.Items() {
+				fmt.Fprintf(w, "  /%s=%s\n", item.Key, item.Value)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+func replShow(s *replSession, args []string) error {
+	w := bufio.NewWriter(s.out)
+	sw := newFormatWriter(w, s.filetype)
+	for _, i := range s.targets() {
+		if _, err := sw.WriteSeq(s.seq(i)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func replSet(s *replSession, args []string) error {
+	if len(args) != 2 || !strings.Contains(args[1], "=") {
+		return errors.New("set: expected a selector and a <qualifier>=<value> pair")
+	}
+	filter, err := compileSelectors(args[:1])
+	if err != nil {
+		return err
+	}
+	i := strings.IndexByte(args[1], '=')
+	name, value := args[1][:i], args[1][i+1:]
+	return s.edit(shellJoin(append([]string{"set"}, args...)), func(txn *gts.Txn) error {
+		mapFeatures(txn, filter, func(f gts.Feature) gts.Feature {
+			f.Props.Set(name, value)
+			return f
+		})
+		return nil
+	})
+}
+
+func replUnset(s *replSession, args []string) error {
+	if len(args) != 2 {
+		return errors.New("unset: expected a selector and a qualifier name")
+	}
+	filter, err := compileSelectors(args[:1])
+	if err != nil {
+		return err
+	}
+	name := args[1]
+	return s.edit(shellJoin(append([]string{"unset"}, args...)), func(txn *gts.Txn) error {
+		mapFeatures(txn, filter, func(f gts.Feature) gts.Feature {
+			f.Props.Del(name)
+			return f
+		})
+		return nil
+	})
+}
+
+func replDelete(s *replSession, args []string) error {
+	if len(args) != 1 {
+		return errors.New("delete: expected a locator")
+	}
+	locate, err := gts.AsLocator(args[0])
+	if err != nil {
+		return err
+	}
+	return s.edit(shellJoin(append([]string{"delete"}, args...)), func(txn *gts.Txn) error {
+		ss := gts.Minimize(locate(txn.Sequence()))
+		flip.Flip(gts.BySegment(ss))
+		for _, s := range ss {
+			txn.Delete(s.Head(), s.Len())
+		}
+		return nil
+	})
+}
+
+func replRotate(s *replSession, args []string) error {
+	if len(args) != 1 {
+		return errors.New("rotate: expected an amount")
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("rotate: invalid amount %q", args[0])
+	}
+	return s.edit(shellJoin(append([]string{"rotate"}, args...)), func(txn *gts.Txn) error {
+		txn.Rotate(n)
+		return nil
+	})
+}
+
+func replReverse(s *replSession, args []string) error {
+	return s.edit("reverse", func(txn *gts.Txn) error {
+		if seqMolecule(txn.Sequence()) == gts.AA {
+			return errors.New("reverse: cannot reverse complement an amino acid sequence")
+		}
+		txn.ReverseComplement()
+		return nil
+	})
+}
+
+func replPreview(s *replSession, args []string) error {
+	if len(args) == 0 || !replEditCommands[args[0]] {
+		return errors.New("preview: expected an edit command")
+	}
+	s.preview = true
+	defer func() { s.preview = false }()
+	return replCommands[args[0]].Run(s, args[1:])
+}
+
+func replUndo(s *replSession, args []string) error {
+	n := 1
+	if len(args) > 0 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+			return fmt.Errorf("undo: invalid count %q", args[0])
+		}
+	}
+	if n > len(s.edits) {
+		return fmt.Errorf("undo: only %d edit(s) to undo", len(s.edits))
+	}
+	for ; n > 0; n-- {
+		e := s.edits[len(s.edits)-1]
+		s.edits = s.edits[:len(s.edits)-1]
+		s.undoOps(e.Ops)
+		fmt.Fprintf(s.out, "undid: %s\n", e.Line)
+	}
+	return nil
+}
+
+func replHistory(s *replSession, args []string) error {
+	w := bufio.NewWriter(s.out)
+	for i, e := range s.edits {
+		fmt.Fprintf(w, "%d\t%s\n", i+1, e.Line)
+	}
+	return w.Flush()
+}
+
+func replWrite(s *replSession, args []string) error {
+	if len(args) != 1 {
+		return errors.New("write: expected a file name")
+	}
+	f, err := createOutput(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	sw := newSeqWriter(w, s.filetype)
+	for _, txn := range s.txns {
+		if _, err := sw.WriteSeq(txn.Sequence()); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// splitWords splits the line into words in the manner of a shell, allowing
+// the words to be quoted with single or double quotes.
+func splitWords(line string) ([]string, error) {
+	words := []string{}
+	word, inword := strings.Builder{}, false
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case c == '\\' && i+1 < len(line) && (quote == 0 || line[i+1] == '"' || line[i+1] == '\\'):
+			i++
+			word.WriteByte(line[i])
+			inword = true
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inword = c, true
+		case c == ' ' || c == '\t':
+			if inword {
+				words = append(words, word.String())
+				word.Reset()
+				inword = false
+			}
+		default:
+			word.WriteByte(c)
+			inword = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inword {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+func filterPrefix(ss []string, prefix, suffix string) []string {
+	ret := []string{}
+	for _, s := range ss {
+		if strings.HasPrefix(s, prefix) {
+			ret = append(ret, s+suffix)
+		}
+	}
+	return ret
+}
+
+// names returns the sorted feature keys and qualifier names of the records.
+func (s *replSession) names() ([]string, []string) {
+	keys, quals := map[string]bool{}, map[string]bool{}
+	for _, txn := range s.txns {
+		for _, f := range txn.Sequence().Features() {
+			keys[f.Key] = true
+			for _, name := range f.Props.Keys() {
+				quals[name] = true
+			}
+		}
+	}
+	sorted := func(m map[string]bool) []string {
+		ss := make([]string, 0, len(m))
+		for s := range m {
+			ss = append(ss, s)
+		}
+		sort.Strings(ss)
+		return ss
+	}
+	return sorted(keys), sorted(quals)
+}
+
+// complete returns the completion candidates for the last word of the line:
+// the command names for the first word, and the feature keys or qualifier
+// names from the loaded records for selectors.
+func (s *replSession) complete(line string) (int, []string) {
+	start := strings.LastIndexAny(line, " \t") + 1
+	word := line[start:]
+	fields := strings.Fields(line[:start])
+
+	if len(fields) == 0 {
+		names := make([]string, 0, len(replCommands))
+		for name := range replCommands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return start, filterPrefix(names, word, " ")
+	}
+
+	if fields[0] == "preview" {
+		rest := strings.TrimLeft(line[strings.Index(line, fields[0])+len(fields[0]):], " \t")
+		offset := len(line) - len(rest)
+		if !strings.ContainsAny(rest, " \t") {
+			start, candidates := s.complete(rest)
+			edits := []string{}
+			for _, candidate := range candidates {
+				if replEditCommands[strings.TrimSpace(candidate)] {
+					edits = append(edits, candidate)
+				}
+			}
+			return offset + start, edits
+		}
+		start, candidates := s.complete(rest)
+		return offset + start, candidates
+	}
+
+	keys, quals := s.names()
+
+	switch fields[0] {
+	case "help":
+		_, names := s.complete("")
+		return start, filterPrefix(names, word, "")
+	case "use":
+		ids := []string{"all"}
+		for i, txn := range s.txns {
+			ids = append(ids, seqID(txn.Sequence(), i))
+		}
+		return start, filterPrefix(ids, word, " ")
+	case "set", "unset":
+		if len(fields) == 2 {
+			suffix := " "
+			if fields[0] == "set" {
+				suffix = "="
+			}
+			return start, filterPrefix(quals, word, suffix)
+		}
+	case "features", "count", "qualifiers", "select", "remove":
+	default:
+		return start, nil
+	}
+
+	i := strings.LastIndexByte(word, '/')
+	if i < 0 {
+		return start, filterPrefix(keys, word, "")
+	}
+	if strings.ContainsAny(word[i+1:], "=:") {
+		return start, nil
+	}
+	return start + i + 1, filterPrefix(quals, word[i+1:], "")
+}
+
+// execute runs a single line of input, reporting whether the session should
+// continue.
+func (s *replSession) execute(line string) (bool, error) {
+	words, err := splitWords(line)
+	if err != nil || len(words) == 0 {
+		return true, err
+	}
+	name, args := words[0], words[1:]
+	switch name {
+	case "quit", "exit":
+		return false, nil
+	}
+	c, ok := replCommands[name]
+	if !ok {
+		return true, fmt.Errorf("unknown command %q (type `help` for the list of commands)", name)
+	}
+	return true, c.Run(s, args)
+}
+
+func replFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	seqinPaths := pos.Extra("seqin", "input sequence file(s)")
+
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	paths := *seqinPaths
+	if len(paths) == 0 && inputPath != "" {
+		paths = []string{inputPath}
+	}
+	if len(paths) == 0 {
+		return ctx.Raise(errors.New("no input sequence files given: the standard input is used for the commands"))
+	}
+
+	s := &replSession{current: -1, filetype: seqio.ToFileType(*format), out: os.Stdout}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		scanner := newSeqScanner(f)
+		for scanner.Scan() {
+			s.txns = append(s.txns, gts.Begin(scanner.Value()))
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+		}
+	}
+	if len(s.txns) == 1 {
+		s.current = 0
+	}
+
+	r := newLineReader(s.complete)
+	defer r.Close()
+
+	for {
+		line, err := r.ReadLine(s.prompt())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		ok, err := s.execute(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		if !ok {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("report", "report the sequences using a custom template", reportFunc)
+}
+
+type reportRecord struct {
+	Index    int
+	ID       string
+	Info     interface{}
+	Length   int
+	Seq      gts.Sequence
+	Features gts.FeatureSlice
+}
+
+func sequenceString(v interface{}) string {
+	switch v := v.(type) {
+	case gts.Sequence:
+		return string(v.Bytes())
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+var reportFuncs = template.FuncMap{
+	"sequence": sequenceString,
+	"length": func(v interface{}) int {
+		if seq, ok := v.(gts.Sequence); ok {
+			return gts.Len(seq)
+		}
+		if loc, ok := v.(gts.Location); ok {
+			return loc.Len()
+		}
+		return len(sequenceString(v))
+	},
+	"gc": func(v interface{}) float64 {
+		s := strings.ToLower(sequenceString(v))
+		if len(s) == 0 {
+			return 0
+		}
+		n := 0
+		for _, c := range "gcs" {
+			n += strings.Count(s, string(c))
+		}
+		return float64(n) / float64(len(s))
+	},
+	"qualifier": func(f gts.Feature, name string) string {
+		return strings.Join(f.Props.Get(name), ",")
+	},
+	"select": func(sel string, ff gts.FeatureSlice) (gts.FeatureSlice, error) {
+		filter, err := gts.Selector(sel)
+		if err != nil {
+			return nil, err
+		}
+		return ff.Filter(filter), nil
+	},
+	"extract": func(f gts.Feature, seq gts.Sequence) (gts.Sequence, error) {
+		return gts.LocateRegion(f.Loc.Region(), seq, seqResolve())
+	},
+	"eval": func(s string, seq gts.Sequence, f gts.Feature) (string, error) {
+		expr, err := gts.AsExpr(s)
+		if err != nil {
+			return "", err
+		}
+		return gts.FormatExprValue(expr(gts.ExprEnv{Seq: seq, Feature: f})), nil
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+func reportFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	tmplPath := pos.String("template", "template file written in the Go text/template syntax")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output report file (specifying `-` will force standard output)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	p, err := ioutil.ReadFile(*tmplPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to read template %q: %v", *tmplPath, err))
+	}
+
+	tmpl, err := template.New(filepath.Base(*tmplPath)).Funcs(reportFuncs).Parse(string(p))
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to parse template %q: %v", *tmplPath, err))
+	}
+
+	h.Reset()
+	h.Write(p)
+	tmplSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"template", encodeToString(tmplSum)},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		record := reportRecord{
+			Index:    i,
+			ID:       seqID(seq, i),
+			Info:     seq.Info(),
+			Length:   gts.Len(seq),
+			Seq:      seq,
+			Features: seq.Features(),
+		}
+
+		if err := tmpl.Execute(w, record); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -3,12 +3,10 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -22,17 +20,13 @@ func rotateFunc(ctx *flags.Context) error {
 
 	locstr := pos.String("locator", "a locator string ([modifier|selector|point|range][@modifier])")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -66,17 +60,17 @@ func rotateFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
+
+	op := gts.RotateOp(locate)
 
 	for scanner.Scan() {
-		seq := scanner.Value()
-		rr := locate(seq)
-		if len(rr) > 0 {
-			seq = gts.Rotate(seq, -rr[0].Head())
+		seq, err := op(scanner.Value())
+		if err != nil {
+			return ctx.Raise(err)
 		}
-		seq = gts.WithTopology(seq, gts.Circular)
 		if _, err := writer.WriteSeq(seq); err != nil {
 			return ctx.Raise(err)
 		}
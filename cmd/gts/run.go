@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+	"gopkg.in/yaml.v2"
+)
+
+func init() {
+	flags.Register("run", "run a pipeline of commands defined in a file", runFunc)
+}
+
+type pipelineStep struct {
+	Command string   `json:"command" yaml:"command"`
+	Args    []string `json:"args" yaml:"args"`
+}
+
+func (step pipelineStep) argv() []string {
+	return append(strings.Fields(step.Command), step.Args...)
+}
+
+type pipeline struct {
+	Steps []pipelineStep `json:"steps" yaml:"steps"`
+}
+
+func readPipeline(path string) (pipeline, error) {
+	p := pipeline{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return p, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &p)
+	default:
+		err = yaml.UnmarshalStrict(data, &p)
+	}
+	if err != nil {
+		return p, err
+	}
+
+	if len(p.Steps) == 0 {
+		return p, errors.New("pipeline has no steps")
+	}
+
+	for i, step := range p.Steps {
+		if len(strings.Fields(step.Command)) == 0 {
+			return p, fmt.Errorf("step %d: missing command", i+1)
+		}
+		switch strings.Fields(step.Command)[0] {
+		case "batch", "run", "watch":
+			return p, fmt.Errorf("step %d: pipelines cannot be nested", i+1)
+		}
+	}
+
+	return p, nil
+}
+
+// pipelineOperations maps the names of the commands which transform each
+// sequence into another to functions building the operation of the command
+// from the arguments of a step. These steps are run in-process and the
+// sequences are passed between them without being formatted and parsed.
+var pipelineOperations = map[string]func(args []string) (gts.Operation, error){
+	"clear":      constantOperation(gts.ClearOp),
+	"complement": constantOperation(gts.ComplementOp),
+	"delete":     deleteOperation,
+	"repair":     constantOperation(gts.RepairOp),
+	"reverse":    constantOperation(gts.ReverseOp),
+	"rotate":     rotateOperation,
+	"select":     selectOperation,
+}
+
+// parseStepArgs parses the arguments of a step run in-process. The
+// `--no-cache` option is accepted for compatibility with the command but has
+// no effect as the steps run in-process are never cached.
+func parseStepArgs(pos *flags.Positional, opt *flags.Optional, args []string) error {
+	opt.Switch(0, "no-cache", "do not use or create cache")
+	extra, err := flags.Parse(pos, opt, args)
+	if err != nil {
+		return err
+	}
+	if len(extra) > 0 {
+		return fmt.Errorf("unexpected argument(s): %s", shellJoin(extra))
+	}
+	return nil
+}
+
+func constantOperation(f func() gts.Operation) func(args []string) (gts.Operation, error) {
+	return func(args []string) (gts.Operation, error) {
+		pos, opt := flags.Flags()
+		if err := parseStepArgs(pos, opt, args); err != nil {
+			return nil, err
+		}
+		return f(), nil
+	}
+}
+
+func deleteOperation(args []string) (gts.Operation, error) {
+	pos, opt := flags.Flags()
+	locstr := pos.String("locator", "a locator string ([modifier|selector|point|range][@modifier])")
+	erase := opt.Switch('e', "erase", "remove features contained in the deleted regions")
+	if err := parseStepArgs(pos, opt, args); err != nil {
+		return nil, err
+	}
+	locate, err := gts.AsLocator(*locstr)
+	if err != nil {
+		return nil, err
+	}
+	return gts.DeleteOp(locate, *erase), nil
+}
+
+func rotateOperation(args []string) (gts.Operation, error) {
+	pos, opt := flags.Flags()
+	locstr := pos.String("locator", "a locator string ([modifier|selector|point|range][@modifier])")
+	if err := parseStepArgs(pos, opt, args); err != nil {
+		return nil, err
+	}
+	locate, err := gts.AsLocator(*locstr)
+	if err != nil {
+		return nil, err
+	}
+	return gts.RotateOp(locate), nil
+}
+
+func selectOperation(args []string) (gts.Operation, error) {
+	pos, opt := flags.Flags()
+	selectors := pos.Extra("selector", "feature selector (syntax: [feature_key][/[qualifier1][=regexp1]][/[qualifier2][=regexp2]]...)")
+	strand := opt.String('s', "strand", "both", "strand to select features from (`both`, `forward`, or `reverse`)")
+	invert := opt.Switch('v', "invert-match", "select features that do not match the given criteria")
+	ignoreCase := opt.Switch('i', "ignore-case", "match the qualifier values without regard to case")
+	fullMatch := opt.Switch('x', "full-match", "match the whole qualifier value instead of any part of it")
+	fixedStrings := opt.Switch(0, "fixed-strings", "interpret the qualifier patterns as plain strings instead of regular expressions")
+	if err := parseStepArgs(pos, opt, args); err != nil {
+		return nil, err
+	}
+
+	selflags := gts.SelectorFlags(0)
+	if *ignoreCase {
+		selflags |= gts.SelectorFoldCase
+	}
+	if *fullMatch {
+		selflags |= gts.SelectorFullMatch
+	}
+	if *fixedStrings {
+		selflags |= gts.SelectorPlain
+	}
+
+	match, err := selectorFilter(*selectors, selflags, *strand, *invert)
+	if err != nil {
+		return nil, err
+	}
+	return gts.SelectOp(gts.Or(gts.Key("source"), match)), nil
+}
+
+// pipelineStage is a run of consecutive steps of a pipeline which are either
+// run in-process as operations, or run as a single plugin executable.
+type pipelineStage struct {
+	first int
+	names []string
+	ops   []gts.Operation
+	path  string
+	args  []string
+}
+
+func (stage pipelineStage) errorf(i int, err error) error {
+	return fmt.Errorf("step %d (%s): %v", stage.first+i+1, stage.names[i], err)
+}
+
+// stages groups the steps of the pipeline into stages. A step is run
+// in-process if the command is available as an operation, or as a subprocess
+// if the command is provided by a plugin executable. Other commands cannot be
+// run in a pipeline.
+func (p pipeline) stages() ([]pipelineStage, error) {
+	stages := []pipelineStage{}
+	for i, step := range p.Steps {
+		argv := step.argv()
+		name, args := argv[0], argv[1:]
+
+		if build, ok := pipelineOperations[name]; ok {
+			op, err := build(args)
+			if err != nil {
+				return nil, fmt.Errorf("step %d (%s): %v", i+1, step.Command, err)
+			}
+			if n := len(stages); n > 0 && stages[n-1].path == "" {
+				stages[n-1].names = append(stages[n-1].names, step.Command)
+				stages[n-1].ops = append(stages[n-1].ops, op)
+				continue
+			}
+			stages = append(stages, pipelineStage{first: i, names: []string{step.Command}, ops: []gts.Operation{op}})
+			continue
+		}
+
+		if path, ok := findPlugin(name); ok {
+			stages = append(stages, pipelineStage{first: i, names: []string{step.Command}, path: path, args: args})
+			continue
+		}
+
+		names := make([]string, 0, len(pipelineOperations))
+		for name := range pipelineOperations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("step %d: %q cannot be run in a pipeline: only plugins and the commands %s may be used", i+1, name, strings.Join(names, ", "))
+	}
+	return stages, nil
+}
+
+// errStageClosed is given to the writers of a stage when the stage reading
+// their output has finished.
+var errStageClosed = errors.New("the next step of the pipeline has finished")
+
+// runOperations reads the sequences from r, applies the operations of the
+// stage to each of them in order and writes the results to w. The global
+// output flags only apply to the final stage.
+func (stage pipelineStage) runOperations(r io.Reader, w io.Writer, filetype seqio.FileType, final bool) error {
+	scanner := newSeqScanner(r)
+	buffer := bufio.NewWriter(w)
+	writer := newFormatWriter(buffer, filetype)
+	if final {
+		writer = newSeqWriter(buffer, filetype)
+	}
+
+	for scanner.Scan() {
+		seq := scanner.Value()
+		for i, op := range stage.ops {
+			out, err := op(seq)
+			if err != nil {
+				return stage.errorf(i, err)
+			}
+			seq = out
+		}
+		if _, err := writer.WriteSeq(seq); err != nil {
+			return err
+		}
+		if err := buffer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return stage.errorf(0, fmt.Errorf("encountered error in scanner: %v", err))
+	}
+	return nil
+}
+
+// runPlugin runs the plugin executable of the stage reading from r and
+// writing to w. The environment variables of the global output flags are only
+// passed to the final stage.
+func (stage pipelineStage) runPlugin(r io.Reader, w io.Writer, final bool) error {
+	c := exec.Command(stage.path, stage.args...)
+	c.Stdin, c.Stdout, c.Stderr = r, w, os.Stderr
+	c.Env = pluginEnviron()
+	if !final {
+		env := c.Env[:0]
+		for _, kv := range c.Env {
+			switch strings.SplitN(kv, "=", 2)[0] {
+			case "GTS_OUTPUT_TEMPLATE", "GTS_APPEND", "GTS_COMPRESS", "GTS_TEE":
+			default:
+				env = append(env, kv)
+			}
+		}
+		c.Env = env
+	}
+	if err := c.Run(); err != nil {
+		return stage.errorf(0, err)
+	}
+	return nil
+}
+
+// Run the pipeline, reading the input of the first step from in and writing
+// the output of the last step to out in the given file type. The stages of
+// the pipeline run concurrently, connected by pipes.
+func (p pipeline) Run(in io.Reader, out io.Writer, filetype seqio.FileType) error {
+	stages, err := p.stages()
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(stages))
+	wg := sync.WaitGroup{}
+
+	r := in
+	for i, stage := range stages {
+		final := i == len(stages)-1
+		w := out
+		var pr *io.PipeReader
+		var pw *io.PipeWriter
+		if !final {
+			pr, pw = io.Pipe()
+			w = pw
+		}
+		input := r
+
+		wg.Add(1)
+		go func(i int, stage pipelineStage) {
+			defer wg.Done()
+			if stage.path != "" {
+				errs[i] = stage.runPlugin(input, w, final)
+			} else {
+				errs[i] = stage.runOperations(input, w, filetype, final)
+			}
+			if pw != nil {
+				pw.CloseWithError(errs[i])
+			}
+			if upstream, ok := input.(*io.PipeReader); ok {
+				upstream.CloseWithError(errStageClosed)
+			}
+		}(i, stage)
+
+		if pr != nil {
+			r = pr
+		}
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, errStageClosed) {
+			return err
+		}
+	}
+	return nil
+}
+
+func runFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	pipelinePath := pos.String("pipeline", "pipeline file in YAML or JSON format")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	outPath := opt.String('o', "output", "-", "output file of the last step (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	dryrun := opt.Switch('n', "dry-run", "print the commands to be run without running them")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	p, err := readPipeline(*pipelinePath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to read pipeline %q: %v", *pipelinePath, err))
+	}
+
+	if _, err := p.stages(); err != nil {
+		return ctx.Raise(err)
+	}
+
+	if *dryrun {
+		lines := make([]string, len(p.Steps))
+		for i, step := range p.Steps {
+			lines[i] = fmt.Sprintf("gts %s", shellJoin(step.argv()))
+		}
+		_, err := fmt.Fprintln(os.Stdout, strings.Join(lines, " |\n"))
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*outPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if err := p.Run(d.infile, d, filetype); err != nil {
+		return ctx.Raise(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+	"github.com/go-gts/gts/seqio"
+)
+
+func TestPipelineRun(t *testing.T) {
+	p := pipeline{Steps: []pipelineStep{
+		{Command: "reverse"},
+		{Command: "delete", Args: []string{"3..5"}},
+		{Command: "complement"},
+	}}
+
+	out := bytes.Buffer{}
+	if err := p.Run(strings.NewReader(">foo\nacgtacgtac\n"), &out, seqio.DefaultFile); err != nil {
+		t.Fatalf("p.Run(): %v", err)
+	}
+	testutils.Equals(t, out.String(), ">foo\ngttacgt\n")
+
+	p = pipeline{Steps: []pipelineStep{{Command: "delete", Args: []string{"5..20"}}}}
+	err := p.Run(strings.NewReader(">foo\nacgtacgtac\n"), &out, seqio.DefaultFile)
+	if err == nil || !strings.Contains(err.Error(), "step 1 (delete)") {
+		t.Errorf("expected error from step 1, got %v", err)
+	}
+
+	for _, steps := range [][]pipelineStep{
+		{{Command: "reverse"}, {Command: "query"}},
+		{{Command: "select", Args: []string{"--count", "CDS"}}},
+		{{Command: "rotate"}},
+	} {
+		p := pipeline{Steps: steps}
+		if _, err := p.stages(); err == nil {
+			t.Errorf("expected error for pipeline %v", steps)
+		}
+	}
+}
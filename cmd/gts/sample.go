@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("sample", "randomly subsample the sequence(s)", sampleFunc)
+}
+
+// sampledItem is a sequence, or a pair of paired-end reads, chosen by a
+// sampler along with its position in the input.
+type sampledItem struct {
+	index int
+	seqs  []gts.Sequence
+}
+
+// sampler chooses either a fixed number of items by reservoir sampling, or
+// each item with the given probability.
+type sampler struct {
+	rng       *rand.Rand
+	count     int
+	fraction  float64
+	n         int
+	reservoir []sampledItem
+}
+
+// add offers an item to the sampler. In the fraction mode, the item is
+// returned if it is chosen. In the count mode, nothing is returned until all
+// of the items are offered and collected with rest.
+func (s *sampler) add(seqs ...gts.Sequence) []gts.Sequence {
+	index := s.n
+	s.n++
+	if s.count == 0 {
+		if s.rng.Float64() < s.fraction {
+			return seqs
+		}
+		return nil
+	}
+	switch {
+	case len(s.reservoir) < s.count:
+		s.reservoir = append(s.reservoir, sampledItem{index, seqs})
+	default:
+		if j := s.rng.Intn(s.n); j < s.count {
+			s.reservoir[j] = sampledItem{index, seqs}
+		}
+	}
+	return nil
+}
+
+// rest returns the items chosen in the count mode in the order of the input.
+func (s *sampler) rest() []sampledItem {
+	sort.Slice(s.reservoir, func(i, j int) bool {
+		return s.reservoir[i].index < s.reservoir[j].index
+	})
+	return s.reservoir
+}
+
+func sampleFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	count := opt.Int('n', "count", 0, "number of sequences to sample")
+	fraction := opt.Float('f', "fraction", 0, "probability with which each sequence is sampled")
+	seed := opt.Int('s', "seed", 0, "seed for the random number generator")
+	matePath := opt.String('p', "paired", "", "sequence file of the mates of the paired-end reads")
+	mateoutPath := opt.String('P', "paired-output", "", "output sequence file for the mates (required with --paired)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	switch {
+	case (*count > 0) == (*fraction > 0):
+		return ctx.Raise(errors.New("exactly one of --count or --fraction must be given"))
+	case *count < 0:
+		return ctx.Raise(fmt.Errorf("count must be positive: got %d", *count))
+	case *fraction < 0 || 1 < *fraction:
+		return ctx.Raise(fmt.Errorf("fraction must be between 0 and 1: got %g", *fraction))
+	case *matePath != "" && *mateoutPath == "":
+		return ctx.Raise(errors.New("the output file for the mates must be given with --paired-output"))
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache && *matePath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"filetype", filetype},
+			{"count", *count},
+			{"fraction", *fraction},
+			{"seed", *seed},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	buffer := bufio.NewWriter(d)
+	writers := []seqio.SeqWriter{newSeqWriter(buffer, filetype)}
+
+	var next func() []gts.Sequence
+	var scanErr func() error
+	var mateout *seqOutput
+
+	if *matePath != "" {
+		f, err := os.Open(*matePath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer f.Close()
+
+		mateFiletype := seqio.Detect(*mateoutPath)
+		if *format != "" {
+			mateFiletype = filetype
+		}
+		mateout, err = createSeqOutput(*mateoutPath, mateFiletype)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		writers = append(writers, mateout)
+
+		scanner := newPairScanner(d, f)
+		next = func() []gts.Sequence {
+			if !scanner.Scan() {
+				return nil
+			}
+			seq1, seq2 := scanner.Values()
+			return []gts.Sequence{seq1, seq2}
+		}
+		scanErr = scanner.Err
+	} else {
+		scanner := newSeqScanner(d)
+		next = func() []gts.Sequence {
+			if !scanner.Scan() {
+				return nil
+			}
+			return []gts.Sequence{scanner.Value()}
+		}
+		scanErr = func() error {
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("encountered error in scanner: %v", err)
+			}
+			return nil
+		}
+	}
+
+	write := func(seqs []gts.Sequence) error {
+		for i, seq := range seqs {
+			if _, err := writers[i].WriteSeq(seq); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s := sampler{rng: rand.New(rand.NewSource(int64(*seed))), count: *count, fraction: *fraction}
+	for seqs := next(); seqs != nil; seqs = next() {
+		if err := write(s.add(seqs...)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanErr(); err != nil {
+		return ctx.Raise(err)
+	}
+
+	for _, item := range s.rest() {
+		if err := write(item.seqs); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := buffer.Flush(); err != nil {
+		return ctx.Raise(err)
+	}
+
+	return ctx.Raise(mateout.Close())
+}
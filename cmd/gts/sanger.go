@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("sanger", "report the agreement of basecalled Sanger reads with a reference", sangerFunc)
+}
+
+// sangerDiscrepancy represents a base of a read disagreeing with the
+// reference.
+type sangerDiscrepancy struct {
+	pos     int
+	typ     string
+	ref     string
+	read    string
+	readID  string
+	readPos int
+	quality string
+}
+
+// sangerPileup accumulates the alignments of the reads to a reference.
+type sangerPileup struct {
+	depth         []int
+	conflict      []int
+	discrepancies []sangerDiscrepancy
+}
+
+func newSangerPileup(n int) *sangerPileup {
+	return &sangerPileup{make([]int, n), make([]int, n), nil}
+}
+
+// add adds the alignment of a read to the pileup. The bases of the read with
+// a quality score below min are ignored, as are the unknown bases.
+func (p *sangerPileup) add(ref, read []byte, qual []byte, aln gts.ReadAlignment, id string, min int) {
+	// quality returns the quality score of a base in the read.
+	quality := func(i int) int {
+		if qual == nil {
+			return min
+		}
+		if aln.Reverse {
+			i = len(qual) - 1 - i
+		}
+		return int(qual[i])
+	}
+	// position returns the 1-based position of a base in the original read.
+	position := func(i int) int {
+		if aln.Reverse {
+			return len(read) - i
+		}
+		return i + 1
+	}
+	qualityString := func(q int) string {
+		if qual == nil {
+			return "."
+		}
+		return strconv.Itoa(q)
+	}
+
+	bases := []byte(strings.ToLower(string(read)))
+	if aln.Reverse {
+		bases = gts.Complement(gts.New(nil, nil, bases)).Bytes()
+		for i, j := 0, len(bases)-1; i < j; i, j = i+1, j-1 {
+			bases[i], bases[j] = bases[j], bases[i]
+		}
+	}
+
+	pairs := aln.Pairs
+	for k, pair := range pairs {
+		switch {
+		case pair.Ref >= 0 && pair.Read >= 0:
+			q := quality(pair.Read)
+			c := bases[pair.Read]
+			if q < min || c == 'n' {
+				continue
+			}
+			p.depth[pair.Ref]++
+			if c != toLower(ref[pair.Ref]) {
+				p.conflict[pair.Ref]++
+				p.discrepancies = append(p.discrepancies, sangerDiscrepancy{
+					pair.Ref, "mismatch", string(ref[pair.Ref : pair.Ref+1]), string(c),
+					id, position(pair.Read), qualityString(q),
+				})
+			}
+
+		case pair.Read < 0:
+			// The quality of a deletion is given by the flanking bases.
+			q := -1
+			for _, l := range []int{k - 1, k + 1} {
+				if 0 <= l && l < len(pairs) && pairs[l].Read >= 0 {
+					if r := quality(pairs[l].Read); q < 0 || r < q {
+						q = r
+					}
+				}
+			}
+			if q < min {
+				continue
+			}
+			p.depth[pair.Ref]++
+			p.conflict[pair.Ref]++
+			readPos := 0
+			for l := k - 1; l >= 0; l-- {
+				if pairs[l].Read >= 0 {
+					readPos = position(pairs[l].Read)
+					break
+				}
+			}
+			p.discrepancies = append(p.discrepancies, sangerDiscrepancy{
+				pair.Ref, "deletion", string(ref[pair.Ref : pair.Ref+1]), "-",
+				id, readPos, qualityString(q),
+			})
+
+		default:
+			q := quality(pair.Read)
+			if q < min || bases[pair.Read] == 'n' {
+				continue
+			}
+			next := -1
+			for l := k + 1; l < len(pairs) && next < 0; l++ {
+				next = pairs[l].Ref
+			}
+			if next < 0 {
+				continue
+			}
+			p.conflict[next]++
+			p.discrepancies = append(p.discrepancies, sangerDiscrepancy{
+				next, "insertion", "-", string(bases[pair.Read]),
+				id, position(pair.Read), qualityString(q),
+			})
+		}
+	}
+}
+
+// discrepant reports whether at least half of the reads covering the base
+// disagree with the reference.
+func (p *sangerPileup) discrepant(i int) bool {
+	return p.conflict[i] > 0 && p.conflict[i]*2 >= p.depth[i]
+}
+
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// sangerFeatureNames returns the names of the features overlapping the
+// given position.
+func sangerFeatureNames(ff gts.FeatureSlice, pos int) string {
+	names := []string{}
+	for _, f := range ff {
+		for _, s := range gts.Minimize(f.Loc.Region()) {
+			if s[0] <= pos && pos < s[1] {
+				name := f.Key
+				if s := featureName(f); s != "" {
+					name = fmt.Sprintf("%s:%s", f.Key, s)
+				}
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ",")
+}
+
+func sangerFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	refPath := pos.String("reference", "reference sequence file")
+
+	seqinPath := pos.String("seqin", "input read file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	k := opt.Int('k', "kmer", 15, "length of the k-mers placing the reads on the reference")
+	minQuality := opt.Int('q', "min-quality", 20, "minimum quality score of the bases to consider")
+	listDiscrepancies := opt.Switch('D', "discrepancies", "list the discrepancies of each read instead of the features")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns of the table")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the table")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *k <= 0 {
+		return ctx.Raise(fmt.Errorf("k-mer length must be positive: got %d", *k))
+	}
+
+	f, err := os.Open(*refPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *refPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	refs := []gts.Sequence{}
+	refScanner := newSeqScanner(attach(h, f))
+	for refScanner.Scan() {
+		refs = append(refs, refScanner.Value())
+	}
+	if err := refScanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+	if len(refs) == 0 {
+		return ctx.Raise(fmt.Errorf("no reference sequence in %q", *refPath))
+	}
+	refSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"reference", encodeToString(refSum)},
+			{"kmer", *k},
+			{"quality", *minQuality},
+			{"discrepancies", *listDiscrepancies},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	circular := make([]bool, len(refs))
+	pileups := make([]*sangerPileup, len(refs))
+	for i, ref := range refs {
+		if info, ok := ref.Info().(seqio.GenBankFields); ok {
+			circular[i] = info.Topology == gts.Circular
+		}
+		pileups[i] = newSangerPileup(gts.Len(ref))
+	}
+
+	scanner := newSeqScanner(d)
+	for i := 0; scanner.Scan(); i++ {
+		read := scanner.Value()
+		id := seqID(read, i)
+
+		best, aln := -1, gts.ReadAlignment{}
+		for j, ref := range refs {
+			if a, ok := gts.AlignRead(ref.Bytes(), read.Bytes(), *k, circular[j]); ok && (best < 0 || a.Score > aln.Score) {
+				best, aln = j, a
+			}
+		}
+		if best < 0 {
+			fmt.Fprintf(os.Stderr, "%s: %s: the read could not be placed on the reference\n", strings.Join(ctx.Name, " "), id)
+			continue
+		}
+
+		pileups[best].add(refs[best].Bytes(), read.Bytes(), gts.QualityScores(read), aln, id, *minQuality)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	buffer := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "feature", "name", "location", "length", "covered", "depth", "discrepancies", "status"}
+		if *listDiscrepancies {
+			fields = []string{"seqid", "position", "type", "reference", "read", "readid", "readpos", "quality", "features"}
+		}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i, ref := range refs {
+		refID := seqID(ref, i)
+		pileup := pileups[i]
+		ff := ref.Features()
+
+		if *listDiscrepancies {
+			for _, disc := range pileup.discrepancies {
+				fields := []string{
+					refID, strconv.Itoa(disc.pos + 1), disc.typ, strings.ToUpper(disc.ref), strings.ToUpper(disc.read),
+					disc.readID, strconv.Itoa(disc.readPos), disc.quality, sangerFeatureNames(ff, disc.pos),
+				}
+				if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+			continue
+		}
+
+		for _, f := range ff {
+			length, covered, depth, discrepancies := 0, 0, 0, 0
+			for _, s := range gts.Minimize(f.Loc.Region()) {
+				for j := s[0]; j < s[1]; j++ {
+					length++
+					depth += pileup.depth[j]
+					if pileup.depth[j] > 0 {
+						covered++
+					}
+					if pileup.discrepant(j) {
+						discrepancies++
+					}
+				}
+			}
+
+			status := "verified"
+			switch {
+			case discrepancies > 0:
+				status = "discrepant"
+			case covered < length:
+				status = "incomplete"
+			}
+
+			name := featureName(f)
+			if name == "" {
+				name = "-"
+			}
+			mean := 0.0
+			if length > 0 {
+				mean = float64(depth) / float64(length)
+			}
+
+			fields := []string{
+				refID, f.Key, name, f.Loc.String(), strconv.Itoa(length), strconv.Itoa(covered),
+				strconv.FormatFloat(mean, 'f', 1, 64), strconv.Itoa(discrepancies), status,
+			}
+			if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+	}
+
+	if err := buffer.Flush(); err != nil {
+		return ctx.Raise(err)
+	}
+
+	return nil
+}
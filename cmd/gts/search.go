@@ -2,13 +2,13 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -22,11 +22,7 @@ func searchFunc(ctx *flags.Context) error {
 
 	queryPath := pos.String("query", "query sequence file (will be interpreted literally if preceded with @)")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
@@ -35,11 +31,20 @@ func searchFunc(ctx *flags.Context) error {
 	propstrs := opt.StringSlice('q', "qualifier", nil, "qualifier key-value pairs (syntax: key=value))")
 	exact := opt.Switch('e', "exact", "match the exact pattern even for ambiguous letters")
 	nocomplement := opt.Switch(0, "no-complement", "do not match the complement strand")
+	mismatches := opt.Int('m', "mismatches", 0, "maximum number of mismatching bases allowed in a match")
+	tablePath := opt.String('t', "table", "", "output file of a table of the match positions")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
+	if *mismatches < 0 {
+		return ctx.Raise(fmt.Errorf("expected a non-negative number of mismatches: got %d", *mismatches))
+	}
+	if *exact && *mismatches > 0 {
+		return ctx.Raise(errors.New("exact matching cannot be combined with mismatches"))
+	}
+
 	queries := []gts.Sequence{}
 	queryBytes := []byte(*queryPath)
 
@@ -47,7 +52,7 @@ func searchFunc(ctx *flags.Context) error {
 	switch queryBytes[0] {
 	case '@':
 		h.Write(queryBytes)
-		query := gts.New(nil, nil, queryBytes[1:])
+		query := gts.New(string(queryBytes[1:]), nil, queryBytes[1:])
 		queries = append(queries, query)
 
 	default:
@@ -57,12 +62,12 @@ func searchFunc(ctx *flags.Context) error {
 		}
 
 		r := attach(h, queryFile)
-		scanner := seqio.NewAutoScanner(r)
+		scanner := newSeqScanner(r)
 		for scanner.Scan() {
 			queries = append(queries, scanner.Value())
 		}
 		if len(queries) == 0 {
-			ctx.Raise(fmt.Errorf("query sequence file %q does not contain a sequence", *queryPath))
+			return ctx.Raise(fmt.Errorf("query sequence file %q does not contain a sequence", *queryPath))
 		}
 	}
 	querySum := h.Sum(nil)
@@ -89,7 +94,7 @@ func searchFunc(ctx *flags.Context) error {
 		order[name] = len(order)
 	}
 
-	if !*nocache {
+	if !*nocache && *tablePath == "" {
 		data := encodePayload([]tuple{
 			{"command", strings.Join(ctx.Name, "-")},
 			{"version", gts.Version.String()},
@@ -99,6 +104,7 @@ func searchFunc(ctx *flags.Context) error {
 			{"propstrs", *propstrs},
 			{"exact", *exact},
 			{"nocomplement", *nocomplement},
+			{"mismatches", *mismatches},
 		})
 
 		ok, err := d.TryCache(h, data)
@@ -107,37 +113,83 @@ func searchFunc(ctx *flags.Context) error {
 		}
 	}
 
-	match := gts.Match
-	if *exact {
-		match = gts.Search
+	match := func(seq, query gts.Sequence) []gts.Hit {
+		return gts.MatchApprox(seq, query, *mismatches)
+	}
+	if *mismatches == 0 {
+		search := gts.Match
+		if *exact {
+			search = gts.Search
+		}
+		match = func(seq, query gts.Sequence) []gts.Hit {
+			segments := search(seq, query)
+			hits := make([]gts.Hit, len(segments))
+			for i, segment := range segments {
+				hits[i] = gts.Hit{Segment: segment}
+			}
+			return hits
+		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	var table *bufio.Writer
+	if *tablePath != "" {
+		w, err := createOutput(*tablePath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		table = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "query", "start", "end", "strand", "mismatches"}
+		if _, err := fmt.Fprintf(table, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
-	for scanner.Scan() {
+	for i := 0; scanner.Scan(); i++ {
 		seq := scanner.Value()
+		id := seqID(seq, i)
 		cmp := gts.Reverse(gts.Complement(gts.New(nil, nil, seq.Bytes())))
 		ff := seq.Features()
-		for _, query := range queries {
+		for j, query := range queries {
+			name := seqID(query, j)
 			fwd := match(seq, query)
-			for _, segment := range fwd {
-				head, tail := gts.Unpack(segment)
+			for _, hit := range fwd {
+				head, tail := gts.Unpack(hit.Segment)
 				f := gts.NewFeature(*featureKey, gts.Range(head, tail), props)
 				ff = ff.Insert(f)
+				if table != nil {
+					if _, err := fmt.Fprintf(table, "%s\t%s\t%d\t%d\t+\t%d\n", id, name, head+1, tail, hit.Mismatches); err != nil {
+						return ctx.Raise(err)
+					}
+				}
 			}
 			if !*nocomplement {
 				bwd := match(cmp, query)
-				for _, segment := range bwd {
-					head, tail := gts.Unpack(segment)
+				for _, hit := range bwd {
+					head, tail := gts.Unpack(hit.Segment)
 					loc := gts.Range(head, tail)
 					loc = loc.Reverse(gts.Len(seq)).(gts.Ranged)
 					f := gts.NewFeature(*featureKey, loc.Complement(), props)
 					ff = ff.Insert(f)
+					if table != nil {
+						head, tail = loc.Start, loc.End
+						if _, err := fmt.Fprintf(table, "%s\t%s\t%d\t%d\t-\t%d\n", id, name, head+1, tail, hit.Mismatches); err != nil {
+							return ctx.Raise(err)
+						}
+					}
 				}
 			}
 		}
+		if table != nil {
+			if err := table.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
 		seq = gts.WithFeatures(seq, ff)
 		if _, err := writer.WriteSeq(seq); err != nil {
 			return ctx.Raise(err)
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestSearchMismatchTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gts-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "table.tsv")
+	record := ">foo\nttttacctgttttaccagttttcaggtttt\n"
+	if _, err := runCommand(t, record, "search", "-m", "1", "-t", path, "@acctg"); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+
+	p, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "seqid\tquery\tstart\tend\tstrand\tmismatches\n" +
+		"foo\tacctg\t5\t9\t+\t0\n" +
+		"foo\tacctg\t14\t18\t+\t1\n" +
+		"foo\tacctg\t23\t27\t-\t0\n" +
+		"foo\tacctg\t16\t20\t-\t1\n"
+	testutils.Equals(t, string(p), exp)
+}
+
+func TestSearchTableStdout(t *testing.T) {
+	record := ">foo\nttttacctgtttt\n"
+	out, err := runCommand(t, record, "search", "-t", "-", "-o", os.DevNull, "@acctg")
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	exp := "seqid\tquery\tstart\tend\tstrand\tmismatches\n" +
+		"foo\tacctg\t5\t9\t+\t0\n"
+	testutils.Equals(t, out, exp)
+}
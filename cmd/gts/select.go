@@ -2,14 +2,14 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -17,51 +17,82 @@ func init() {
 	flags.Register("select", "select features using the given feature selector(s)", selectFunc)
 }
 
+// selectorFilter compiles the feature selectors into a single filter which
+// matches the features on the given strand matching any of the selectors, or
+// none of them if invert is true.
+func selectorFilter(selectors []string, selflags gts.SelectorFlags, strand string, invert bool) (gts.Filter, error) {
+	filters := make([]gts.Filter, len(selectors))
+	for i, selector := range selectors {
+		cs, err := gts.CompileSelector(selector, selflags)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector syntax: %v", err)
+		}
+		filters[i] = cs.Filter()
+	}
+	match := gts.Or(filters...)
+	if invert {
+		match = gts.Not(match)
+	}
+
+	switch strand {
+	case "forward":
+		match = gts.And(match, gts.ForwardStrand)
+	case "reverse":
+		match = gts.And(match, gts.ReverseStrand)
+	}
+
+	return match, nil
+}
+
 func selectFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
 	selectors := pos.Extra("selector", "feature selector (syntax: [feature_key][/[qualifier1][=regexp1]][/[qualifier2][=regexp2]]...)")
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	strand := opt.String('s', "strand", "both", "strand to select features from (`both`, `forward`, or `reverse`)")
 	invert := opt.Switch('v', "invert-match", "select features that do not match the given criteria")
-
-	if err := ctx.Parse(pos, opt); err != nil {
+	ignoreCase := opt.Switch('i', "ignore-case", "match the qualifier values without regard to case")
+	fullMatch := opt.Switch('x', "full-match", "match the whole qualifier value instead of any part of it")
+	fixedStrings := opt.Switch(0, "fixed-strings", "interpret the qualifier patterns as plain strings instead of regular expressions")
+	count := opt.Switch('c', "count", "report the number of matching features in each sequence instead of the sequences")
+	list := opt.Switch('l', "list-matching", "report a table of the matching features instead of the sequences")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns of the --count or --list-matching table")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the --count or --list-matching table")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
 	sort.Strings(*selectors)
 
-	filters := make([]gts.Filter, len(*selectors))
-	for i, selector := range *selectors {
-		f, err := gts.Selector(selector)
-		if err != nil {
-			return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
-		}
-		filters[i] = f
+	if *count && *list {
+		return ctx.Raise(errors.New("--count and --list-matching are mutually exclusive"))
+	}
+
+	selflags := gts.SelectorFlags(0)
+	if *ignoreCase {
+		selflags |= gts.SelectorFoldCase
 	}
-	filter := gts.Or(filters...)
-	if *invert {
-		filter = gts.Not(filter)
+	if *fullMatch {
+		selflags |= gts.SelectorFullMatch
+	}
+	if *fixedStrings {
+		selflags |= gts.SelectorPlain
 	}
-	filter = gts.Or(gts.Key("source"), filter)
 
-	switch *strand {
-	case "forward":
-		filter = gts.And(filter, gts.ForwardStrand)
-	case "reverse":
-		filter = gts.And(filter, gts.ReverseStrand)
+	match, err := selectorFilter(*selectors, selflags, *strand, *invert)
+	if err != nil {
+		return ctx.Raise(err)
 	}
 
+	filter := gts.Or(gts.Key("source"), match)
+
 	d, err := newIODelegate(*seqinPath, *seqoutPath)
 	if err != nil {
 		return ctx.Raise(err)
@@ -80,6 +111,11 @@ func selectFunc(ctx *flags.Context) error {
 			{"selectors", *selectors},
 			{"strand", *strand},
 			{"invert", *invert},
+			{"flags", selflags},
+			{"count", *count},
+			{"list", *list},
+			{"delim", *delim},
+			{"noheader", *noheader},
 			{"filetype", filetype},
 		})
 
@@ -89,14 +125,50 @@ func selectFunc(ctx *flags.Context) error {
 		}
 	}
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
 
-	for scanner.Scan() {
+	if (*count || *list) && !*noheader {
+		fields := []string{"seqid", "count"}
+		if *list {
+			fields = []string{"seqid", "feature", "location", "name"}
+		}
+		header := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+		if _, err := io.WriteString(buffer, header); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
 		seq := scanner.Value()
-		ff := seq.Features().Filter(filter)
-		seq = gts.WithFeatures(seq, ff)
+
+		if *count || *list {
+			id := seqID(seq, i)
+			ff := seq.Features().Filter(match)
+			lines := []string{fmt.Sprintf("%s%s%d\n", id, *delim, len(ff))}
+			if *list {
+				lines = make([]string, len(ff))
+				for j, f := range ff {
+					fields := []string{id, f.Key, f.Loc.String(), featureName(f)}
+					lines[j] = fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+				}
+			}
+			for _, line := range lines {
+				if _, err := io.WriteString(buffer, line); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+			if err := buffer.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+			continue
+		}
+
+		seq, err := gts.SelectOp(filter)(seq)
+		if err != nil {
+			return ctx.Raise(err)
+		}
 		if _, err := writer.WriteSeq(seq); err != nil {
 			return ctx.Raise(err)
 		}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("sketch", "compute MinHash sketches of the sequence(s)", sketchFunc)
+}
+
+type sketchRecord struct {
+	ID     string `json:"id"`
+	Length int    `json:"length"`
+	gts.Sketch
+}
+
+func seqID(seq gts.Sequence, i int) string {
+	if id := seqio.ID(seq); id != "" {
+		return id
+	}
+	return fmt.Sprintf("%d", i+1)
+}
+
+func readSketches(r io.Reader, k, size int) ([]sketchRecord, error) {
+	br := bufio.NewReader(r)
+
+	c, err := br.Peek(1)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	records := []sketchRecord{}
+
+	if len(c) > 0 && c[0] == '{' {
+		dec := json.NewDecoder(br)
+		for dec.More() {
+			rec := sketchRecord{}
+			if err := dec.Decode(&rec); err != nil {
+				return nil, fmt.Errorf("malformed sketch file: %v", err)
+			}
+			records = append(records, rec)
+		}
+		return records, nil
+	}
+
+	scanner := newSeqScanner(br)
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		sketch := gts.NewSketch(seq, k, size)
+		records = append(records, sketchRecord{seqID(seq, i), gts.Len(seq), sketch})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("encountered error in scanner: %v", err)
+	}
+
+	return records, nil
+}
+
+func sketchFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output sketch file (specifying `-` will force standard output)")
+	k := opt.Int('k', "kmer", 21, "k-mer size")
+	size := opt.Int('s', "size", 1000, "number of hashes to keep in each sketch")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *k < 1 {
+		return ctx.Raise(fmt.Errorf("k-mer size must be positive: got %d", *k))
+	}
+
+	if *size < 1 {
+		return ctx.Raise(fmt.Errorf("sketch size must be positive: got %d", *size))
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"kmer", *k},
+			{"size", *size},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	w := bufio.NewWriter(d)
+	enc := json.NewEncoder(w)
+
+	scanner := newSeqScanner(d)
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		sketch := gts.NewSketch(seq, *k, *size)
+		rec := sketchRecord{seqID(seq, i), gts.Len(seq), sketch}
+
+		if err := enc.Encode(rec); err != nil {
+			return ctx.Raise(err)
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -3,13 +3,14 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
 
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -31,22 +32,90 @@ func (ss byLength) Swap(i, j int) {
 	ss[i], ss[j] = ss[j], ss[i]
 }
 
+// seqSpill holds the sequences sorted by a command on a temporary file
+// instead of the memory. Only the length and the location of each sequence in
+// the file are kept in memory.
+type seqSpill struct {
+	f      *os.File
+	buffer *bufio.Writer
+	writer seqio.SeqWriter
+	offset int64
+	keys   []spillKey
+}
+
+type spillKey struct {
+	length       int
+	offset, size int64
+}
+
+func newSeqSpill(filetype seqio.FileType) (*seqSpill, error) {
+	f, err := ioutil.TempFile("", "gts-spill-*")
+	if err != nil {
+		return nil, err
+	}
+	buffer := bufio.NewWriter(f)
+	return &seqSpill{f, buffer, newFormatWriter(buffer, filetype), 0, nil}, nil
+}
+
+func (s *seqSpill) Len() int {
+	return len(s.keys)
+}
+
+func (s *seqSpill) Less(i, j int) bool {
+	return s.keys[j].length < s.keys[i].length
+}
+
+func (s *seqSpill) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// Add writes the sequence to the temporary file.
+func (s *seqSpill) Add(seq gts.Sequence) error {
+	n, err := s.writer.WriteSeq(seq)
+	if err != nil {
+		return err
+	}
+	s.keys = append(s.keys, spillKey{gts.Len(seq), s.offset, int64(n)})
+	s.offset += int64(n)
+	return nil
+}
+
+// Get reads the i-th sequence back from the temporary file.
+func (s *seqSpill) Get(i int) (gts.Sequence, error) {
+	key := s.keys[i]
+	scanner := newSeqScanner(io.NewSectionReader(s.f, key.offset, key.size))
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	return scanner.Value(), nil
+}
+
+// Flush writes any buffered data to the temporary file.
+func (s *seqSpill) Flush() error {
+	return s.buffer.Flush()
+}
+
+// Close removes the temporary file.
+func (s *seqSpill) Close() error {
+	s.f.Close()
+	return os.Remove(s.f.Name())
+}
+
 func sortFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
 	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
 	reverse := opt.Switch('r', "reverse", "reverse the sort order")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -74,24 +143,67 @@ func sortFunc(ctx *flags.Context) error {
 			return ctx.Raise(err)
 		}
 	}
+	// The sequences are moved to a temporary file once they exceed the
+	// memory budget given by the `--max-memory` flag.
 	seqs := []gts.Sequence{}
-	scanner := seqio.NewAutoScanner(d)
+	var spill *seqSpill
+	meter := memoryMeter{}
+	scanner := newSeqScanner(d)
 	for scanner.Scan() {
 		seq := scanner.Value()
-		seqs = append(seqs, seq)
+		if spill == nil && !meter.add(seq) {
+			spill, err = newSeqSpill(filetype)
+			if err != nil {
+				return ctx.Raise(err)
+			}
+			defer spill.Close()
+			for _, seq := range seqs {
+				if err := spill.Add(seq); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+			seqs = nil
+		}
+		if spill != nil {
+			if err := spill.Add(seq); err != nil {
+				return ctx.Raise(err)
+			}
+		} else {
+			seqs = append(seqs, seq)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
 	}
 
 	var iface sort.Interface
 	iface = byLength(seqs)
+	if spill != nil {
+		if err := spill.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+		iface = spill
+	}
 	if *reverse {
 		iface = sort.Reverse(iface)
 	}
-	sort.Sort(iface)
+	sort.Stable(iface)
 
 	buffer := bufio.NewWriter(d)
-	writer := seqio.NewWriter(buffer, filetype)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; i < iface.Len(); i++ {
+		var seq gts.Sequence
+		if spill != nil {
+			seq, err = spill.Get(i)
+			if err != nil {
+				return ctx.Raise(err)
+			}
+		} else {
+			seq = seqs[i]
+		}
 
-	for _, seq := range seqs {
 		if _, err := writer.WriteSeq(seq); err != nil {
 			return ctx.Raise(err)
 		}
@@ -101,9 +213,5 @@ func sortFunc(ctx *flags.Context) error {
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
-	}
-
 	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strings"
 
@@ -13,7 +12,6 @@ import (
 	"github.com/go-ascii/ascii"
 	"github.com/go-gts/flags"
 	"github.com/go-gts/gts"
-	"github.com/go-gts/gts/cmd"
 	"github.com/go-gts/gts/seqio"
 )
 
@@ -50,18 +48,15 @@ func summaryFunc(ctx *flags.Context) error {
 	h := newHash()
 	pos, opt := flags.Flags()
 
-	seqinPath := new(string)
-	*seqinPath = "-"
-	if cmd.IsTerminal(os.Stdin.Fd()) {
-		seqinPath = pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
-	}
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
 
 	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
 	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
 	nofeature := opt.Switch('F', "no-feature", "suppress feature summary")
 	noqualifier := opt.Switch('Q', "no-qualifier", "suppress qualifier summary")
+	nocoverage := opt.Switch('C', "no-coverage", "suppress coverage summary")
 
-	if err := ctx.Parse(pos, opt); err != nil {
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
 		return err
 	}
 
@@ -77,6 +72,7 @@ func summaryFunc(ctx *flags.Context) error {
 			{"version", gts.Version.String()},
 			{"nofeature", *nofeature},
 			{"noqualifier", noqualifier},
+			{"nocoverage", *nocoverage},
 		})
 
 		ok, err := d.TryCache(h, data)
@@ -87,7 +83,7 @@ func summaryFunc(ctx *flags.Context) error {
 
 	w := bufio.NewWriter(d)
 
-	scanner := seqio.NewAutoScanner(d)
+	scanner := newSeqScanner(d)
 	i := 0
 	for scanner.Scan() {
 		seq := scanner.Value()
@@ -141,7 +137,14 @@ func summaryFunc(ctx *flags.Context) error {
 		}
 		sort.Sort(byValue(props))
 
+		coverageLabels := []string{"Gene loci", "Coverage", "Coding density", "Mean intergenic"}
+
 		longest := 0
+		for _, label := range coverageLabels {
+			if n := len(label); !*nocoverage && n > longest {
+				longest = n
+			}
+		}
 		for _, p := range bases {
 			if n := len(p.Key); n > longest {
 				longest = n
@@ -174,6 +177,19 @@ func summaryFunc(ctx *flags.Context) error {
 			}
 		}
 
+		if !*nocoverage {
+			circular := false
+			if info, ok := seq.Info().(seqio.GenBankFields); ok {
+				circular = info.Topology == gts.Circular
+			}
+			c := gts.FeatureCoverage(ff, gts.Len(seq), circular)
+			b.WriteString("Coverage Summary\n")
+			b.WriteString(fmt.Sprintf(format, coverageLabels[0], humanize.Comma(int64(c.Genes))))
+			b.WriteString(fmt.Sprintf(format, coverageLabels[1], fmt.Sprintf("%.2f%%", 100*c.Fraction())))
+			b.WriteString(fmt.Sprintf(format, coverageLabels[2], fmt.Sprintf("%.2f%%", 100*c.CodingDensity())))
+			b.WriteString(fmt.Sprintf(format, coverageLabels[3], fmt.Sprintf("%.1f bp", c.MeanIntergenic())))
+		}
+
 		if !*noqualifier {
 			b.WriteString("Qualifier Summary\n")
 			for _, p := range props {
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("tile", "design oligos tiling the target region(s)", tileFunc)
+}
+
+func flipStrand(strand string) string {
+	if strand == "+" {
+		return "-"
+	}
+	return "+"
+}
+
+func tileFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	locstr := opt.String('r', "region", "^..$", "a locator string specifying the target region(s)")
+	length := opt.Int('l', "length", 60, "length of each oligo")
+	overlap := opt.Int(0, "overlap", 0, "number of bases shared by adjacent oligos (may be negative to leave gaps)")
+	shift := opt.Int(0, "shift", 0, "maximum number of bases to shift an oligo to satisfy the constraints")
+	minGC := opt.Float(0, "min-gc", 0, "minimum GC content in percent")
+	maxGC := opt.Float(0, "max-gc", 100, "maximum GC content in percent")
+	minTm := opt.Float(0, "min-tm", 0, "minimum melting temperature in degrees Celsius")
+	maxTm := opt.Float(0, "max-tm", 0, "maximum melting temperature in degrees Celsius (0 for no limit)")
+	complement := opt.Switch('c', "complement", "design oligos complementary to the target region(s)")
+	prefix := opt.String('p', "prefix", "", "prefix for the oligo names (defaults to the sequence ID)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *length <= 0 {
+		return ctx.Raise(fmt.Errorf("oligo length must be positive: got %d", *length))
+	}
+	if *overlap >= *length {
+		return ctx.Raise(fmt.Errorf("overlap must be smaller than the oligo length: got %d for length %d", *overlap, *length))
+	}
+
+	locate, err := gts.AsLocator(*locstr)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	oc := gts.OligoConstraints{
+		MinGC: *minGC / 100,
+		MaxGC: *maxGC / 100,
+		MinTm: *minTm,
+		MaxTm: *maxTm,
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"locator", *locstr},
+			{"length", *length},
+			{"overlap", *overlap},
+			{"shift", *shift},
+			{"constraints", oc},
+			{"complement", *complement},
+			{"prefix", *prefix},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"name", "seqid", "start", "end", "strand", "length", "gc", "tm", "sequence", "status"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		name := *prefix
+		if name == "" {
+			name = id
+		}
+
+		count := 0
+		for _, region := range locate(seq) {
+			if rr, ok := region.(gts.Regions); ok && len(rr) == 1 {
+				region = rr[0]
+			}
+			if gts.HasRemote(region) {
+				return ctx.Raise(fmt.Errorf("%s: cannot tile region %v in a remote entry", id, region))
+			}
+			segment, ok := region.(gts.Segment)
+			if !ok {
+				return ctx.Raise(fmt.Errorf("%s: cannot tile non-contiguous region %v", id, region))
+			}
+
+			head, tail := gts.Unpack(segment)
+			p := segment.Locate(seq).Bytes()
+
+			for _, oligo := range gts.TileOligos(p, *length, *overlap, *shift, oc) {
+				s, e := gts.Unpack(oligo.Segment)
+				start, end, strand := head+s, head+e, "+"
+				if tail < head {
+					start, end, strand = head-e, head-s, "-"
+				}
+
+				q := p[s:e]
+				if *complement {
+					q = gts.Reverse(gts.Complement(gts.New(nil, nil, q))).Bytes()
+					strand = flipStrand(strand)
+				}
+
+				status := "ok"
+				if len(oligo.Problems) > 0 {
+					status = strings.Join(oligo.Problems, ",")
+				}
+
+				count++
+				fields := []string{
+					fmt.Sprintf("%s_%d", name, count), id,
+					strconv.Itoa(start + 1), strconv.Itoa(end),
+					strand, strconv.Itoa(len(q)),
+					strconv.FormatFloat(gts.GCContent(q)*100, 'f', 1, 64),
+					strconv.FormatFloat(gts.MeltingTemp(q), 'f', 1, 64),
+					string(q), status,
+				}
+				if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
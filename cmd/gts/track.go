@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("track", "export feature density or sequence metrics as a bedGraph/wiggle track", trackFunc)
+}
+
+func trackValues(seq gts.Sequence, ff gts.FeatureSlice, windows []gts.Segment, metric string) []float64 {
+	values := make([]float64, len(windows))
+	switch metric {
+	case "count":
+		for i, n := range gts.FeatureCount(ff, windows) {
+			values[i] = float64(n)
+		}
+	case "gc":
+		p := seq.Bytes()
+		for i, window := range windows {
+			values[i] = gts.GCContent(p[window[0]:window[1]])
+		}
+	default:
+		depth := gts.FeatureDepth(ff, gts.Len(seq))
+		sums := make([]int, len(depth)+1)
+		for i, n := range depth {
+			if metric == "coverage" && n > 0 {
+				n = 1
+			}
+			sums[i+1] = sums[i] + n
+		}
+		for i, window := range windows {
+			start, end := gts.Unpack(window)
+			values[i] = float64(sums[end]-sums[start]) / float64(end-start)
+		}
+	}
+	return values
+}
+
+func trackFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output track file (specifying `-` will force standard output)")
+	metric := opt.String('m', "metric", "coverage", "metric to compute for each window (coverage, depth, count, gc)")
+	selector := opt.String('s', "selector", "CDS", "feature selector for the feature based metrics")
+	window := opt.Int('w', "window", 1000, "window size in bases")
+	step := opt.Int(0, "step", 0, "distance between the starts of adjacent windows (defaults to the window size)")
+	format := opt.String('f', "format", "bedgraph", "track format (bedgraph, wiggle)")
+	name := opt.String('n', "name", "", "track name (defaults to the metric name)")
+	noheader := opt.Switch('H', "no-header", "do not print the track definition line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	switch *metric {
+	case "coverage", "depth", "count", "gc":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown metric %q: expected one of coverage, depth, count, gc", *metric))
+	}
+
+	switch *format {
+	case "bedgraph", "wiggle":
+	default:
+		return ctx.Raise(fmt.Errorf("unknown track format %q: expected one of bedgraph, wiggle", *format))
+	}
+
+	if *window <= 0 {
+		return ctx.Raise(fmt.Errorf("window size must be positive: got %d", *window))
+	}
+	if *step == 0 {
+		*step = *window
+	}
+	if *step < 0 || *step > *window {
+		return ctx.Raise(fmt.Errorf("step must be between 1 and the window size: got %d for window size %d", *step, *window))
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+
+	if *name == "" {
+		*name = *metric
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"metric", *metric},
+			{"selector", *selector},
+			{"window", *window},
+			{"step", *step},
+			{"format", *format},
+			{"name", *name},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		kind := "bedGraph"
+		if *format == "wiggle" {
+			kind = "wiggle_0"
+		}
+		if _, err := fmt.Fprintf(w, "track type=%s name=%q\n", kind, *name); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+		n := gts.Len(seq)
+
+		windows := gts.SlidingWindows(n, *window, *step)
+		ff := seq.Features().Filter(filter)
+		values := trackValues(seq, ff, windows, *metric)
+
+		if *format == "wiggle" {
+			if _, err := fmt.Fprintf(w, "fixedStep chrom=%s start=1 step=%d span=%d\n", id, *step, *step); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		for j, window := range windows {
+			value := strconv.FormatFloat(values[j], 'g', 6, 64)
+			var err error
+			switch *format {
+			case "wiggle":
+				_, err = fmt.Fprintf(w, "%s\n", value)
+			default:
+				start := window[0]
+				end := gts.Min(start+*step, n)
+				_, err = fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", id, start, end, value)
+			}
+			if err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
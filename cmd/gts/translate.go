@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("translate", "translate the CDS features into protein sequences", translateFunc)
+}
+
+func checkPseudoMode(mode string, modes ...string) error {
+	for _, m := range modes {
+		if mode == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown pseudo feature handling mode %q: expected one of %s", mode, strings.Join(modes, ", "))
+}
+
+func warnPseudo(ctx *flags.Context, id string, f gts.Feature) {
+	fmt.Fprintf(os.Stderr, "%s: %s: %s %s is flagged as pseudo: the translation may not be meaningful\n", strings.Join(ctx.Name, " "), id, f.Key, f.Loc)
+}
+
+// featureName returns the first of the protein_id, locus_tag, or gene
+// qualifier values of the feature, or an empty string if none are present.
+func featureName(f gts.Feature) string {
+	for _, name := range []string{"protein_id", "locus_tag", "gene"} {
+		if values := f.Props.Get(name); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func translationHeader(id string, f gts.Feature) string {
+	header := fmt.Sprintf("%s:%s", id, f.Loc)
+	if name := featureName(f); name != "" {
+		header = fmt.Sprintf("%s %s", header, name)
+	}
+	if values := f.Props.Get("product"); len(values) > 0 {
+		header = fmt.Sprintf("%s %s", header, values[0])
+	}
+	return header
+}
+
+// genpeptRecord returns the GenPept record of the protein encoded by the
+// feature of the sequence with the given ID. The fields and the source
+// qualifiers of the record are inherited from the sequence if available.
+func genpeptRecord(seq gts.Sequence, id string, f gts.Feature, table gts.CodonTable, p []byte) gts.Sequence {
+	name := featureName(f)
+	if name == "" {
+		name = id
+	}
+
+	definition := translationHeader(id, f)
+	if values := f.Props.Get("product"); len(values) > 0 {
+		definition = values[0]
+	}
+
+	fields := seqio.GenBankFields{
+		LocusName:  name,
+		Molecule:   gts.AA,
+		Topology:   gts.Linear,
+		Division:   "UNA",
+		Date:       seqio.Date{Year: 1970, Month: time.January, Day: 1},
+		Definition: definition,
+		Extra:      []seqio.ExtraField{seqio.GenBankExtraField("DBSOURCE", "accession "+id)},
+	}
+	if values := f.Props.Get("protein_id"); len(values) > 0 {
+		fields.Accession, fields.Version = values[0], values[0]
+		if i := strings.LastIndexByte(values[0], '.'); i > 0 {
+			fields.Accession = values[0][:i]
+		}
+		fields.LocusName = fields.Accession
+	}
+	if info, ok := seq.Info().(seqio.GenBankFields); ok {
+		fields.Division, fields.Date = info.Division, info.Date
+		fields.Source = info.Source
+	}
+
+	n := len(p)
+	partial := gts.LocationPartial(f.Loc)
+	if gts.CodonStart(f) > 0 {
+		partial.Partial5 = true
+	}
+
+	sourceProps := gts.Props{}
+	if ff := seq.Features().Filter(gts.Key("source")); len(ff) > 0 {
+		sourceProps = ff[0].Props.Clone()
+		sourceProps.Del("mol_type")
+	}
+
+	proteinProps := gts.Props{}
+	for _, key := range []string{"product", "EC_number"} {
+		if values := f.Props.Get(key); len(values) > 0 {
+			proteinProps.Add(key, values...)
+		}
+	}
+
+	cdsProps := gts.Props{}
+	for _, key := range []string{"gene", "locus_tag"} {
+		if values := f.Props.Get(key); len(values) > 0 {
+			cdsProps.Add(key, values...)
+		}
+	}
+	cdsProps.Add("coded_by", fmt.Sprintf("%s:%s", id, f.Loc))
+	if table.ID != 1 {
+		cdsProps.Add("transl_table", strconv.Itoa(table.ID))
+	}
+
+	ff := gts.FeatureSlice{
+		gts.NewFeature("source", gts.Range(0, n), sourceProps),
+		gts.NewFeature("Protein", gts.PartialRange(0, n, partial), proteinProps),
+		gts.NewFeature("CDS", gts.PartialRange(0, n, partial), cdsProps),
+	}
+
+	return gts.New(fields, ff, p)
+}
+
+// translateSixFrames translates the given segment of the sequence in six
+// frames, optionally split into stop-to-stop segments. The frames are
+// numbered relative to the segment.
+func translateSixFrames(segment gts.Segment, seq gts.Sequence, table gts.CodonTable, split bool, min int) []gts.FrameTranslation {
+	tt := gts.TranslateFrames(segment.Locate(seq), table)
+	if !split {
+		return tt
+	}
+	uu := []gts.FrameTranslation{}
+	for _, t := range tt {
+		uu = append(uu, t.Split(min)...)
+	}
+	return uu
+}
+
+// sixFrameLocation returns the location of a translation of the given
+// segment in the coordinates of the sequence.
+func sixFrameLocation(segment gts.Segment, t gts.FrameTranslation) gts.Location {
+	head, tail := gts.Unpack(segment)
+	loc := gts.Location(gts.Range(head+t.Start, head+t.End))
+	reverse := t.Frame < 0
+	if tail < head {
+		loc = gts.Range(head-t.End, head-t.Start)
+		reverse = !reverse
+	}
+	if reverse {
+		loc = loc.Complement()
+	}
+	return loc
+}
+
+// embossTranslationHeader returns the description of a six-frame
+// translation as written by the EMBOSS programs: transeq names the frames
+// +1, +2, +3, -1, -2, and -3 with the suffixes _1 to _6, and getorf numbers
+// the stop-to-stop segments consecutively, followed by their positions.
+func embossTranslationHeader(name string, loc gts.Location, frame gts.Frame, split bool, n int) string {
+	if !split {
+		number := int(frame)
+		if frame < 0 {
+			number = 3 - number
+		}
+		return fmt.Sprintf("%s_%d", name, number)
+	}
+	r := loc.Region()
+	head, tail := r.Head(), r.Tail()
+	if tail < head {
+		return fmt.Sprintf("%s_%d [%d - %d] (REVERSE SENSE)", name, n, head, tail+1)
+	}
+	return fmt.Sprintf("%s_%d [%d - %d]", name, n, head+1, tail)
+}
+
+func translateFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	pseudo := opt.String('p', "pseudo", "skip", "handling of pseudo features (skip, include)")
+	table := opt.Int('t', "table", 1, "translation table to use for features without a /transl_table qualifier")
+	sixFrame := opt.Switch(0, "six-frame", "translate the region(s) in the six reading frames instead of the CDS features")
+	locstr := opt.String('r', "region", "^..$", "a locator string specifying the region(s) to translate in six frames")
+	split := opt.Switch('s', "stop-to-stop", "split the six-frame translations into segments between stop codons")
+	minLength := opt.Int('m', "min-length", 1, "minimum number of residues in a stop-to-stop segment")
+	tabular := opt.Switch('T', "tabular", "write the six-frame translations as a table instead of FASTA")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns of the table")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the table")
+	emboss := opt.Switch(0, "emboss", "name the six-frame translations as the EMBOSS transeq and getorf programs")
+	selector := opt.String(0, "selector", "CDS", "feature selector for the features to translate")
+	records := opt.Switch(0, "records", "translate the entire records instead of the CDS features")
+	format := opt.String('F', "format", "fasta", "output file format (fasta or genbank for GenPept)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if err := checkPseudoMode(*pseudo, "skip", "include"); err != nil {
+		return ctx.Raise(err)
+	}
+
+	filter, err := gts.Selector(*selector)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+	}
+
+	filetype := seqio.ToFileType(*format)
+	switch {
+	case *emboss:
+		filetype = seqio.EMBOSSFile
+	case filetype != seqio.FastaFile && filetype != seqio.GenBankFile:
+		return ctx.Raise(fmt.Errorf("unsupported output format %q: expected fasta or genbank", *format))
+	}
+
+	sixFrameTable, err := gts.LookupCodonTable(*table)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	locate, err := gts.AsLocator(*locstr)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"pseudo", *pseudo},
+			{"table", *table},
+			{"sixFrame", *sixFrame},
+			{"locator", *locstr},
+			{"split", *split},
+			{"minLength", *minLength},
+			{"tabular", *tabular},
+			{"delim", *delim},
+			{"noheader", *noheader},
+			{"emboss", *emboss},
+			{"selector", *selector},
+			{"records", *records},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	if *sixFrame && *tabular && !*noheader {
+		fields := []string{"seqid", "frame", "location", "length", "protein"}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		if seqMolecule(seq) == gts.AA {
+			return ctx.Raise(fmt.Errorf("%s: cannot translate an amino acid sequence", id))
+		}
+
+		if *sixFrame {
+			orfs := 0
+			for _, region := range locate(seq) {
+				if rr, ok := region.(gts.Regions); ok && len(rr) == 1 {
+					region = rr[0]
+				}
+				if gts.HasRemote(region) {
+					return ctx.Raise(fmt.Errorf("%s: cannot translate region %v in a remote entry", id, region))
+				}
+				segment, ok := region.(gts.Segment)
+				if !ok {
+					return ctx.Raise(fmt.Errorf("%s: cannot translate non-contiguous region %v", id, region))
+				}
+
+				for _, t := range translateSixFrames(segment, seq, sixFrameTable, *split, *minLength) {
+					loc := sixFrameLocation(segment, t)
+					if *tabular {
+						fields := []string{id, t.Frame.String(), loc.String(), strconv.Itoa(len(t.Protein)), string(t.Protein)}
+						if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+							return ctx.Raise(err)
+						}
+						continue
+					}
+					header := fmt.Sprintf("%s:%s frame=%s", id, loc, t.Frame)
+					if *emboss {
+						orfs++
+						header = embossTranslationHeader(seqio.EMBOSSName(seq), loc, t.Frame, *split, orfs)
+					}
+					out := seqio.WithMolecule(gts.New(header, nil, t.Protein), gts.AA)
+					if _, err := writer.WriteSeq(out); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+			}
+
+			if err := buffer.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+			continue
+		}
+
+		ff := seq.Features().Filter(gts.And(gts.Key("CDS"), filter))
+		if *records {
+			// Translate the record as the feature it was extracted from.
+			f := definingFeature(seq)
+			if f.Key == "" {
+				f = gts.NewFeature("CDS", gts.Range(0, gts.Len(seq)), gts.Props{})
+			}
+			ff = []gts.Feature{f}
+		}
+
+		for _, f := range ff {
+			if gts.IsPseudo(f) {
+				if *pseudo == "skip" {
+					continue
+				}
+				warnPseudo(ctx, id, f)
+			}
+
+			codons, err := gts.TranslationTable(f, *table)
+			if err != nil {
+				return ctx.Raise(fmt.Errorf("%s: %s %s: %v", id, f.Key, f.Loc, err))
+			}
+
+			header := translationHeader(id, f)
+			if *records {
+				header = id
+			}
+
+			p, err := gts.TranslateCDS(f, seq, codons, seqResolve())
+			if err != nil {
+				return ctx.Raise(fmt.Errorf("%s: %s %s: %v", id, f.Key, f.Loc, err))
+			}
+			var out gts.Sequence = gts.New(header, nil, p)
+			if filetype == seqio.GenBankFile {
+				out = genpeptRecord(seq, id, f, codons, p)
+			}
+			if _, err := writer.WriteSeq(out); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
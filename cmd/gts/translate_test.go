@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+	"github.com/go-gts/gts/seqio"
+)
+
+const remoteRecord = `LOCUS       TEST0001                  30 bp    DNA     linear   SYN 01-JAN-2020
+DEFINITION  Test record with a remote location.
+ACCESSION   TEST0001
+VERSION     TEST0001.1
+FEATURES             Location/Qualifiers
+     source          1..30
+                     /mol_type="genomic DNA"
+     CDS             join(3..8,J00194.1:1..9)
+                     /product="remote protein"
+ORIGIN      
+        1 ccatgaaatt tgggtaaccg tgaaataacc
+//
+`
+
+func TestTranslateRemote(t *testing.T) {
+	_, err := runCommand(t, remoteRecord, "translate")
+	if err == nil {
+		t.Fatal("expected error translating a CDS in a remote entry without a resolver")
+	}
+	if !strings.Contains(err.Error(), "J00194.1") {
+		t.Errorf("error should name the remote entry: %v", err)
+	}
+}
+
+func TestTranslateRemoteResolver(t *testing.T) {
+	seqResolver = seqio.ResolverFunc(func(accession string) (gts.Sequence, error) {
+		return gts.New(nil, nil, []byte("tttgggtaa")), nil
+	})
+	defer func() { seqResolver = nil }()
+
+	out, err := runCommand(t, remoteRecord, "translate")
+	if err != nil {
+		t.Fatalf("translate failed: %v", err)
+	}
+	testutils.Equals(t, out, ">TEST0001.1:join(3..8,J00194.1:1..9) remote protein\nMKFG\n")
+}
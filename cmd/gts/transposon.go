@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("transposon", "simulate a library of cassette insertions at each site", transposonFunc)
+}
+
+// transposonSites returns the points of insertion within the given regions:
+// every n bases if n is positive, and the middle of each occurrence of the
+// motif otherwise.
+func transposonSites(seq gts.Sequence, rr gts.Regions, n int, motif gts.Sequence) []int {
+	sites := []int{}
+	for _, s := range gts.Minimize(rr) {
+		head, tail := gts.Unpack(s)
+		if n > 0 {
+			for i := head + n; i < tail; i += n {
+				sites = append(sites, i)
+			}
+			continue
+		}
+		sub := gts.Slice(seq, head, tail)
+		for _, m := range gts.Match(sub, motif) {
+			start, end := gts.Unpack(m)
+			sites = append(sites, head+(start+end)/2)
+		}
+	}
+	return sites
+}
+
+func transposonFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	cassettePath := pos.String("cassette", "cassette sequence file (will be interpreted literally if preceded with @)")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+	every := opt.Int('n', "every", 0, "insert the cassette every given number of bases instead of at each motif")
+	motifStr := opt.String('m', "motif", "TA", "insert the cassette in the middle of each occurrence of the motif")
+	locstr := opt.String('r', "region", "", "a locator string specifying the region(s) to insert into (defaults to the whole sequence)")
+	featureKey := opt.String('k', "key", "mobile_element", "key for the inserted cassette features")
+	propstrs := opt.StringSlice('q', "qualifier", nil, "qualifier key-value pairs (syntax: key=value))")
+	tablePath := opt.String('t', "table", "", "output file of a table of the insertion sites and the features they disrupt")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *every < 0 {
+		return ctx.Raise(fmt.Errorf("expected a positive interval: got %d", *every))
+	}
+	if *every == 0 && *motifStr == "" {
+		return ctx.Raise(fmt.Errorf("either an interval or a motif is required"))
+	}
+	motif := gts.New(nil, nil, []byte(*motifStr))
+
+	locate := gts.Locator(nil)
+	if *locstr != "" {
+		var err error
+		if locate, err = gts.AsLocator(*locstr); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	cassettes := []gts.Sequence{}
+	cassetteBytes := []byte(*cassettePath)
+
+	h.Reset()
+	switch cassetteBytes[0] {
+	case '@':
+		h.Write(cassetteBytes)
+		cassettes = append(cassettes, gts.New(nil, nil, cassetteBytes[1:]))
+
+	default:
+		f, err := os.Open(*cassettePath)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to open file: %q: %v", *cassettePath, err))
+		}
+		defer f.Close()
+
+		r := attach(h, f)
+		scanner := newSeqScanner(r)
+		for scanner.Scan() {
+			cassettes = append(cassettes, scanner.Value())
+		}
+		if len(cassettes) == 0 {
+			return ctx.Raise(fmt.Errorf("cassette sequence file %q does not contain a sequence", *cassettePath))
+		}
+	}
+	cassette := cassettes[0]
+	cassetteSum := h.Sum(nil)
+
+	props := gts.Props{}
+	for _, s := range *propstrs {
+		name, value := s, ""
+		if i := strings.IndexByte(s, '='); i >= 0 {
+			name, value = s[:i], s[i+1:]
+		}
+		props.Add(name, value)
+	}
+	if *featureKey == "mobile_element" && !props.Has("mobile_element_type") {
+		props.Add("mobile_element_type", "transposon")
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache && *tablePath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"cassette", encodeToString(cassetteSum)},
+			{"every", *every},
+			{"motif", *motifStr},
+			{"region", *locstr},
+			{"featureKey", *featureKey},
+			{"propstrs", *propstrs},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	var table *bufio.Writer
+	if *tablePath != "" {
+		w, err := createOutput(*tablePath)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+		defer w.Close()
+		table = bufio.NewWriter(w)
+
+		fields := []string{"seqid", "site", "disrupted"}
+		if _, err := fmt.Fprintf(table, "%s\n", strings.Join(fields, "\t")); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		rr := gts.Regions{gts.Segment{0, gts.Len(seq)}}
+		if locate != nil {
+			rr = locate(seq)
+		}
+
+		for _, site := range transposonSites(seq, rr, *every, motif) {
+			out, invs := gts.InsertReport(gts.Copy(seq), site, cassette)
+
+			disrupted := make([]string, len(invs))
+			for j, inv := range invs {
+				disrupted[j] = inv.Feature.Key
+				if name := featureName(inv.Feature); name != "" {
+					disrupted[j] += " " + name
+				}
+			}
+
+			fprops := props.Clone()
+			fprops.Add("note", fmt.Sprintf("inserted before base %d", site+1))
+			if len(disrupted) > 0 {
+				fprops.Add("note", fmt.Sprintf("disrupts %s", strings.Join(disrupted, ", ")))
+			}
+			loc, err := gts.TryRange(site, site+gts.Len(cassette))
+			if err != nil {
+				return ctx.Raise(fmt.Errorf("cassette sequence is empty: %v", err))
+			}
+			f := gts.NewFeature(*featureKey, loc, fprops)
+			out = gts.WithFeatures(out, out.Features().Insert(f))
+
+			if _, err := writer.WriteSeq(out); err != nil {
+				return ctx.Raise(err)
+			}
+			if err := buffer.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+
+			if table != nil {
+				fields := []string{id, strconv.Itoa(site + 1), strings.Join(disrupted, ", ")}
+				if _, err := fmt.Fprintf(table, "%s\n", strings.Join(fields, "\t")); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if table != nil {
+		if err := table.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	return nil
+}
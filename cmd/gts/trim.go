@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("trim", "trim and filter FASTQ reads by quality and adapters", trimFunc)
+}
+
+// readTrimmer trims the adapters and low quality bases from a read and
+// reports whether the trimmed read passes the filters.
+type readTrimmer struct {
+	adapters   []gts.Sequence
+	mismatches int
+	overlap    int
+	window     int
+	quality    float64
+	minLength  int
+	minMean    float64
+}
+
+func (rt readTrimmer) trim(read seqio.Fastq) (seqio.Fastq, bool) {
+	end := len(read.Data)
+	for _, adapter := range rt.adapters {
+		seq := gts.New(nil, nil, read.Data[:end])
+		end = gts.AdapterPosition(seq, adapter, rt.mismatches, rt.overlap)
+	}
+	if rt.quality > 0 {
+		end = gts.QualityTrim(read.Quality[:end], rt.window, rt.quality)
+	}
+	read = read.Slice(0, end)
+	ok := len(read.Data) >= rt.minLength && gts.MeanQuality(read.Quality) >= rt.minMean
+	return read, ok
+}
+
+func asRead(seq gts.Sequence, i int) (seqio.Fastq, error) {
+	read, ok := seq.(seqio.Fastq)
+	if !ok {
+		return read, fmt.Errorf("%s: reads must be given in FASTQ format to be trimmed", seqID(seq, i))
+	}
+	return read, nil
+}
+
+// trimPairs trims the paired-end reads given in r and the mate file in sync.
+// The pairs of which both reads pass the filters are written to w and the
+// mate output, and the reads whose mate was discarded are written to the
+// respective unpaired outputs if given.
+func trimPairs(r io.Reader, w seqio.SeqWriter, rt readTrimmer, matePath, mateoutPath, orphanPath, mateOrphanPath string) (err error) {
+	f, err := os.Open(matePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	outputs := make([]*seqOutput, 3)
+	for i, path := range []string{mateoutPath, orphanPath, mateOrphanPath} {
+		if outputs[i], err = createSeqOutput(path, seqio.FastqFile); err != nil {
+			return err
+		}
+		defer func(o *seqOutput) {
+			if cerr := o.Close(); err == nil {
+				err = cerr
+			}
+		}(outputs[i])
+	}
+	mateout, orphans, mateOrphans := outputs[0], outputs[1], outputs[2]
+
+	scanner := newPairScanner(r, f)
+	for i := 0; scanner.Scan(); i++ {
+		seq1, seq2 := scanner.Values()
+		read1, err := asRead(seq1, i)
+		if err != nil {
+			return err
+		}
+		read2, err := asRead(seq2, i)
+		if err != nil {
+			return err
+		}
+
+		read1, ok1 := rt.trim(read1)
+		read2, ok2 := rt.trim(read2)
+
+		switch {
+		case ok1 && ok2:
+			if _, err := w.WriteSeq(read1); err != nil {
+				return err
+			}
+			_, err = mateout.WriteSeq(read2)
+		case ok1:
+			_, err = orphans.WriteSeq(read1)
+		case ok2:
+			_, err = mateOrphans.WriteSeq(read2)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func trimFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input FASTQ file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output FASTQ file (specifying `-` will force standard output)")
+	adapters := opt.StringSlice('a', "adapter", nil, "adapter sequence to trim from the 3' end of the reads")
+	mismatches := opt.Int('e', "mismatches", 1, "maximum number of mismatches allowed within an adapter")
+	overlap := opt.Int('O', "min-overlap", 3, "minimum number of bases of an adapter overlapping the 3' end of a read")
+	window := opt.Int('w', "window", 4, "size of the sliding window for quality trimming")
+	quality := opt.Float('q', "quality", 20, "mean quality below which the sliding window is trimmed (0 to disable)")
+	minLength := opt.Int('l', "min-length", 1, "discard reads shorter than this length after trimming")
+	minMean := opt.Float('Q', "min-mean-quality", 0, "discard reads with a mean quality below this value after trimming")
+	matePath := opt.String('p', "paired", "", "FASTQ file of the mates of the paired-end reads")
+	mateoutPath := opt.String('P', "paired-output", "", "output FASTQ file for the mates (required with --paired)")
+	orphanPath := opt.String('u', "unpaired", "", "output FASTQ file for the reads whose mate was discarded")
+	mateOrphanPath := opt.String('U', "unpaired-mate", "", "output FASTQ file for the mates whose read was discarded")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *window < 1 {
+		return ctx.Raise(fmt.Errorf("window size must be positive: got %d", *window))
+	}
+
+	if *matePath != "" && *mateoutPath == "" {
+		return ctx.Raise(errors.New("the output file for the mates must be given with --paired-output"))
+	}
+
+	rt := readTrimmer{
+		mismatches: *mismatches,
+		overlap:    *overlap,
+		window:     *window,
+		quality:    *quality,
+		minLength:  *minLength,
+		minMean:    *minMean,
+	}
+	for _, adapter := range *adapters {
+		rt.adapters = append(rt.adapters, gts.New(nil, nil, []byte(adapter)))
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache && *matePath == "" {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"adapters", *adapters},
+			{"mismatches", *mismatches},
+			{"overlap", *overlap},
+			{"window", *window},
+			{"quality", *quality},
+			{"minLength", *minLength},
+			{"minMean", *minMean},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, seqio.FastqFile)
+
+	if *matePath != "" {
+		err := trimPairs(d, writer, rt, *matePath, *mateoutPath, *orphanPath, *mateOrphanPath)
+		if err == nil {
+			err = buffer.Flush()
+		}
+		return ctx.Raise(err)
+	}
+
+	scanner := newSeqScanner(d)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		read, err := asRead(seq, i)
+		if err != nil {
+			return ctx.Raise(err)
+		}
+
+		read, ok := rt.trim(read)
+		if !ok {
+			continue
+		}
+
+		if _, err := writer.WriteSeq(read); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := buffer.Flush(); err != nil {
+		return ctx.Raise(err)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
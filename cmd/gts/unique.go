@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+)
+
+func init() {
+	flags.Register("unique", "find subsequences absent from a background set", uniqueFunc)
+}
+
+func uniqueFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	bgPath := pos.String("background", "background sequence file")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	k := opt.Int('k', "kmer", 21, "length of the subsequences")
+	single := opt.Switch('s', "single", "only report subsequences occurring once in the input sequence")
+	merge := opt.Switch('m', "merge", "merge overlapping subsequences into regions")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *k < 1 {
+		return ctx.Raise(fmt.Errorf("k-mer size must be positive: got %d", *k))
+	}
+
+	f, err := os.Open(*bgPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *bgPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	background := gts.NewKmerIndex(*k)
+	bgScanner := newSeqScanner(attach(h, f))
+	for bgScanner.Scan() {
+		background.Add(bgScanner.Value())
+	}
+	if err := bgScanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+	bgSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"background", encodeToString(bgSum)},
+			{"kmer", *k},
+			{"single", *single},
+			{"merge", *merge},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	if !*noheader {
+		fields := []string{"seqid", "start", "end", "sequence"}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+		p := seq.Bytes()
+
+		segments := gts.UniqueKmers(seq, background, *single)
+		if *merge {
+			segments = gts.MergeSegments(segments)
+		}
+
+		for _, segment := range segments {
+			start, end := gts.Unpack(segment)
+			fields := []string{id, strconv.Itoa(start + 1), strconv.Itoa(end), string(p[start:end])}
+			if _, err := fmt.Fprintf(w, "%s\n", strings.Join(fields, *delim)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
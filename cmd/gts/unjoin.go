@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("unjoin", "split joined sequence(s) back into the original sequences", unjoinFunc)
+}
+
+// joinMapEntry represents the range of an original sequence in a joined
+// sequence, where Start and End are 1-based inclusive positions.
+type joinMapEntry struct {
+	ID    string
+	Start int
+	End   int
+}
+
+// readJoinMap reads a coordinate map written by join.
+func readJoinMap(path string) ([]joinMapEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '\t'
+	r.Comment = '#'
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("coordinate map is empty")
+	}
+
+	header := records[0]
+	if len(header) < 3 || header[0] != "seqid" || header[1] != "start" || header[2] != "end" {
+		return nil, fmt.Errorf("expected a header of `seqid`, `start`, and `end` columns: got %q", strings.Join(header, "\t"))
+	}
+
+	entries := make([]joinMapEntry, len(records)-1)
+	for i, record := range records[1:] {
+		start, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid start %q", i+2, record[1])
+		}
+		end, err := strconv.Atoi(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid end %q", i+2, record[2])
+		}
+		if start < 1 || end < start {
+			return nil, fmt.Errorf("line %d: %d..%d is not a valid range", i+2, start, end)
+		}
+		entries[i] = joinMapEntry{record[0], start, end}
+	}
+
+	return entries, nil
+}
+
+func unjoinFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	mapPath := pos.String("map", "coordinate map file written by `gts join --map`")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	seqoutPath := opt.String('o', "output", "-", "output sequence file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format (defaults to same as input)")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	f, err := os.Open(*mapPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file: %q: %v", *mapPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	if _, err := io.Copy(h, f); err != nil {
+		return ctx.Raise(err)
+	}
+	mapSum := h.Sum(nil)
+
+	entries, err := readJoinMap(*mapPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to read coordinate map %q: %v", *mapPath, err))
+	}
+
+	d, err := newIODelegate(*seqinPath, *seqoutPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*seqoutPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"map", encodeToString(mapSum)},
+			{"filetype", filetype},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+
+		for _, entry := range entries {
+			if gts.Len(seq) < entry.End {
+				return ctx.Raise(fmt.Errorf("%s: %s spans %d..%d but the sequence has %d bases", seqID(seq, i), entry.ID, entry.Start, entry.End, gts.Len(seq)))
+			}
+
+			out := gts.Slice(seq, entry.Start-1, entry.End)
+			out = seqio.WithID(out, entry.ID)
+
+			if _, err := writer.WriteSeq(out); err != nil {
+				return ctx.Raise(err)
+			}
+
+			if err := buffer.Flush(); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("variants", "report the variants between a reference and near-identical sequences", variantsFunc)
+}
+
+// variantAllele returns the bases of an allele for a table, where an empty
+// allele is written as `-`.
+func variantAllele(p []byte) string {
+	if len(p) == 0 {
+		return "-"
+	}
+	return string(p)
+}
+
+// vcfRecord returns the POS, REF, and ALT fields of the variant in the VCF
+// format. Insertions and deletions are padded with the preceding base of the
+// reference, or the following base if the variant is at the start of the
+// reference.
+func vcfRecord(v gts.Variant, ref []byte) (int, string, string) {
+	pos, r, a := v.Pos+1, v.Ref, v.Alt
+	if len(r) == 0 || len(a) == 0 {
+		switch {
+		case v.Pos > 0:
+			pos--
+			r = append([]byte{ref[v.Pos-1]}, r...)
+			a = append([]byte{ref[v.Pos-1]}, a...)
+		case len(v.Ref) < len(ref):
+			c := ref[len(v.Ref)]
+			r = append(append([]byte{}, r...), c)
+			a = append(append([]byte{}, a...), c)
+		}
+	}
+	return pos, string(bytes.ToUpper(r)), string(bytes.ToUpper(a))
+}
+
+// variationFeature returns a variation feature describing the variant.
+func variationFeature(v gts.Variant, queryID string) gts.Feature {
+	props := gts.Props{}
+	props.Add("replace", string(v.Alt))
+	props.Add("note", fmt.Sprintf("%s relative to %s at %d", v.Type(), queryID, v.Query+1))
+	return gts.NewFeature("variation", v.Location(), props)
+}
+
+func variantsFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	refPath := pos.String("reference", "reference sequence file")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output file (specifying `-` will force standard output)")
+	format := opt.String('F', "format", "", "output file format for --annotate (defaults to same as input)")
+	k := opt.Int('k', "kmer", 20, "length of the k-mers anchoring the sequences (0 to align the whole sequences)")
+	vcf := opt.Switch(0, "vcf", "write the variants in the VCF format")
+	annotate := opt.Switch('a', "annotate", "write the reference sequences with a variation feature for each variant")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns of the table")
+	noheader := opt.Switch('H', "no-header", "do not print the header line of the table")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	if *k < 0 {
+		return ctx.Raise(fmt.Errorf("k-mer length must not be negative: got %d", *k))
+	}
+	if *vcf && *annotate {
+		return ctx.Raise(fmt.Errorf("--vcf and --annotate cannot be given together"))
+	}
+
+	f, err := os.Open(*refPath)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *refPath, err))
+	}
+	defer f.Close()
+
+	h.Reset()
+	refs := []gts.Sequence{}
+	refIndex := map[string]int{}
+	refScanner := newSeqScanner(attach(h, f))
+	for i := 0; refScanner.Scan(); i++ {
+		seq := refScanner.Value()
+		refIndex[seqID(seq, i)] = i
+		refs = append(refs, seq)
+	}
+	if err := refScanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+	refSum := h.Sum(nil)
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	filetype := seqio.Detect(*outPath)
+	if *format != "" {
+		filetype = seqio.ToFileType(*format)
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"reference", encodeToString(refSum)},
+			{"kmer", *k},
+			{"vcf", *vcf},
+			{"annotate", *annotate},
+			{"filetype", filetype},
+			{"delim", *delim},
+			{"noheader", *noheader},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	scanner := newSeqScanner(d)
+	buffer := bufio.NewWriter(d)
+	writer := newSeqWriter(buffer, filetype)
+
+	switch {
+	case *vcf:
+		lines := []string{
+			"##fileformat=VCFv4.2",
+			fmt.Sprintf("##source=gts-variants %s", gts.Version),
+			fmt.Sprintf("##reference=%s", *refPath),
+		}
+		for i, ref := range refs {
+			lines = append(lines, fmt.Sprintf("##contig=<ID=%s,length=%d>", seqID(ref, i), gts.Len(ref)))
+		}
+		lines = append(lines,
+			"##INFO=<ID=TYPE,Number=1,Type=String,Description=\"Type of the variant\">",
+			"##INFO=<ID=QPOS,Number=1,Type=Integer,Description=\"Position of the variant in the query sequence\">",
+			"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO",
+		)
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(lines, "\n")); err != nil {
+			return ctx.Raise(err)
+		}
+	case *annotate || *noheader:
+	default:
+		fields := []string{"seqid", "query", "location", "type", "reference", "alternate", "position"}
+		if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		j, ok := refIndex[id]
+		if !ok {
+			j = i
+		}
+		if j >= len(refs) {
+			return ctx.Raise(fmt.Errorf("%s: no corresponding reference sequence", id))
+		}
+		ref := refs[j]
+		refID := seqID(ref, j)
+
+		circular := false
+		if info, ok := ref.Info().(seqio.GenBankFields); ok {
+			circular = info.Topology == gts.Circular
+		}
+
+		p := ref.Bytes()
+		vv := gts.FindVariants(p, seq.Bytes(), *k, circular)
+
+		if *annotate {
+			ff := ref.Features()
+			for _, v := range vv {
+				ff = ff.Insert(variationFeature(v, id))
+			}
+			if _, err := writer.WriteSeq(gts.WithFeatures(ref, ff)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		for _, v := range vv {
+			var fields []string
+			switch {
+			case *annotate:
+				continue
+			case *vcf:
+				pos, r, a := vcfRecord(v, p)
+				info := fmt.Sprintf("TYPE=%s;QPOS=%d", v.Type(), v.Query+1)
+				fields = []string{refID, strconv.Itoa(pos), ".", r, a, ".", ".", info}
+			default:
+				fields = []string{refID, id, v.Location().String(), v.Type(), variantAllele(v.Ref), variantAllele(v.Alt), strconv.Itoa(v.Query + 1)}
+			}
+			if _, err := fmt.Fprintf(buffer, "%s\n", strings.Join(fields, *delim)); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := buffer.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
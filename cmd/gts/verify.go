@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("verify", "verify the checksums of the sequence and features", verifyFunc)
+}
+
+func readChecksums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		switch len(fields) {
+		case 0:
+			continue
+		case 2:
+			sums[fields[1]] = fields[0]
+		default:
+			return nil, fmt.Errorf("line %d: expected a checksum and a sequence ID", n)
+		}
+	}
+	return sums, scanner.Err()
+}
+
+func verifyFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	sumsPath := opt.String('c', "checksums", "", "file containing detached checksums created with gts-stamp(1)")
+	quiet := opt.Switch('q', "quiet", "do not report the records which passed verification")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	var sums map[string]string
+	if *sumsPath != "" {
+		f, err := os.Open(*sumsPath)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("failed to open file %q: %v", *sumsPath, err))
+		}
+		defer f.Close()
+
+		if sums, err = readChecksums(f); err != nil {
+			return ctx.Raise(fmt.Errorf("failed to read checksums in %q: %v", *sumsPath, err))
+		}
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	scanner := newSeqScanner(d)
+	w := bufio.NewWriter(d)
+
+	failed := 0
+
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		var expected string
+		var ok bool
+		if sums != nil {
+			expected, ok = sums[id]
+		} else {
+			expected, ok = seqio.Stamped(seq)
+		}
+
+		status := "OK"
+		switch {
+		case !ok:
+			status = "MISSING"
+		case expected != gts.Checksum(seq):
+			status = "FAILED"
+		}
+
+		if status != "OK" {
+			failed++
+		}
+
+		if status != "OK" || !*quiet {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", id, status); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	if failed > 0 {
+		return ctx.Raise(fmt.Errorf("%d record(s) did not pass verification", failed))
+	}
+
+	return nil
+}
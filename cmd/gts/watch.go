@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("watch", "re-run a pipeline whenever the input files change", watchFunc)
+}
+
+func modTimes(paths []string) ([]time.Time, error) {
+	tt := make([]time.Time, len(paths))
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		tt[i] = info.ModTime()
+	}
+	return tt, nil
+}
+
+func timesEqual(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// createTemp creates a new temporary file in the directory as with
+// ioutil.TempFile, but with the given permissions before the umask is applied
+// instead of 0600, so that the file can take the place of an output file.
+func createTemp(dir, prefix string, perm os.FileMode) (*os.File, error) {
+	for i := 0; ; i++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) && i < 10000 {
+			continue
+		}
+		return f, err
+	}
+}
+
+// runAtomic runs the pipeline writing the output to a temporary file which
+// is renamed to the output path once the pipeline succeeds so that readers of
+// the output never observe a partially written file. The output file keeps
+// the permissions of the file it replaces, or is created with the permissions
+// 0644 before the umask is applied.
+func runAtomic(p pipeline, inPath, outPath string) error {
+	in, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	filetype := seqio.Detect(outPath)
+
+	if outPath == "-" {
+		return p.Run(in, os.Stdout, filetype)
+	}
+
+	dir, base := filepath.Split(outPath)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := createTemp(dir, fmt.Sprintf(".%s.", base), 0644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// Keep the permissions of an existing output file.
+	if info, err := os.Stat(outPath); err == nil {
+		if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if err := p.Run(in, tmp, filetype); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), outPath)
+}
+
+func watchFunc(ctx *flags.Context) error {
+	pos, opt := flags.Flags()
+
+	pipelinePath := pos.String("pipeline", "pipeline file in YAML or JSON format")
+	seqinPath := pos.String("seqin", "input sequence file to watch")
+
+	outPath := opt.String('o', "output", "-", "output file of the last step (specifying `-` will force standard output)")
+	interval := opt.String('i', "interval", "1s", "interval between checks for changes (e.g. `500ms`, `2s`)")
+	watched := opt.StringSlice('w', "watch", nil, "additional file(s) to watch for changes")
+	once := opt.Switch(0, "once", "run the pipeline once and exit (useful for testing the pipeline)")
+
+	if err := ctx.Parse(pos, opt); err != nil {
+		return err
+	}
+
+	wait, err := time.ParseDuration(*interval)
+	if err != nil {
+		return ctx.Raise(fmt.Errorf("invalid interval %q: %v", *interval, err))
+	}
+	if wait <= 0 {
+		return ctx.Raise(fmt.Errorf("interval must be positive: got %s", wait))
+	}
+
+	paths := append([]string{*pipelinePath, *seqinPath}, *watched...)
+
+	var last []time.Time
+	var lastErr string
+
+	for {
+		tt, err := modTimes(paths)
+		switch {
+		case err != nil:
+			if *once {
+				return ctx.Raise(err)
+			}
+			// Files may be briefly missing while editors replace them.
+			if msg := err.Error(); msg != lastErr {
+				fmt.Fprintf(os.Stderr, "gts watch: %s\n", msg)
+				lastErr = msg
+			}
+
+		case !timesEqual(tt, last):
+			last, lastErr = tt, ""
+			start := time.Now()
+			p, err := readPipeline(*pipelinePath)
+			if err == nil {
+				err = runAtomic(p, *seqinPath, *outPath)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "gts watch: %s: %v\n", start.Format("15:04:05"), err)
+				if *once {
+					return ctx.Raise(err)
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "gts watch: %s: finished in %s\n", start.Format("15:04:05"), time.Since(start).Round(time.Millisecond))
+			}
+			if *once {
+				return nil
+			}
+		}
+
+		time.Sleep(wait)
+	}
+}
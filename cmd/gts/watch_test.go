@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunAtomicMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gts-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	inPath := filepath.Join(dir, "in.fasta")
+	if err := ioutil.WriteFile(inPath, []byte(">foo\nacgt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := pipeline{Steps: []pipelineStep{{Command: "reverse"}}}
+
+	outPath := filepath.Join(dir, "out.fasta")
+	if err := ioutil.WriteFile(outPath, nil, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(outPath, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAtomic(p, inPath, outPath); err != nil {
+		t.Fatalf("runAtomic(): %v", err)
+	}
+	info, err := os.Stat(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0640 {
+		t.Errorf("expected the mode of the existing output 0640, got %o", mode)
+	}
+
+	newPath := filepath.Join(dir, "new.fasta")
+	if err := runAtomic(p, inPath, newPath); err != nil {
+		t.Fatalf("runAtomic(): %v", err)
+	}
+	info, err = os.Stat(newPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refPath := filepath.Join(dir, "ref")
+	f, err := os.OpenFile(refPath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	ref, err := os.Stat(refPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != ref.Mode().Perm() {
+		t.Errorf("expected the mode of a new output %o, got %o", ref.Mode().Perm(), mode)
+	}
+}
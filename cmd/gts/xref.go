@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-gts/flags"
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func init() {
+	flags.Register("xref", "list and resolve the database cross-references of features", xrefFunc)
+}
+
+func xrefFunc(ctx *flags.Context) error {
+	h := newHash()
+	pos, opt := flags.Flags()
+
+	selectors := pos.Extra("selector", "feature selector (syntax: [feature_key][/[qualifier1][=regexp1]][/[qualifier2][=regexp2]]...)")
+
+	seqinPath := pos.String("seqin", "input sequence file (may be omitted if standard input is provided)")
+
+	nocache := opt.Switch(0, "no-cache", "do not use or create cache")
+	outPath := opt.String('o', "output", "-", "output table file (specifying `-` will force standard output)")
+	databases := opt.StringSlice('D', "database", nil, "database name(s) to report (defaults to all)")
+	templates := opt.StringSlice('T', "template", nil, "additional URL template(s) (syntax: database=template, where `{id}` is replaced with the identifier)")
+	delim := opt.String('d', "delimiter", "\t", "string to insert between columns")
+	noheader := opt.Switch('H', "no-header", "do not print the header line")
+	dblink := opt.Switch(0, "dblink", "include the DBLINK entries of the record(s)")
+	resolved := opt.Switch('r', "resolved", "only report cross-references with a known URL template")
+	list := opt.Switch('l', "list", "list the known databases and URL templates and exit")
+
+	if err := parseWithSeqin(ctx, pos, opt); err != nil {
+		return err
+	}
+
+	for _, s := range *templates {
+		i := strings.IndexByte(s, '=')
+		if i <= 0 {
+			return ctx.Raise(fmt.Errorf("invalid URL template %q: expected `database=template`", s))
+		}
+		seqio.RegisterXrefTemplate(s[:i], s[i+1:])
+	}
+
+	sort.Strings(*selectors)
+
+	filters := make([]gts.Filter, len(*selectors))
+	for i, selector := range *selectors {
+		f, err := gts.Selector(selector)
+		if err != nil {
+			return ctx.Raise(fmt.Errorf("invalid selector syntax: %v", err))
+		}
+		filters[i] = f
+	}
+	filter := gts.Or(filters...)
+
+	include := func(db string) bool {
+		if len(*databases) == 0 {
+			return true
+		}
+		for _, name := range *databases {
+			if strings.EqualFold(name, db) {
+				return true
+			}
+		}
+		return false
+	}
+
+	d, err := newIODelegate(*seqinPath, *outPath)
+	if err != nil {
+		return ctx.Raise(err)
+	}
+	defer d.Close()
+
+	w := bufio.NewWriter(d)
+
+	if *list {
+		if !*noheader {
+			header := fmt.Sprintf("%s\n", strings.Join([]string{"database", "template"}, *delim))
+			if _, err := io.WriteString(w, header); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+		for _, db := range seqio.XrefDatabases() {
+			if !include(db) {
+				continue
+			}
+			line := fmt.Sprintf("%s\n", strings.Join([]string{db, seqio.XrefTemplates[db]}, *delim))
+			if _, err := io.WriteString(w, line); err != nil {
+				return ctx.Raise(err)
+			}
+		}
+		return ctx.Raise(w.Flush())
+	}
+
+	if !*nocache {
+		data := encodePayload([]tuple{
+			{"command", strings.Join(ctx.Name, "-")},
+			{"version", gts.Version.String()},
+			{"selectors", *selectors},
+			{"databases", *databases},
+			{"templates", *templates},
+			{"delim", *delim},
+			{"noheader", *noheader},
+			{"dblink", *dblink},
+			{"resolved", *resolved},
+		})
+
+		ok, err := d.TryCache(h, data)
+		if ok || err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if !*noheader {
+		fields := []string{"seqid", "feature", "location", "database", "identifier", "url"}
+		header := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+		if _, err := io.WriteString(w, header); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	report := func(id, key, loc string, x seqio.Xref) error {
+		if !include(x.DB) {
+			return nil
+		}
+		url, ok := x.URL()
+		if !ok && *resolved {
+			return nil
+		}
+		fields := []string{id, key, loc, x.DB, x.ID, url}
+		line := fmt.Sprintf("%s\n", strings.Join(fields, *delim))
+		_, err := io.WriteString(w, line)
+		return err
+	}
+
+	scanner := newSeqScanner(d)
+	for i := 0; scanner.Scan(); i++ {
+		seq := scanner.Value()
+		id := seqID(seq, i)
+
+		if info, ok := seq.Info().(seqio.GenBankFields); ok && *dblink {
+			for _, pair := range info.DBLink {
+				for _, value := range strings.Split(pair.Value, ",") {
+					x := seqio.Xref{DB: pair.Key, ID: strings.TrimSpace(value)}
+					if err := report(id, "DBLINK", "", x); err != nil {
+						return ctx.Raise(err)
+					}
+				}
+			}
+		}
+
+		for _, f := range seq.Features().Filter(filter) {
+			for _, value := range f.Props.Get("db_xref") {
+				x, err := seqio.AsXref(value)
+				if err != nil {
+					return ctx.Raise(fmt.Errorf("in sequence %q: %v", id, err))
+				}
+				if err := report(id, f.Key, f.Loc.String(), x); err != nil {
+					return ctx.Raise(err)
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return ctx.Raise(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ctx.Raise(fmt.Errorf("encountered error in scanner: %v", err))
+	}
+
+	return nil
+}
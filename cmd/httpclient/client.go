@@ -0,0 +1,177 @@
+// Package httpclient provides an HTTP client which retries failed requests
+// with exponential backoff and limits the rate of requests made to each host.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default parameters for a Client created with New.
+const (
+	DefaultRetries    = 3
+	DefaultBackoff    = 500 * time.Millisecond
+	DefaultMaxBackoff = 30 * time.Second
+	DefaultUserAgent  = "gts"
+)
+
+// DefaultHostIntervals lists the minimum intervals between requests for the
+// hosts with published usage policies. NCBI E-utilities allow up to three
+// requests per second without an API key.
+var DefaultHostIntervals = map[string]time.Duration{
+	"eutils.ncbi.nlm.nih.gov": time.Second / 3,
+}
+
+// Client is an HTTP client which retries failed requests and limits the rate
+// of requests made to each host. A request is retried if it fails with a
+// network error or a `429 Too Many Requests` or `5xx` status. The delay
+// before each retry doubles from Backoff up to MaxBackoff, unless the server
+// specifies one with the `Retry-After` header.
+type Client struct {
+	Client     *http.Client
+	Limiter    *Limiter
+	Retries    int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	UserAgent  string
+}
+
+// New creates a new Client with the default parameters.
+func New() *Client {
+	limiter := NewLimiter(0)
+	for host, interval := range DefaultHostIntervals {
+		limiter.SetInterval(host, interval)
+	}
+	return &Client{
+		Client:     http.DefaultClient,
+		Limiter:    limiter,
+		Retries:    DefaultRetries,
+		Backoff:    DefaultBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+		UserAgent:  DefaultUserAgent,
+	}
+}
+
+func (c *Client) client() *http.Client {
+	if c.Client == nil {
+		return http.DefaultClient
+	}
+	return c.Client
+}
+
+func (c *Client) backoff(n int) time.Duration {
+	d := c.Backoff
+	for i := 0; i < n && (c.MaxBackoff <= 0 || d < c.MaxBackoff); i++ {
+		d *= 2
+	}
+	if c.MaxBackoff > 0 && d > c.MaxBackoff {
+		d = c.MaxBackoff
+	}
+	return d
+}
+
+func retryable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	s := resp.Header.Get("Retry-After")
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second, true
+	}
+	if t, err := http.ParseTime(s); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Do sends an HTTP request and returns an HTTP response, retrying the request
+// as necessary. The context of the request is respected while waiting for
+// the rate limit or a retry. A request with a body can only be retried if
+// its GetBody field is set. The response of the last attempt is returned if
+// all attempts fail with a retryable status.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if c.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	for n := 0; ; n++ {
+		if n > 0 && req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return nil, errors.New("cannot retry request with a body without GetBody")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx, req.URL.Host); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.client().Do(req)
+
+		if n >= c.Retries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := c.backoff(n)
+		switch {
+		case err != nil:
+		case retryable(resp):
+			if d, ok := retryAfter(resp); ok {
+				delay = d
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Get issues a GET request to the given URL with the context.
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("while creating request: %v", err)
+	}
+	return c.Do(req)
+}
@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func testClient(ts *httptest.Server) *Client {
+	c := New()
+	c.Client = ts.Client()
+	c.Backoff = time.Millisecond
+	c.MaxBackoff = 4 * time.Millisecond
+	return c
+}
+
+func TestClientRetry(t *testing.T) {
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		testutils.Equals(t, req.Header.Get("User-Agent"), DefaultUserAgent)
+		switch atomic.AddInt32(&count, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		case 2:
+			http.Error(w, "oops", http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer ts.Close()
+
+	resp, err := testClient(ts).Get(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	p, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.Equals(t, resp.StatusCode, http.StatusOK)
+	testutils.Equals(t, string(p), "ok")
+	testutils.Equals(t, atomic.LoadInt32(&count), int32(3))
+}
+
+func TestClientGiveUp(t *testing.T) {
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&count, 1)
+		http.Error(w, "oops", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	c.Retries = 2
+
+	resp, err := c.Get(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	testutils.Equals(t, resp.StatusCode, http.StatusInternalServerError)
+	testutils.Equals(t, atomic.LoadInt32(&count), int32(3))
+}
+
+func TestClientNoRetry(t *testing.T) {
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&count, 1)
+		http.NotFound(w, req)
+	}))
+	defer ts.Close()
+
+	resp, err := testClient(ts).Get(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	testutils.Equals(t, resp.StatusCode, http.StatusNotFound)
+	testutils.Equals(t, atomic.LoadInt32(&count), int32(1))
+}
+
+func TestClientBody(t *testing.T) {
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p, _ := ioutil.ReadAll(req.Body)
+		testutils.Equals(t, string(p), "payload")
+		if atomic.AddInt32(&count, 1) == 1 {
+			http.Error(w, "oops", http.StatusBadGateway)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := testClient(ts).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	testutils.Equals(t, resp.StatusCode, http.StatusOK)
+	testutils.Equals(t, atomic.LoadInt32(&count), int32(2))
+}
+
+func TestClientContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "oops", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	c.Backoff = time.Hour
+	c.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.Get(ctx, ts.URL); err != context.DeadlineExceeded {
+		t.Errorf("c.Get() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClientBackoff(t *testing.T) {
+	c := &Client{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	testutils.Equals(t, c.backoff(0), time.Second)
+	testutils.Equals(t, c.backoff(1), 2*time.Second)
+	testutils.Equals(t, c.backoff(2), 4*time.Second)
+	testutils.Equals(t, c.backoff(3), 5*time.Second)
+	testutils.Equals(t, c.backoff(100), 5*time.Second)
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := retryAfter(resp); ok {
+		t.Errorf("retryAfter() expected false for missing header")
+	}
+
+	resp.Header.Set("Retry-After", "3")
+	d, ok := retryAfter(resp)
+	testutils.Equals(t, ok, true)
+	testutils.Equals(t, d, 3*time.Second)
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	d, ok = retryAfter(resp)
+	testutils.Equals(t, ok, true)
+	testutils.Equals(t, d, time.Duration(0))
+
+	resp.Header.Set("Retry-After", "soon")
+	if _, ok := retryAfter(resp); ok {
+		t.Errorf("retryAfter() expected false for malformed header")
+	}
+}
@@ -0,0 +1,85 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter limits the rate of requests made to each host by enforcing a
+// minimum interval between consecutive requests.
+type Limiter struct {
+	Default time.Duration
+	Hosts   map[string]time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// NewLimiter creates a new Limiter with the given default interval.
+func NewLimiter(interval time.Duration) *Limiter {
+	return &Limiter{
+		Default: interval,
+		Hosts:   make(map[string]time.Duration),
+		next:    make(map[string]time.Time),
+	}
+}
+
+// SetInterval sets the minimum interval between requests to the host.
+func (l *Limiter) SetInterval(host string, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Hosts[host] = interval
+}
+
+// Interval returns the minimum interval between requests to the host.
+func (l *Limiter) Interval(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.interval(host)
+}
+
+func (l *Limiter) interval(host string) time.Duration {
+	if interval, ok := l.Hosts[host]; ok {
+		return interval
+	}
+	return l.Default
+}
+
+// Wait blocks until a request to the host is allowed or the context is done.
+// If the context is done before the request is allowed, the context error is
+// returned and the slot reserved for the request is released.
+func (l *Limiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	if l.next == nil {
+		l.next = make(map[string]time.Time)
+	}
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	interval := l.interval(host)
+	l.next[host] = slot.Add(interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next[host].Equal(slot.Add(interval)) {
+			l.next[host] = slot
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
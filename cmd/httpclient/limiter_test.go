@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(0)
+	l.SetInterval("slow", 20*time.Millisecond)
+
+	testutils.Equals(t, l.Interval("fast"), time.Duration(0))
+	testutils.Equals(t, l.Interval("slow"), 20*time.Millisecond)
+
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, "fast"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d >= 20*time.Millisecond {
+		t.Errorf("unlimited host took %v", d)
+	}
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, "slow"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("limited host took %v, want at least 40ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(ctx, "slow"); err != context.Canceled {
+		t.Errorf("l.Wait() = %v, want %v", err, context.Canceled)
+	}
+}
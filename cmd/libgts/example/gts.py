@@ -0,0 +1,92 @@
+"""Example Python binding for libgts using ctypes.
+
+Build the shared library first:
+
+    $ go build -buildmode=c-shared -o libgts.so ./cmd/libgts
+
+and run this script with the path to the library and a sequence file:
+
+    $ python3 cmd/libgts/example/gts.py ./libgts.so seqio/testdata/NC_001422.gb
+"""
+
+import ctypes
+import json
+import sys
+
+
+class GTSError(Exception):
+    pass
+
+
+class GTS:
+    def __init__(self, path):
+        lib = ctypes.CDLL(path)
+
+        # Strings returned by libgts are owned by the caller and must be
+        # released with gts_free, so the return types are declared as raw
+        # pointers rather than c_char_p, which would copy and leak them.
+        lib.gts_version.argtypes = []
+        lib.gts_version.restype = ctypes.c_void_p
+        lib.gts_free.argtypes = [ctypes.c_void_p]
+        lib.gts_free.restype = None
+        lib.gts_parse.argtypes = [ctypes.c_char_p, ctypes.POINTER(ctypes.c_void_p)]
+        lib.gts_parse.restype = ctypes.c_void_p
+        for name in ["format", "convert", "select", "extract"]:
+            fn = getattr(lib, "gts_" + name)
+            fn.argtypes = [
+                ctypes.c_char_p,
+                ctypes.c_char_p,
+                ctypes.POINTER(ctypes.c_void_p),
+            ]
+            fn.restype = ctypes.c_void_p
+
+        self.lib = lib
+
+    def _take(self, ptr):
+        try:
+            return ctypes.string_at(ptr).decode("utf-8")
+        finally:
+            self.lib.gts_free(ptr)
+
+    def _call(self, name, *args):
+        err = ctypes.c_void_p()
+        fn = getattr(self.lib, "gts_" + name)
+        ptr = fn(*[arg.encode("utf-8") for arg in args], ctypes.byref(err))
+        if not ptr:
+            raise GTSError(self._take(err.value))
+        return self._take(ptr)
+
+    def version(self):
+        return self._take(self.lib.gts_version())
+
+    def parse(self, text):
+        return json.loads(self._call("parse", text))
+
+    def format(self, records, format=""):
+        return self._call("format", json.dumps(records), format)
+
+    def convert(self, text, format=""):
+        return self._call("convert", text, format)
+
+    def select(self, records, selector):
+        return json.loads(self._call("select", json.dumps(records), selector))
+
+    def extract(self, records, locator):
+        return json.loads(self._call("extract", json.dumps(records), locator))
+
+
+def main():
+    gts = GTS(sys.argv[1])
+    with open(sys.argv[2]) as f:
+        records = gts.parse(f.read())
+
+    print("gts", gts.version())
+    for record in records:
+        print(record["id"], len(record["sequence"]), len(record.get("features", [])))
+
+    cds = gts.extract(gts.select(records, "CDS"), "@^..$")
+    sys.stdout.write(gts.format(cds, "fasta"))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,106 @@
+// Command libgts exports the gts library through a C ABI so that it can be
+// called from other languages without invoking the CLI. Build it as a shared
+// library with:
+//
+//	go build -buildmode=c-shared -o libgts.so ./cmd/libgts
+//
+// which also generates the header file libgts.h.
+//
+// Sequences are exchanged as JSON encoded arrays of records following the
+// versioned record schema of the seqio package. Every function taking a
+// string expects a NUL terminated UTF-8 string which remains owned by the
+// caller. Every string returned by the library is allocated with malloc and
+// is owned by the caller, who must release it with gts_free. Functions which
+// may fail take a pointer to a string as their last argument: on failure they
+// return NULL and, if the pointer is not NULL, store an error message in it
+// which must also be released with gts_free. On success the error message is
+// set to NULL. The functions do not retain any state between calls and may be
+// called concurrently.
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/go-gts/gts/internal/binding"
+)
+
+func result(s string, err error, errp **C.char) *C.char {
+	if errp != nil {
+		*errp = nil
+	}
+	if err != nil {
+		if errp != nil {
+			*errp = C.CString(err.Error())
+		}
+		return nil
+	}
+	return C.CString(s)
+}
+
+func resultBytes(p []byte, err error, errp **C.char) *C.char {
+	return result(string(p), err, errp)
+}
+
+// gts_version returns the version of gts the library was built with.
+//
+//export gts_version
+func gts_version() *C.char {
+	return C.CString(binding.Version())
+}
+
+// gts_free releases a string returned by the library.
+//
+//export gts_free
+func gts_free(p *C.char) {
+	C.free(unsafe.Pointer(p))
+}
+
+// gts_parse reads the sequences in the given FASTA, FASTQ, or GenBank text
+// and returns them as a JSON array of records.
+//
+//export gts_parse
+func gts_parse(text *C.char, errp **C.char) *C.char {
+	p, err := binding.Parse(C.GoString(text))
+	return resultBytes(p, err, errp)
+}
+
+// gts_format writes the given JSON array of records in the named file
+// format. The format of each record is detected if the name is empty.
+//
+//export gts_format
+func gts_format(records, format *C.char, errp **C.char) *C.char {
+	s, err := binding.Format([]byte(C.GoString(records)), C.GoString(format))
+	return result(s, err, errp)
+}
+
+// gts_convert reads the sequences in the given text and writes them in the
+// named file format.
+//
+//export gts_convert
+func gts_convert(text, format *C.char, errp **C.char) *C.char {
+	s, err := binding.Convert(C.GoString(text), C.GoString(format))
+	return result(s, err, errp)
+}
+
+// gts_select retains only the features matching the given selector in each
+// of the records in the given JSON array.
+//
+//export gts_select
+func gts_select(records, selector *C.char, errp **C.char) *C.char {
+	p, err := binding.Select([]byte(C.GoString(records)), C.GoString(selector))
+	return resultBytes(p, err, errp)
+}
+
+// gts_extract extracts the regions referenced by the given locator from each
+// of the records in the given JSON array.
+//
+//export gts_extract
+func gts_extract(records, locator *C.char, errp **C.char) *C.char {
+	p, err := binding.Extract([]byte(C.GoString(records)), C.GoString(locator))
+	return resultBytes(p, err, errp)
+}
+
+func main() {}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// PluginPrefix is the file name prefix of executables providing plugin
+// commands. An executable named `gts-foo` provides the command `gts foo`.
+const PluginPrefix = "gts-"
+
+// FindPlugins searches the directories in the given list, separated by the
+// OS specific path list separator as in the PATH environment variable, for
+// plugin executables. The returned map associates each plugin command name
+// with the path to its executable. If multiple executables provide the same
+// command, the one found in the earliest directory is used.
+func FindPlugins(path string) map[string]string {
+	plugins := map[string]string{}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := filepath.Glob(filepath.Join(dir, PluginPrefix+"*"))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.TrimPrefix(filepath.Base(entry), PluginPrefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, ".exe")
+			}
+			if _, ok := plugins[name]; ok || !isPluginName(name) {
+				continue
+			}
+			if !isExecutable(entry) {
+				continue
+			}
+			plugins[name] = entry
+		}
+	}
+	return plugins
+}
+
+// LookPlugin searches the directories in the given list, separated as in
+// FindPlugins, for the plugin executable providing the named command and
+// returns the path to the one found in the earliest directory.
+func LookPlugin(path, name string) (string, bool) {
+	if !isPluginName(name) {
+		return "", false
+	}
+	file := PluginPrefix + name
+	if runtime.GOOS == "windows" {
+		file += ".exe"
+	}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		if entry := filepath.Join(dir, file); isExecutable(entry) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && (runtime.GOOS == "windows" || info.Mode()&0111 != 0)
+}
+
+func isPluginName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
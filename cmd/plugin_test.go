@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestFindPlugins(t *testing.T) {
+	root, err := ioutil.TempDir("", "gts-plugin-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	a, b := filepath.Join(root, "a"), filepath.Join(root, "b")
+	files := []struct {
+		path string
+		mode os.FileMode
+	}{
+		{filepath.Join(a, "gts-foo"), 0755},
+		{filepath.Join(a, "gts-bar"), 0644},
+		{filepath.Join(a, "gts-completion.bash"), 0755},
+		{filepath.Join(b, "gts-foo"), 0755},
+		{filepath.Join(b, "gts-baz"), 0755},
+		{filepath.Join(b, "other"), 0755},
+	}
+
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file.path, []byte("#!/bin/sh\n"), file.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := strings.Join([]string{a, "", b, filepath.Join(root, "missing")}, string(os.PathListSeparator))
+	testutils.Equals(t, FindPlugins(path), map[string]string{
+		"foo": filepath.Join(a, "gts-foo"),
+		"baz": filepath.Join(b, "gts-baz"),
+	})
+
+	for _, name := range []string{"foo", "baz", "bar", "completion.bash", "qux"} {
+		p, ok := LookPlugin(path, name)
+		exp, want := FindPlugins(path)[name]
+		testutils.Equals(t, ok, want)
+		testutils.Equals(t, p, exp)
+	}
+}
@@ -0,0 +1,231 @@
+// Package registry implements a local collection of sequence records stored
+// under stable identifiers.
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const indexName = "index.json"
+
+// DefaultPrefix is the prefix used for automatically assigned identifiers.
+const DefaultPrefix = "GTS"
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Entry represents the metadata of a record in the registry.
+type Entry struct {
+	ID         string    `json:"id"`
+	Definition string    `json:"definition,omitempty"`
+	Accession  string    `json:"accession,omitempty"`
+	Organism   string    `json:"organism,omitempty"`
+	Length     int       `json:"length"`
+	Tags       []string  `json:"tags,omitempty"`
+	Checksum   string    `json:"checksum,omitempty"`
+	Ext        string    `json:"ext"`
+	Added      time.Time `json:"added"`
+}
+
+// Match tests if all of the given terms are found in any of the fields of the
+// entry. The comparison is case insensitive.
+func (e Entry) Match(terms ...string) bool {
+	fields := append([]string{e.ID, e.Definition, e.Accession, e.Organism}, e.Tags...)
+	for i := range fields {
+		fields[i] = strings.ToLower(fields[i])
+	}
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		found := false
+		for _, field := range fields {
+			if strings.Contains(field, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Registry represents a collection of records stored in a directory.
+type Registry struct {
+	dir     string
+	entries []Entry
+}
+
+// Open the registry in the given directory, creating one if necessary.
+func Open(dir string) (*Registry, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "records"), 0755); err != nil {
+		return nil, err
+	}
+
+	r := &Registry{dir, []Entry{}}
+
+	p, err := ioutil.ReadFile(filepath.Join(dir, indexName))
+	switch {
+	case os.IsNotExist(err):
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(p, &r.entries); err != nil {
+		return nil, fmt.Errorf("malformed registry index: %v", err)
+	}
+
+	return r, nil
+}
+
+// Dir returns the directory of the registry.
+func (r *Registry) Dir() string {
+	return r.dir
+}
+
+// Entries returns the list of entries in the registry sorted by ID.
+func (r *Registry) Entries() []Entry {
+	entries := make([]Entry, len(r.entries))
+	copy(entries, r.entries)
+	return entries
+}
+
+func (r *Registry) index(id string) int {
+	i := sort.Search(len(r.entries), func(i int) bool {
+		return r.entries[i].ID >= id
+	})
+	if i < len(r.entries) && r.entries[i].ID == id {
+		return i
+	}
+	return -1
+}
+
+// Get the entry with the given ID.
+func (r *Registry) Get(id string) (Entry, bool) {
+	if i := r.index(id); i >= 0 {
+		return r.entries[i], true
+	}
+	return Entry{}, false
+}
+
+// Path returns the path to the record file of the given entry.
+func (r *Registry) Path(e Entry) string {
+	return filepath.Join(r.dir, "records", e.ID+e.Ext)
+}
+
+// Search returns the entries matching all of the given terms.
+func (r *Registry) Search(terms ...string) []Entry {
+	entries := []Entry{}
+	for _, e := range r.entries {
+		if e.Match(terms...) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// NextID returns the next available automatically assigned identifier.
+func (r *Registry) NextID(prefix string) string {
+	n := 1
+	for _, e := range r.entries {
+		if !strings.HasPrefix(e.ID, prefix) {
+			continue
+		}
+		if m, err := strconv.Atoi(e.ID[len(prefix):]); err == nil && m >= n {
+			n = m + 1
+		}
+	}
+	return fmt.Sprintf("%s%06d", prefix, n)
+}
+
+// Add the record data with the given entry to the registry. If the entry ID
+// is empty, a new ID will be assigned. An existing entry will only be
+// replaced if replace is true.
+func (r *Registry) Add(e Entry, data []byte, replace bool) (Entry, error) {
+	if e.ID == "" {
+		e.ID = r.NextID(DefaultPrefix)
+	}
+
+	if !idPattern.MatchString(e.ID) {
+		return e, fmt.Errorf("invalid registry ID %q", e.ID)
+	}
+
+	i := r.index(e.ID)
+	if i >= 0 && !replace {
+		return e, fmt.Errorf("registry ID %q already exists", e.ID)
+	}
+
+	if e.Added.IsZero() {
+		e.Added = time.Now().UTC()
+	}
+
+	if i >= 0 {
+		if err := os.Remove(r.Path(r.entries[i])); err != nil && !os.IsNotExist(err) {
+			return e, err
+		}
+		r.entries[i] = e
+	} else {
+		r.entries = append(r.entries, e)
+		sort.Slice(r.entries, func(i, j int) bool {
+			return r.entries[i].ID < r.entries[j].ID
+		})
+	}
+
+	if err := writeFileAtomic(r.Path(e), data); err != nil {
+		return e, err
+	}
+
+	return e, r.save()
+}
+
+// Remove the entry with the given ID from the registry.
+func (r *Registry) Remove(id string) error {
+	i := r.index(id)
+	if i < 0 {
+		return fmt.Errorf("registry ID %q does not exist", id)
+	}
+
+	if err := os.Remove(r.Path(r.entries[i])); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	r.entries = append(r.entries[:i], r.entries[i+1:]...)
+
+	return r.save()
+}
+
+func (r *Registry) save() error {
+	p, err := json.MarshalIndent(r.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(r.dir, indexName), p)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
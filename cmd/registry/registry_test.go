@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+
+	r, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", dir, err)
+	}
+
+	e, err := r.Add(Entry{Definition: "pUC19 cloning vector", Ext: ".gb", Tags: []string{"ampR"}}, []byte("foo"), false)
+	if err != nil {
+		t.Fatalf("r.Add(): %v", err)
+	}
+	testutils.Equals(t, e.ID, "GTS000001")
+
+	if _, err := r.Add(Entry{ID: "pX330", Definition: "Cas9 expression vector", Organism: "synthetic construct", Ext: ".gb"}, []byte("bar"), false); err != nil {
+		t.Fatalf("r.Add(): %v", err)
+	}
+
+	if _, err := r.Add(Entry{ID: "pX330", Ext: ".gb"}, []byte("baz"), false); err == nil {
+		t.Error("expected error when adding a duplicate ID")
+	}
+
+	if _, err := r.Add(Entry{ID: "../escape", Ext: ".gb"}, []byte("baz"), false); err == nil {
+		t.Error("expected error when adding an invalid ID")
+	}
+
+	testutils.Equals(t, r.NextID(DefaultPrefix), "GTS000002")
+
+	r, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", dir, err)
+	}
+
+	entries := r.Entries()
+	testutils.Equals(t, len(entries), 2)
+	testutils.Equals(t, entries[0].ID, "GTS000001")
+	testutils.Equals(t, entries[1].ID, "pX330")
+
+	e, ok := r.Get("pX330")
+	if !ok {
+		t.Fatal("r.Get(\"pX330\") failed")
+	}
+	p, err := ioutil.ReadFile(r.Path(e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, string(p), "bar")
+
+	testutils.Equals(t, len(r.Search("vector")), 2)
+	testutils.Equals(t, len(r.Search("AMPR")), 1)
+	testutils.Equals(t, len(r.Search("vector", "synthetic")), 1)
+	testutils.Equals(t, len(r.Search("missing")), 0)
+
+	if _, err := r.Add(Entry{ID: "pX330", Ext: ".fasta"}, []byte("qux"), true); err != nil {
+		t.Fatalf("r.Add() with replace: %v", err)
+	}
+	e, _ = r.Get("pX330")
+	p, _ = ioutil.ReadFile(r.Path(e))
+	testutils.Equals(t, string(p), "qux")
+
+	if err := r.Remove("pX330"); err != nil {
+		t.Fatalf("r.Remove(): %v", err)
+	}
+	if _, ok := r.Get("pX330"); ok {
+		t.Error("entry exists after removal")
+	}
+	if err := r.Remove("pX330"); err == nil {
+		t.Error("expected error when removing a missing entry")
+	}
+}
+
+func TestOpenMalformed(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(dir+"/index.json", []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(dir); err == nil {
+		t.Error("expected error when opening a malformed registry")
+	}
+}
@@ -0,0 +1,155 @@
+package gts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CodonUsage represents the usage of the 64 codons in the order of TTT, TTC,
+// TTA, TTG, TCT, ..., GGG as in CodonTable. The usage may be given as counts
+// or as any value proportional to the counts.
+type CodonUsage [64]float64
+
+// Add the codons of the given in-frame coding sequence to the usage. Codons
+// containing bases other than A, C, G, T, or U and trailing bases which do
+// not form a complete codon are ignored.
+func (usage *CodonUsage) Add(p []byte) {
+	for i := 0; i+3 <= len(p); i += 3 {
+		if j := codonIndex(p[i : i+3]); j >= 0 {
+			usage[j]++
+		}
+	}
+}
+
+// Total returns the sum of the usage of all codons.
+func (usage CodonUsage) Total() float64 {
+	total := 0.0
+	for _, n := range usage {
+		total += n
+	}
+	return total
+}
+
+// Codon returns the codon at the given index in the order of CodonUsage.
+func (usage CodonUsage) Codon(i int) string {
+	const bases = "TCAG"
+	return string([]byte{bases[i/16%4], bases[i/4%4], bases[i%4]})
+}
+
+// Fractions returns the usage of each codon relative to the total usage of
+// the codons encoding the same amino acid, or stop, for the given genetic
+// code. The fraction is zero for codons of unused amino acids.
+func (usage CodonUsage) Fractions(table CodonTable) [64]float64 {
+	totals := map[byte]float64{}
+	for i, n := range usage {
+		totals[table.AAs[i]] += n
+	}
+
+	ret := [64]float64{}
+	for i, n := range usage {
+		if total := totals[table.AAs[i]]; total > 0 {
+			ret[i] = n / total
+		}
+	}
+	return ret
+}
+
+// ReadCodonUsage reads a codon usage table where each line consists of a
+// codon followed by any number of whitespace delimited columns, the last of
+// which is the usage of the codon. Lines which do not start with a codon,
+// such as headers and comments, are ignored.
+func ReadCodonUsage(r io.Reader) (CodonUsage, error) {
+	usage := CodonUsage{}
+	found := false
+
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		i := codonIndex([]byte(fields[0]))
+		if i < 0 {
+			continue
+		}
+		value := fields[len(fields)-1]
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || n < 0 {
+			return usage, fmt.Errorf("line %d: invalid usage %q for codon %s", lineno, value, fields[0])
+		}
+		usage[i] += n
+		found = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return usage, err
+	}
+	if !found {
+		return usage, errors.New("no codons found in codon usage table")
+	}
+	return usage, nil
+}
+
+// unusedAdaptiveness is the relative adaptiveness assigned to unused codons.
+const unusedAdaptiveness = 0.01
+
+// Adaptiveness returns the relative adaptiveness of each codon for the given
+// genetic code, which is the usage of the codon relative to the usage of the
+// most used codon encoding the same amino acid. Unused codons are assigned an
+// adaptiveness of 0.01 so that the adaptiveness is never zero. The
+// adaptiveness of stop codons and codons of amino acids encoded by a single
+// codon is zero as they are not informative of codon bias.
+func (usage CodonUsage) Adaptiveness(table CodonTable) [64]float64 {
+	ret := [64]float64{}
+
+	synonyms := map[byte][]int{}
+	for i := range usage {
+		if aa := table.AAs[i]; aa != '*' {
+			synonyms[aa] = append(synonyms[aa], i)
+		}
+	}
+
+	for _, codons := range synonyms {
+		if len(codons) < 2 {
+			continue
+		}
+		max := 0.0
+		for _, i := range codons {
+			max = math.Max(max, usage[i])
+		}
+		if max == 0 {
+			continue
+		}
+		for _, i := range codons {
+			ret[i] = math.Max(usage[i]/max, unusedAdaptiveness)
+		}
+	}
+
+	return ret
+}
+
+// CAI returns the codon adaptation index of the given in-frame coding
+// sequence with respect to the reference codon usage, which is the geometric
+// mean of the relative adaptiveness of its codons. Codons with no relative
+// adaptiveness are excluded, and zero is returned if there are no codons left.
+func CAI(p []byte, usage CodonUsage, table CodonTable) float64 {
+	w := usage.Adaptiveness(table)
+	sum, n := 0.0, 0
+	for i := 0; i+3 <= len(p); i += 3 {
+		j := codonIndex(p[i : i+3])
+		if j < 0 || w[j] == 0 {
+			continue
+		}
+		sum += math.Log(w[j])
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return math.Exp(sum / float64(n))
+}
@@ -0,0 +1,72 @@
+package gts
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestCodonUsage(t *testing.T) {
+	usage := CodonUsage{}
+	usage.Add([]byte("ctgctgctgttann"))
+	testutils.Equals(t, usage.Total(), 4.0)
+	testutils.Equals(t, usage[codonIndex([]byte("ctg"))], 3.0)
+	testutils.Equals(t, usage[codonIndex([]byte("tta"))], 1.0)
+
+	table := CodonTables[1]
+	w := usage.Adaptiveness(table)
+	testutils.Equals(t, w[codonIndex([]byte("ctg"))], 1.0)
+	testutils.Equals(t, w[codonIndex([]byte("tta"))], 1.0/3.0)
+	testutils.Equals(t, w[codonIndex([]byte("ttg"))], unusedAdaptiveness)
+	testutils.Equals(t, w[codonIndex([]byte("atg"))], 0.0)
+	testutils.Equals(t, w[codonIndex([]byte("gct"))], 0.0)
+
+	if cai := CAI([]byte("ctgtta"), usage, table); math.Abs(cai-math.Sqrt(1.0/3.0)) > 1e-9 {
+		t.Errorf("CAI(%q) = %f, want %f", "ctgtta", cai, math.Sqrt(1.0/3.0))
+	}
+	testutils.Equals(t, CAI([]byte("atgtggtaa"), usage, table), 0.0)
+}
+
+func TestCodonUsageFractions(t *testing.T) {
+	usage := CodonUsage{}
+	usage.Add([]byte("ctgctgctgttaatgtaa"))
+
+	for i := range usage {
+		testutils.Equals(t, codonIndex([]byte(usage.Codon(i))), i)
+	}
+	testutils.Equals(t, usage.Codon(0), "TTT")
+	testutils.Equals(t, usage.Codon(63), "GGG")
+
+	f := usage.Fractions(CodonTables[1])
+	testutils.Equals(t, f[codonIndex([]byte("ctg"))], 0.75)
+	testutils.Equals(t, f[codonIndex([]byte("tta"))], 0.25)
+	testutils.Equals(t, f[codonIndex([]byte("ttg"))], 0.0)
+	testutils.Equals(t, f[codonIndex([]byte("atg"))], 1.0)
+	testutils.Equals(t, f[codonIndex([]byte("taa"))], 1.0)
+	testutils.Equals(t, f[codonIndex([]byte("gct"))], 0.0)
+}
+
+func TestReadCodonUsage(t *testing.T) {
+	in := strings.Join([]string{
+		"# codon usage",
+		"codon\taa\tcount",
+		"CTG\tL\t3",
+		"UUA\tL\t1",
+		"",
+	}, "\n")
+	usage, err := ReadCodonUsage(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadCodonUsage(): %v", err)
+	}
+	exp := CodonUsage{}
+	exp.Add([]byte("ctgctgctgtta"))
+	testutils.Equals(t, usage, exp)
+
+	for _, in := range []string{"", "codon\tcount\n", "CTG\tL\tmany\n", "CTG\t-1\n"} {
+		if _, err := ReadCodonUsage(strings.NewReader(in)); err == nil {
+			t.Errorf("expected error reading codon usage %q", in)
+		}
+	}
+}
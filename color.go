@@ -0,0 +1,191 @@
+package gts
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Color represents the display color of a feature.
+type Color struct {
+	R, G, B uint8
+}
+
+var colorSeparators = strings.NewReplacer(",", " ", ";", " ")
+
+// AsColor interprets the given string as a Color. The string may be a
+// hexadecimal color code of the form `#rrggbb` or `#rgb` (the leading `#`
+// may be omitted), or a triplet of decimal RGB values delimited by spaces or
+// commas as used by Artemis.
+func AsColor(s string) (Color, error) {
+	v := strings.TrimSpace(s)
+
+	if fields := strings.Fields(colorSeparators.Replace(v)); len(fields) == 3 {
+		rgb := [3]uint8{}
+		for i, field := range fields {
+			n, err := strconv.ParseUint(field, 10, 8)
+			if err != nil {
+				return Color{}, fmt.Errorf("cannot interpret %q as a color: %q is not a value between 0 and 255", s, field)
+			}
+			rgb[i] = uint8(n)
+		}
+		return Color{rgb[0], rgb[1], rgb[2]}, nil
+	}
+
+	hex := strings.TrimPrefix(v, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		if n, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return Color{uint8(n >> 16), uint8(n >> 8), uint8(n)}, nil
+		}
+	}
+
+	return Color{}, fmt.Errorf("cannot interpret %q as a color: expected `#rrggbb` or `<r> <g> <b>`", s)
+}
+
+// String satisfies the fmt.Stringer interface. The color is formatted as a
+// lowercase hexadecimal color code.
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// RGB returns the color formatted as a triplet of decimal RGB values.
+func (c Color) RGB() string {
+	return fmt.Sprintf("%d %d %d", c.R, c.G, c.B)
+}
+
+// ColorStyle represents a convention for storing the color of a feature in
+// its qualifiers.
+type ColorStyle string
+
+// ColorStyle constants for the conventions used by common sequence viewers.
+const (
+	// ColorStyleColor stores the color in a `/color="#rrggbb"` qualifier.
+	ColorStyleColor ColorStyle = "color"
+
+	// ColorStyleArtemis stores the color in a `/colour="<r> <g> <b>"`
+	// qualifier as used by Artemis.
+	ColorStyleArtemis ColorStyle = "artemis"
+
+	// ColorStyleApE stores the color in the `/ApEinfo_fwdcolor` and
+	// `/ApEinfo_revcolor` qualifiers as used by ApE and Benchling.
+	ColorStyleApE ColorStyle = "ape"
+
+	// ColorStyleSnapGene stores the color in a `/note="color: #rrggbb"`
+	// qualifier as used by SnapGene.
+	ColorStyleSnapGene ColorStyle = "snapgene"
+)
+
+// ColorStyles lists the known color styles in the order of precedence used
+// when reading the color of a feature.
+var ColorStyles = []ColorStyle{ColorStyleColor, ColorStyleArtemis, ColorStyleApE, ColorStyleSnapGene}
+
+// AsColorStyle interprets the given string as a ColorStyle.
+func AsColorStyle(s string) (ColorStyle, error) {
+	names := make([]string, len(ColorStyles))
+	for i, style := range ColorStyles {
+		if strings.EqualFold(s, string(style)) {
+			return style, nil
+		}
+		names[i] = string(style)
+	}
+	return "", fmt.Errorf("unknown color style %q: expected one of %s", s, strings.Join(names, ", "))
+}
+
+var snapgeneColorRegexp = regexp.MustCompile(`\s*color:\s*(#[0-9A-Fa-f]{6})\s*;?`)
+
+func firstColor(values []string) (Color, bool) {
+	for _, value := range values {
+		if c, err := AsColor(value); err == nil {
+			return c, true
+		}
+	}
+	return Color{}, false
+}
+
+// FeatureColor returns the color of the feature stored in its qualifiers.
+// The qualifiers are searched in the order of ColorStyles. For the ApE
+// style, the reverse color is preferred for features on the reverse strand.
+func FeatureColor(f Feature) (Color, bool) {
+	for _, style := range ColorStyles {
+		switch style {
+		case ColorStyleColor:
+			if c, ok := firstColor(f.Props.Get("color")); ok {
+				return c, true
+			}
+		case ColorStyleArtemis:
+			if c, ok := firstColor(f.Props.Get("colour")); ok {
+				return c, true
+			}
+		case ColorStyleApE:
+			names := []string{"ApEinfo_fwdcolor", "ApEinfo_revcolor"}
+			if CheckStrand(f.Loc) == StrandReverse {
+				names[0], names[1] = names[1], names[0]
+			}
+			for _, name := range names {
+				if c, ok := firstColor(f.Props.Get(name)); ok {
+					return c, true
+				}
+			}
+		case ColorStyleSnapGene:
+			for _, note := range f.Props.Get("note") {
+				if m := snapgeneColorRegexp.FindStringSubmatch(note); m != nil {
+					c, err := AsColor(m[1])
+					return c, err == nil
+				}
+			}
+		}
+	}
+	return Color{}, false
+}
+
+// ClearFeatureColor returns the feature with the color qualifiers of every
+// known style removed. Notes which only consist of a SnapGene color are
+// removed entirely.
+func ClearFeatureColor(f Feature) Feature {
+	props := f.Props.Clone()
+	for _, name := range []string{"color", "colour", "ApEinfo_fwdcolor", "ApEinfo_revcolor"} {
+		props.Del(name)
+	}
+
+	if notes := props.Get("note"); len(notes) > 0 {
+		kept := []string{}
+		for _, note := range notes {
+			note = strings.TrimSpace(snapgeneColorRegexp.ReplaceAllString(note, ""))
+			if note != "" {
+				kept = append(kept, note)
+			}
+		}
+		if len(kept) > 0 {
+			props.Set("note", kept...)
+		} else {
+			props.Del("note")
+		}
+	}
+
+	f.Props = props
+	return f
+}
+
+// SetFeatureColor returns the feature with its color qualifiers replaced by
+// the given color stored in each of the given styles.
+func SetFeatureColor(f Feature, c Color, styles ...ColorStyle) Feature {
+	f = ClearFeatureColor(f)
+	for _, style := range styles {
+		switch style {
+		case ColorStyleColor:
+			f.Props.Set("color", c.String())
+		case ColorStyleArtemis:
+			f.Props.Set("colour", c.RGB())
+		case ColorStyleApE:
+			f.Props.Set("ApEinfo_fwdcolor", c.String())
+			f.Props.Set("ApEinfo_revcolor", c.String())
+		case ColorStyleSnapGene:
+			f.Props.Add("note", "color: "+c.String())
+		}
+	}
+	return f
+}
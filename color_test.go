@@ -0,0 +1,108 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestColor(t *testing.T) {
+	tests := []struct {
+		in  string
+		out Color
+	}{
+		{"#ff8000", Color{255, 128, 0}},
+		{"FF8000", Color{255, 128, 0}},
+		{"#f80", Color{255, 136, 0}},
+		{"255 128 0", Color{255, 128, 0}},
+		{"255,128,0", Color{255, 128, 0}},
+	}
+
+	for _, tt := range tests {
+		out, err := AsColor(tt.in)
+		if err != nil {
+			t.Errorf("AsColor(%q): %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, out, tt.out)
+	}
+
+	for _, in := range []string{"", "red", "#ff80", "256 0 0", "#gg0000"} {
+		if _, err := AsColor(in); err == nil {
+			t.Errorf("AsColor(%q) expected error", in)
+		}
+	}
+
+	c := Color{255, 128, 0}
+	testutils.Equals(t, c.String(), "#ff8000")
+	testutils.Equals(t, c.RGB(), "255 128 0")
+}
+
+func TestColorStyle(t *testing.T) {
+	for _, style := range ColorStyles {
+		out, err := AsColorStyle(string(style))
+		testutils.Equals(t, err, nil)
+		testutils.Equals(t, out, style)
+	}
+
+	out, err := AsColorStyle("ApE")
+	testutils.Equals(t, err, nil)
+	testutils.Equals(t, out, ColorStyleApE)
+
+	if _, err := AsColorStyle("foo"); err == nil {
+		t.Errorf("AsColorStyle(%q) expected error", "foo")
+	}
+}
+
+func TestFeatureColor(t *testing.T) {
+	red, blue := Color{255, 0, 0}, Color{0, 0, 255}
+
+	tests := []struct {
+		in  Feature
+		out Color
+		ok  bool
+	}{
+		{NewFeature("CDS", Range(0, 9), Props{}), Color{}, false},
+		{NewFeature("CDS", Range(0, 9), Props{[]string{"color", "#ff0000"}}), red, true},
+		{NewFeature("CDS", Range(0, 9), Props{[]string{"colour", "255 0 0"}}), red, true},
+		{NewFeature("CDS", Range(0, 9), Props{[]string{"ApEinfo_fwdcolor", "#ff0000"}, []string{"ApEinfo_revcolor", "#0000ff"}}), red, true},
+		{NewFeature("CDS", Range(0, 9).Complement(), Props{[]string{"ApEinfo_fwdcolor", "#ff0000"}, []string{"ApEinfo_revcolor", "#0000ff"}}), blue, true},
+		{NewFeature("CDS", Range(0, 9), Props{[]string{"note", "promoter", "color: #0000ff; direction: RIGHT"}}), blue, true},
+		{NewFeature("CDS", Range(0, 9), Props{[]string{"colour", "255 0 0"}, []string{"note", "color: #0000ff"}}), red, true},
+	}
+
+	for _, tt := range tests {
+		out, ok := FeatureColor(tt.in)
+		testutils.Equals(t, out, tt.out)
+		testutils.Equals(t, ok, tt.ok)
+	}
+}
+
+func TestSetFeatureColor(t *testing.T) {
+	in := NewFeature("CDS", Range(0, 9), Props{
+		[]string{"gene", "foo"},
+		[]string{"note", "color: #0000ff; direction: RIGHT", "color: #0000ff"},
+		[]string{"colour", "0 0 255"},
+		[]string{"ApEinfo_fwdcolor", "#0000ff"},
+	})
+
+	cleared := ClearFeatureColor(in)
+	testutils.Equals(t, cleared.Props, Props{[]string{"gene", "foo"}, []string{"note", "direction: RIGHT"}})
+	testutils.Equals(t, len(in.Props), 4)
+
+	out := SetFeatureColor(in, Color{255, 0, 0}, ColorStyles...)
+	testutils.Equals(t, out.Props, Props{
+		[]string{"gene", "foo"},
+		[]string{"note", "direction: RIGHT", "color: #ff0000"},
+		[]string{"color", "#ff0000"},
+		[]string{"colour", "255 0 0"},
+		[]string{"ApEinfo_fwdcolor", "#ff0000"},
+		[]string{"ApEinfo_revcolor", "#ff0000"},
+	})
+
+	for _, style := range ColorStyles {
+		c, ok := FeatureColor(SetFeatureColor(in, Color{255, 0, 0}, style))
+		testutils.Equals(t, c, Color{255, 0, 0})
+		testutils.Equals(t, ok, true)
+	}
+}
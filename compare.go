@@ -0,0 +1,126 @@
+package gts
+
+// Status values of a FeatureMatch.
+const (
+	FeatureMatched = "match"
+	FeaturePartial = "partial"
+	FeatureMissed  = "missed"
+	FeatureExtra   = "extra"
+)
+
+// FeatureMatch represents the correspondence of a reference feature and a
+// query feature. Reference and Query hold the indices of the features in the
+// respective feature slices, or -1 if the feature has no counterpart.
+type FeatureMatch struct {
+	Reference int
+	Query     int
+	Status    string
+}
+
+func featureBoundaryDiff(a, b []Segment, tolerance int) (int, bool) {
+	if len(a) != len(b) {
+		return 0, false
+	}
+	total := 0
+	for i := range a {
+		for j := 0; j < 2; j++ {
+			diff := Abs(a[i][j] - b[i][j])
+			if diff > tolerance {
+				return 0, false
+			}
+			total += diff
+		}
+	}
+	return total, true
+}
+
+func featureOverlap(a, b []Segment) int {
+	total := 0
+	for _, s := range a {
+		for _, t := range b {
+			if n := Min(s[1], t[1]) - Max(s[0], t[0]); n > 0 {
+				total += n
+			}
+		}
+	}
+	return total
+}
+
+// CompareFeatures compares the query features against the reference
+// features. A reference feature is matched to a query feature with the same
+// key and strand if every boundary of the two locations is within the given
+// tolerance. Reference features without a match are then paired with the
+// remaining query feature with the same key and strand sharing the largest
+// number of bases as a partial match. The remaining reference features are
+// reported as missed and the remaining query features as extra.
+func CompareFeatures(ref, query FeatureSlice, tolerance int) []FeatureMatch {
+	refSegments := make([][]Segment, len(ref))
+	for i, f := range ref {
+		refSegments[i] = Minimize(f.Loc.Region())
+	}
+	querySegments := make([][]Segment, len(query))
+	for j, f := range query {
+		querySegments[j] = Minimize(f.Loc.Region())
+	}
+
+	compatible := func(i, j int) bool {
+		return ref[i].Key == query[j].Key && CheckStrand(ref[i].Loc) == CheckStrand(query[j].Loc)
+	}
+
+	refPairs := make([]int, len(ref))
+	queryPairs := make([]int, len(query))
+	for i := range refPairs {
+		refPairs[i] = -1
+	}
+	for j := range queryPairs {
+		queryPairs[j] = -1
+	}
+
+	status := make([]string, len(ref))
+
+	for i := range ref {
+		best, min := -1, 0
+		for j := range query {
+			if queryPairs[j] >= 0 || !compatible(i, j) {
+				continue
+			}
+			if diff, ok := featureBoundaryDiff(refSegments[i], querySegments[j], tolerance); ok && (best < 0 || diff < min) {
+				best, min = j, diff
+			}
+		}
+		if best >= 0 {
+			refPairs[i], queryPairs[best], status[i] = best, i, FeatureMatched
+		}
+	}
+
+	for i := range ref {
+		if refPairs[i] >= 0 {
+			continue
+		}
+		best, max := -1, 0
+		for j := range query {
+			if queryPairs[j] >= 0 || !compatible(i, j) {
+				continue
+			}
+			if n := featureOverlap(refSegments[i], querySegments[j]); n > max {
+				best, max = j, n
+			}
+		}
+		if best >= 0 {
+			refPairs[i], queryPairs[best], status[i] = best, i, FeaturePartial
+		} else {
+			status[i] = FeatureMissed
+		}
+	}
+
+	matches := make([]FeatureMatch, 0, len(ref)+len(query))
+	for i := range ref {
+		matches = append(matches, FeatureMatch{i, refPairs[i], status[i]})
+	}
+	for j := range query {
+		if queryPairs[j] < 0 {
+			matches = append(matches, FeatureMatch{-1, j, FeatureExtra})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,46 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestCompareFeatures(t *testing.T) {
+	ref := FeatureSlice{
+		NewFeature("CDS", Range(0, 90), Props{}),
+		NewFeature("CDS", Range(100, 190).Complement(), Props{}),
+		NewFeature("CDS", Range(200, 290), Props{}),
+		NewFeature("tRNA", Range(300, 370), Props{}),
+		NewFeature("CDS", Join(Range(400, 450), Range(500, 550)), Props{}),
+	}
+	query := FeatureSlice{
+		NewFeature("CDS", Range(3, 90), Props{}),
+		NewFeature("CDS", Range(100, 190), Props{}),
+		NewFeature("CDS", Range(230, 290), Props{}),
+		NewFeature("CDS", Join(Range(400, 450), Range(500, 550)), Props{}),
+		NewFeature("CDS", Range(600, 690), Props{}),
+	}
+
+	testutils.Equals(t, CompareFeatures(ref, query, 0), []FeatureMatch{
+		{0, 0, FeaturePartial},
+		{1, -1, FeatureMissed},
+		{2, 2, FeaturePartial},
+		{3, -1, FeatureMissed},
+		{4, 3, FeatureMatched},
+		{-1, 1, FeatureExtra},
+		{-1, 4, FeatureExtra},
+	})
+
+	testutils.Equals(t, CompareFeatures(ref, query, 3), []FeatureMatch{
+		{0, 0, FeatureMatched},
+		{1, -1, FeatureMissed},
+		{2, 2, FeaturePartial},
+		{3, -1, FeatureMissed},
+		{4, 3, FeatureMatched},
+		{-1, 1, FeatureExtra},
+		{-1, 4, FeatureExtra},
+	})
+
+	testutils.Equals(t, CompareFeatures(nil, nil, 0), []FeatureMatch{})
+}
@@ -1,6 +1,28 @@
 _gts_annotate()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -F --format -m --merge --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_batch()
+{
+    opts="-h --help --version -d --delimiter -H --no-header -n --dry-run -o --output"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -22,7 +44,7 @@ _gts_annotate()
 
 _gts_cache_list()
 {
-    opts="-h --help --version "
+    opts="-h --help --version -f --fetch"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -64,6 +86,28 @@ _gts_cache_path()
     esac
 }
 
+_gts_cache_prune()
+{
+    opts="-h --help --version "
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
 _gts_cache_purge()
 {
     opts="-h --help --version "
@@ -88,7 +132,7 @@ _gts_cache_purge()
 
 _gts_cache()
 {
-    cmds="-h --help --version list path purge"
+    cmds="-h --help --version list path prune purge"
     local i=0 cmd
 
     while [[ "$i" -lt "$COMP_CWORD" ]]
@@ -130,14 +174,15 @@ _gts_cache()
     case "$cmd" in
         list)  _gts_cache_list ;;
         path)  _gts_cache_path ;;
+        prune) _gts_cache_prune ;;
         purge) _gts_cache_purge ;;
         *) ;;
     esac
 }
 
-_gts_clear()
+_gts_cds_check()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -d --delimiter -H --no-header -o --output -p --pseudo -t --table"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -157,9 +202,9 @@ _gts_clear()
     esac
 }
 
-_gts_complement()
+_gts_cds_retranslate()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -F --format --no-cache -o --output -p --pseudo -t --table"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -179,9 +224,9 @@ _gts_complement()
     esac
 }
 
-_gts_define()
+_gts_cds_snap()
 {
-    opts="-h --help --version -F --format --no-cache -o --output -q --qualifier"
+    opts="-h --help --version -F --format --no-cache -o --output -p --pseudo -r --report --selector --start --start-codon --stop -t --table"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -201,9 +246,9 @@ _gts_define()
     esac
 }
 
-_gts_delete()
+_gts_cds_starts()
 {
-    opts="-h --help --version -e --erase -F --format --no-cache -o --output"
+    opts="-h --help --version -a --apply -F --format -H --no-header --min-rbs --no-cache -o --output -p --pseudo -r --report --selector --start-codon -t --table -w --window"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -223,7 +268,79 @@ _gts_delete()
     esac
 }
 
-_gts_extract()
+_gts_cds()
+{
+    cmds="-h --help --version check retranslate snap starts"
+    local i=0 cmd
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            cds)
+                (( i++ ))
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            -*) ;;
+            *)
+                cmd="$s"
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    if [[ "$i" -eq "$COMP_CWORD" ]]
+    then
+        local cur="${COMP_WORDS[$COMP_CWORD]}"
+        COMPREPLY=()
+        while IFS='' read -r line
+        do
+            COMPREPLY+=("$line")
+        done < <(compgen -W "$cmds" -- "$cur")
+        return
+    fi
+
+    case "$cmd" in
+        check)       _gts_cds_check ;;
+        retranslate) _gts_cds_retranslate ;;
+        snap)        _gts_cds_snap ;;
+        starts)      _gts_cds_starts ;;
+        *) ;;
+    esac
+}
+
+_gts_clean()
+{
+    opts="-h --help --version -F --format -g --keep-gaps -k --keep-invalid --no-cache -o --output -r --report"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_clear()
 {
     opts="-h --help --version -F --format --no-cache -o --output"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
@@ -245,9 +362,9 @@ _gts_extract()
     esac
 }
 
-_gts_infix()
+_gts_codon()
 {
-    opts="-h --help --version -e --embed -F --format --no-cache -o --output"
+    opts="-h --help --version -f --format -H --no-header --no-cache -o --output -p --pseudo --selector -t --table"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -267,9 +384,9 @@ _gts_infix()
     esac
 }
 
-_gts_insert()
+_gts_colorize()
 {
-    opts="-h --help --version -e --embed -F --format --no-cache -o --output"
+    opts="-h --help --version -c --color --clear -F --format --no-cache -o --output -s --style"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -289,9 +406,9 @@ _gts_insert()
     esac
 }
 
-_gts_join()
+_gts_compare-annotations()
 {
-    opts="-h --help --version -c --circular -F --format --no-cache -o --output"
+    opts="-h --help --version --detail -d --delimiter -H --no-header --no-cache -o --output -s --selector -t --tolerance"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -311,9 +428,9 @@ _gts_join()
     esac
 }
 
-_gts_length()
+_gts_complement()
 {
-    opts="-h --help --version -o --output"
+    opts="-h --help --version -F --format --no-cache -o --output"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -333,9 +450,9 @@ _gts_length()
     esac
 }
 
-_gts_pick()
+_gts_complexity()
 {
-    opts="-h --help --version -f --feature -F --format --no-cache -o --output"
+    opts="-h --help --version -F --format -k --key -m --mask --no-cache -o --output -p --homopolymer -t --threshold -w --window"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -355,9 +472,9 @@ _gts_pick()
     esac
 }
 
-_gts_query()
+_gts_coordinates()
 {
-    opts="-h --help --version -d --delimiter --empty -H --no-header -I --no-seqid -K --no-key -L --no-location --no-cache -n --name -o --output --source -t --separator"
+    opts="-h --help --version -d --delimiter -f --from -H --no-header --no-cache -o --output -p --position -r --rotate"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -377,9 +494,9 @@ _gts_query()
     esac
 }
 
-_gts_repair()
+_gts_curate()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -b --ban -f --fallback -F --format --keep-case --no-default-ban -n --name --no-cache -o --output -s --synonyms"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -399,9 +516,9 @@ _gts_repair()
     esac
 }
 
-_gts_reverse()
+_gts_define()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -F --format --no-cache -o --output -q --qualifier"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -421,9 +538,9 @@ _gts_reverse()
     esac
 }
 
-_gts_rotate()
+_gts_degenerate_consensus()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -F --format --id --no-cache -o --output"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -443,9 +560,9 @@ _gts_rotate()
     esac
 }
 
-_gts_search()
+_gts_degenerate_expand()
 {
-    opts="-h --help --version -e --exact -F --format -k --key --no-cache --no-complement -o --output -q --qualifier"
+    opts="-h --help --version -c --count -F --format -m --max --no-cache -o --output"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -465,9 +582,57 @@ _gts_search()
     esac
 }
 
-_gts_select()
+_gts_degenerate()
+{
+    cmds="-h --help --version consensus expand"
+    local i=0 cmd
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            degenerate)
+                (( i++ ))
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            -*) ;;
+            *)
+                cmd="$s"
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    if [[ "$i" -eq "$COMP_CWORD" ]]
+    then
+        local cur="${COMP_WORDS[$COMP_CWORD]}"
+        COMPREPLY=()
+        while IFS='' read -r line
+        do
+            COMPREPLY+=("$line")
+        done < <(compgen -W "$cmds" -- "$cur")
+        return
+    fi
+
+    case "$cmd" in
+        consensus) _gts_degenerate_consensus ;;
+        expand)    _gts_degenerate_expand ;;
+        *) ;;
+    esac
+}
+
+_gts_delete()
 {
-    opts="-h --help --version -F --format --no-cache -o --output -s --strand -v --invert-match"
+    opts="-h --help --version -e --erase -F --format --no-cache -o --output -v --verbose"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -487,9 +652,9 @@ _gts_select()
     esac
 }
 
-_gts_sort()
+_gts_digest()
 {
-    opts="-h --help --version -F --format --no-cache -o --output -r --reverse"
+    opts="-h --help --version -d --database -e --enzyme -f --fragments -F --format --no-cache -o --output -t --table"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -509,9 +674,9 @@ _gts_sort()
     esac
 }
 
-_gts_split()
+_gts_dist()
 {
-    opts="-h --help --version -F --format --no-cache -o --output"
+    opts="-h --help --version -d --delimiter -H --no-header -k --kmer -m --max-distance --no-cache -o --output -s --size"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -531,9 +696,1367 @@ _gts_split()
     esac
 }
 
-_gts_summary()
+_gts_extract()
+{
+    opts="-h --help --version -d --delimiter -e --expr -F --format -H --no-header --no-cache -o --output -s --stat -t --table -v --invert-region"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_fetch()
+{
+    opts="-h --help --version -D --directory -F --format --no-cache --no-entrez -o --output --offline -t --ttl -u --url"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_gaps()
+{
+    opts="-h --help --version -a --annotate -d --delimiter -F --format -H --no-header -l --length --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_gc()
+{
+    opts="-h --help --version -f --format -H --no-header -m --metric -n --name --no-cache -o --output --step -w --window"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_grep()
+{
+    opts="-h --help --version -c --clade -F --format --no-cache -o --output -t --taxdump -v --invert-match"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_hairpin()
+{
+    opts="-h --help --version -a --all -d --delimiter -H --no-header --max-loop --min-loop --min-stem --no-cache -o --output -r --region -t --threshold"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_infix()
+{
+    opts="-h --help --version --embed-into -e --embed -f --force -F --format --no-cache -o --output --protect --split -v --verbose"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_insert()
+{
+    opts="-h --help --version -e --embed --embed-into -f --force -F --format --no-cache -o --output --protect --split -v --verbose"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_intervals()
+{
+    opts="-h --help --version -F --format -k --key -m --merge --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_join()
+{
+    opts="-h --help --version -c --circular -F --format -m --map --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_length()
+{
+    opts="-h --help --version -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_locate()
+{
+    opts="-h --help --version -c --codon-start --no-cache -o --output -t --table -T --translate"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_lower()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_map()
+{
+    opts="-h --help --version --drop -F --format -k --key --no-cache -o --output -s --set -w --where"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_normalize()
+{
+    opts="-h --help --version -F --format --keep-duplicates --no-cache -n --name -o --output --qualifier-order -s --skip -t --table"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_orfmap()
+{
+    opts="-h --help --version -m --min-length --no-cache -o --output -r --region --svg -t --table -w --width"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_peptide_add()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_peptide_check()
+{
+    opts="-h --help --version -d --delimiter -H --no-header -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_peptide_fix()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_peptide()
+{
+    cmds="-h --help --version add check fix"
+    local i=0 cmd
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            peptide)
+                (( i++ ))
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            -*) ;;
+            *)
+                cmd="$s"
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    if [[ "$i" -eq "$COMP_CWORD" ]]
+    then
+        local cur="${COMP_WORDS[$COMP_CWORD]}"
+        COMPREPLY=()
+        while IFS='' read -r line
+        do
+            COMPREPLY+=("$line")
+        done < <(compgen -W "$cmds" -- "$cur")
+        return
+    fi
+
+    case "$cmd" in
+        add)   _gts_peptide_add ;;
+        check) _gts_peptide_check ;;
+        fix)   _gts_peptide_fix ;;
+        *) ;;
+    esac
+}
+
+_gts_pick()
+{
+    opts="-h --help --version -f --feature -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_primersearch()
+{
+    opts="-h --help --version -d --delimiter -F --format -H --no-header -k --key -m --mismatches --no-cache --no-complement -o --output -T --table"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_query()
+{
+    opts="-h --help --version -d --delimiter --empty -H --no-header -I --no-seqid -K --no-key -L --no-location -n --name --no-cache -o --output --source -t --separator"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_registry_add()
+{
+    opts="-h --help --version -i --id --replace -R --registry -t --tag"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_registry_get()
+{
+    opts="-h --help --version -F --format -o --output -R --registry"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_registry_list()
+{
+    opts="-h --help --version -d --delimiter -H --no-header -R --registry"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_registry_remove()
+{
+    opts="-h --help --version -R --registry"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_registry_search()
+{
+    opts="-h --help --version -d --delimiter -H --no-header -R --registry"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_registry()
+{
+    cmds="-h --help --version add get list remove search"
+    local i=0 cmd
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            registry)
+                (( i++ ))
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            -*) ;;
+            *)
+                cmd="$s"
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    if [[ "$i" -eq "$COMP_CWORD" ]]
+    then
+        local cur="${COMP_WORDS[$COMP_CWORD]}"
+        COMPREPLY=()
+        while IFS='' read -r line
+        do
+            COMPREPLY+=("$line")
+        done < <(compgen -W "$cmds" -- "$cur")
+        return
+    fi
+
+    case "$cmd" in
+        add)    _gts_registry_add ;;
+        get)    _gts_registry_get ;;
+        list)   _gts_registry_list ;;
+        remove) _gts_registry_remove ;;
+        search) _gts_registry_search ;;
+        *) ;;
+    esac
+}
+
+_gts_repair()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_repl()
+{
+    opts="-h --help --version -F --format"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_report()
+{
+    opts="-h --help --version --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_reverse()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_rotate()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_run()
+{
+    opts="-h --help --version -F --format -n --dry-run -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_sample()
+{
+    opts="-h --help --version -f --fraction -F --format --no-cache -n --count -o --output -p --paired -P --paired-output -s --seed"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_sanger()
+{
+    opts="-h --help --version -d --delimiter -D --discrepancies -H --no-header -k --kmer --no-cache -o --output -q --min-quality"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_search()
+{
+    opts="-h --help --version -e --exact -F --format -k --key -m --mismatches --no-cache --no-complement -o --output -q --qualifier -t --table"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_select()
+{
+    opts="-h --help --version -c --count -d --delimiter --fixed-strings -F --format -H --no-header -i --ignore-case -l --list-matching --no-cache -o --output -s --strand -v --invert-match -x --full-match"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_sketch()
+{
+    opts="-h --help --version -k --kmer --no-cache -o --output -s --size"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_sort()
+{
+    opts="-h --help --version -F --format --no-cache -o --output -r --reverse"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_split()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_stamp()
+{
+    opts="-h --help --version -d --detached -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_summary()
+{
+    opts="-h --help --version -C --no-coverage -F --no-feature --no-cache -o --output -Q --no-qualifier"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_tile()
+{
+    opts="-h --help --version -c --complement -d --delimiter -H --no-header -l --length --max-gc --max-tm --min-gc --min-tm --no-cache -o --output --overlap -p --prefix -r --region --shift"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_track()
+{
+    opts="-h --help --version -f --format -H --no-header -m --metric --no-cache -n --name -o --output -s --selector --step -w --window"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_translate()
+{
+    opts="-h --help --version -d --delimiter --emboss -F --format -H --no-header -m --min-length --no-cache -o --output -p --pseudo --records -r --region --selector --six-frame -s --stop-to-stop -t --table -T --tabular"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_transposon()
+{
+    opts="-h --help --version -F --format -k --key -m --motif --no-cache -n --every -o --output -q --qualifier -r --region -t --table"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_trim()
+{
+    opts="-h --help --version -a --adapter -e --mismatches -l --min-length --no-cache -o --output -O --min-overlap -p --paired -P --paired-output -q --quality -Q --min-mean-quality -u --unpaired -U --unpaired-mate -w --window"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_trna_check()
+{
+    opts="-h --help --version -d --delimiter -H --no-header -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_trna_fix()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_trna()
+{
+    cmds="-h --help --version check fix"
+    local i=0 cmd
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            trna)
+                (( i++ ))
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    while [[ "$i" -lt "$COMP_CWORD" ]]
+    do
+        local s="${COMP_WORDS[$i]}"
+        case "$s" in
+            -*) ;;
+            *)
+                cmd="$s"
+                break
+                ;;
+        esac
+        (( i++ ))
+    done
+
+    if [[ "$i" -eq "$COMP_CWORD" ]]
+    then
+        local cur="${COMP_WORDS[$COMP_CWORD]}"
+        COMPREPLY=()
+        while IFS='' read -r line
+        do
+            COMPREPLY+=("$line")
+        done < <(compgen -W "$cmds" -- "$cur")
+        return
+    fi
+
+    case "$cmd" in
+        check) _gts_trna_check ;;
+        fix)   _gts_trna_fix ;;
+        *) ;;
+    esac
+}
+
+_gts_unique()
+{
+    opts="-h --help --version -d --delimiter -H --no-header -k --kmer -m --merge --no-cache -o --output -s --single"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_unjoin()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_upper()
+{
+    opts="-h --help --version -F --format --no-cache -o --output"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_variants()
+{
+    opts="-h --help --version -a --annotate -d --delimiter -F --format -H --no-header -k --kmer --no-cache -o --output --vcf"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_verify()
+{
+    opts="-h --help --version -c --checksums -o --output -q --quiet"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_watch()
+{
+    opts="-h --help --version -i --interval -o --output --once -w --watch"
+    local cur="${COMP_WORDS[$COMP_CWORD]}"
+    case "$cur" in
+        -*)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do
+                COMPREPLY+=("$line")
+            done < <(compgen -W "$opts" -- "$cur")
+            ;;
+        *)
+            COMPREPLY=()
+            while IFS='' read -r line
+            do 
+                COMPREPLY+=("$line")
+            done < <(compgen -f -- "$cur")
+            ;;
+    esac
+}
+
+_gts_xref()
 {
-    opts="-h --help --version -F --no-feature --no-cache -o --output -Q --no-qualifier"
+    opts="-h --help --version --dblink -d --delimiter -D --database -H --no-header -l --list --no-cache -o --output -r --resolved -T --template"
     local cur="${COMP_WORDS[$COMP_CWORD]}"
     case "$cur" in
         -*)
@@ -555,7 +2078,7 @@ _gts_summary()
 
 _gts()
 {
-    cmds="-h --help --version annotate cache clear complement define delete extract infix insert join length pick query repair reverse rotate search select sort split summary"
+    cmds="-h --help --version annotate batch cache cds clean clear codon colorize compare-annotations complement complexity coordinates curate define degenerate delete digest dist extract fetch gaps gc grep hairpin infix insert intervals join length locate lower map normalize orfmap peptide pick primersearch query registry repair repl report reverse rotate run sample sanger search select sketch sort split stamp summary tile track translate transposon trim trna unique unjoin upper variants verify watch xref"
     local i=0 cmd
 
     while [[ "$i" -lt "$COMP_CWORD" ]]
@@ -595,27 +2118,73 @@ _gts()
     fi
 
     case "$cmd" in
-        annotate)   _gts_annotate ;;
-        cache)      _gts_cache ;;
-        clear)      _gts_clear ;;
-        complement) _gts_complement ;;
-        define)     _gts_define ;;
-        delete)     _gts_delete ;;
-        extract)    _gts_extract ;;
-        infix)      _gts_infix ;;
-        insert)     _gts_insert ;;
-        join)       _gts_join ;;
-        length)     _gts_length ;;
-        pick)       _gts_pick ;;
-        query)      _gts_query ;;
-        repair)     _gts_repair ;;
-        reverse)    _gts_reverse ;;
-        rotate)     _gts_rotate ;;
-        search)     _gts_search ;;
-        select)     _gts_select ;;
-        sort)       _gts_sort ;;
-        split)      _gts_split ;;
-        summary)    _gts_summary ;;
+        annotate)            _gts_annotate ;;
+        batch)               _gts_batch ;;
+        cache)               _gts_cache ;;
+        cds)                 _gts_cds ;;
+        clean)               _gts_clean ;;
+        clear)               _gts_clear ;;
+        codon)               _gts_codon ;;
+        colorize)            _gts_colorize ;;
+        compare-annotations) _gts_compare-annotations ;;
+        complement)          _gts_complement ;;
+        complexity)          _gts_complexity ;;
+        coordinates)         _gts_coordinates ;;
+        curate)              _gts_curate ;;
+        define)              _gts_define ;;
+        degenerate)          _gts_degenerate ;;
+        delete)              _gts_delete ;;
+        digest)              _gts_digest ;;
+        dist)                _gts_dist ;;
+        extract)             _gts_extract ;;
+        fetch)               _gts_fetch ;;
+        gaps)                _gts_gaps ;;
+        gc)                  _gts_gc ;;
+        grep)                _gts_grep ;;
+        hairpin)             _gts_hairpin ;;
+        infix)               _gts_infix ;;
+        insert)              _gts_insert ;;
+        intervals)           _gts_intervals ;;
+        join)                _gts_join ;;
+        length)              _gts_length ;;
+        locate)              _gts_locate ;;
+        lower)               _gts_lower ;;
+        map)                 _gts_map ;;
+        normalize)           _gts_normalize ;;
+        orfmap)              _gts_orfmap ;;
+        peptide)             _gts_peptide ;;
+        pick)                _gts_pick ;;
+        primersearch)        _gts_primersearch ;;
+        query)               _gts_query ;;
+        registry)            _gts_registry ;;
+        repair)              _gts_repair ;;
+        repl)                _gts_repl ;;
+        report)              _gts_report ;;
+        reverse)             _gts_reverse ;;
+        rotate)              _gts_rotate ;;
+        run)                 _gts_run ;;
+        sample)              _gts_sample ;;
+        sanger)              _gts_sanger ;;
+        search)              _gts_search ;;
+        select)              _gts_select ;;
+        sketch)              _gts_sketch ;;
+        sort)                _gts_sort ;;
+        split)               _gts_split ;;
+        stamp)               _gts_stamp ;;
+        summary)             _gts_summary ;;
+        tile)                _gts_tile ;;
+        track)               _gts_track ;;
+        translate)           _gts_translate ;;
+        transposon)          _gts_transposon ;;
+        trim)                _gts_trim ;;
+        trna)                _gts_trna ;;
+        unique)              _gts_unique ;;
+        unjoin)              _gts_unjoin ;;
+        upper)               _gts_upper ;;
+        variants)            _gts_variants ;;
+        verify)              _gts_verify ;;
+        watch)               _gts_watch ;;
+        xref)                _gts_xref ;;
         *) ;;
     esac
 }
@@ -0,0 +1,136 @@
+package gts
+
+// Homopolymers returns the regions of the runs of an identical base with at
+// least the given length. Bases are compared case insensitively.
+func Homopolymers(p []byte, min int) []Segment {
+	segments := []Segment{}
+	if min <= 0 {
+		return segments
+	}
+
+	start := 0
+	for i := 1; i <= len(p); i++ {
+		if i < len(p) && toLower(p[i]) == toLower(p[start]) {
+			continue
+		}
+		if i-start >= min {
+			segments = append(segments, Segment{start, i})
+		}
+		start = i
+	}
+	return segments
+}
+
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+func tripletIndex(p []byte) int {
+	index := 0
+	for _, c := range p {
+		i := baseIndex(c)
+		if i < 0 || c == 'u' || c == 'U' {
+			return -1
+		}
+		index = index*4 + i
+	}
+	return index
+}
+
+// DustScore returns the DUST score of the given sequence. The score is
+// computed from the counts c of each of the 64 triplets in the sequence as
+// the sum of c * (c - 1) / 2 divided by the number of triplets minus one.
+// Triplets containing bases other than A, C, G, or T are not counted.
+func DustScore(p []byte) float64 {
+	counts := [64]int{}
+	sum, n := 0, 0
+	for i := 0; i+3 <= len(p); i++ {
+		if t := tripletIndex(p[i : i+3]); t >= 0 {
+			sum += counts[t]
+			counts[t]++
+			n++
+		}
+	}
+	if n < 2 {
+		return 0
+	}
+	return float64(sum) / float64(n-1)
+}
+
+// LowComplexity returns the regions of the given sequence with a DUST score
+// greater than the threshold. Every window of the given size is scored and
+// the overlapping windows exceeding the threshold are merged into regions.
+// The ends of each region are then trimmed as long as the DUST score of the
+// region does not decrease, so that the flanking bases of the windows are
+// excluded.
+func LowComplexity(p []byte, window int, threshold float64) []Segment {
+	if window < 3 || len(p) < 3 {
+		return []Segment{}
+	}
+	if len(p) < window {
+		window = len(p)
+	}
+
+	triplets := make([]int, len(p)-2)
+	for i := range triplets {
+		triplets[i] = tripletIndex(p[i : i+3])
+	}
+
+	counts := [64]int{}
+	sum, n := 0, 0
+	add := func(t int) {
+		if t >= 0 {
+			sum += counts[t]
+			counts[t]++
+			n++
+		}
+	}
+	remove := func(t int) {
+		if t >= 0 {
+			counts[t]--
+			sum -= counts[t]
+			n--
+		}
+	}
+
+	w := window - 2
+	segments := []Segment{}
+	for i, t := range triplets {
+		add(t)
+		if i >= w {
+			remove(triplets[i-w])
+		}
+		if i+1 < w {
+			continue
+		}
+		if n >= 2 && float64(sum)/float64(n-1) > threshold {
+			segments = append(segments, Segment{i + 1 - w, i + 3})
+		}
+	}
+
+	segments = MergeSegments(segments)
+	for i, segment := range segments {
+		segments[i] = trimLowComplexity(p, segment)
+	}
+	return segments
+}
+
+func trimLowComplexity(p []byte, segment Segment) Segment {
+	start, end := Unpack(segment)
+	score := DustScore(p[start:end])
+	for end-start > 3 {
+		switch {
+		case DustScore(p[start+1:end]) >= score:
+			start++
+		case DustScore(p[start:end-1]) >= score:
+			end--
+		default:
+			return Segment{start, end}
+		}
+		score = DustScore(p[start:end])
+	}
+	return Segment{start, end}
+}
@@ -0,0 +1,52 @@
+package gts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestHomopolymers(t *testing.T) {
+	p := []byte("aaaaCgggggtAAAaaat")
+	testutils.Equals(t, Homopolymers(p, 4), []Segment{{0, 4}, {5, 10}, {11, 17}})
+	testutils.Equals(t, Homopolymers(p, 6), []Segment{{11, 17}})
+	testutils.Equals(t, Homopolymers(p, 0), []Segment{})
+	testutils.Equals(t, Homopolymers(nil, 1), []Segment{})
+}
+
+func TestDustScore(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"", 0},
+		{"acg", 0},
+		{"aaaaaa", 6.0 / 3.0},
+		{"acgtac", 0},
+		{"acacac", 2.0 / 3.0},
+		{"aaannaaa", 1.0},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, DustScore([]byte(tt.in)), tt.out)
+	}
+}
+
+func TestLowComplexity(t *testing.T) {
+	random := "gagttttatcgcttccatgacgcagaagttaacactttcggatatttctgatgagtcgaaaaattatcttgataaagcag"
+	repeat := strings.Repeat("ca", 40)
+	p := []byte(random + repeat + random)
+
+	segments := LowComplexity(p, 64, 10)
+	testutils.Equals(t, len(segments), 1)
+	start, end := Unpack(segments[0])
+	testutils.Equals(t, start <= len(random), true)
+	testutils.Equals(t, len(random)+len(repeat) <= end, true)
+
+	testutils.Equals(t, LowComplexity([]byte(random), 64, 10), []Segment{})
+	testutils.Equals(t, LowComplexity([]byte(repeat), 64, 10), []Segment{{0, len(repeat)}})
+	testutils.Equals(t, LowComplexity([]byte(repeat), 64, 20), []Segment{})
+	testutils.Equals(t, LowComplexity([]byte(strings.Repeat("a", 80)), 64, 20), []Segment{{0, 80}})
+	testutils.Equals(t, LowComplexity([]byte("ac"), 64, 20), []Segment{})
+}
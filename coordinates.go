@@ -0,0 +1,193 @@
+package gts
+
+// RotatedPosition returns the position in a sequence of the given length
+// rotated by n with the Rotate function which corresponds to the given
+// position in the original sequence. The position in the original sequence
+// is obtained by rotating by -n.
+func RotatedPosition(pos, n, length int) int {
+	if length == 0 {
+		return pos
+	}
+	return Mod(pos+n, length)
+}
+
+// orientedSegments flattens the region into segments in the order of the
+// region, retaining the orientation of each segment.
+func orientedSegments(r Region) []Segment {
+	switch v := r.(type) {
+	case Regions:
+		ss := []Segment{}
+		for _, r := range v {
+			ss = append(ss, orientedSegments(r)...)
+		}
+		return ss
+	case RemoteRegion:
+		return nil
+	default:
+		return []Segment{v.(Segment)}
+	}
+}
+
+// FeatureCoordinates maps positions between the coordinate systems of a
+// feature in a sequence. All positions are 0-based. The coordinate systems
+// are:
+//
+//	genomic:    the position in the sequence
+//	local:      the distance from the 5' end of the feature along its span,
+//	            including any gaps between the segments of the feature
+//	transcript: the position in the spliced sequence of the feature
+//	protein:    the residue encoded by the codon at a transcript position,
+//	            starting at the offset given by the `/codon_start` qualifier
+//
+// The local and transcript coordinates follow the orientation of the
+// feature, so that a feature on the reverse strand starts at its highest
+// genomic position. The span of a feature whose location crosses the origin
+// of a circular sequence wraps around the end of the sequence.
+type FeatureCoordinates struct {
+	segments []Segment
+	length   int
+	forward  bool
+	head     int
+	span     int
+	offset   int
+}
+
+// NewFeatureCoordinates creates the FeatureCoordinates for the given feature
+// in a sequence of the given length.
+func NewFeatureCoordinates(f Feature, length int) FeatureCoordinates {
+	fc := FeatureCoordinates{
+		segments: orientedSegments(f.Loc.Region()),
+		length:   length,
+		forward:  true,
+		offset:   CodonStart(f),
+	}
+	for _, s := range fc.segments {
+		if s.Len() > 0 {
+			fc.forward = s[0] <= s[1]
+			fc.head = s[0]
+			if !fc.forward {
+				fc.head--
+			}
+			break
+		}
+	}
+	for i := len(fc.segments) - 1; i >= 0; i-- {
+		if s := fc.segments[i]; s.Len() > 0 {
+			last := s[1] - 1
+			if s[1] < s[0] {
+				last = s[1]
+			}
+			fc.span = fc.distance(last) + 1
+			break
+		}
+	}
+	return fc
+}
+
+func (fc FeatureCoordinates) distance(pos int) int {
+	if fc.length == 0 {
+		return Abs(pos - fc.head)
+	}
+	if fc.forward {
+		return Mod(pos-fc.head, fc.length)
+	}
+	return Mod(fc.head-pos, fc.length)
+}
+
+// Len returns the length of the transcript of the feature.
+func (fc FeatureCoordinates) Len() int {
+	total := 0
+	for _, s := range fc.segments {
+		total += s.Len()
+	}
+	return total
+}
+
+// Span returns the length of the span of the feature.
+func (fc FeatureCoordinates) Span() int {
+	return fc.span
+}
+
+// Local returns the local position of the given genomic position, or false
+// if the position is outside of the span of the feature.
+func (fc FeatureCoordinates) Local(pos int) (int, bool) {
+	if pos < 0 || (fc.length > 0 && fc.length <= pos) {
+		return 0, false
+	}
+	d := fc.distance(pos)
+	return d, d < fc.span
+}
+
+// FromLocal returns the genomic position of the given local position, or
+// false if the position is outside of the span of the feature.
+func (fc FeatureCoordinates) FromLocal(local int) (int, bool) {
+	if local < 0 || fc.span <= local {
+		return 0, false
+	}
+	pos := fc.head - local
+	if fc.forward {
+		pos = fc.head + local
+	}
+	if fc.length > 0 {
+		pos = Mod(pos, fc.length)
+	}
+	return pos, true
+}
+
+// Transcript returns the transcript position of the given genomic position,
+// or false if the position is not covered by the feature. If the feature
+// covers the position more than once, the first occurrence is returned.
+func (fc FeatureCoordinates) Transcript(pos int) (int, bool) {
+	total := 0
+	for _, s := range fc.segments {
+		switch {
+		case s[0] <= pos && pos < s[1]:
+			return total + pos - s[0], true
+		case s[1] <= pos && pos < s[0]:
+			return total + s[0] - 1 - pos, true
+		}
+		total += s.Len()
+	}
+	return 0, false
+}
+
+// FromTranscript returns the genomic position of the given transcript
+// position, or false if the position is outside of the transcript.
+func (fc FeatureCoordinates) FromTranscript(t int) (int, bool) {
+	if t < 0 {
+		return 0, false
+	}
+	for _, s := range fc.segments {
+		if n := s.Len(); t >= n {
+			t -= n
+			continue
+		}
+		if s[1] < s[0] {
+			return s[0] - 1 - t, true
+		}
+		return s[0] + t, true
+	}
+	return 0, false
+}
+
+// Protein returns the residue and the position within the codon (0, 1, or
+// 2) of the given transcript position, or false if the position precedes the
+// first complete codon or is outside of the transcript.
+func (fc FeatureCoordinates) Protein(t int) (int, int, bool) {
+	if t < fc.offset || fc.Len() <= t {
+		return 0, 0, false
+	}
+	t -= fc.offset
+	return t / 3, t % 3, true
+}
+
+// FromProtein returns the transcript position of the first base of the
+// codon encoding the given residue, or false if the codon is outside of the
+// transcript.
+func (fc FeatureCoordinates) FromProtein(residue int) (int, bool) {
+	t := fc.offset + residue*3
+	if residue < 0 || fc.Len() <= t {
+		return 0, false
+	}
+	return t, true
+}
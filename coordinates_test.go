@@ -0,0 +1,132 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestRotatedPosition(t *testing.T) {
+	seq := New(nil, nil, []byte("atgcatgcat"))
+	for _, n := range []int{0, 3, -3, 12} {
+		out := Rotate(seq, n).Bytes()
+		for pos := range seq.Bytes() {
+			rot := RotatedPosition(pos, n, Len(seq))
+			testutils.Equals(t, out[rot], seq.Bytes()[pos])
+			testutils.Equals(t, RotatedPosition(rot, -n, Len(seq)), pos)
+		}
+	}
+}
+
+func TestFeatureCoordinates(t *testing.T) {
+	props := Props{}
+	props.Add("codon_start", "2")
+
+	f := NewFeature("CDS", Join(Range(10, 15), Range(20, 30)), props)
+	fc := NewFeatureCoordinates(f, 100)
+	testutils.Equals(t, fc.Len(), 15)
+	testutils.Equals(t, fc.Span(), 20)
+
+	local := []struct {
+		pos, local int
+		ok         bool
+	}{
+		{9, 0, false},
+		{10, 0, true},
+		{17, 7, true},
+		{29, 19, true},
+		{30, 0, false},
+	}
+	for _, tt := range local {
+		out, ok := fc.Local(tt.pos)
+		testutils.Equals(t, ok, tt.ok)
+		if ok {
+			testutils.Equals(t, out, tt.local)
+			pos, ok := fc.FromLocal(out)
+			testutils.Equals(t, ok, true)
+			testutils.Equals(t, pos, tt.pos)
+		}
+	}
+
+	transcript := []struct {
+		pos, t int
+		ok     bool
+	}{
+		{10, 0, true},
+		{14, 4, true},
+		{17, 0, false},
+		{20, 5, true},
+		{29, 14, true},
+	}
+	for _, tt := range transcript {
+		out, ok := fc.Transcript(tt.pos)
+		testutils.Equals(t, ok, tt.ok)
+		if ok {
+			testutils.Equals(t, out, tt.t)
+			pos, ok := fc.FromTranscript(out)
+			testutils.Equals(t, ok, true)
+			testutils.Equals(t, pos, tt.pos)
+		}
+	}
+	if _, ok := fc.FromTranscript(15); ok {
+		t.Error("FromTranscript(15) = true for a transcript of length 15")
+	}
+
+	protein := []struct {
+		t, residue, phase int
+		ok                bool
+	}{
+		{0, 0, 0, false},
+		{1, 0, 0, true},
+		{3, 0, 2, true},
+		{4, 1, 0, true},
+		{14, 4, 1, true},
+		{15, 0, 0, false},
+	}
+	for _, tt := range protein {
+		residue, phase, ok := fc.Protein(tt.t)
+		testutils.Equals(t, ok, tt.ok)
+		if ok {
+			testutils.Equals(t, residue, tt.residue)
+			testutils.Equals(t, phase, tt.phase)
+			if phase == 0 {
+				out, ok := fc.FromProtein(residue)
+				testutils.Equals(t, ok, true)
+				testutils.Equals(t, out, tt.t)
+			}
+		}
+	}
+
+	rev := NewFeatureCoordinates(NewFeature("CDS", Join(Range(10, 15), Range(20, 30)).Complement(), Props{}), 100)
+	testutils.Equals(t, rev.Span(), 20)
+	for _, tt := range []struct{ pos, local, t int }{
+		{29, 0, 0},
+		{20, 9, 9},
+		{14, 15, 10},
+		{10, 19, 14},
+	} {
+		local, ok := rev.Local(tt.pos)
+		testutils.Equals(t, ok, true)
+		testutils.Equals(t, local, tt.local)
+		tr, ok := rev.Transcript(tt.pos)
+		testutils.Equals(t, ok, true)
+		testutils.Equals(t, tr, tt.t)
+		pos, _ := rev.FromTranscript(tt.t)
+		testutils.Equals(t, pos, tt.pos)
+		pos, _ = rev.FromLocal(tt.local)
+		testutils.Equals(t, pos, tt.pos)
+	}
+
+	wrap := NewFeatureCoordinates(NewFeature("CDS", Join(Range(95, 100), Range(0, 5)), Props{}), 100)
+	testutils.Equals(t, wrap.Span(), 10)
+	for _, tt := range []struct{ pos, local int }{{95, 0}, {99, 4}, {0, 5}, {4, 9}} {
+		local, ok := wrap.Local(tt.pos)
+		testutils.Equals(t, ok, true)
+		testutils.Equals(t, local, tt.local)
+		tr, _ := wrap.Transcript(tt.pos)
+		testutils.Equals(t, tr, tt.local)
+	}
+	if _, ok := wrap.Local(50); ok {
+		t.Error("Local(50) = true for a feature spanning the origin")
+	}
+}
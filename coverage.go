@@ -0,0 +1,129 @@
+package gts
+
+// Coverage represents the annotation completeness metrics of a sequence.
+type Coverage struct {
+	// Length is the length of the sequence.
+	Length int
+
+	// Genes is the number of gene loci, where CDS and gene features with
+	// overlapping footprints are counted as a single locus.
+	Genes int
+
+	// Annotated is the number of bases covered by CDS or gene features.
+	Annotated int
+
+	// Coding is the number of bases covered by CDS features.
+	Coding int
+
+	// Intergenic is the list of distances between adjacent gene loci.
+	Intergenic []int
+}
+
+// Fraction returns the fraction of the sequence covered by CDS or gene
+// features.
+func (c Coverage) Fraction() float64 {
+	if c.Length == 0 {
+		return 0
+	}
+	return float64(c.Annotated) / float64(c.Length)
+}
+
+// CodingDensity returns the fraction of the sequence covered by CDS features.
+func (c Coverage) CodingDensity() float64 {
+	if c.Length == 0 {
+		return 0
+	}
+	return float64(c.Coding) / float64(c.Length)
+}
+
+// MeanIntergenic returns the mean distance between adjacent gene loci.
+func (c Coverage) MeanIntergenic() float64 {
+	if len(c.Intergenic) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, d := range c.Intergenic {
+		sum += d
+	}
+	return float64(sum) / float64(len(c.Intergenic))
+}
+
+// footprint returns the segment spanned by a region of a sequence of length
+// n. The footprint of a region on a circular sequence is the shortest arc
+// covering all of its segments, which may span the origin.
+func footprint(r Region, n int, circular bool) []Segment {
+	ss := Minimize(r)
+	if len(ss) < 2 {
+		return ss
+	}
+	if !circular {
+		return []Segment{{ss[0][0], ss[len(ss)-1][1]}}
+	}
+
+	// Leave out the largest gap between the segments, including the gap
+	// spanning the origin.
+	j, gap := len(ss)-1, ss[0][0]+n-ss[len(ss)-1][1]
+	for i := 0; i < len(ss)-1; i++ {
+		if d := ss[i+1][0] - ss[i][1]; d > gap {
+			j, gap = i, d
+		}
+	}
+	if j == len(ss)-1 {
+		return []Segment{{ss[0][0], ss[len(ss)-1][1]}}
+	}
+	return []Segment{{ss[j+1][0], n}, {0, ss[j][1]}}
+}
+
+// FeatureCoverage computes the annotation completeness metrics of a sequence
+// of length n using the CDS and gene features. The distance between adjacent
+// gene loci is measured between the footprints of the features so that the
+// introns of a feature are not counted as intergenic regions. On a circular
+// sequence, the region spanning the origin between the last and first loci
+// is also counted as intergenic.
+func FeatureCoverage(ff FeatureSlice, n int, circular bool) Coverage {
+	annotated, coding, loci := Regions{}, Regions{}, Regions{}
+	for _, f := range ff {
+		if f.Key != "CDS" && f.Key != "gene" {
+			continue
+		}
+		r := f.Loc.Region()
+		annotated = append(annotated, r)
+		if f.Key == "CDS" {
+			coding = append(coding, r)
+		}
+		for _, s := range footprint(r, n, circular) {
+			loci = append(loci, s)
+		}
+	}
+
+	c := Coverage{Length: n, Intergenic: []int{}}
+	for _, s := range Minimize(annotated) {
+		c.Annotated += Min(n, s[1]) - Max(0, s[0])
+	}
+	for _, s := range Minimize(coding) {
+		c.Coding += Min(n, s[1]) - Max(0, s[0])
+	}
+
+	ss := Minimize(loci)
+	if len(ss) == 0 {
+		return c
+	}
+
+	for i := 0; i < len(ss)-1; i++ {
+		c.Intergenic = append(c.Intergenic, ss[i+1][0]-ss[i][1])
+	}
+	c.Genes = len(ss)
+
+	if circular {
+		head, tail := ss[0][0], ss[len(ss)-1][1]
+		switch {
+		case len(ss) > 1 && head == 0 && tail == n:
+			// The first and last loci are a single locus spanning the origin.
+			c.Genes--
+		case head > 0 || tail < n:
+			c.Intergenic = append(c.Intergenic, head+n-tail)
+		}
+	}
+
+	return c
+}
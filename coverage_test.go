@@ -0,0 +1,52 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestFootprint(t *testing.T) {
+	r := Regions{Segment{80, 100}, Segment{0, 10}}
+	testutils.Equals(t, footprint(r, 100, true), []Segment{{80, 100}, {0, 10}})
+	testutils.Equals(t, footprint(r, 100, false), []Segment{{0, 100}})
+	testutils.Equals(t, footprint(Regions{Segment{10, 20}, Segment{30, 40}}, 100, true), []Segment{{10, 40}})
+	testutils.Equals(t, footprint(Segment{10, 20}, 100, true), []Segment{{10, 20}})
+}
+
+func TestFeatureCoverage(t *testing.T) {
+	ff := FeatureSlice{
+		NewFeature("source", Range(0, 100), Props{}),
+		NewFeature("gene", Range(10, 40), Props{}),
+		NewFeature("CDS", Join(Range(10, 20), Range(30, 40)), Props{}),
+		NewFeature("CDS", Range(50, 60).Complement(), Props{}),
+		NewFeature("misc_feature", Range(60, 80), Props{}),
+		NewFeature("CDS", Join(Range(90, 100), Range(0, 5)), Props{}),
+	}
+
+	linear := FeatureCoverage(ff, 100, false)
+	testutils.Equals(t, linear, Coverage{
+		Length:     100,
+		Genes:      1,
+		Annotated:  55,
+		Coding:     45,
+		Intergenic: []int{},
+	})
+
+	circular := FeatureCoverage(ff, 100, true)
+	testutils.Equals(t, circular, Coverage{
+		Length:     100,
+		Genes:      3,
+		Annotated:  55,
+		Coding:     45,
+		Intergenic: []int{5, 10, 30},
+	})
+	testutils.Equals(t, circular.Fraction(), 0.55)
+	testutils.Equals(t, circular.CodingDensity(), 0.45)
+	testutils.Equals(t, circular.MeanIntergenic(), 15.0)
+
+	empty := FeatureCoverage(nil, 0, false)
+	testutils.Equals(t, empty.Fraction(), 0.0)
+	testutils.Equals(t, empty.CodingDensity(), 0.0)
+	testutils.Equals(t, empty.MeanIntergenic(), 0.0)
+}
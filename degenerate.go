@@ -0,0 +1,177 @@
+package gts
+
+import "fmt"
+
+const maxInt = int(^uint(0) >> 1)
+
+var nucleotideCodes = [16]byte{
+	0x1: 'a', 0x2: 'c', 0x3: 'm', 0x4: 'g', 0x5: 'r', 0x6: 's', 0x7: 'v',
+	0x8: 't', 0x9: 'w', 0xa: 'y', 0xb: 'h', 0xc: 'k', 0xd: 'd', 0xe: 'b',
+	0xf: 'n',
+}
+
+func isLower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isUracil(p []byte) bool {
+	for _, c := range p {
+		if c == 'u' || c == 'U' {
+			return true
+		}
+	}
+	return false
+}
+
+// DegenerateCount returns the number of concrete sequences represented by
+// the given degenerate nucleotide sequence. The count saturates at the
+// maximum value of int. Characters which are not IUPAC nucleotide codes
+// represent themselves.
+func DegenerateCount(p []byte) int {
+	n := 1
+	for _, c := range p {
+		k := 0
+		for m := nucleotideMasks[c]; m != 0; m >>= 1 {
+			k += int(m & 1)
+		}
+		if k > 1 {
+			if n > maxInt/k {
+				return maxInt
+			}
+			n *= k
+		}
+	}
+	return n
+}
+
+// ExpandDegenerate returns the concrete sequences represented by the given
+// degenerate nucleotide sequence in lexicographical order of A, C, G, and T.
+// T is substituted with U if the sequence contains U. The case of each
+// character is retained. At most limit sequences are returned if limit is
+// positive.
+func ExpandDegenerate(p []byte, limit int) [][]byte {
+	t := byte('t')
+	if isUracil(p) {
+		t = 'u'
+	}
+	bases := [4]byte{'a', 'c', 'g', t}
+
+	choices := make([][]byte, len(p))
+	for i, c := range p {
+		m := nucleotideMasks[c]
+		if m == 0 || c == 'u' || c == 'U' {
+			choices[i] = []byte{c}
+			continue
+		}
+		for j, b := range bases {
+			if m&(1<<uint(j)) != 0 {
+				if !isLower(c) {
+					b = toUpper(b)
+				}
+				choices[i] = append(choices[i], b)
+			}
+		}
+	}
+
+	ret := [][]byte{}
+	index := make([]int, len(p))
+	for limit <= 0 || len(ret) < limit {
+		q := make([]byte, len(p))
+		for i, j := range index {
+			q[i] = choices[i][j]
+		}
+		ret = append(ret, q)
+
+		i := len(index) - 1
+		for ; i >= 0; i-- {
+			index[i]++
+			if index[i] < len(choices[i]) {
+				break
+			}
+			index[i] = 0
+		}
+		if i < 0 {
+			break
+		}
+	}
+
+	return ret
+}
+
+// ConsensusBuilder computes the degenerate consensus of nucleotide sequences
+// added one at a time, so that the sequences need not be held in memory all
+// at once. The zero value is ready to use.
+type ConsensusBuilder struct {
+	n      int
+	masks  []byte
+	lower  []bool
+	uracil bool
+}
+
+// Add adds a sequence to the consensus. All sequences must have the same
+// length as the first sequence added.
+func (b *ConsensusBuilder) Add(p []byte) error {
+	if b.n == 0 {
+		b.masks = make([]byte, len(p))
+		b.lower = make([]bool, len(p))
+		for i, c := range p {
+			b.lower[i] = isLower(c)
+		}
+	}
+	if len(p) != len(b.masks) {
+		return fmt.Errorf("sequence %d has length %d, expected %d", b.n+1, len(p), len(b.masks))
+	}
+	for j, c := range p {
+		if nucleotideMasks[c] == 0 {
+			return fmt.Errorf("sequence %d has non-nucleotide character %q at position %d", b.n+1, c, j+1)
+		}
+	}
+	for j, c := range p {
+		b.masks[j] |= nucleotideMasks[c]
+	}
+	b.uracil = b.uracil || isUracil(p)
+	b.n++
+	return nil
+}
+
+// Len returns the number of sequences added to the consensus.
+func (b *ConsensusBuilder) Len() int {
+	return b.n
+}
+
+// Bytes returns the degenerate consensus of the sequences added so far,
+// using the least ambiguous IUPAC code at each position. The consensus is in
+// lower case if the first sequence is in lower case at the position, and U is
+// used in place of T if any of the sequences contain U.
+func (b *ConsensusBuilder) Bytes() []byte {
+	if b.n == 0 {
+		return nil
+	}
+	q := make([]byte, len(b.masks))
+	for i, m := range b.masks {
+		c := nucleotideCodes[m]
+		if c == 't' && b.uracil {
+			c = 'u'
+		}
+		if !b.lower[i] {
+			c = toUpper(c)
+		}
+		q[i] = c
+	}
+	return q
+}
+
+// DegenerateConsensus returns the degenerate nucleotide sequence which
+// represents all of the given sequences of equal length, using the least
+// ambiguous IUPAC code at each position. The consensus is in lower case if
+// the first sequence is in lower case at the position, and U is used in
+// place of T if any of the sequences contain U.
+func DegenerateConsensus(pp ...[]byte) ([]byte, error) {
+	b := ConsensusBuilder{}
+	for _, p := range pp {
+		if err := b.Add(p); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func stringsOf(pp [][]byte) []string {
+	ss := make([]string, len(pp))
+	for i, p := range pp {
+		ss[i] = string(p)
+	}
+	return ss
+}
+
+var expandDegenerateTests = []struct {
+	in    string
+	limit int
+	count int
+	out   []string
+}{
+	{"atgc", 0, 1, []string{"atgc"}},
+	{"aRy", 0, 4, []string{"aAc", "aAt", "aGc", "aGt"}},
+	{"aRy", 3, 4, []string{"aAc", "aAt", "aGc"}},
+	{"nu", 0, 4, []string{"au", "cu", "gu", "uu"}},
+	{"a-w", 0, 2, []string{"a-a", "a-t"}},
+}
+
+func TestExpandDegenerate(t *testing.T) {
+	for _, tt := range expandDegenerateTests {
+		testutils.Equals(t, DegenerateCount([]byte(tt.in)), tt.count)
+		out := ExpandDegenerate([]byte(tt.in), tt.limit)
+		testutils.Equals(t, stringsOf(out), tt.out)
+	}
+
+	n := make([]byte, 64)
+	for i := range n {
+		n[i] = 'n'
+	}
+	testutils.Equals(t, DegenerateCount(n), maxInt)
+}
+
+func TestDegenerateConsensus(t *testing.T) {
+	out, err := DegenerateConsensus([]byte("aAcg"), []byte("gAtg"), []byte("aAtc"))
+	if err != nil {
+		t.Fatalf("DegenerateConsensus(): %v", err)
+	}
+	testutils.Equals(t, string(out), "rAys")
+
+	out, err = DegenerateConsensus([]byte("acgu"), []byte("acgc"))
+	if err != nil {
+		t.Fatalf("DegenerateConsensus(): %v", err)
+	}
+	testutils.Equals(t, string(out), "acgy")
+
+	out, err = DegenerateConsensus([]byte("aRy"), []byte("tta"))
+	if err != nil {
+		t.Fatalf("DegenerateConsensus(): %v", err)
+	}
+	testutils.Equals(t, string(out), "wDh")
+
+	out, err = DegenerateConsensus()
+	testutils.Equals(t, out, []byte(nil))
+	testutils.Equals(t, err, nil)
+
+	for _, pp := range [][][]byte{
+		{[]byte("acgt"), []byte("acg")},
+		{[]byte("acgt"), []byte("ac-t")},
+	} {
+		if _, err := DegenerateConsensus(pp...); err == nil {
+			t.Errorf("expected error in DegenerateConsensus(%q)", pp)
+		}
+	}
+}
+
+func TestConsensusBuilder(t *testing.T) {
+	b := ConsensusBuilder{}
+	testutils.Equals(t, b.Bytes(), []byte(nil))
+
+	for _, p := range []string{"aAcg", "gAtg", "aAtc"} {
+		if err := b.Add([]byte(p)); err != nil {
+			t.Fatalf("b.Add(%q): %v", p, err)
+		}
+	}
+	testutils.Equals(t, b.Len(), 3)
+	testutils.Equals(t, string(b.Bytes()), "rAys")
+
+	if err := b.Add([]byte("ac-t")); err == nil {
+		t.Error("expected error in b.Add(\"ac-t\")")
+	}
+	testutils.Equals(t, b.Len(), 3)
+	testutils.Equals(t, string(b.Bytes()), "rAys")
+}
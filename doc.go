@@ -0,0 +1,16 @@
+// Package gts provides the core data structures and operations for genome
+// flat-file manipulation. A sequence is represented by the Sequence
+// interface, which exposes the metadata, the feature table, and the bytes of
+// a sequence. Features are located on a sequence by a Location and can be
+// picked out with a Filter, which may be constructed from the selector syntax
+// with Selector. Sequences are edited through functions such as Insert,
+// Delete, Slice, and WithFeatures which return shallow copies of the
+// original sequence, so that any implementation of Sequence can be edited
+// without depending on a particular file format. The operations of the
+// commands in cmd/gts are also available as an Operation, which may be
+// composed with others into a Pipeline and applied to a sequence.
+//
+// Reading and writing sequences in the supported file formats is provided by
+// the seqio package. Neither package depends on the command line interface
+// in cmd/gts.
+package gts
@@ -0,0 +1,278 @@
+package gts
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Enzyme represents a restriction enzyme. Cut and Cut2 are the positions at
+// which the top and bottom strands are cut relative to the start of the
+// recognition site, given in the coordinates of the top strand.
+type Enzyme struct {
+	Name string
+	Site []byte
+	Cut  int
+	Cut2 int
+}
+
+// ParseEnzyme parses a recognition site in the REBASE notation. The site is
+// either given with a `^` marking the cut position in the top strand, such as
+// `G^AATTC`, in which case the bottom strand is assumed to be cut
+// symmetrically, or followed by the cut positions of the top and bottom
+// strands downstream of the site in parentheses, such as `GGTCTC(1/5)`.
+func ParseEnzyme(name, s string) (Enzyme, error) {
+	e := Enzyme{Name: name}
+
+	if i := strings.IndexByte(s, '('); i >= 0 {
+		if !strings.HasSuffix(s, ")") {
+			return e, fmt.Errorf("unterminated cut positions in site %q", s)
+		}
+		cuts := strings.Split(s[i+1:len(s)-1], "/")
+		if len(cuts) != 2 {
+			return e, fmt.Errorf("expected two cut positions in site %q", s)
+		}
+		cut, err := strconv.Atoi(cuts[0])
+		if err != nil {
+			return e, fmt.Errorf("invalid cut position in site %q", s)
+		}
+		cut2, err := strconv.Atoi(cuts[1])
+		if err != nil {
+			return e, fmt.Errorf("invalid cut position in site %q", s)
+		}
+		e.Site = []byte(s[:i])
+		e.Cut, e.Cut2 = i+cut, i+cut2
+	} else {
+		i := strings.IndexByte(s, '^')
+		if i < 0 || strings.Count(s, "^") > 1 {
+			return e, fmt.Errorf("expected a single `^` marking the cut position in site %q", s)
+		}
+		e.Site = []byte(s[:i] + s[i+1:])
+		e.Cut, e.Cut2 = i, len(e.Site)-i
+	}
+
+	if len(e.Site) == 0 {
+		return e, fmt.Errorf("empty recognition site in site %q", s)
+	}
+	for _, c := range e.Site {
+		if nucleotideMasks[c] == 0 {
+			return e, fmt.Errorf("invalid nucleotide %q in site %q", c, s)
+		}
+	}
+
+	return e, nil
+}
+
+// ReadEnzymes reads a table of restriction enzymes where each line consists
+// of the name of the enzyme followed by its recognition site in the notation
+// accepted by ParseEnzyme. Empty lines and lines starting with `#` are
+// ignored.
+func ReadEnzymes(r io.Reader) ([]Enzyme, error) {
+	enzymes := []Enzyme{}
+
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected an enzyme name and a site: got %q", lineno, line)
+		}
+		e, err := ParseEnzyme(fields[0], fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		enzymes = append(enzymes, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return enzymes, nil
+}
+
+// Palindromic tests if the recognition site of the enzyme is identical to its
+// reverse complement.
+func (e Enzyme) Palindromic() bool {
+	site := New(nil, nil, e.Site)
+	rc := Reverse(Complement(site))
+	return bytes.EqualFold(e.Site, rc.Bytes())
+}
+
+// Cuts returns the sorted positions at which the top strand of the given
+// sequence is cut by the enzyme, searching both strands for the recognition
+// site. A position is the number of bases preceding the cut. If the sequence
+// is circular, sites spanning the origin are recognized and cut positions
+// beyond either end wrap around. Otherwise, cuts at or beyond the ends of the
+// sequence are omitted.
+func (e Enzyme) Cuts(seq Sequence, circular bool) []int {
+	n, m := Len(seq), len(e.Site)
+	if n == 0 {
+		return nil
+	}
+
+	p := seq.Bytes()
+	if circular {
+		for len(p) < n+m-1 {
+			p = append(p[:len(p):len(p)], seq.Bytes()...)
+		}
+		p = p[:n+m-1]
+	}
+	target := New(nil, nil, p)
+
+	site := New(nil, nil, e.Site)
+	sites := []Sequence{site}
+	if !e.Palindromic() {
+		sites = append(sites, Reverse(Complement(site)))
+	}
+
+	cuts := []int{}
+	for i, query := range sites {
+		for _, hit := range MatchApprox(target, query, 0) {
+			start := hit.Segment[0]
+			if start >= n {
+				continue
+			}
+			cut := start + e.Cut
+			if i > 0 {
+				cut = start + m - e.Cut2
+			}
+			switch {
+			case circular:
+				cut = ((cut % n) + n) % n
+			case cut <= 0 || n <= cut:
+				continue
+			}
+			cuts = append(cuts, cut)
+		}
+	}
+
+	return uniqueInts(cuts)
+}
+
+// Digest returns the fragments of the given sequence cut at the given
+// positions in ascending order. A circular sequence is linearized at the
+// first cut, and the fragment spanning the origin is returned last. The
+// sequence is returned as is if there are no cuts.
+func Digest(seq Sequence, cuts []int, circular bool) []Sequence {
+	cuts = uniqueInts(cuts)
+	if len(cuts) == 0 {
+		return []Sequence{seq}
+	}
+
+	if circular {
+		if len(cuts) == 1 {
+			return []Sequence{WithTopology(Rotate(seq, -cuts[0]), Linear)}
+		}
+		ret := make([]Sequence, len(cuts))
+		for i := range cuts {
+			start, end := cuts[i], cuts[(i+1)%len(cuts)]
+			ret[i] = Slice(seq, start, end)
+		}
+		return ret
+	}
+
+	bounds := append(append([]int{0}, cuts...), Len(seq))
+	ret := make([]Sequence, len(bounds)-1)
+	for i := range ret {
+		ret[i] = Slice(seq, bounds[i], bounds[i+1])
+	}
+	return ret
+}
+
+// DigestSegments returns the segments of the fragments of a sequence of the
+// given length cut at the given positions, in the same order as the fragments
+// returned by Digest. The segment of the fragment spanning the origin of a
+// circular sequence ends beyond the length of the sequence.
+func DigestSegments(length int, cuts []int, circular bool) []Segment {
+	cuts = uniqueInts(cuts)
+	if len(cuts) == 0 {
+		return []Segment{{0, length}}
+	}
+
+	if circular {
+		ret := make([]Segment, len(cuts))
+		for i := range cuts {
+			end := cuts[0] + length
+			if i+1 < len(cuts) {
+				end = cuts[i+1]
+			}
+			ret[i] = Segment{cuts[i], end}
+		}
+		return ret
+	}
+
+	bounds := append(append([]int{0}, cuts...), length)
+	ret := make([]Segment, len(bounds)-1)
+	for i := range ret {
+		ret[i] = Segment{bounds[i], bounds[i+1]}
+	}
+	return ret
+}
+
+// RestrictionEnzymes is a table of commonly used restriction enzymes in the
+// format accepted by ReadEnzymes.
+const RestrictionEnzymes = `# name	site
+AatII	GACGT^C
+AgeI	A^CCGGT
+AluI	AG^CT
+ApaI	GGGCC^C
+AscI	GG^CGCGCC
+AvrII	C^CTAGG
+BamHI	G^GATCC
+BbsI	GAAGAC(2/6)
+BglII	A^GATCT
+BsaI	GGTCTC(1/5)
+BsmBI	CGTCTC(1/5)
+BspHI	T^CATGA
+BstBI	TT^CGAA
+ClaI	AT^CGAT
+DpnII	^GATC
+DraI	TTT^AAA
+EagI	C^GGCCG
+EcoRI	G^AATTC
+EcoRV	GAT^ATC
+FseI	GGCCGG^CC
+HaeIII	GG^CC
+HincII	GTY^RAC
+HindIII	A^AGCTT
+HpaI	GTT^AAC
+KpnI	GGTAC^C
+MboI	^GATC
+MfeI	C^AATTG
+MluI	A^CGCGT
+MspI	C^CGG
+NcoI	C^CATGG
+NdeI	CA^TATG
+NheI	G^CTAGC
+NotI	GC^GGCCGC
+NsiI	ATGCA^T
+PacI	TTAAT^TAA
+PciI	A^CATGT
+PmeI	GTTT^AAAC
+PstI	CTGCA^G
+PvuI	CGAT^CG
+PvuII	CAG^CTG
+SacI	GAGCT^C
+SacII	CCGC^GG
+SalI	G^TCGAC
+SapI	GCTCTTC(1/4)
+Sau3AI	^GATC
+ScaI	AGT^ACT
+SfiI	GGCCNNNN^NGGCC
+SmaI	CCC^GGG
+SpeI	A^CTAGT
+SphI	GCATG^C
+SspI	AAT^ATT
+StuI	AGG^CCT
+SwaI	ATTT^AAAT
+TaqI	T^CGA
+XbaI	T^CTAGA
+XhoI	C^TCGAG
+XmaI	C^CCGGG
+`
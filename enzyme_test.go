@@ -0,0 +1,106 @@
+package gts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var parseEnzymeTests = []struct {
+	in   string
+	site string
+	cut  int
+	cut2 int
+}{
+	{"G^AATTC", "GAATTC", 1, 5},
+	{"^GATC", "GATC", 0, 4},
+	{"GGCCNNNN^NGGCC", "GGCCNNNNNGGCC", 8, 5},
+	{"GGTCTC(1/5)", "GGTCTC", 7, 11},
+	{"GCTCTTC(1/4)", "GCTCTTC", 8, 11},
+}
+
+func TestParseEnzyme(t *testing.T) {
+	for _, tt := range parseEnzymeTests {
+		e, err := ParseEnzyme("enzyme", tt.in)
+		if err != nil {
+			t.Errorf("ParseEnzyme(%q): %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, e, Enzyme{"enzyme", []byte(tt.site), tt.cut, tt.cut2})
+	}
+
+	for _, in := range []string{"GAATTC", "G^AA^TTC", "^", "G^AAXTC", "GGTCTC(1/5", "GGTCTC(1)", "GGTCTC(a/5)"} {
+		if _, err := ParseEnzyme("enzyme", in); err == nil {
+			t.Errorf("expected error in ParseEnzyme(%q)", in)
+		}
+	}
+}
+
+func TestReadEnzymes(t *testing.T) {
+	enzymes, err := ReadEnzymes(strings.NewReader(RestrictionEnzymes))
+	if err != nil {
+		t.Fatalf("ReadEnzymes(RestrictionEnzymes): %v", err)
+	}
+	if len(enzymes) == 0 {
+		t.Fatal("ReadEnzymes(RestrictionEnzymes) returned no enzymes")
+	}
+
+	for _, in := range []string{"EcoRI\n", "EcoRI GAATTC\n", "EcoRI G^AATTC extra\n"} {
+		if _, err := ReadEnzymes(strings.NewReader(in)); err == nil {
+			t.Errorf("expected error in ReadEnzymes(%q)", in)
+		}
+	}
+}
+
+func TestEnzymeCuts(t *testing.T) {
+	ecori, _ := ParseEnzyme("EcoRI", "G^AATTC")
+	bsai, _ := ParseEnzyme("BsaI", "GGTCTC(1/5)")
+
+	testutils.Equals(t, ecori.Palindromic(), true)
+	testutils.Equals(t, bsai.Palindromic(), false)
+
+	seq := New(nil, nil, []byte("aagaattcaaggtctcaaaaaaaagagaccaa"))
+	testutils.Equals(t, ecori.Cuts(seq, false), []int{3})
+	testutils.Equals(t, bsai.Cuts(seq, false), []int{17, 19})
+
+	seq = New(nil, nil, []byte("attcaaaaaaga"))
+	testutils.Equals(t, ecori.Cuts(seq, false), []int{})
+	testutils.Equals(t, ecori.Cuts(seq, true), []int{11})
+
+	seq = New(nil, nil, []byte("ggtctcaa"))
+	testutils.Equals(t, bsai.Cuts(seq, false), []int{7})
+	testutils.Equals(t, bsai.Cuts(seq, true), []int{7})
+
+	seq = New(nil, nil, []byte("aaggtctc"))
+	testutils.Equals(t, bsai.Cuts(seq, false), []int{})
+	testutils.Equals(t, bsai.Cuts(seq, true), []int{1})
+
+	testutils.Equals(t, ecori.Cuts(New(nil, nil, nil), true), []int(nil))
+}
+
+func TestDigest(t *testing.T) {
+	seq := New(nil, nil, []byte("aaaccctttggg"))
+
+	fragments := func(ss []Sequence) []string {
+		ret := make([]string, len(ss))
+		for i, s := range ss {
+			ret[i] = string(s.Bytes())
+		}
+		return ret
+	}
+
+	testutils.Equals(t, fragments(Digest(seq, nil, false)), []string{"aaaccctttggg"})
+	testutils.Equals(t, fragments(Digest(seq, []int{3, 9}, false)), []string{"aaa", "cccttt", "ggg"})
+	testutils.Equals(t, fragments(Digest(seq, []int{3}, true)), []string{"ccctttgggaaa"})
+	testutils.Equals(t, fragments(Digest(seq, []int{9, 3, 9}, true)), []string{"cccttt", "gggaaa"})
+	testutils.Equals(t, fragments(Digest(seq, []int{0, 6}, true)), []string{"aaaccc", "tttggg"})
+}
+
+func TestDigestSegments(t *testing.T) {
+	testutils.Equals(t, DigestSegments(12, nil, false), []Segment{{0, 12}})
+	testutils.Equals(t, DigestSegments(12, []int{9, 3}, false), []Segment{{0, 3}, {3, 9}, {9, 12}})
+	testutils.Equals(t, DigestSegments(12, []int{3}, true), []Segment{{3, 15}})
+	testutils.Equals(t, DigestSegments(12, []int{9, 3, 9}, true), []Segment{{3, 9}, {9, 15}})
+	testutils.Equals(t, DigestSegments(12, []int{0, 6}, true), []Segment{{0, 6}, {6, 12}})
+}
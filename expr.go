@@ -0,0 +1,521 @@
+package gts
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ExprEnv represents the environment in which an Expr is evaluated.
+type ExprEnv struct {
+	Seq     Sequence
+	Feature Feature
+}
+
+// Expr represents a compiled expression which computes a value from an
+// ExprEnv. The resulting value is either nil, a bool, a float64, a string, or
+// a Location.
+type Expr func(env ExprEnv) interface{}
+
+type exprFunc struct {
+	nargs int
+	apply func(args []interface{}) interface{}
+}
+
+var exprFuncs = map[string]exprFunc{
+	"len": {1, func(args []interface{}) interface{} {
+		switch v := args[0].(type) {
+		case nil:
+			return 0.0
+		case Location:
+			return float64(v.Len())
+		default:
+			return float64(len(FormatExprValue(v)))
+		}
+	}},
+	"gc": {1, func(args []interface{}) interface{} {
+		s := FormatExprValue(args[0])
+		if len(s) == 0 {
+			return 0.0
+		}
+		n := 0
+		for _, c := range s {
+			switch c {
+			case 'g', 'c', 's', 'G', 'C', 'S':
+				n++
+			}
+		}
+		return float64(n) / float64(len(s))
+	}},
+	"upper": {1, func(args []interface{}) interface{} {
+		return strings.ToUpper(FormatExprValue(args[0]))
+	}},
+	"lower": {1, func(args []interface{}) interface{} {
+		return strings.ToLower(FormatExprValue(args[0]))
+	}},
+	"replace": {3, func(args []interface{}) interface{} {
+		re, err := compileRegexp(FormatExprValue(args[1]))
+		if err != nil {
+			return nil
+		}
+		return re.ReplaceAllString(FormatExprValue(args[0]), FormatExprValue(args[2]))
+	}},
+	"start": {1, func(args []interface{}) interface{} {
+		if loc, ok := args[0].(Location); ok {
+			r := loc.Region()
+			return float64(Min(r.Head(), r.Tail()) + 1)
+		}
+		return nil
+	}},
+	"end": {1, func(args []interface{}) interface{} {
+		if loc, ok := args[0].(Location); ok {
+			r := loc.Region()
+			return float64(Max(r.Head(), r.Tail()))
+		}
+		return nil
+	}},
+}
+
+var exprVars = map[string]Expr{
+	"seq": func(env ExprEnv) interface{} {
+		if env.Seq == nil {
+			return nil
+		}
+		return string(env.Seq.Bytes())
+	},
+	"key": func(env ExprEnv) interface{} {
+		if env.Feature.Key == "" {
+			return nil
+		}
+		return env.Feature.Key
+	},
+	"location": func(env ExprEnv) interface{} {
+		if env.Feature.Loc != nil {
+			return env.Feature.Loc
+		}
+		if env.Seq == nil {
+			return nil
+		}
+		return Range(0, Len(env.Seq))
+	},
+}
+
+func exprQualifier(name Expr) Expr {
+	return func(env ExprEnv) interface{} {
+		values := env.Feature.Props.Get(FormatExprValue(name(env)))
+		if len(values) == 0 {
+			return nil
+		}
+		return strings.Join(values, ",")
+	}
+}
+
+// FormatExprValue formats the value computed by an Expr as a string.
+func FormatExprValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// ExprTruthy tests if the value computed by an Expr is considered true. The
+// values nil, false, zero, NaN, and the empty string are considered false.
+func ExprTruthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+func exprNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case nil:
+		return 0, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case float64:
+		return v, true
+	case Location:
+		return float64(v.Len()), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+type exprToken struct {
+	kind byte // 'n'umber, 's'tring, 'i'dent, 'o'perator
+	text string
+	pos  int
+}
+
+var exprOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "!", "(", ")", ","}
+
+func tokenizeExpr(s string) ([]exprToken, error) {
+	tokens := []exprToken{}
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+
+		case c >= '0' && c <= '9' || c == '.':
+			j := i
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, exprToken{'n', s[i:j], i})
+			i = j
+
+		case c == '"' || c == '\'':
+			b := strings.Builder{}
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, fmt.Errorf("unterminated string literal at position %d", i)
+			}
+			tokens = append(tokens, exprToken{'s', b.String(), i})
+			i = j + 1
+
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(s) && (s[j] == '_' || unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			tokens = append(tokens, exprToken{'i', s[i:j], i})
+			i = j
+
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(s[i:], op) {
+					tokens = append(tokens, exprToken{'o', op, i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []exprToken
+	index  int
+}
+
+func (p *exprParser) peek(ops ...string) (string, bool) {
+	if p.index < len(p.tokens) && p.tokens[p.index].kind == 'o' {
+		text := p.tokens[p.index].text
+		for _, op := range ops {
+			if text == op {
+				return op, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expect(op string) error {
+	if _, ok := p.peek(op); !ok {
+		if p.index < len(p.tokens) {
+			token := p.tokens[p.index]
+			return fmt.Errorf("expected %q at position %d, got %q", op, token.pos, token.text)
+		}
+		return fmt.Errorf("expected %q at end of expression", op)
+	}
+	p.index++
+	return nil
+}
+
+func (p *exprParser) binary(next func() (Expr, error), ops []string, apply func(op string, lhs, rhs Expr) Expr) (Expr, error) {
+	lhs, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.peek(ops...)
+		if !ok {
+			return lhs, nil
+		}
+		p.index++
+		rhs, err := next()
+		if err != nil {
+			return nil, err
+		}
+		lhs = apply(op, lhs, rhs)
+	}
+}
+
+func (p *exprParser) or() (Expr, error) {
+	return p.binary(p.and, []string{"||"}, func(op string, lhs, rhs Expr) Expr {
+		return func(env ExprEnv) interface{} {
+			if v := lhs(env); ExprTruthy(v) {
+				return v
+			}
+			return rhs(env)
+		}
+	})
+}
+
+func (p *exprParser) and() (Expr, error) {
+	return p.binary(p.compare, []string{"&&"}, func(op string, lhs, rhs Expr) Expr {
+		return func(env ExprEnv) interface{} {
+			if v := lhs(env); !ExprTruthy(v) {
+				return v
+			}
+			return rhs(env)
+		}
+	})
+}
+
+func compareExprValues(a, b interface{}) int {
+	x, okx := exprNumber(a)
+	y, oky := exprNumber(b)
+	if okx && oky {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(FormatExprValue(a), FormatExprValue(b))
+}
+
+func (p *exprParser) compare() (Expr, error) {
+	ops := []string{"==", "!=", "<=", ">=", "<", ">"}
+	return p.binary(p.add, ops, func(op string, lhs, rhs Expr) Expr {
+		return func(env ExprEnv) interface{} {
+			c := compareExprValues(lhs(env), rhs(env))
+			switch op {
+			case "==":
+				return c == 0
+			case "!=":
+				return c != 0
+			case "<=":
+				return c <= 0
+			case ">=":
+				return c >= 0
+			case "<":
+				return c < 0
+			default:
+				return c > 0
+			}
+		}
+	})
+}
+
+func arithmeticExpr(op string, lhs, rhs Expr) Expr {
+	return func(env ExprEnv) interface{} {
+		a, b := lhs(env), rhs(env)
+		x, okx := exprNumber(a)
+		y, oky := exprNumber(b)
+		if !okx || !oky {
+			if op == "+" {
+				return FormatExprValue(a) + FormatExprValue(b)
+			}
+			return math.NaN()
+		}
+		switch op {
+		case "+":
+			return x + y
+		case "-":
+			return x - y
+		case "*":
+			return x * y
+		default:
+			return x / y
+		}
+	}
+}
+
+func (p *exprParser) add() (Expr, error) {
+	return p.binary(p.mul, []string{"+", "-"}, arithmeticExpr)
+}
+
+func (p *exprParser) mul() (Expr, error) {
+	return p.binary(p.unary, []string{"*", "/"}, arithmeticExpr)
+}
+
+func (p *exprParser) unary() (Expr, error) {
+	op, ok := p.peek("!", "-")
+	if !ok {
+		return p.primary()
+	}
+	p.index++
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if op == "!" {
+		return func(env ExprEnv) interface{} {
+			return !ExprTruthy(operand(env))
+		}, nil
+	}
+	return func(env ExprEnv) interface{} {
+		if x, ok := exprNumber(operand(env)); ok {
+			return -x
+		}
+		return math.NaN()
+	}, nil
+}
+
+func (p *exprParser) call(name string, pos int) (Expr, error) {
+	args := []Expr{}
+	if _, ok := p.peek(")"); !ok {
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.peek(","); !ok {
+				break
+			}
+			p.index++
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if name == "qualifier" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("function %q at position %d expects 1 argument, got %d", name, pos, len(args))
+		}
+		return exprQualifier(args[0]), nil
+	}
+
+	fn, ok := exprFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name, pos)
+	}
+	if len(args) != fn.nargs {
+		return nil, fmt.Errorf("function %q at position %d expects %d argument(s), got %d", name, pos, fn.nargs, len(args))
+	}
+
+	return func(env ExprEnv) interface{} {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = arg(env)
+		}
+		return fn.apply(values)
+	}, nil
+}
+
+func (p *exprParser) primary() (Expr, error) {
+	if p.index == len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	token := p.tokens[p.index]
+	p.index++
+
+	switch token.kind {
+	case 'n':
+		f, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q at position %d", token.text, token.pos)
+		}
+		return func(env ExprEnv) interface{} { return f }, nil
+
+	case 's':
+		s := token.text
+		return func(env ExprEnv) interface{} { return s }, nil
+
+	case 'i':
+		if _, ok := p.peek("("); ok {
+			p.index++
+			return p.call(token.text, token.pos)
+		}
+		switch token.text {
+		case "true", "false":
+			b := token.text == "true"
+			return func(env ExprEnv) interface{} { return b }, nil
+		}
+		if v, ok := exprVars[token.text]; ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("unknown identifier %q at position %d", token.text, token.pos)
+
+	default:
+		if token.text == "(" {
+			e, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return e, nil
+		}
+		return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+	}
+}
+
+// AsExpr compiles the given string as an Expr. An expression consists of
+// numbers, quoted strings, variables, function calls, and operators.
+//
+// The available variables are `seq` (the sequence), `key` (the feature key),
+// and `location` (the feature location). The available functions are
+// `len(x)`, `gc(x)`, `upper(x)`, `lower(x)`, `replace(x, regexp, repl)`,
+// `start(location)`, `end(location)`, and `qualifier(name)`. The operators in order of
+// increasing precedence are `||`, `&&`, comparisons (`==`, `!=`, `<`, `<=`,
+// `>`, `>=`), `+` and `-`, `*` and `/`, and the unary `!` and `-`. The `||`
+// and `&&` operators return the operand which determined the result so that
+// `qualifier("gene") || qualifier("locus_tag")` will yield the value of the
+// first qualifier present.
+func AsExpr(s string) (Expr, error) {
+	tokens, err := tokenizeExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens, 0}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.index != len(p.tokens) {
+		token := p.tokens[p.index]
+		return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+	}
+	return e, nil
+}
@@ -0,0 +1,104 @@
+package gts
+
+import "testing"
+
+var exprTestEnv = ExprEnv{
+	Seq: New(nil, nil, []byte("atgcgcatgtaa")),
+	Feature: Feature{
+		Key:   "CDS",
+		Loc:   Range(3, 9),
+		Props: Props{[]string{"locus_tag", "b0001"}, []string{"note", "foo", "bar"}},
+	},
+}
+
+var exprTests = []struct {
+	in  string
+	out string
+}{
+	{`1 + 2 * 3`, "7"},
+	{`(1 + 2) * 3`, "9"},
+	{`10 / 4 - -1`, "3.5"},
+	{`"foo" + 'bar'`, "foobar"},
+	{`len(seq)`, "12"},
+	{`len(location)`, "6"},
+	{`len("foo")`, "3"},
+	{`gc(seq)`, "0.4166666666666667"},
+	{`gc("")`, "0"},
+	{`upper(key)`, "CDS"},
+	{`lower("ABC")`, "abc"},
+	{`replace(qualifier("locus_tag"), "^b", "ECK_")`, "ECK_0001"},
+	{`replace("foo", "(", "")`, ""},
+	{`start(location)`, "4"},
+	{`end(location)`, "9"},
+	{`start(1)`, ""},
+	{`location`, "4..9"},
+	{`qualifier("gene") || qualifier("locus_tag")`, "b0001"},
+	{`qualifier("note")`, "foo,bar"},
+	{`qualifier("gene") && 1`, ""},
+	{`1 && "foo"`, "foo"},
+	{`len(seq) > 10`, "true"},
+	{`len(seq) <= 10`, "false"},
+	{`key == "CDS" && !false`, "true"},
+	{`key != "CDS"`, "false"},
+	{`"a" < "b"`, "true"},
+	{`"10" >= 9`, "true"},
+	{`"foo" * 2`, "NaN"},
+	{`-"foo"`, "NaN"},
+	{`true`, "true"},
+}
+
+func TestExpr(t *testing.T) {
+	for _, tt := range exprTests {
+		e, err := AsExpr(tt.in)
+		if err != nil {
+			t.Errorf("AsExpr(%q): %v", tt.in, err)
+			continue
+		}
+		out := FormatExprValue(e(exprTestEnv))
+		if out != tt.out {
+			t.Errorf("AsExpr(%q)(env) = %q, want %q", tt.in, out, tt.out)
+		}
+	}
+}
+
+func TestExprEmptyEnv(t *testing.T) {
+	for _, in := range []string{"seq", "key", "location", `qualifier("gene")`} {
+		e, err := AsExpr(in)
+		if err != nil {
+			t.Errorf("AsExpr(%q): %v", in, err)
+			continue
+		}
+		if v := e(ExprEnv{}); v != nil {
+			t.Errorf("AsExpr(%q)(ExprEnv{}) = %v, want nil", in, v)
+		}
+	}
+
+	e, _ := AsExpr("location")
+	env := ExprEnv{Seq: New(nil, nil, []byte("atgc"))}
+	if out := FormatExprValue(e(env)); out != "1..4" {
+		t.Errorf("AsExpr(%q)(env) = %q, want %q", "location", out, "1..4")
+	}
+}
+
+var exprFailTests = []string{
+	``,
+	`1 +`,
+	`(1`,
+	`1 2`,
+	`"foo`,
+	`1 $ 2`,
+	`foo`,
+	`foo(1)`,
+	`len(1, 2)`,
+	`qualifier()`,
+	`1..2`,
+	`)`,
+}
+
+func TestExprFail(t *testing.T) {
+	for _, in := range exprFailTests {
+		if _, err := AsExpr(in); err == nil {
+			t.Errorf("expected error in AsExpr(%q)", in)
+		}
+	}
+}
@@ -2,9 +2,8 @@ package gts
 
 import (
 	"fmt"
-	"regexp"
+	"hash/fnv"
 	"sort"
-	"strings"
 )
 
 type Feature struct {
@@ -17,6 +16,34 @@ func NewFeature(key string, loc Location, props Props) Feature {
 	return Feature{key, loc, props}
 }
 
+// mapLocation returns the feature with the function applied to its location
+// and to the locations contained in its LocationQualifiers. A qualifier value
+// which cannot be interpreted is left untouched.
+func (f Feature) mapLocation(fn func(Location) Location) Feature {
+	f.Loc = fn(f.Loc)
+	cloned := false
+	for name, mapper := range LocationQualifiers {
+		values := f.Props.Get(name)
+		if len(values) == 0 {
+			continue
+		}
+		if !cloned {
+			f.Props = f.Props.Clone()
+			cloned = true
+		}
+		mapped := make([]string, len(values))
+		for i, value := range values {
+			if v, err := mapper(value, fn); err == nil {
+				mapped[i] = v
+			} else {
+				mapped[i] = value
+			}
+		}
+		f.Props.Set(name, mapped...)
+	}
+	return f
+}
+
 // Repair attempts to reconstruct features by joining features with identical
 // feature keys and values which have adjacent locations.
 func Repair(ff []Feature) []Feature {
@@ -147,88 +174,16 @@ func Key(key string) Filter {
 
 // Qualifier tests if any of the values associated with the given qualifier
 // name matches the given regular expression query. If the qualifier name is
-// empty, the values for every qualifier name will be tested.
+// empty, the names and values of every qualifier will be tested.
 func Qualifier(name, query string) (Filter, error) {
-	re, err := regexp.Compile(query)
+	if name != "" && query == "" {
+		return newSelectorTerm(name, nil).Match, nil
+	}
+	re, err := compileRegexp(query)
 	if err != nil {
 		return FalseFilter, err
 	}
-
-	if name == "" {
-		return func(f Feature) bool {
-			for _, vv := range f.Props {
-				for _, v := range vv {
-					if re.MatchString(v) {
-						return true
-					}
-				}
-			}
-			return false
-		}, nil
-	}
-
-	if query == "" {
-		return func(f Feature) bool {
-			return f.Props.Has(name)
-		}, nil
-	}
-
-	return func(f Feature) bool {
-		if vv := f.Props.Get(name); vv != nil {
-			for _, v := range vv {
-				if re.MatchString(v) {
-					return true
-				}
-			}
-		}
-		return false
-	}, nil
-}
-
-func shiftSelector(s string) (string, string) {
-	esc := false
-	for i := 0; i < len(s); i++ {
-		switch s[i] {
-		case '\\':
-			esc = true
-		case '/':
-			if !esc {
-				return s[:i], s[i+1:]
-			}
-		default:
-			esc = false
-		}
-	}
-	return s, ""
-}
-
-func toQualifier(s string) (Filter, error) {
-	switch i := strings.IndexByte(s, '='); i {
-	case -1:
-		return Qualifier(s, "")
-	default:
-		return Qualifier(s[:i], s[i+1:])
-	}
-}
-
-// Selector generates a new Filter which will return true if a given Feature
-// satisfies the criteria specified by the selection string. A selector in GTS
-// is defined as follows:
-//   [feature_key]/qualifier_name=regexp[/qualifier_name=regexp]...
-// If the qualifier name is omitted, the values for every qualifier name will
-// be tested.
-func Selector(sel string) (Filter, error) {
-	head, tail := shiftSelector(sel)
-	filter := Key(head)
-	for tail != "" {
-		head, tail = shiftSelector(tail)
-		props, err := toQualifier(head)
-		if err != nil {
-			return FalseFilter, err
-		}
-		filter = And(filter, props)
-	}
-	return filter, nil
+	return newSelectorTerm(name, re).Match, nil
 }
 
 // ForwardStrand returns true if the feature strictly resides on the forward
@@ -267,17 +222,68 @@ func (ff FeatureSlice) Len() int {
 	return len(ff)
 }
 
-// Less reports whether the element with index i should sort before the element
-// with index j.
-func (ff FeatureSlice) Less(i, j int) bool {
-	f, g := ff[i], ff[j]
+// propsHash returns a hash of the qualifiers which does not depend on the
+// order of the qualifier names.
+func propsHash(props Props) uint64 {
+	h := fnv.New64a()
+	for _, item := range props.Sorted() {
+		for _, s := range item {
+			h.Write([]byte(s))
+			h.Write([]byte{0})
+		}
+		h.Write([]byte{1})
+	}
+	return h.Sum64()
+}
+
+// featureKeyOrder lists the feature keys in the order they appear in when
+// the features are at identical locations, following the usual layout of a
+// feature table where a gene is followed by its transcripts and coding
+// sequence. Keys not listed come after these in lexicographical order.
+var featureKeyOrder = []string{
+	"gene", "mRNA", "ncRNA", "rRNA", "tRNA", "tmRNA", "misc_RNA",
+	"precursor_RNA", "prim_transcript", "CDS",
+}
+
+func featureKeyRank(key string) int {
+	for i, k := range featureKeyOrder {
+		if k == key {
+			return i
+		}
+	}
+	return len(featureKeyOrder)
+}
+
+// featureLess reports whether the feature f should sort before the feature g.
+// Source features come first, followed by the other features ordered by
+// location. Features at identical locations are ordered by key as given in
+// featureKeyOrder.
+func featureLess(f, g Feature) bool {
 	if f.Key == "source" && g.Key != "source" {
 		return true
 	}
 	if f.Key != "source" && g.Key == "source" {
 		return false
 	}
-	return LocationLess(f.Loc, g.Loc)
+	if LocationLess(f.Loc, g.Loc) {
+		return true
+	}
+	if LocationLess(g.Loc, f.Loc) {
+		return false
+	}
+	if f.Key == g.Key {
+		return false
+	}
+	if a, b := featureKeyRank(f.Key), featureKeyRank(g.Key); a != b {
+		return a < b
+	}
+	return f.Key < g.Key
+}
+
+// Less reports whether the element with index i should sort before the element
+// with index j.
+func (ff FeatureSlice) Less(i, j int) bool {
+	return featureLess(ff[i], ff[j])
 }
 
 // Swap the elements with indexes i and j.
@@ -285,17 +291,69 @@ func (ff FeatureSlice) Swap(i, j int) {
 	ff[i], ff[j] = ff[j], ff[i]
 }
 
+// hashedFeatures sorts features as in FeatureSlice.Less, breaking the
+// remaining ties with the precomputed hashes of the qualifiers.
+type hashedFeatures struct {
+	ff     FeatureSlice
+	hashes []uint64
+}
+
+func (hf hashedFeatures) Len() int {
+	return len(hf.ff)
+}
+
+func (hf hashedFeatures) Less(i, j int) bool {
+	if featureLess(hf.ff[i], hf.ff[j]) {
+		return true
+	}
+	if featureLess(hf.ff[j], hf.ff[i]) {
+		return false
+	}
+	return hf.hashes[i] < hf.hashes[j]
+}
+
+func (hf hashedFeatures) Swap(i, j int) {
+	hf.ff[i], hf.ff[j] = hf.ff[j], hf.ff[i]
+	hf.hashes[i], hf.hashes[j] = hf.hashes[j], hf.hashes[i]
+}
+
+// SortFeatures sorts the features in place as in FeatureSlice.Less. Features
+// at identical locations with the same key are ordered by the hash of their
+// qualifiers, so that the order does not depend on the order in which the
+// features were given.
+func SortFeatures(ff FeatureSlice) {
+	hashes := make([]uint64, len(ff))
+	for i, f := range ff {
+		hashes[i] = propsHash(f.Props)
+	}
+	sort.Stable(hashedFeatures{ff, hashes})
+}
+
 // Insert takes the given Feature and inserts it into the sorted position in
-// the FeatureSlice.
+// the FeatureSlice. Features at identical locations are ordered as in
+// SortFeatures.
 func (ff FeatureSlice) Insert(f Feature) FeatureSlice {
 	i := 0
 	for i < len(ff) && ff[i].Key == "source" {
 		i++
 	}
 	if f.Key != "source" {
-		i += sort.Search(len(ff[i:]), func(j int) bool {
-			return LocationLess(f.Loc, ff[i+j].Loc)
+		lo := i + sort.Search(len(ff[i:]), func(j int) bool {
+			return !featureLess(ff[i+j], f)
+		})
+		hi := lo + sort.Search(len(ff[lo:]), func(j int) bool {
+			return featureLess(f, ff[lo+j])
 		})
+
+		// The features tied with the given feature are sorted by the hash of
+		// their qualifiers, so only a logarithmic number of them are hashed.
+		i = hi
+		if lo < hi {
+			h := propsHash(f.Props)
+			i = lo + sort.Search(hi-lo, func(j int) bool {
+				return h < propsHash(ff[lo+j].Props)
+			})
+		}
 	}
 
 	ff = append(ff, Feature{})
@@ -2,7 +2,9 @@ package gts
 
 import (
 	"bytes"
+	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -196,6 +198,53 @@ func TestFeatureSliceSort(t *testing.T) {
 	}
 }
 
+func TestFeatureSliceTieBreak(t *testing.T) {
+	ff := FeatureSlice{
+		NewFeature("gene", Range(0, 9), Props{[]string{"gene", "abc"}}),
+		NewFeature("CDS", Range(0, 9), Props{[]string{"gene", "abc"}}),
+		NewFeature("CDS", Range(0, 9), Props{[]string{"gene", "def"}}),
+		NewFeature("CDS", Range(0, 9), Props{[]string{"gene", "abc"}, []string{"note", "foo"}}),
+	}
+
+	exp := make(FeatureSlice, len(ff))
+	copy(exp, ff)
+	SortFeatures(exp)
+
+	rev := make(FeatureSlice, len(ff))
+	for i, f := range ff {
+		rev[len(ff)-i-1] = f
+	}
+	SortFeatures(rev)
+	testutils.Equals(t, rev, exp)
+
+	testutils.Equals(t, exp[0].Key, "gene")
+	if !sort.IsSorted(exp) {
+		t.Errorf("SortFeatures result is not sorted: %v", exp)
+	}
+
+	for _, in := range []FeatureSlice{ff, rev} {
+		out := FeatureSlice{}
+		for _, f := range in {
+			out = out.Insert(f)
+		}
+		testutils.Equals(t, out, exp)
+	}
+
+	many := make(FeatureSlice, 64)
+	for i := range many {
+		many[i] = NewFeature("CDS", Range(0, 9), Props{[]string{"note", strconv.Itoa(i)}})
+	}
+	out := FeatureSlice{}
+	for _, i := range rand.New(rand.NewSource(1)).Perm(len(many)) {
+		out = out.Insert(many[i])
+	}
+	SortFeatures(many)
+	testutils.Equals(t, out, many)
+
+	swapped := NewFeature("CDS", Range(0, 9), Props{[]string{"note", "foo"}, []string{"gene", "abc"}})
+	testutils.Equals(t, propsHash(swapped.Props), propsHash(ff[3].Props))
+}
+
 func TestFeatureInsert(t *testing.T) {
 	ff := FeatureSlice{}
 	ff = ff.Insert(sampleCDSFeature)
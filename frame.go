@@ -0,0 +1,174 @@
+package gts
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Frame represents one of the six reading frames of a nucleotide sequence.
+// Frames +1, +2, and +3 read the forward strand starting at the first, second,
+// and third bases, and frames -1, -2, and -3 read the reverse strand starting
+// at the last, second to last, and third to last bases.
+type Frame int
+
+// Frames lists the six reading frames in the conventional order.
+var Frames = []Frame{1, 2, 3, -1, -2, -3}
+
+// String satisfies the fmt.Stringer interface.
+func (frame Frame) String() string {
+	return fmt.Sprintf("%+d", int(frame))
+}
+
+// FrameTranslation represents a translation of a sequence in a reading
+// frame. Start and End are the zero-based, half-open boundaries of the
+// translated codons on the forward strand.
+type FrameTranslation struct {
+	Frame   Frame
+	Start   int
+	End     int
+	Protein []byte
+}
+
+// Location returns the location of the translated codons, which is
+// complemented for the frames on the reverse strand.
+func (t FrameTranslation) Location() Location {
+	loc := Location(Range(t.Start, t.End))
+	if t.Frame < 0 {
+		loc = loc.Complement()
+	}
+	return loc
+}
+
+// Split the translation at the stop codons, returning the stretches of
+// residues between the stops which are at least min residues long.
+func (t FrameTranslation) Split(min int) []FrameTranslation {
+	ret := []FrameTranslation{}
+	i := 0
+	for _, q := range bytes.Split(t.Protein, []byte{'*'}) {
+		j := i + len(q)
+		if len(q) > 0 && len(q) >= min {
+			start, end := t.Start+3*i, t.Start+3*j
+			if t.Frame < 0 {
+				start, end = t.End-3*j, t.End-3*i
+			}
+			ret = append(ret, FrameTranslation{t.Frame, start, end, q})
+		}
+		i = j + 1
+	}
+	return ret
+}
+
+// TranslateFrames translates the given nucleotide sequence in each of the
+// six reading frames in the order of Frames. Trailing bases which do not form
+// a complete codon are ignored, and frames without a complete codon are
+// omitted.
+func TranslateFrames(seq Sequence, table CodonTable) []FrameTranslation {
+	n := Len(seq)
+	forward := seq.Bytes()
+	reverse := Reverse(Complement(seq)).Bytes()
+
+	ret := []FrameTranslation{}
+	for _, frame := range Frames {
+		offset := Abs(int(frame)) - 1
+		codons := (n - offset) / 3
+		if codons <= 0 {
+			continue
+		}
+
+		p, start, end := forward, offset, offset+3*codons
+		if frame < 0 {
+			p, start, end = reverse, n-offset-3*codons, n-offset
+		}
+
+		q := table.Translate(p[offset : offset+3*codons])
+		ret = append(ret, FrameTranslation{frame, start, end, q})
+	}
+	return ret
+}
+
+// FrameMap represents the start codons, stop codons, and open reading frames
+// found in a reading frame. The start and stop codons are given as the
+// zero-based offsets of the codons on the forward strand, in the order they
+// are read in the frame.
+type FrameMap struct {
+	Frame  Frame
+	Starts []int
+	Stops  []int
+	ORFs   []FrameTranslation
+}
+
+// MapFrames finds the start codons, stop codons, and open reading frames of
+// the given nucleotide sequence in each of the six reading frames in the
+// order of Frames. An open reading frame spans from the first start codon
+// following a stop codon up to and including the next stop codon, and is
+// only reported if it encodes at least min residues. An open reading frame
+// without a stop codon extends to the last complete codon of the frame.
+func MapFrames(seq Sequence, table CodonTable, min int) []FrameMap {
+	n := Len(seq)
+	forward := seq.Bytes()
+	reverse := Reverse(Complement(seq)).Bytes()
+
+	ret := []FrameMap{}
+	for _, frame := range Frames {
+		offset := Abs(int(frame)) - 1
+		codons := (n - offset) / 3
+		if codons <= 0 {
+			continue
+		}
+
+		p := forward
+		if frame < 0 {
+			p = reverse
+		}
+
+		// position returns the forward strand offset of the i-th codon.
+		position := func(i int) int {
+			if frame < 0 {
+				return n - offset - 3*i - 3
+			}
+			return offset + 3*i
+		}
+
+		m := FrameMap{frame, []int{}, []int{}, []FrameTranslation{}}
+
+		// orf adds the open reading frame from the i-th to the j-th codon.
+		orf := func(i, j int) {
+			q := table.Translate(p[offset+3*i : offset+3*j])
+			if n := len(q); n > 0 && q[n-1] == '*' {
+				q = q[:n-1]
+			}
+			if len(q) < min {
+				return
+			}
+			start, end := position(i), position(j-1)+3
+			if frame < 0 {
+				start, end = position(j-1), position(i)+3
+			}
+			m.ORFs = append(m.ORFs, FrameTranslation{frame, start, end, q})
+		}
+
+		open := -1
+		for i := 0; i < codons; i++ {
+			codon := p[offset+3*i : offset+3*i+3]
+			switch {
+			case table.IsStop(codon):
+				m.Stops = append(m.Stops, position(i))
+				if open >= 0 {
+					orf(open, i+1)
+				}
+				open = -1
+			case table.IsStart(codon):
+				m.Starts = append(m.Starts, position(i))
+				if open < 0 {
+					open = i
+				}
+			}
+		}
+		if open >= 0 {
+			orf(open, codons)
+		}
+
+		ret = append(ret, m)
+	}
+	return ret
+}
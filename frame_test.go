@@ -0,0 +1,84 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestFrame(t *testing.T) {
+	names := []string{"+1", "+2", "+3", "-1", "-2", "-3"}
+	for i, frame := range Frames {
+		testutils.Equals(t, frame.String(), names[i])
+	}
+}
+
+func TestTranslateFrames(t *testing.T) {
+	table, _ := LookupCodonTable(1)
+	seq := New(nil, nil, []byte("atgaaataggcc"))
+
+	testutils.Equals(t, TranslateFrames(seq, table), []FrameTranslation{
+		{1, 0, 12, []byte("MK*A")},
+		{2, 1, 10, []byte("*NR")},
+		{3, 2, 11, []byte("EIG")},
+		{-1, 0, 12, []byte("GLFH")},
+		{-2, 2, 11, []byte("AYF")},
+		{-3, 1, 10, []byte("PIS")},
+	})
+
+	testutils.Equals(t, len(TranslateFrames(New(nil, nil, []byte("atg")), table)), 2)
+	testutils.Equals(t, len(TranslateFrames(New(nil, nil, nil), table)), 0)
+}
+
+func TestFrameTranslationSplit(t *testing.T) {
+	forward := FrameTranslation{1, 0, 12, []byte("MK*A")}
+	testutils.Equals(t, forward.Split(1), []FrameTranslation{
+		{1, 0, 6, []byte("MK")},
+		{1, 9, 12, []byte("A")},
+	})
+	testutils.Equals(t, forward.Split(2), []FrameTranslation{
+		{1, 0, 6, []byte("MK")},
+	})
+
+	leading := FrameTranslation{2, 1, 10, []byte("*NR")}
+	testutils.Equals(t, leading.Split(0), []FrameTranslation{
+		{2, 4, 10, []byte("NR")},
+	})
+
+	reverse := FrameTranslation{-1, 0, 12, []byte("GL*H")}
+	testutils.Equals(t, reverse.Split(1), []FrameTranslation{
+		{-1, 6, 12, []byte("GL")},
+		{-1, 0, 3, []byte("H")},
+	})
+	testutils.Equals(t, reverse.Location().String(), "complement(1..12)")
+	testutils.Equals(t, forward.Location().String(), "1..12")
+}
+
+func TestMapFrames(t *testing.T) {
+	table, _ := LookupCodonTable(1)
+	seq := New(nil, nil, []byte("atgaaataggcc"))
+
+	testutils.Equals(t, MapFrames(seq, table, 1), []FrameMap{
+		{1, []int{0}, []int{6}, []FrameTranslation{{1, 0, 9, []byte("MK")}}},
+		{2, []int{}, []int{1}, []FrameTranslation{}},
+		{3, []int{}, []int{}, []FrameTranslation{}},
+		{-1, []int{}, []int{}, []FrameTranslation{}},
+		{-2, []int{}, []int{}, []FrameTranslation{}},
+		{-3, []int{}, []int{}, []FrameTranslation{}},
+	})
+
+	testutils.Equals(t, MapFrames(seq, table, 3)[0].ORFs, []FrameTranslation{})
+
+	// An ORF on the reverse strand, and an unterminated ORF.
+	seq = New(nil, nil, []byte("ggcctatttcatcat"))
+	maps := MapFrames(seq, table, 1)
+	testutils.Equals(t, maps[3], FrameMap{-1, []int{12, 9}, []int{3}, []FrameTranslation{
+		{-1, 3, 15, []byte("MMK")},
+	}})
+	testutils.Equals(t, maps[3].ORFs[0].Location().String(), "complement(4..15)")
+
+	seq = New(nil, nil, []byte("ccatgatgaaa"))
+	testutils.Equals(t, MapFrames(seq, table, 1)[2].ORFs, []FrameTranslation{
+		{3, 2, 11, []byte("MMK")},
+	})
+}
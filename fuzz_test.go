@@ -0,0 +1,91 @@
+//go:build go1.18
+// +build go1.18
+
+package gts
+
+import (
+	"testing"
+)
+
+func fuzzSequence() Sequence {
+	ff := []Feature{
+		NewFeature("source", Range(0, 40), Props{{"organism", "Escherichia coli"}}),
+		NewFeature("gene", Range(3, 21), Props{{"gene", "foo"}}),
+		NewFeature("CDS", Join(Range(3, 9), Range(12, 21)), Props{{"gene", "foo"}, {"product", "Foo"}}),
+		NewFeature("misc_feature", Range(30, 35).Complement(), nil),
+	}
+	return New(nil, ff, []byte("atgcatgcatgcatgcatgcatgcatgcatgcatgcatgc"))
+}
+
+func FuzzAsLocation(f *testing.F) {
+	for _, s := range []string{
+		"1", "1^2", "1..2", "<1..>2", "1.2",
+		"complement(1..2)", "join(1..2,4..5)", "order(1..2,4..5)",
+		"complement(join(1..10,complement(21..30)))",
+	} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		loc, err := AsLocation(s)
+		if err != nil {
+			return
+		}
+
+		n := loc.Len()
+		_ = loc.String()
+		_ = loc.Region()
+		_ = loc.Complement()
+		_ = CheckStrand(loc)
+		for _, length := range []int{n, n + 1, 2 * n} {
+			_ = loc.Reverse(length)
+			if length > 0 {
+				_ = loc.Normalize(length)
+			}
+		}
+		_ = loc.Shift(0, 1)
+		_ = loc.Expand(0, 1)
+
+		if _, err := AsLocation(loc.String()); err != nil {
+			t.Errorf("AsLocation(%q).String() = %q: failed to parse: %v", s, loc.String(), err)
+		}
+	})
+}
+
+func FuzzSelector(f *testing.F) {
+	for _, s := range []string{
+		"CDS", "CDS/gene=foo", "/product=^Foo$", "-source", "gene|CDS/gene",
+	} {
+		f.Add(s)
+	}
+
+	seq := fuzzSequence()
+
+	f.Fuzz(func(t *testing.T, s string) {
+		filter, err := Selector(s)
+		if err != nil {
+			return
+		}
+		_ = seq.Features().Filter(filter)
+	})
+}
+
+func FuzzAsLocator(f *testing.F) {
+	for _, s := range []string{
+		"^..$", "@^..$", "CDS", "CDS@^-3..^", "1..10", "CDS@.1..$-2", "@^-20..$+20",
+	} {
+		f.Add(s)
+	}
+
+	seq := fuzzSequence()
+
+	f.Fuzz(func(t *testing.T, s string) {
+		locate, err := AsLocator(s)
+		if err != nil {
+			return
+		}
+		for _, r := range locate(seq) {
+			_ = r.Locate(seq)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package gts
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Gap disruption types.
+const (
+	GapDisrupted = "disrupted"
+	GapPartial   = "partial"
+	GapWithin    = "within"
+)
+
+// IsGap reports whether the feature represents a gap in the sequence, which
+// is an assembly_gap or a gap feature.
+func IsGap(f Feature) bool {
+	return f.Key == "assembly_gap" || f.Key == "gap"
+}
+
+// Gaps returns the segments of the sequence covered by gap features, sorted
+// in ascending order.
+func Gaps(seq Sequence) []Segment {
+	rr := Regions{}
+	for _, f := range seq.Features() {
+		if IsGap(f) {
+			rr = append(rr, f.Loc.Region())
+		}
+	}
+	return Minimize(rr)
+}
+
+// GapDisruption returns how the location is interrupted by the given gap.
+// The location is disrupted if the gap lies within a segment of the location,
+// partial if one of the ends of the location lies within the gap, and within
+// if the whole location lies within the gap. An empty string is returned if
+// the location does not overlap with the gap, as is the case for a gap lying
+// between two segments of a location.
+func GapDisruption(loc Location, gap Segment) string {
+	ss := Minimize(loc.Region())
+	if len(ss) == 0 {
+		return ""
+	}
+
+	overlap := false
+	for _, s := range ss {
+		if s[0] < gap[1] && gap[0] < s[1] {
+			overlap = true
+		}
+	}
+	if !overlap {
+		return ""
+	}
+
+	start, end := ss[0][0], ss[len(ss)-1][1]
+	head := gap[0] <= start && start < gap[1]
+	tail := gap[0] < end && end <= gap[1]
+	switch {
+	case head && tail:
+		return GapWithin
+	case head || tail:
+		return GapPartial
+	default:
+		return GapDisrupted
+	}
+}
+
+// ResizeGap resizes the gap at the given segment of the sequence to n bases,
+// which are filled with `n`. The features are projected onto the resized gap
+// as follows: features spanning the gap are lengthened or shortened by the
+// difference in length, features with an end within the gap are clipped to
+// the boundary of the gap and marked as partial, and features lying within
+// the gap are removed. The gap features at the segment are resized to n
+// bases, and their /estimated_length qualifiers are updated accordingly. If
+// n is zero, the gap features are removed as well.
+func ResizeGap(seq Sequence, gap Segment, n int) Sequence {
+	gapFeatures := []Feature{}
+	ff := FeatureSlice{}
+	for _, f := range seq.Features() {
+		switch {
+		case IsGap(f) && LocationWithin(f.Loc, gap[0], gap[1]):
+			gapFeatures = append(gapFeatures, f)
+		case f.Key != "source" && GapDisruption(f.Loc, gap) == GapWithin:
+		default:
+			ff = append(ff, f)
+		}
+	}
+	seq = WithFeatures(seq, ff)
+
+	seq = Delete(seq, gap[0], gap[1]-gap[0])
+	if n > 0 {
+		seq = Embed(seq, gap[0], New(nil, nil, bytes.Repeat([]byte("n"), n)))
+	}
+
+	if n == 0 {
+		return seq
+	}
+
+	ff = seq.Features()
+	for _, f := range gapFeatures {
+		props := f.Props.Clone()
+		if values := props.Get("estimated_length"); len(values) > 0 {
+			if _, err := strconv.Atoi(values[0]); err == nil {
+				props.Set("estimated_length", strconv.Itoa(n))
+			}
+		}
+		ff = ff.Insert(NewFeature(f.Key, Range(gap[0], gap[0]+n), props))
+	}
+	return WithFeatures(seq, ff)
+}
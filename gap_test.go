@@ -0,0 +1,69 @@
+package gts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var gapDisruptionTests = []struct {
+	loc Location
+	out string
+}{
+	{Range(0, 10), ""},
+	{Range(0, 20), GapPartial},
+	{Range(15, 25), GapPartial},
+	{Range(12, 18), GapWithin},
+	{Range(0, 30), GapDisrupted},
+	{Range(0, 30).Complement(), GapDisrupted},
+	{Join(Range(0, 10), Range(20, 30)), ""},
+	{Join(Range(0, 12), Range(20, 30)), GapDisrupted},
+}
+
+func TestGapDisruption(t *testing.T) {
+	for _, tt := range gapDisruptionTests {
+		testutils.Equals(t, GapDisruption(tt.loc, Segment{10, 20}), tt.out)
+	}
+}
+
+func TestResizeGap(t *testing.T) {
+	p := []byte(strings.Repeat("a", 10) + strings.Repeat("n", 10) + strings.Repeat("a", 10))
+	ff := []Feature{
+		NewFeature("source", Range(0, 30), Props{}),
+		NewFeature("assembly_gap", Range(10, 20), Props{[]string{"estimated_length", "10"}}),
+		NewFeature("gene", Range(0, 30), Props{}),
+		NewFeature("gene", Range(5, 15), Props{}),
+		NewFeature("gene", Range(15, 25), Props{}),
+		NewFeature("gene", Range(12, 18), Props{}),
+		NewFeature("gene", Range(20, 25), Props{}),
+	}
+	seq := New(nil, ff, p)
+	testutils.Equals(t, Gaps(seq), []Segment{{10, 20}})
+
+	out := ResizeGap(seq, Segment{10, 20}, 4)
+	testutils.Equals(t, string(out.Bytes()), strings.Repeat("a", 10)+"nnnn"+strings.Repeat("a", 10))
+	testutils.Equals(t, out.Features(), FeatureSlice{
+		NewFeature("source", Range(0, 24), Props{}),
+		NewFeature("gene", Range(0, 24), Props{}),
+		NewFeature("gene", PartialRange(5, 10, Partial3), Props{}),
+		NewFeature("assembly_gap", Range(10, 14), Props{[]string{"estimated_length", "4"}}),
+		NewFeature("gene", Range(14, 19), Props{}),
+		NewFeature("gene", PartialRange(14, 19, Partial5), Props{}),
+	})
+
+	out = ResizeGap(seq, Segment{10, 20}, 15)
+	testutils.Equals(t, Len(out), 35)
+	testutils.Equals(t, out.Features(), FeatureSlice{
+		NewFeature("source", Range(0, 35), Props{}),
+		NewFeature("gene", Range(0, 35), Props{}),
+		NewFeature("gene", PartialRange(5, 10, Partial3), Props{}),
+		NewFeature("assembly_gap", Range(10, 25), Props{[]string{"estimated_length", "15"}}),
+		NewFeature("gene", Range(25, 30), Props{}),
+		NewFeature("gene", PartialRange(25, 30, Partial5), Props{}),
+	})
+
+	out = ResizeGap(seq, Segment{10, 20}, 0)
+	testutils.Equals(t, Len(out), 20)
+	testutils.Equals(t, len(out.Features()), 5)
+}
@@ -1,6 +1,6 @@
 module github.com/go-gts/gts
 
-go 1.15
+go 1.22
 
 require (
 	github.com/dustin/go-humanize v1.0.0
@@ -10,6 +10,8 @@ require (
 	github.com/go-pars/pars v1.1.6
 	github.com/go-test/deep v1.0.7
 	github.com/go-wrap/wrap v1.0.3
+	github.com/klauspost/compress v1.18.0
 	github.com/mattn/go-isatty v0.0.12
-	golang.org/x/sys v0.0.0-20210514084401-e8d321eab015 // indirect
+	golang.org/x/sys v0.0.0-20210514084401-e8d321eab015
+	gopkg.in/yaml.v2 v2.4.0
 )
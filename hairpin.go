@@ -0,0 +1,125 @@
+package gts
+
+import (
+	"math"
+	"sort"
+)
+
+// nearestNeighborDeltaG lists the free energy in kcal/mol at 37°C of each
+// Watson-Crick nearest-neighbor pair indexed by the 5' to 3' dinucleotide of
+// the top strand, using the unified parameters of SantaLucia (1998).
+var nearestNeighborDeltaG = map[string]float64{
+	"aa": -1.00, "tt": -1.00,
+	"at": -0.88,
+	"ta": -0.58,
+	"ca": -1.45, "tg": -1.45,
+	"gt": -1.44, "ac": -1.44,
+	"ct": -1.28, "ag": -1.28,
+	"ga": -1.30, "tc": -1.30,
+	"cg": -2.17,
+	"gc": -2.24,
+	"gg": -1.84, "cc": -1.84,
+}
+
+// hairpinLoopDeltaG lists the free energy in kcal/mol at 37°C for the
+// initiation of a hairpin loop of the given length as reported by SantaLucia
+// and Hicks (2004).
+var hairpinLoopDeltaG = map[int]float64{
+	3: 3.5, 4: 3.5, 5: 3.3, 6: 4.0, 7: 4.2, 8: 4.3, 9: 4.5, 10: 4.6,
+	12: 5.0, 14: 5.1, 16: 5.3, 18: 5.5, 20: 5.7, 25: 6.1, 30: 6.3,
+}
+
+// StemDeltaG returns the free energy in kcal/mol at 37°C of the duplex formed
+// by the given sequence and its reverse complement as the sum of the
+// nearest-neighbor parameters. Dinucleotides containing bases other than A,
+// C, G, or T contribute no energy.
+func StemDeltaG(p []byte) float64 {
+	dg := 0.0
+	for i := 0; i+1 < len(p); i++ {
+		dg += nearestNeighborDeltaG[string([]byte{toLower(p[i]), toLower(p[i+1])})]
+	}
+	return dg
+}
+
+// LoopDeltaG returns the free energy in kcal/mol at 37°C for the initiation
+// of a hairpin loop of the given length. Lengths missing from the reference
+// table are interpolated, and lengths beyond 30 are extrapolated with the
+// Jacobson-Stockmayer formula.
+func LoopDeltaG(n int) float64 {
+	if dg, ok := hairpinLoopDeltaG[n]; ok {
+		return dg
+	}
+	if n < 3 {
+		return math.Inf(1)
+	}
+	if n > 30 {
+		return hairpinLoopDeltaG[30] + 2.44*1.9872e-3*310.15*math.Log(float64(n)/30)
+	}
+	lower, upper := n-1, n+1
+	for _, ok := hairpinLoopDeltaG[lower]; !ok; _, ok = hairpinLoopDeltaG[lower] {
+		lower--
+	}
+	for _, ok := hairpinLoopDeltaG[upper]; !ok; _, ok = hairpinLoopDeltaG[upper] {
+		upper++
+	}
+	a, b := hairpinLoopDeltaG[lower], hairpinLoopDeltaG[upper]
+	return a + (b-a)*float64(n-lower)/float64(upper-lower)
+}
+
+// Hairpin represents a foldback structure formed by an inverted repeat. The
+// hairpin spans the range [Start, End) with stems of length Stem on both
+// ends and a loop of length Loop in between.
+type Hairpin struct {
+	Start, End int
+	Stem, Loop int
+	DeltaG     float64
+}
+
+func isComplementary(a, b byte) bool {
+	x, y := nucleotideMasks[a], nucleotideMasks[b]
+	switch x | y {
+	case 0x9, 0x6:
+		return x != 0 && y != 0 && x&(x-1) == 0 && y&(y-1) == 0
+	default:
+		return false
+	}
+}
+
+// FindHairpins searches for hairpins with a perfectly complementary stem of
+// at least minStem bases and a loop of minLoop to maxLoop bases. Only stems
+// which cannot be extended further are reported. The free energy of each
+// hairpin is estimated as the sum of the stem and loop contributions. The
+// hairpins are sorted from the most stable to the least stable.
+func FindHairpins(p []byte, minStem, minLoop, maxLoop int) []Hairpin {
+	if minLoop < 3 {
+		minLoop = 3
+	}
+	if minStem < 1 {
+		minStem = 1
+	}
+
+	hairpins := []Hairpin{}
+	for a := minStem; a < len(p); a++ {
+		for loop := minLoop; loop <= maxLoop && a+loop+minStem <= len(p); loop++ {
+			b := a + loop
+			if isComplementary(p[a], p[b-1]) && loop > minLoop {
+				continue // The loop can be closed by an additional pair.
+			}
+			stem := 0
+			for a-stem-1 >= 0 && b+stem < len(p) && isComplementary(p[a-stem-1], p[b+stem]) {
+				stem++
+			}
+			if stem < minStem {
+				continue
+			}
+			start, end := a-stem, b+stem
+			dg := StemDeltaG(p[start:a]) + LoopDeltaG(loop)
+			hairpins = append(hairpins, Hairpin{start, end, stem, loop, dg})
+		}
+	}
+
+	sort.SliceStable(hairpins, func(i, j int) bool {
+		return hairpins[i].DeltaG < hairpins[j].DeltaG
+	})
+	return hairpins
+}
@@ -0,0 +1,59 @@
+package gts
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func roundDeltaG(dg float64) float64 {
+	return math.Round(dg*100) / 100
+}
+
+func TestStemDeltaG(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"", 0},
+		{"a", 0},
+		{"aa", -1.00},
+		{"GGGCGC", -10.33},
+		{"anc", 0},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, roundDeltaG(StemDeltaG([]byte(tt.in))), tt.out)
+	}
+}
+
+func TestLoopDeltaG(t *testing.T) {
+	testutils.Equals(t, LoopDeltaG(2), math.Inf(1))
+	testutils.Equals(t, LoopDeltaG(4), 3.5)
+	testutils.Equals(t, roundDeltaG(LoopDeltaG(11)), 4.8)
+	testutils.Equals(t, roundDeltaG(LoopDeltaG(60)), 7.34)
+}
+
+func TestFindHairpins(t *testing.T) {
+	p := []byte("ttGGGCGCaaaaGCGCCCtt")
+	hairpins := FindHairpins(p, 6, 3, 10)
+	testutils.Equals(t, len(hairpins), 1)
+	hairpin := hairpins[0]
+	testutils.Equals(t, hairpin.Start, 2)
+	testutils.Equals(t, hairpin.End, 18)
+	testutils.Equals(t, hairpin.Stem, 6)
+	testutils.Equals(t, hairpin.Loop, 4)
+	testutils.Equals(t, roundDeltaG(hairpin.DeltaG), -6.83)
+
+	hairpins = FindHairpins(p, 4, 3, 10)
+	testutils.Equals(t, hairpins[0], hairpin)
+	for i := 1; i < len(hairpins); i++ {
+		if hairpins[i].DeltaG < hairpins[i-1].DeltaG {
+			t.Errorf("hairpins not sorted by free energy: %v", hairpins)
+		}
+	}
+
+	testutils.Equals(t, FindHairpins([]byte("aaaaaaaaaaaa"), 4, 3, 10), []Hairpin{})
+	testutils.Equals(t, FindHairpins([]byte("ggggnnnncccc"), 4, 3, 10), []Hairpin{{0, 12, 4, 4, LoopDeltaG(4) - 3*1.84}})
+}
@@ -0,0 +1,148 @@
+// Package binding implements the entry points shared by the bindings of gts
+// for other languages and runtimes. Sequences cross the binding boundary as
+// JSON encoded arrays of seqio.Record values so that the callers do not need
+// to depend on the format specific metadata of a sequence.
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+// Version returns the version of gts the binding was built with.
+func Version() string {
+	return gts.Version.String()
+}
+
+func decodeRecords(p []byte) ([]seqio.Record, error) {
+	raws := []json.RawMessage{}
+	if err := json.Unmarshal(p, &raws); err != nil {
+		return nil, fmt.Errorf("records must be a JSON array: %v", err)
+	}
+	recs := make([]seqio.Record, len(raws))
+	for i, raw := range raws {
+		rec, err := seqio.MigrateRecord(raw)
+		if err != nil {
+			return nil, fmt.Errorf("in record %d: %v", i, err)
+		}
+		recs[i] = rec
+	}
+	return recs, nil
+}
+
+func decodeSeqs(p []byte) ([]gts.Sequence, error) {
+	recs, err := decodeRecords(p)
+	if err != nil {
+		return nil, err
+	}
+	seqs := make([]gts.Sequence, len(recs))
+	for i, rec := range recs {
+		seq, err := rec.Seq()
+		if err != nil {
+			return nil, fmt.Errorf("in record %d: %v", i, err)
+		}
+		seqs[i] = seq
+	}
+	return seqs, nil
+}
+
+func encodeSeqs(seqs []gts.Sequence) ([]byte, error) {
+	recs := make([]seqio.Record, len(seqs))
+	for i, seq := range seqs {
+		recs[i] = seqio.NewRecord(seq)
+	}
+	return json.Marshal(recs)
+}
+
+// Parse reads the sequences in the given text in any of the formats
+// supported by seqio and returns them as a JSON array of records.
+func Parse(text string) ([]byte, error) {
+	seqs, err := seqio.ReadAll(strings.NewReader(text))
+	if err != nil {
+		return nil, err
+	}
+	return encodeSeqs(seqs)
+}
+
+// Format writes the given JSON array of records in the named file format. The
+// format of each sequence is detected if the format name is empty or unknown.
+func Format(records []byte, format string) (string, error) {
+	seqs, err := decodeSeqs(records)
+	if err != nil {
+		return "", err
+	}
+	b := &bytes.Buffer{}
+	if err := seqio.WriteAll(seqio.NewWriter(b, seqio.ToFileType(format)), seqs); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Convert reads the sequences in the given text and writes them in the named
+// file format. It is equivalent to calling Parse followed by Format.
+func Convert(text, format string) (string, error) {
+	records, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	return Format(records, format)
+}
+
+// Select retains only the features matching the given selector in each of
+// the records in the given JSON array.
+func Select(records []byte, selector string) ([]byte, error) {
+	filter, err := gts.Selector(selector)
+	if err != nil {
+		return nil, err
+	}
+	seqs, err := decodeSeqs(records)
+	if err != nil {
+		return nil, err
+	}
+	for i, seq := range seqs {
+		seqs[i] = gts.WithFeatures(seq, seq.Features().Filter(filter))
+	}
+	return encodeSeqs(seqs)
+}
+
+// Extract extracts the regions referenced by the given locator from each of
+// the records in the given JSON array, following the semantics of the
+// gts-extract(1) command.
+func Extract(records []byte, locator string) ([]byte, error) {
+	locate, err := gts.AsLocator(locator)
+	if err != nil {
+		return nil, err
+	}
+	seqs, err := decodeSeqs(records)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []gts.Sequence{}
+	for _, seq := range seqs {
+		rr := []gts.Region{}
+		for _, r := range locate(seq) {
+			found := false
+			for _, s := range rr {
+				found = found || reflect.DeepEqual(r, s)
+			}
+			if !found {
+				rr = append(rr, r)
+			}
+		}
+
+		for _, r := range rr {
+			if len(rr) == 1 || r.Len() != gts.Len(seq) {
+				out = append(out, r.Locate(seq))
+			}
+		}
+	}
+
+	return encodeSeqs(out)
+}
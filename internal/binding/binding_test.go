@@ -0,0 +1,98 @@
+package binding
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+	"github.com/go-gts/gts/seqio"
+)
+
+func TestBindingFasta(t *testing.T) {
+	in := ">foo bar\natgcatgc\n"
+
+	records, err := Parse(in)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", in, err)
+	}
+
+	recs := []seqio.Record{}
+	if err := json.Unmarshal(records, &recs); err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, len(recs), 1)
+	testutils.Equals(t, recs[0].ID, "foo")
+	testutils.Equals(t, recs[0].Sequence, "atgcatgc")
+
+	out, err := Format(records, "fasta")
+	if err != nil {
+		t.Fatalf("Format(): %v", err)
+	}
+	testutils.Equals(t, out, in)
+
+	out, err = Convert(in, "")
+	if err != nil {
+		t.Fatalf("Convert(): %v", err)
+	}
+	testutils.Equals(t, out, in)
+}
+
+func TestBindingGenBank(t *testing.T) {
+	p, err := ioutil.ReadFile("../../seqio/testdata/NC_001422.gb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := Parse(string(p))
+	if err != nil {
+		t.Fatalf("Parse(): %v", err)
+	}
+
+	selected, err := Select(records, "CDS/locus_tag=^phiX174p01$")
+	if err != nil {
+		t.Fatalf("Select(): %v", err)
+	}
+
+	recs := []seqio.Record{}
+	if err := json.Unmarshal(selected, &recs); err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, len(recs), 1)
+	testutils.Equals(t, len(recs[0].Features), 1)
+	testutils.Equals(t, recs[0].Features[0].Key, "CDS")
+
+	extracted, err := Extract(selected, "@^..$")
+	if err != nil {
+		t.Fatalf("Extract(): %v", err)
+	}
+
+	recs = []seqio.Record{}
+	if err := json.Unmarshal(extracted, &recs); err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, len(recs), 1)
+	testutils.Equals(t, recs[0].Sequence[:30], "atggttcgttcttattacccttctgaatgt")
+
+	if _, err := Format(extracted, "genbank"); err != nil {
+		t.Fatalf("Format(): %v", err)
+	}
+}
+
+func TestBindingFail(t *testing.T) {
+	if _, err := Format([]byte(`{}`), ""); err == nil {
+		t.Error("expected error in Format() with a JSON object")
+	}
+	if _, err := Format([]byte(`[{"id": "foo"}]`), ""); err == nil {
+		t.Error("expected error in Format() without a schema version")
+	}
+	if _, err := Select([]byte(`[]`), "CDS/product=("); err == nil {
+		t.Error("expected error in Select() with an invalid selector")
+	}
+	if _, err := Extract([]byte(`[]`), "@foo("); err == nil {
+		t.Error("expected error in Extract() with an invalid locator")
+	}
+	if _, err := Extract([]byte(`[`), "^..$"); err == nil {
+		t.Error("expected error in Extract() with malformed records")
+	}
+}
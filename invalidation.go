@@ -0,0 +1,83 @@
+package gts
+
+import "fmt"
+
+// InvalidationReason represents the way a feature was altered by an edit.
+type InvalidationReason int
+
+// Available InvalidationReason values.
+const (
+	// FeatureSplit indicates that a sequence was inserted within the feature.
+	FeatureSplit InvalidationReason = iota
+
+	// FeatureTruncated indicates that some of the bases of the feature were
+	// deleted.
+	FeatureTruncated
+
+	// FeatureCollapsed indicates that all of the bases of the feature were
+	// deleted, leaving a location at the point of deletion.
+	FeatureCollapsed
+
+	// FeatureRemoved indicates that the feature was removed.
+	FeatureRemoved
+)
+
+// String satisfies the fmt.Stringer interface.
+func (reason InvalidationReason) String() string {
+	switch reason {
+	case FeatureSplit:
+		return "split"
+	case FeatureTruncated:
+		return "truncated"
+	case FeatureCollapsed:
+		return "collapsed"
+	case FeatureRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("InvalidationReason(%d)", int(reason))
+	}
+}
+
+// Invalidation represents a feature whose location was altered by an edit in
+// a way that may no longer match its annotation, such as a coding sequence
+// which was truncated by a deletion. The Feature is given as it was before
+// the edit, and Loc is the location after the edit, which is nil if the
+// feature was removed.
+type Invalidation struct {
+	Feature Feature
+	Loc     Location
+	Reason  InvalidationReason
+}
+
+// String satisfies the fmt.Stringer interface.
+func (inv Invalidation) String() string {
+	if inv.Loc == nil {
+		return fmt.Sprintf("%s %s: %s", inv.Feature.Key, inv.Feature.Loc, inv.Reason)
+	}
+	return fmt.Sprintf("%s %s: %s to %s", inv.Feature.Key, inv.Feature.Loc, inv.Reason, inv.Loc)
+}
+
+// invalidateShift reports the feature if the insertion of bases at the given
+// index splits its location. Source features are never reported.
+func invalidateShift(f Feature, loc Location, index int) []Invalidation {
+	if f.Key == "source" || !LocationOverlap(f.Loc, index, index) {
+		return nil
+	}
+	return []Invalidation{{f, loc, FeatureSplit}}
+}
+
+// invalidateExpand reports the feature if the deletion of the bases in the
+// range [offset, offset+length) shortens its location. Source features are
+// never reported.
+func invalidateExpand(f Feature, loc Location, offset, length int) []Invalidation {
+	switch {
+	case f.Key == "source":
+		return nil
+	case LocationWithin(f.Loc, offset, offset+length):
+		return []Invalidation{{f, loc, FeatureCollapsed}}
+	case loc.Len() == f.Loc.Len():
+		return nil
+	default:
+		return []Invalidation{{f, loc, FeatureTruncated}}
+	}
+}
@@ -0,0 +1,47 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestInvalidation(t *testing.T) {
+	props := Props{}
+	source := NewFeature("source", Range(0, 20), props)
+	gene := NewFeature("gene", Range(5, 10), props)
+	point := NewFeature("variation", Point(12), props)
+	seq := New(nil, []Feature{source, gene, point}, []byte("atgcatgcatgcatgcatgc"))
+
+	_, invs := InsertReport(seq, 7, New(nil, nil, []byte("aaa")))
+	testutils.Equals(t, invs, []Invalidation{
+		{gene, Join(Range(5, 7), Range(10, 13)), FeatureSplit},
+	})
+	testutils.Equals(t, invs[0].String(), "gene 6..10: split to join(6..7,11..13)")
+
+	_, invs = InsertReport(seq, 5, New(nil, nil, []byte("aaa")))
+	testutils.Equals(t, len(invs), 0)
+
+	out, invs := InsertEmbed(seq, 7, New(nil, nil, []byte("aaa")), Key("gene"))
+	testutils.Equals(t, len(invs), 0)
+	testutils.Equals(t, out.Features()[1].Loc, Location(Range(5, 13)))
+
+	_, invs = DeleteReport(seq, 8, 6)
+	testutils.Equals(t, invs, []Invalidation{
+		{gene, PartialRange(5, 8, Partial3), FeatureTruncated},
+		{point, Point(8), FeatureCollapsed},
+	})
+
+	_, invs = EraseReport(seq, 8, 6)
+	testutils.Equals(t, invs, []Invalidation{
+		{point, nil, FeatureRemoved},
+		{gene, PartialRange(5, 8, Partial3), FeatureTruncated},
+	})
+	testutils.Equals(t, invs[0].String(), "variation 13: removed")
+
+	reasons := []InvalidationReason{FeatureSplit, FeatureTruncated, FeatureCollapsed, FeatureRemoved, InvalidationReason(-1)}
+	names := []string{"split", "truncated", "collapsed", "removed", "InvalidationReason(-1)"}
+	for i, reason := range reasons {
+		testutils.Equals(t, reason.String(), names[i])
+	}
+}
@@ -0,0 +1,77 @@
+package gts
+
+// KmerIndex represents the multiset of canonical k-mers contained in a
+// collection of sequences. K-mers are stored as hash values, so the index
+// does not retain the sequences themselves.
+type KmerIndex struct {
+	K      int
+	counts map[uint64]int
+}
+
+// NewKmerIndex creates an empty index of k-mers of length k.
+func NewKmerIndex(k int) *KmerIndex {
+	return &KmerIndex{k, make(map[uint64]int)}
+}
+
+// Add the canonical k-mers of the given sequence to the index.
+func (idx *KmerIndex) Add(seq Sequence) {
+	forEachCanonicalKmer(seq.Bytes(), idx.K, func(i int, q []byte) {
+		idx.counts[hashKmer(q)]++
+	})
+}
+
+// Len returns the number of distinct k-mers in the index.
+func (idx *KmerIndex) Len() int {
+	return len(idx.counts)
+}
+
+// Count returns the number of occurrences of the canonical form of the given
+// k-mer in the index on either strand.
+func (idx *KmerIndex) Count(p []byte) int {
+	if len(p) != idx.K {
+		return 0
+	}
+	count := 0
+	forEachCanonicalKmer(p, idx.K, func(i int, q []byte) {
+		count = idx.counts[hashKmer(q)]
+	})
+	return count
+}
+
+// UniqueKmers returns the regions of the k-mers in the given sequence which
+// are absent from the background index. If single is true, the k-mers must
+// also occur exactly once in the sequence, counting both strands. K-mers
+// containing bases other than A, C, G, or T are never reported.
+func UniqueKmers(seq Sequence, background *KmerIndex, single bool) []Segment {
+	k := background.K
+	self := NewKmerIndex(k)
+	if single {
+		self.Add(seq)
+	}
+
+	segments := []Segment{}
+	forEachCanonicalKmer(seq.Bytes(), k, func(i int, q []byte) {
+		h := hashKmer(q)
+		if background.counts[h] > 0 || (single && self.counts[h] > 1) {
+			return
+		}
+		segments = append(segments, Segment{i, i + k})
+	})
+	return segments
+}
+
+// MergeSegments merges the overlapping or adjacent segments in the given
+// list of forward segments sorted by their start positions.
+func MergeSegments(segments []Segment) []Segment {
+	merged := []Segment{}
+	for _, segment := range segments {
+		if n := len(merged); n > 0 && segment[0] <= merged[n-1][1] {
+			if merged[n-1][1] < segment[1] {
+				merged[n-1][1] = segment[1]
+			}
+			continue
+		}
+		merged = append(merged, segment)
+	}
+	return merged
+}
@@ -0,0 +1,39 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestKmerIndex(t *testing.T) {
+	idx := NewKmerIndex(3)
+	idx.Add(New(nil, nil, []byte("atgcatnatg")))
+
+	testutils.Equals(t, idx.Len(), 2)
+	testutils.Equals(t, idx.Count([]byte("atg")), 3)
+	testutils.Equals(t, idx.Count([]byte("CAT")), 3)
+	testutils.Equals(t, idx.Count([]byte("tgc")), 2)
+	testutils.Equals(t, idx.Count([]byte("ggg")), 0)
+	testutils.Equals(t, idx.Count([]byte("atgc")), 0)
+	testutils.Equals(t, idx.Count([]byte("atn")), 0)
+}
+
+func TestUniqueKmers(t *testing.T) {
+	background := NewKmerIndex(3)
+	background.Add(New(nil, nil, []byte("aaaccc")))
+
+	seq := New(nil, nil, []byte("aaatttgcgcgn"))
+	testutils.Equals(t, UniqueKmers(seq, background, false), []Segment{
+		{1, 4}, {2, 5}, {4, 7}, {5, 8}, {6, 9}, {7, 10}, {8, 11},
+	})
+	testutils.Equals(t, UniqueKmers(seq, background, true), []Segment{
+		{4, 7}, {5, 8},
+	})
+}
+
+func TestMergeSegments(t *testing.T) {
+	in := []Segment{{0, 3}, {1, 4}, {4, 7}, {9, 12}, {10, 11}}
+	testutils.Equals(t, MergeSegments(in), []Segment{{0, 7}, {9, 12}})
+	testutils.Equals(t, MergeSegments(nil), []Segment{})
+}
@@ -129,6 +129,7 @@ type Location interface {
 	Len() int
 	Region() Region
 	Complement() Location
+	Strand() Strand
 	Reverse(length int) Location
 	Normalize(length int) Location
 	Shift(i, n int) Location
@@ -141,6 +142,9 @@ func LocationWithin(loc Location, lower, upper int) bool {
 	case Complemented:
 		return LocationWithin(v.Location, lower, upper)
 
+	case RemoteLocation:
+		return true
+
 	case locationSlice:
 		for _, l := range v.slice() {
 			if !LocationWithin(l, lower, upper) {
@@ -181,6 +185,20 @@ func LocationOverlap(loc Location, lower, upper int) bool {
 	}
 }
 
+// SpansOrigin tests if the location crosses the origin of a circular sequence
+// of the given length, i.e. it has a part which ends at the end of the
+// sequence immediately followed by a part which starts at the beginning of
+// the sequence in the direction of its strand, as in join(4500..5386,1..120).
+func SpansOrigin(loc Location, length int) bool {
+	ss := orientedSegments(loc.Region())
+	for i := 0; i+1 < len(ss); i++ {
+		if spansOrigin(ss[i], ss[i+1], length) {
+			return true
+		}
+	}
+	return false
+}
+
 type contiguousLocation interface {
 	Location
 	span() (int, int)
@@ -240,6 +258,11 @@ func (between Between) Complement() Location {
 	return Complemented{between}
 }
 
+// Strand returns the strand of the location.
+func (between Between) Strand() Strand {
+	return StrandForward
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (between Between) Reverse(length int) Location {
 	return Between(length - 1 - int(between))
@@ -293,6 +316,11 @@ func (point Point) Complement() Location {
 	return Complemented{point}
 }
 
+// Strand returns the strand of the location.
+func (point Point) Strand() Strand {
+	return StrandForward
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (point Point) Reverse(length int) Location {
 	return Point(length - 1 - int(point))
@@ -365,24 +393,43 @@ func asComplete(loc Location) Location {
 
 // PartialRange returns the range between the start and end positions where the
 // specified ends are partial. They can be Complete, Partial5, Partial3, or
-// PartialBoth.
+// PartialBoth. Will panic if the range is empty: use TryPartialRange when the
+// positions are derived from untrusted data.
 func PartialRange(start, end int, partial Partial) Ranged {
+	ret, err := TryPartialRange(start, end, partial)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// TryPartialRange is identical to PartialRange except that an error is
+// returned instead of panicking if the range is empty.
+func TryPartialRange(start, end int, partial Partial) (Ranged, error) {
 	if end <= start {
-		panic(fmt.Errorf("Ranged bounds out of range [%d:%d]", start, end))
+		return Ranged{}, fmt.Errorf("Ranged bounds out of range [%d:%d]", start, end)
 	}
 	/* DISCUSS: should a complete, one base range be reduced to a Point?
 	if partial == Complete && start+1 == end {
 		return Point(start)
 	}
 	*/
-	return Ranged{start, end, partial}
+	return Ranged{start, end, partial}, nil
 }
 
-// Range returns the complete range between the start and end positions.
+// Range returns the complete range between the start and end positions. Will
+// panic if the range is empty: use TryRange when the positions are derived
+// from untrusted data.
 func Range(start, end int) Ranged {
 	return PartialRange(start, end, Complete)
 }
 
+// TryRange is identical to Range except that an error is returned instead of
+// panicking if the range is empty.
+func TryRange(start, end int) (Ranged, error) {
+	return TryPartialRange(start, end, Complete)
+}
+
 func (ranged Ranged) span() (int, int) {
 	return ranged.Start, ranged.End
 }
@@ -418,6 +465,11 @@ func (ranged Ranged) Complement() Location {
 	return Complemented{ranged}
 }
 
+// Strand returns the strand of the location.
+func (ranged Ranged) Strand() Strand {
+	return StrandForward
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (ranged Ranged) Reverse(length int) Location {
 	ret := PartialRange(length-ranged.End, length-ranged.Start, ranged.Partial)
@@ -534,6 +586,11 @@ func (ambiguous Ambiguous) Complement() Location {
 	return Complemented{ambiguous}
 }
 
+// Strand returns the strand of the location.
+func (ambiguous Ambiguous) Strand() Strand {
+	return StrandForward
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (ambiguous Ambiguous) Reverse(length int) Location {
 	return Ambiguous{length - ambiguous.End, length - ambiguous.Start}
@@ -761,11 +818,16 @@ func (joined Joined) Complement() Location {
 	return Complemented{joined}
 }
 
+// Strand returns the strand of the location.
+func (joined Joined) Strand() Strand {
+	return checkStrand(joined)
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (joined Joined) Reverse(length int) Location {
 	ll := make([]Location, len(joined))
-	for l, r := 0, len(ll)-1; l < r; l, r = l+1, r-1 {
-		ll[l], ll[r] = joined[r].Reverse(length), joined[l].Reverse(length)
+	for i, loc := range joined {
+		ll[len(ll)-i-1] = loc.Reverse(length)
 	}
 	return Join(ll...)
 }
@@ -884,11 +946,16 @@ func (ordered Ordered) Complement() Location {
 	return Complemented{ordered}
 }
 
+// Strand returns the strand of the location.
+func (ordered Ordered) Strand() Strand {
+	return checkStrand(ordered)
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (ordered Ordered) Reverse(length int) Location {
 	ll := make([]Location, len(ordered))
-	for l, r := 0, len(ll)-1; l < r; l, r = l+1, r-1 {
-		ll[l], ll[r] = ordered[r].Reverse(length), ordered[l].Reverse(length)
+	for i, loc := range ordered {
+		ll[len(ll)-i-1] = loc.Reverse(length)
 	}
 	return Order(ll...)
 }
@@ -945,6 +1012,11 @@ func (complement Complemented) Complement() Location {
 	return complement.Location
 }
 
+// Strand returns the strand of the location.
+func (complement Complemented) Strand() Strand {
+	return complement.Location.Strand().Complement()
+}
+
 // Reverse returns the reversed location for the given length sequence.
 func (complement Complemented) Reverse(length int) Location {
 	return Complemented{complement.Location.Reverse(length)}
@@ -965,18 +1037,32 @@ func (complement Complemented) Expand(i, n int) Location {
 	return Complemented{complement.Location.Expand(i, n)}
 }
 
+// Strand represents the strand of a sequence which a location lies on.
 type Strand int
 
+// Available Strand values.
 const (
 	StrandBoth Strand = iota
 	StrandForward
 	StrandReverse
 )
 
+// Complement returns the opposite strand. StrandBoth is returned as is.
+func (strand Strand) Complement() Strand {
+	switch strand {
+	case StrandForward:
+		return StrandReverse
+	case StrandReverse:
+		return StrandForward
+	default:
+		return strand
+	}
+}
+
 func checkStrand(ll []Location) Strand {
 	f, r := 0, 0
 	for _, l := range ll {
-		switch CheckStrand(l) {
+		switch l.Strand() {
 		case StrandForward:
 			f++
 		case StrandReverse:
@@ -996,17 +1082,69 @@ func checkStrand(ll []Location) Strand {
 	}
 }
 
+// CheckStrand returns the strand of the given location. It is equivalent to
+// calling the Strand method of the location.
 func CheckStrand(loc Location) Strand {
+	return loc.Strand()
+}
+
+// MapLocation returns the location of the bases in the range [start, end) of
+// the sequence obtained by splicing the given location. For example, mapping
+// the range [8, 12) of `complement(join(1..10,21..30))` will yield
+// `complement(join(9..10,21..22))`. The partiality of a range will be retained
+// only if the mapped range includes the partial end. Will panic if the range
+// is empty or not within the length of the location: use TryMapLocation when
+// the range is derived from untrusted data.
+func MapLocation(loc Location, start, end int) Location {
+	ret, err := TryMapLocation(loc, start, end)
+	if err != nil {
+		panic(fmt.Errorf("MapLocation %v", err))
+	}
+	return ret
+}
+
+// TryMapLocation is identical to MapLocation except that an error is returned
+// instead of panicking if the range is empty or not within the length of the
+// location.
+func TryMapLocation(loc Location, start, end int) (Location, error) {
+	if start < 0 || loc.Len() < end || end <= start {
+		return nil, fmt.Errorf("range [%d:%d] out of range for location %s of length %d", start, end, loc, loc.Len())
+	}
+	return mapLocation(loc, start, end), nil
+}
+
+func mapLocation(loc Location, start, end int) Location {
 	switch v := loc.(type) {
+	case Ranged:
+		partial := Partial{
+			v.Partial.Partial5 && start == 0,
+			v.Partial.Partial3 && end == v.Len(),
+		}
+		return PartialRange(v.Start+start, v.Start+end, partial)
 	case Joined:
-		return checkStrand(v)
+		return Join(mapLocations(v, start, end)...)
 	case Ordered:
-		return checkStrand(v)
+		return Order(mapLocations(v, start, end)...)
 	case Complemented:
-		return StrandReverse
+		n := v.Location.Len()
+		return mapLocation(v.Location, n-end, n-start).Complement()
 	default:
-		return StrandForward
+		return loc
+	}
+}
+
+func mapLocations(locs []Location, start, end int) []Location {
+	ret := []Location{}
+	offset := 0
+	for _, loc := range locs {
+		n := loc.Len()
+		head, tail := Max(start-offset, 0), Min(end-offset, n)
+		if head < tail {
+			ret = append(ret, mapLocation(loc, head, tail))
+		}
+		offset += n
 	}
+	return ret
 }
 
 func parseBetween(state *pars.State, result *pars.Result) error {
@@ -1033,8 +1171,13 @@ func parseBetween(state *pars.State, result *pars.Result) error {
 	}
 	end := result.Value.(int)
 	if start+1 != end {
+		state.Pop()
 		return fmt.Errorf("%d^%d is not a valid location: coordinates should be adjacent", start, end)
 	}
+	if start < 0 {
+		state.Pop()
+		return fmt.Errorf("%d^%d is not a valid location: coordinates should not be negative", start, end)
+	}
 	result.SetValue(Between(start))
 	state.Drop()
 	return nil
@@ -1042,6 +1185,9 @@ func parseBetween(state *pars.State, result *pars.Result) error {
 
 var parsePoint = pars.Parser(pars.Int).Map(func(result *pars.Result) error {
 	point := result.Value.(int)
+	if point < 1 {
+		return fmt.Errorf("%d is not a valid location: coordinates should be positive", point)
+	}
 	result.SetValue(Point(point - 1))
 	return nil
 })
@@ -1094,6 +1240,10 @@ func parseRange(state *pars.State, result *pars.Result) error {
 		partial3 = true
 		state.Advance()
 	}
+	if start < 0 || end <= start {
+		state.Pop()
+		return fmt.Errorf("%d..%d is not a valid location: coordinates should be positive and in ascending order", start+1, end)
+	}
 	result.SetValue(Ranged{start, end, Partial{partial5, partial3}})
 	state.Drop()
 	return nil
@@ -1141,6 +1291,10 @@ func parseAmbiguous(state *pars.State, result *pars.Result) error {
 		return err
 	}
 	end := result.Value.(int)
+	if start < 0 || end <= start {
+		state.Pop()
+		return fmt.Errorf("%d.%d is not a valid location: coordinates should be positive and in ascending order", start+1, end)
+	}
 	result.SetValue(Ambiguous{start, end})
 	state.Drop()
 	return nil
@@ -1259,7 +1413,9 @@ func AsLocation(s string) (Location, error) {
 
 func init() {
 	ParseLocation = pars.Any(
+		parseRemote,
 		parseRange,
+		parseOneOf,
 		parseBetween,
 		parseAmbiguous,
 		parseComplementDefault,
@@ -138,6 +138,10 @@ func (null NullLocation) Complement() Location {
 	return Complemented{null}
 }
 
+func (null NullLocation) Strand() Strand {
+	return StrandForward
+}
+
 func (null NullLocation) Reverse(length int) Location {
 	return null
 }
@@ -281,6 +285,35 @@ func TestLocationOverlap(t *testing.T) {
 	}
 }
 
+var spansOriginTests = []struct {
+	loc Location
+	n   int
+	out bool
+}{
+	{Ranged{3, 6, Complete}, 6, false},
+	{Joined{Ranged{3, 6, Complete}, Ranged{0, 2, Complete}}, 6, true},
+	{Joined{Ranged{3, 6, Complete}, Ranged{0, 2, Complete}}, 8, false},
+	{Joined{Ranged{0, 2, Complete}, Ranged{3, 6, Complete}}, 6, false},
+	{Joined{Ranged{3, 6, Complete}, Ranged{0, 4, Complete}}, 6, false},
+}
+
+func TestSpansOrigin(t *testing.T) {
+	for _, tt := range spansOriginTests {
+		format := "expected %s to span the origin of length %d"
+		if !tt.out {
+			format = "expected %s not to span the origin of length %d"
+		}
+		loc := tt.loc
+		if SpansOrigin(loc, tt.n) != tt.out {
+			t.Errorf(format, locRep(loc), tt.n)
+		}
+		loc = loc.Complement()
+		if SpansOrigin(loc, tt.n) != tt.out {
+			t.Errorf(format, locRep(loc), tt.n)
+		}
+	}
+}
+
 func locRep(loc Location) string {
 	switch v := loc.(type) {
 	case Between:
@@ -400,6 +433,12 @@ var locationReverseTest = []struct {
 	{Range(0, 3).Complement(), Range(7, 10).Complement()},
 	{Ambiguous{0, 3}, Ambiguous{7, 10}},
 	{Order(Range(0, 3), Range(5, 8)), Order(Range(2, 5), Range(7, 10))},
+	{Join(Range(0, 2), Range(4, 6), Range(7, 9)), Join(Range(1, 3), Range(4, 6), Range(8, 10))},
+	{Order(Range(0, 2), Range(4, 6), Range(7, 9)), Order(Range(1, 3), Range(4, 6), Range(8, 10))},
+	{
+		Join(Range(0, 2).Complement(), Range(4, 6), Range(7, 9).Complement()),
+		Join(Range(1, 3).Complement(), Range(4, 6), Range(8, 10).Complement()),
+	},
 }
 
 func TestLocationReverse(t *testing.T) {
@@ -602,6 +641,10 @@ var locationShiftTests = []struct {
 	{Ordered{Ranged{3, 6, Complete}, Ranged{13, 16, Complete}}, Ordered{Between(2), Ranged{9, 12, Complete}}, 2, -4},
 	{Ordered{Ranged{3, 6, Complete}, Ranged{13, 16, Complete}}, Ordered{Ranged{3, 6, Complete}, Between(12)}, 12, -4},
 	{Ordered{Ranged{3, 6, Complete}, Ranged{13, 16, Complete}}, Ordered{Between(2), Between(2)}, 2, -14},
+
+	{Joined{Ranged{13, 16, Complete}, Ranged{0, 3, Complete}}, Joined{Ranged{14, 17, Complete}, Ranged{1, 4, Complete}}, 0, 1},
+	{Joined{Ranged{13, 16, Complete}, Ranged{0, 3, Complete}}, Joined{Ranged{13, 16, Complete}, Ranged{0, 3, Complete}}, 16, 1},
+	{Joined{Ranged{13, 16, Complete}, Ranged{0, 3, Complete}}, Joined{Ranged{14, 17, Complete}, Ranged{0, 1, Complete}, Ranged{2, 4, Complete}}, 1, 1},
 }
 
 func TestLocationShift(t *testing.T) {
@@ -889,12 +932,23 @@ var locationStrandTests = []struct {
 	{Joined{Ranged{3, 6, Complete}, Complemented{Ranged{13, 16, Complete}}}, StrandBoth},
 	{Ordered{Ranged{3, 6, Complete}, Complemented{Ranged{13, 16, Complete}}}, StrandBoth},
 	{Joined{Joined{Ranged{3, 6, Complete}, Complemented{Ranged{13, 16, Complete}}}}, StrandBoth},
+	{Complemented{Joined{Complemented{Ranged{3, 6, Complete}}, Complemented{Ranged{13, 16, Complete}}}}, StrandForward},
+	{Complemented{Joined{Ranged{3, 6, Complete}, Complemented{Ranged{13, 16, Complete}}}}, StrandBoth},
+	{Between(3), StrandForward},
+	{Point(3), StrandForward},
+	{Ambiguous{3, 6}, StrandForward},
+	{OneOf([]int{3, 4}, []int{6}), StrandForward},
+	{OneOf([]int{3, 4}, []int{6}).Complement(), StrandReverse},
+	{RemoteLocation{"J00194.1", Range(3, 6)}, StrandForward},
+	{RemoteLocation{"J00194.1", Range(3, 6)}.Complement(), StrandReverse},
 }
 
 func TestLocationStrand(t *testing.T) {
 	for _, tt := range locationStrandTests {
 		out := CheckStrand(tt.in)
 		testutils.Equals(t, out, tt.out)
+		testutils.Equals(t, tt.in.Strand(), tt.out)
+		testutils.Equals(t, tt.in.Complement().Strand(), tt.out.Complement())
 	}
 }
 
@@ -926,9 +980,11 @@ var locationParserFailTests = []struct {
 	{parseBetween, "1?"},
 	{parseBetween, "1^?"},
 	{parseBetween, "1^3"},
+	{parseBetween, "-1^0"},
 
 	{parsePoint, ""},
 	{parsePoint, "?"},
+	{parsePoint, "0"},
 
 	{parseRange, ""},
 	{parseRange, "?"},
@@ -936,6 +992,8 @@ var locationParserFailTests = []struct {
 	{parseRange, "1??"},
 	{parseRange, "1.."},
 	{parseRange, "1..?"},
+	{parseRange, "0..5"},
+	{parseRange, "10..1"},
 
 	{parseComplementDefault, ""},
 	{parseComplementDefault, "complement?"},
@@ -962,6 +1020,7 @@ var locationParserFailTests = []struct {
 	{parseAmbiguous, "1"},
 	{parseAmbiguous, "1?"},
 	{parseAmbiguous, "1.?"},
+	{parseAmbiguous, "5.1"},
 }
 
 func TestLocationParsers(t *testing.T) {
@@ -1044,8 +1103,157 @@ func TestAsLocation(t *testing.T) {
 	}
 }
 
+var mapLocationTests = []struct {
+	in         string
+	start, end int
+	out        string
+}{
+	{"1..10", 0, 10, "1..10"},
+	{"1..10", 2, 5, "3..5"},
+	{"<1..>10", 0, 3, "<1..3"},
+	{"<1..>10", 3, 10, "4..>10"},
+	{"<1..>10", 3, 6, "4..6"},
+	{"5", 0, 1, "5"},
+	{"5.6", 0, 1, "5.6"},
+	{"complement(1..10)", 0, 3, "complement(8..10)"},
+	{"join(1..10,21..30)", 8, 12, "join(9..10,21..22)"},
+	{"join(1..10,21..30)", 12, 15, "23..25"},
+	{"join(1..10,15,21..30)", 9, 12, "join(10..10,15,21..21)"},
+	{"order(1..10,21..30)", 5, 15, "order(6..10,21..25)"},
+	{"complement(join(1..10,21..30))", 8, 12, "complement(join(9..10,21..22))"},
+	{"complement(join(1..10,21..30))", 0, 3, "complement(28..30)"},
+	{"join(90..100,1..10)", 9, 13, "join(99..100,1..2)"},
+}
+
+func TestMapLocation(t *testing.T) {
+	for _, tt := range mapLocationTests {
+		in, err := AsLocation(tt.in)
+		if err != nil {
+			t.Fatalf("AsLocation(%q): %v", tt.in, err)
+		}
+		out := MapLocation(in, tt.start, tt.end)
+		testutils.Equals(t, out.String(), tt.out)
+		testutils.Equals(t, out.Len(), tt.end-tt.start)
+
+		out, err = TryMapLocation(in, tt.start, tt.end)
+		if err != nil {
+			t.Errorf("TryMapLocation(%s, %d, %d): %v", tt.in, tt.start, tt.end, err)
+			continue
+		}
+		testutils.Equals(t, out.String(), tt.out)
+	}
+}
+
+func TestTryMapLocationFail(t *testing.T) {
+	tests := []struct {
+		loc        Location
+		start, end int
+	}{
+		{Range(0, 10), -1, 5},
+		{Range(0, 10), 5, 11},
+		{Range(0, 10), 5, 5},
+		{Join(Range(0, 10), Range(20, 30)), 15, 21},
+		{Range(0, 10).Complement(), 8, 4},
+	}
+
+	for _, tt := range tests {
+		if _, err := TryMapLocation(tt.loc, tt.start, tt.end); err == nil {
+			t.Errorf("expected error in TryMapLocation(%s, %d, %d)", tt.loc, tt.start, tt.end)
+		}
+	}
+}
+
+func TestTryRange(t *testing.T) {
+	out, err := TryRange(2, 5)
+	if err != nil {
+		t.Fatalf("TryRange(2, 5): %v", err)
+	}
+	testutils.Equals(t, out, Range(2, 5))
+
+	out, err = TryPartialRange(2, 5, Partial5)
+	if err != nil {
+		t.Fatalf("TryPartialRange(2, 5, Partial5): %v", err)
+	}
+	testutils.Equals(t, out, PartialRange(2, 5, Partial5))
+
+	for _, tt := range []Segment{{5, 5}, {5, 2}} {
+		if _, err := TryRange(tt[0], tt[1]); err == nil {
+			t.Errorf("expected error in TryRange(%d, %d)", tt[0], tt[1])
+		}
+		if _, err := TryPartialRange(tt[0], tt[1], PartialBoth); err == nil {
+			t.Errorf("expected error in TryPartialRange(%d, %d, PartialBoth)", tt[0], tt[1])
+		}
+	}
+}
+
+var slippageLocationTests = []struct {
+	in     string
+	length int
+	size   int
+}{
+	// SARS-CoV-2 (NC_045512.2) ORF1ab polyprotein.
+	{"join(266..13468,13468..21555)", 29903, 21291},
+	// HIV-1 (NC_001802.1) Gag-Pol polyprotein.
+	{"join(336..1637,1637..4642)", 9181, 4308},
+}
+
+func TestRibosomalSlippage(t *testing.T) {
+	for _, tt := range slippageLocationTests {
+		loc, err := AsLocation(tt.in)
+		if err != nil {
+			t.Fatalf("AsLocation(%q): %v", tt.in, err)
+		}
+
+		testutils.Equals(t, loc.String(), tt.in)
+		testutils.Equals(t, loc.Len(), tt.size)
+		testutils.Equals(t, loc.Region().Len(), tt.size)
+		testutils.Equals(t, loc.Normalize(tt.length), loc)
+		testutils.Equals(t, loc.Reverse(tt.length).Reverse(tt.length), loc)
+		testutils.Equals(t, loc.Shift(0, 10).Shift(0, -10), loc)
+		testutils.Equals(t, CheckStrand(loc), StrandForward)
+	}
+
+	loc, err := AsLocation("join(266..13468,13468..21555)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, MapLocation(loc, 13200, 13206).String(), "join(13466..13468,13468..13470)")
+
+	seq := New(nil, nil, []byte("atgaaacccgggtaa"))
+	loc = Join(Range(0, 6), Range(5, 15))
+	testutils.Equals(t, string(loc.Region().Locate(seq).Bytes()), "atgaaaacccgggtaa")
+}
+
+func TestTransSplicing(t *testing.T) {
+	in := "join(complement(91..100),1..10,complement(41..50))"
+	loc, err := AsLocation(in)
+	if err != nil {
+		t.Fatalf("AsLocation(%q): %v", in, err)
+	}
+
+	testutils.Equals(t, loc.String(), in)
+	testutils.Equals(t, loc.Len(), 30)
+	testutils.Equals(t, loc.Normalize(100), loc)
+	testutils.Equals(t, loc.Reverse(100).String(), "join(complement(51..60),91..100,complement(1..10))")
+	testutils.Equals(t, loc.Reverse(100).Reverse(100), loc)
+	testutils.Equals(t, loc.Shift(20, 5).String(), "join(complement(96..105),1..10,complement(46..55))")
+	testutils.Equals(t, CheckStrand(loc), StrandBoth)
+	testutils.Equals(t, MapLocation(loc, 8, 12).String(), "join(complement(91..92),1..2)")
+	testutils.Equals(t, MapLocation(loc, 18, 22).String(), "join(9..10,complement(49..50))")
+
+	p := make([]byte, 100)
+	for i := range p {
+		p[i] = "acgt"[i/10%4]
+	}
+	seq := New(nil, nil, p)
+	testutils.Equals(t, string(loc.Region().Locate(seq).Bytes()), "ggggggggggaaaaaaaaaatttttttttt")
+}
+
 func TestLocationPanics(t *testing.T) {
 	testutils.Panics(t, func() { Range(2, 0) })
 	testutils.Panics(t, func() { Join() })
 	testutils.Panics(t, func() { Order() })
+	testutils.Panics(t, func() { MapLocation(Range(0, 10), -1, 5) })
+	testutils.Panics(t, func() { MapLocation(Range(0, 10), 5, 11) })
+	testutils.Panics(t, func() { MapLocation(Range(0, 10), 5, 5) })
 }
@@ -2,6 +2,9 @@ package gts
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-pars/pars"
@@ -64,8 +67,133 @@ func tryLocation(s string) (Location, bool) {
 	return result.Value.(Location), true
 }
 
+// qualifierEquals returns a Filter which will return true if the feature has
+// a qualifier with the given name and exactly the given value. If the value
+// is empty, the feature only needs to have the qualifier.
+func qualifierEquals(name, value string) Filter {
+	return func(f Feature) bool {
+		values := f.Props.Get(name)
+		if value == "" {
+			return f.Props.Has(name)
+		}
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isQualifierName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// anchorFilter interprets the feature specifier of an anchor: either
+// `[feature_key][qualifier=value,...]` or `@qualifier:value`.
+func anchorFilter(s string) (Filter, error) {
+	if strings.HasPrefix(s, "@") {
+		i := strings.IndexByte(s, ':')
+		if i < 0 || !isQualifierName(s[1:i]) || i == len(s)-1 {
+			return nil, fmt.Errorf("expected `@qualifier:value` in anchor %q", s)
+		}
+		return qualifierEquals(s[1:i], s[i+1:]), nil
+	}
+
+	i := strings.IndexByte(s, '[')
+	if i < 0 || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("expected `[feature_key][qualifier=value,...]` in anchor %q", s)
+	}
+
+	key := s[:i]
+	if strings.ContainsAny(key, " /=:@") {
+		return nil, fmt.Errorf("invalid feature key in anchor %q", s)
+	}
+
+	filters := []Filter{}
+	if key != "" {
+		filters = append(filters, Key(key))
+	}
+	if inner := s[i+1 : len(s)-1]; inner != "" {
+		for _, term := range strings.Split(inner, ",") {
+			name, value := term, ""
+			if j := strings.IndexByte(term, '='); j >= 0 {
+				name, value = term[:j], term[j+1:]
+			}
+			if !isQualifierName(name) {
+				return nil, fmt.Errorf("expected a qualifier name in anchor %q", s)
+			}
+			filters = append(filters, qualifierEquals(name, value))
+		}
+	}
+	return And(filters...), nil
+}
+
+var anchorOffset = regexp.MustCompile(`[+-]\d+$`)
+
+// asAnchor interprets the given string as an anchor, which refers to the
+// features with the given key and qualifier values. An anchor may be followed
+// by `.start` or `.end` to refer to the 5' or 3' end of the features and an
+// offset relative to the end, where a negative offset is upstream of the
+// feature in the orientation of the feature. An offset without `.start` or
+// `.end` is relative to the 5' end of the features, and must be preceded by
+// white space as the qualifier value may otherwise contain the offset.
+func asAnchor(s string) (Locator, error) {
+	spec, anchor, offset := strings.TrimSpace(s), "", 0
+	if loc := anchorOffset.FindStringIndex(spec); loc != nil {
+		head := spec[:loc[0]]
+		trimmed := strings.TrimRight(head, " \t")
+		if trimmed != head || strings.HasSuffix(head, ".start") || strings.HasSuffix(head, ".end") {
+			n, err := strconv.Atoi(spec[loc[0]:])
+			if err != nil {
+				return nil, err
+			}
+			spec, offset, anchor = trimmed, n, ".start"
+		}
+	}
+	for _, suffix := range []string{".start", ".end"} {
+		if strings.HasSuffix(spec, suffix) {
+			spec, anchor = strings.TrimSuffix(spec, suffix), suffix
+		}
+	}
+
+	filter, err := anchorFilter(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	locate := filterLocator(filter)
+	switch anchor {
+	case ".start":
+		return resizeLocator(locate, Head(offset)), nil
+	case ".end":
+		return resizeLocator(locate, Tail(offset)), nil
+	default:
+		return locate, nil
+	}
+}
+
+func isAnchor(s string) bool {
+	if strings.HasPrefix(s, "@") {
+		return len(s) > 1 && s[1] != '^' && s[1] != '$'
+	}
+	return strings.Contains(s, "[")
+}
+
 // AsLocator interprets the given string as a Locator.
 func AsLocator(s string) (Locator, error) {
+	if isAnchor(s) {
+		if locate, err := asAnchor(s); err == nil {
+			return locate, nil
+		}
+	}
+
 	switch i := strings.IndexByte(s, '@'); i {
 	case -1:
 		mod, err := AsModifier(s)
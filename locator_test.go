@@ -22,6 +22,18 @@ var asLocatorTests = []struct {
 	{"@^-20..^", resizeLocator(allLocator, HeadHead{-20, 0})},
 	{"@^..$", resizeLocator(allLocator, HeadTail{0, 0})},
 	{"exon@^..$", resizeLocator(filterLocator(selectorFilter("exon")), HeadTail{0, 0})},
+
+	{"CDS[gene=INS]", filterLocator(selectorFilter("CDS"))},
+	{"CDS[gene=INS].start", resizeLocator(filterLocator(selectorFilter("CDS")), Head(0))},
+	{"CDS[gene=INS].start-50", resizeLocator(filterLocator(selectorFilter("CDS")), Head(-50))},
+	{"CDS[gene=INS,gene_synonym].end+20", resizeLocator(filterLocator(selectorFilter("CDS")), Tail(20))},
+	{"[gene=INS]", filterLocator(selectorFilter("/gene=^INS$"))},
+	{"exon[gene=IN]", filterLocator(FalseFilter)},
+	{"CDS[gene=INS]@^-20..^", resizeLocator(filterLocator(selectorFilter("CDS")), HeadHead{-20, 0})},
+	{"@protein_id:NP_000198.1", filterLocator(selectorFilter("CDS"))},
+	{"@protein_id:NP_000198.1 +200", resizeLocator(filterLocator(selectorFilter("CDS")), Head(200))},
+	{"@protein_id:NP_000198.1.end-3", resizeLocator(filterLocator(selectorFilter("CDS")), Tail(-3))},
+	{"/gene=I[N]S", filterLocator(selectorFilter("/gene=INS"))},
 }
 
 var asLocatorFailTests = []string{
@@ -29,6 +41,7 @@ var asLocatorFailTests = []string{
 	"@",
 	"exon/gene=[@",
 	"exon/gene=INS@",
+	"@protein_id",
 }
 
 func TestAsLocator(t *testing.T) {
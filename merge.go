@@ -0,0 +1,94 @@
+package gts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergePolicy represents how the qualifiers of a feature are merged into a
+// feature with an identical key and location.
+type MergePolicy string
+
+// MergePolicy constants for merging features with identical keys and
+// locations.
+const (
+	// MergeKeepBoth keeps both features as separate entries.
+	MergeKeepBoth MergePolicy = "keep-both"
+
+	// MergeUnion merges the features into one, keeping every distinct value
+	// of the qualifiers of both features.
+	MergeUnion MergePolicy = "union"
+
+	// MergePreferExisting merges the features into one, keeping the values
+	// of the existing feature for qualifiers present in both features.
+	MergePreferExisting MergePolicy = "existing"
+
+	// MergePreferIncoming merges the features into one, replacing the values
+	// of the existing feature for qualifiers present in both features.
+	MergePreferIncoming MergePolicy = "incoming"
+)
+
+// MergePolicies lists the known merge policies.
+var MergePolicies = []MergePolicy{MergeKeepBoth, MergeUnion, MergePreferExisting, MergePreferIncoming}
+
+// AsMergePolicy interprets the given string as a MergePolicy.
+func AsMergePolicy(s string) (MergePolicy, error) {
+	names := make([]string, len(MergePolicies))
+	for i, policy := range MergePolicies {
+		if strings.EqualFold(s, string(policy)) {
+			return policy, nil
+		}
+		names[i] = string(policy)
+	}
+	return "", fmt.Errorf("unknown merge policy %q: expected one of %s", s, strings.Join(names, ", "))
+}
+
+func mergeProps(existing, incoming Props, policy MergePolicy) Props {
+	props := existing.Clone()
+	for _, name := range incoming.Keys() {
+		values := incoming.Get(name)
+		switch {
+		case !props.Has(name):
+			props.Set(name, values...)
+		case policy == MergePreferIncoming:
+			props.Set(name, values...)
+		case policy == MergeUnion:
+			for _, value := range values {
+				if !containsString(props.Get(name), value) {
+					props.Add(name, value)
+				}
+			}
+		}
+	}
+	return props
+}
+
+func containsString(ss []string, s string) bool {
+	for _, t := range ss {
+		if s == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge takes the given Feature and merges it into the first feature in the
+// FeatureSlice with the same key and location according to the policy. The
+// merged feature is moved to its sorted position as in Insert, as the
+// qualifiers take part in the order of features at identical locations. If
+// no such feature exists or the policy is MergeKeepBoth, the feature is
+// inserted into the sorted position as in Insert.
+func (ff FeatureSlice) Merge(f Feature, policy MergePolicy) FeatureSlice {
+	if policy != MergeKeepBoth {
+		loc := f.Loc.String()
+		for i, g := range ff {
+			if g.Key == f.Key && g.Loc.String() == loc {
+				gg := make(FeatureSlice, 0, len(ff))
+				gg = append(gg, ff[:i]...)
+				gg = append(gg, ff[i+1:]...)
+				return gg.Insert(NewFeature(g.Key, g.Loc, mergeProps(g.Props, f.Props, policy)))
+			}
+		}
+	}
+	return ff.Insert(f)
+}
@@ -0,0 +1,92 @@
+package gts
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestMergePolicy(t *testing.T) {
+	for _, policy := range MergePolicies {
+		out, err := AsMergePolicy(string(policy))
+		testutils.Equals(t, err, nil)
+		testutils.Equals(t, out, policy)
+	}
+
+	if _, err := AsMergePolicy("foo"); err == nil {
+		t.Errorf("AsMergePolicy(%q) expected error", "foo")
+	}
+}
+
+func TestFeatureSliceMerge(t *testing.T) {
+	existing := NewFeature("CDS", Range(0, 9), Props{
+		[]string{"gene", "abc"},
+		[]string{"note", "foo"},
+	})
+	incoming := NewFeature("CDS", Range(0, 9), Props{
+		[]string{"note", "foo", "bar"},
+		[]string{"product", "baz"},
+	})
+
+	both := FeatureSlice{existing, incoming}
+	SortFeatures(both)
+
+	tests := []struct {
+		policy MergePolicy
+		out    FeatureSlice
+	}{
+		{MergeKeepBoth, both},
+		{MergeUnion, FeatureSlice{NewFeature("CDS", Range(0, 9), Props{
+			[]string{"gene", "abc"},
+			[]string{"note", "foo", "bar"},
+			[]string{"product", "baz"},
+		})}},
+		{MergePreferExisting, FeatureSlice{NewFeature("CDS", Range(0, 9), Props{
+			[]string{"gene", "abc"},
+			[]string{"note", "foo"},
+			[]string{"product", "baz"},
+		})}},
+		{MergePreferIncoming, FeatureSlice{NewFeature("CDS", Range(0, 9), Props{
+			[]string{"gene", "abc"},
+			[]string{"note", "foo", "bar"},
+			[]string{"product", "baz"},
+		})}},
+	}
+
+	for _, tt := range tests {
+		ff := FeatureSlice{existing}
+		out := ff.Merge(incoming, tt.policy)
+		testutils.Equals(t, out, tt.out)
+		testutils.Equals(t, ff, FeatureSlice{existing})
+	}
+
+	other := NewFeature("gene", Range(0, 9), Props{[]string{"gene", "abc"}})
+	out := FeatureSlice{existing}.Merge(other, MergeUnion)
+	testutils.Equals(t, len(out), 2)
+}
+
+func TestFeatureSliceMergeSorted(t *testing.T) {
+	ff := FeatureSlice{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		ff = ff.Insert(NewFeature("CDS", Range(0, 9), Props{[]string{"gene", name}}))
+	}
+	ff = ff.Insert(NewFeature("gene", Range(0, 9), Props{[]string{"gene", "a"}}))
+	ff = ff.Insert(NewFeature("source", Range(0, 20), Props{}))
+
+	for _, policy := range []MergePolicy{MergeUnion, MergePreferExisting, MergePreferIncoming} {
+		for _, note := range []string{"foo", "bar", "baz"} {
+			incoming := NewFeature("CDS", Range(0, 9), Props{[]string{"note", note}})
+			out := ff.Merge(incoming, policy)
+			testutils.Equals(t, len(out), len(ff))
+
+			if !sort.IsSorted(out) {
+				t.Errorf("Merge(%v, %s) is not sorted", incoming, policy)
+			}
+			exp := make(FeatureSlice, len(out))
+			copy(exp, out)
+			SortFeatures(exp)
+			testutils.Equals(t, out, exp)
+		}
+	}
+}
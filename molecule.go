@@ -41,3 +41,37 @@ func AsMolecule(s string) (Molecule, error) {
 	}
 	return "", fmt.Errorf("molecule type for %q not known", s)
 }
+
+// GuessMolecule infers the molecule type from the contents of a sequence. A
+// sequence is considered to be a nucleotide sequence if at least 90% of its
+// letters are one of A, C, G, T, U, or N (ignoring case), in which case it is
+// considered to be RNA if it contains a U but no T and DNA otherwise. Any
+// other sequence is considered to be an amino acid sequence. Characters other
+// than letters such as gaps and stop codons are ignored, and a sequence
+// without any letters is considered to be DNA.
+func GuessMolecule(p []byte) Molecule {
+	letters, nucleotides, t, u := 0, 0, false, false
+	for _, c := range p {
+		switch c {
+		case 'T', 't':
+			t = true
+			nucleotides++
+		case 'U', 'u':
+			u = true
+			nucleotides++
+		case 'A', 'a', 'C', 'c', 'G', 'g', 'N', 'n':
+			nucleotides++
+		}
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') {
+			letters++
+		}
+	}
+	switch {
+	case nucleotides*10 < letters*9:
+		return AA
+	case u && !t:
+		return RNA
+	default:
+		return DNA
+	}
+}
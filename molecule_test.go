@@ -46,3 +46,26 @@ func TestAsMolecule(t *testing.T) {
 		t.Errorf("expected error in AsMolecule(%q)", "")
 	}
 }
+
+var guessMoleculeTests = []struct {
+	in  string
+	out Molecule
+}{
+	{"", DNA},
+	{"atgcatgcat", DNA},
+	{"ATGCNNNN--ATGC", DNA},
+	{"augcaugcau", RNA},
+	{"AUGCAUGCAT", DNA},
+	{"atgcratgcatgcatgcatg", DNA},
+	{"MKVLAAGIVGLLLAQ*", AA},
+	{"ACGTACGTACGTEFGH", AA},
+}
+
+func TestGuessMolecule(t *testing.T) {
+	for _, tt := range guessMoleculeTests {
+		out := GuessMolecule([]byte(tt.in))
+		if out != tt.out {
+			t.Errorf("GuessMolecule(%q) = %q, want %q", tt.in, out, tt.out)
+		}
+	}
+}
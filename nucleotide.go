@@ -31,7 +31,7 @@ func Complement(seq Sequence) Sequence {
 	)
 	ff := make([]Feature, len(seq.Features()))
 	for i, f := range seq.Features() {
-		ff[i] = Feature{f.Key, f.Loc.Complement(), f.Props.Clone()}
+		ff[i] = Feature{f.Key, f.Loc, f.Props.Clone()}.mapLocation(Location.Complement)
 	}
 	return WithBytes(WithFeatures(seq, ff), p)
 }
@@ -99,3 +99,45 @@ func Match(seq Sequence, query Sequence) []Segment {
 	sort.Sort(BySegment(segments))
 	return segments
 }
+
+var nucleotideMasks = [256]byte{
+	'a': 0x1, 'c': 0x2, 'g': 0x4, 't': 0x8, 'u': 0x8,
+	'r': 0x5, 'y': 0xa, 'k': 0xc, 'm': 0x3, 's': 0x6, 'w': 0x9,
+	'b': 0xe, 'd': 0xd, 'h': 0xb, 'v': 0x7, 'n': 0xf,
+	'A': 0x1, 'C': 0x2, 'G': 0x4, 'T': 0x8, 'U': 0x8,
+	'R': 0x5, 'Y': 0xa, 'K': 0xc, 'M': 0x3, 'S': 0x6, 'W': 0x9,
+	'B': 0xe, 'D': 0xd, 'H': 0xb, 'V': 0x7, 'N': 0xf,
+}
+
+// Hit represents an approximate match of a query within a sequence.
+type Hit struct {
+	Segment    Segment
+	Mismatches int
+}
+
+// MatchApprox searches for an oligomer within a sequence allowing up to the
+// given number of mismatching bases. The ambiguous nucleotides in the query
+// sequence will match any of the respective nucleotides, and an ambiguous
+// nucleotide in the sequence is considered a match only if every nucleotide
+// it represents is matched by the query.
+func MatchApprox(seq Sequence, query Sequence, mismatches int) []Hit {
+	p, q := seq.Bytes(), query.Bytes()
+	if len(p) == 0 || len(q) == 0 || len(p) < len(q) {
+		return nil
+	}
+
+	hits := []Hit{}
+	for i := 0; i+len(q) <= len(p); i++ {
+		n := 0
+		for j := 0; j < len(q) && n <= mismatches; j++ {
+			a, b := nucleotideMasks[p[i+j]], nucleotideMasks[q[j]]
+			if a == 0 || a&b != a {
+				n++
+			}
+		}
+		if n <= mismatches {
+			hits = append(hits, Hit{Segment{i, i + len(q)}, n})
+		}
+	}
+	return hits
+}
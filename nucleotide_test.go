@@ -71,3 +71,26 @@ func TestMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchApprox(t *testing.T) {
+	seq := New(nil, nil, []byte("atgcatgcaagcatnc"))
+
+	tests := []struct {
+		query      string
+		mismatches int
+		out        []Hit
+	}{
+		{"atgc", 0, []Hit{{Segment{0, 4}, 0}, {Segment{4, 8}, 0}}},
+		{"atgc", 1, []Hit{{Segment{0, 4}, 0}, {Segment{4, 8}, 0}, {Segment{8, 12}, 1}, {Segment{12, 16}, 1}}},
+		{"AURC", 0, []Hit{{Segment{0, 4}, 0}, {Segment{4, 8}, 0}}},
+		{"catn", 0, []Hit{{Segment{3, 7}, 0}, {Segment{11, 15}, 0}}},
+		{"catg", 1, []Hit{{Segment{3, 7}, 0}, {Segment{7, 11}, 1}, {Segment{11, 15}, 1}}},
+		{"ggggg", 0, []Hit{}},
+		{"atgcatgcaagcatnca", 5, nil},
+	}
+
+	for _, tt := range tests {
+		out := MatchApprox(seq, New(nil, nil, []byte(tt.query)), tt.mismatches)
+		testutils.Equals(t, out, tt.out)
+	}
+}
@@ -0,0 +1,142 @@
+package gts
+
+// GCContent returns the fraction of G and C bases in the given nucleotide
+// sequence. Strong (S) bases are counted as G or C and weak (W) bases as A or
+// T. Other ambiguous bases are excluded from the computation.
+func GCContent(p []byte) float64 {
+	gc, total := 0, 0
+	for _, c := range p {
+		switch c {
+		case 'g', 'c', 's', 'G', 'C', 'S':
+			gc++
+			total++
+		case 'a', 't', 'u', 'w', 'A', 'T', 'U', 'W':
+			total++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(gc) / float64(total)
+}
+
+// GCSkew returns the GC skew (G - C) / (G + C) of the given nucleotide
+// sequence. Ambiguous bases are excluded from the computation.
+func GCSkew(p []byte) float64 {
+	g, c := 0, 0
+	for _, b := range p {
+		switch b {
+		case 'g', 'G':
+			g++
+		case 'c', 'C':
+			c++
+		}
+	}
+	if g+c == 0 {
+		return 0
+	}
+	return float64(g-c) / float64(g+c)
+}
+
+// MeltingTemp returns the estimated melting temperature of the given oligomer
+// in degrees Celsius. The Wallace rule (2°C per A/T and 4°C per G/C) is used
+// for oligomers shorter than 14 bases, and the basic formula
+// 64.9 + 41 * (nGC - 16.4) / N is used otherwise.
+func MeltingTemp(p []byte) float64 {
+	if len(p) == 0 {
+		return 0
+	}
+	gc := GCContent(p)
+	n := float64(len(p))
+	if len(p) < 14 {
+		return 2*n*(1-gc) + 4*n*gc
+	}
+	return 64.9 + 41*(n*gc-16.4)/n
+}
+
+// OligoConstraints represents the acceptable ranges of GC content (as a
+// fraction) and melting temperature for an oligomer. A zero maximum value
+// leaves the respective upper bound unconstrained.
+type OligoConstraints struct {
+	MinGC, MaxGC float64
+	MinTm, MaxTm float64
+}
+
+// Check the oligomer against the constraints and return the list of
+// violated constraints.
+func (oc OligoConstraints) Check(p []byte) []string {
+	problems := []string{}
+	gc, tm := GCContent(p), MeltingTemp(p)
+	if gc < oc.MinGC {
+		problems = append(problems, "low-gc")
+	}
+	if oc.MaxGC > 0 && gc > oc.MaxGC {
+		problems = append(problems, "high-gc")
+	}
+	if tm < oc.MinTm {
+		problems = append(problems, "low-tm")
+	}
+	if oc.MaxTm > 0 && tm > oc.MaxTm {
+		problems = append(problems, "high-tm")
+	}
+	return problems
+}
+
+// TileSegments returns the segments of the given length tiling the range
+// [0, n) where adjacent segments overlap by the given number of bases. The
+// last segment is aligned to the end of the range, and may overlap the
+// preceding segment by more than the given overlap.
+func TileSegments(n, length, overlap int) []Segment {
+	if n <= 0 || length <= 0 || overlap >= length {
+		return nil
+	}
+	if n <= length {
+		return []Segment{{0, n}}
+	}
+
+	segments := []Segment{}
+	for i := 0; ; i += length - overlap {
+		if i+length >= n {
+			segments = append(segments, Segment{n - length, n})
+			return segments
+		}
+		segments = append(segments, Segment{i, i + length})
+	}
+}
+
+// Oligo represents an oligomer designed by TileOligos. The list of problems
+// is empty if the oligomer satisfies the constraints.
+type Oligo struct {
+	Segment  Segment
+	Problems []string
+}
+
+// TileOligos tiles the given sequence with oligomers of the given length and
+// overlap subject to the constraints. Each oligomer which does not satisfy
+// the constraints is shifted by up to the given number of bases in either
+// direction, nearest first, until the constraints are satisfied. If no
+// shifted oligomer satisfies the constraints, the oligomer is kept in place
+// along with its problems.
+func TileOligos(p []byte, length, overlap, shift int, oc OligoConstraints) []Oligo {
+	segments := TileSegments(len(p), length, overlap)
+	oligos := make([]Oligo, len(segments))
+
+	for i, segment := range segments {
+		start, end := Unpack(segment)
+		oligos[i] = Oligo{segment, oc.Check(p[start:end])}
+
+		for d := 1; d <= shift && len(oligos[i].Problems) > 0; d++ {
+			for _, delta := range []int{-d, d} {
+				if start+delta < 0 || len(p) < end+delta {
+					continue
+				}
+				if len(oc.Check(p[start+delta:end+delta])) == 0 {
+					oligos[i] = Oligo{Segment{start + delta, end + delta}, []string{}}
+					break
+				}
+			}
+		}
+	}
+
+	return oligos
+}
@@ -0,0 +1,105 @@
+package gts
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestGCContent(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"", 0},
+		{"atat", 0},
+		{"gcgc", 1},
+		{"ATGC", 0.5},
+		{"acgtnnnn", 0.5},
+		{"sw", 0.5},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, GCContent([]byte(tt.in)), tt.out)
+	}
+}
+
+func TestGCSkew(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"", 0},
+		{"atat", 0},
+		{"gggg", 1},
+		{"cccc", -1},
+		{"GGGC", 0.5},
+		{"gcsnnn", 0},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, GCSkew([]byte(tt.in)), tt.out)
+	}
+}
+
+func TestMeltingTemp(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"", 0},
+		{"atgc", 12},
+		{"gggggggggggggggggggg", 64.9 + 41*(20-16.4)/20},
+		{"GTAAAACGACGGCCAGT", 64.9 + 41*(9-16.4)/17},
+	}
+
+	for _, tt := range tests {
+		if out := MeltingTemp([]byte(tt.in)); math.Abs(out-tt.out) > 1e-9 {
+			t.Errorf("MeltingTemp(%q) = %v, want %v", tt.in, out, tt.out)
+		}
+	}
+}
+
+func TestOligoConstraints(t *testing.T) {
+	oc := OligoConstraints{MinGC: 0.4, MaxGC: 0.6, MinTm: 10, MaxTm: 14}
+	testutils.Equals(t, oc.Check([]byte("atgc")), []string{})
+	testutils.Equals(t, oc.Check([]byte("atat")), []string{"low-gc", "low-tm"})
+	testutils.Equals(t, oc.Check([]byte("gcgc")), []string{"high-gc", "high-tm"})
+	testutils.Equals(t, OligoConstraints{}.Check([]byte("gcgc")), []string{})
+}
+
+func TestTileSegments(t *testing.T) {
+	tests := []struct {
+		n, length, overlap int
+		out                []Segment
+	}{
+		{10, 4, 0, []Segment{{0, 4}, {4, 8}, {6, 10}}},
+		{10, 4, 2, []Segment{{0, 4}, {2, 6}, {4, 8}, {6, 10}}},
+		{8, 4, 0, []Segment{{0, 4}, {4, 8}}},
+		{3, 4, 0, []Segment{{0, 3}}},
+		{10, 4, 4, nil},
+		{0, 4, 0, nil},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, TileSegments(tt.n, tt.length, tt.overlap), tt.out)
+	}
+}
+
+func TestTileOligos(t *testing.T) {
+	p := []byte("atatgcgcatat")
+	oc := OligoConstraints{MinGC: 0.5}
+
+	testutils.Equals(t, TileOligos(p, 4, 0, 0, oc), []Oligo{
+		{Segment{0, 4}, []string{"low-gc"}},
+		{Segment{4, 8}, []string{}},
+		{Segment{8, 12}, []string{"low-gc"}},
+	})
+
+	testutils.Equals(t, TileOligos(p, 4, 0, 2, oc), []Oligo{
+		{Segment{2, 6}, []string{}},
+		{Segment{4, 8}, []string{}},
+		{Segment{6, 10}, []string{}},
+	})
+}
@@ -0,0 +1,251 @@
+package gts
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-pars/pars"
+)
+
+// OneOfLocation represents a range of bases where the start and/or end is one
+// of several alternative positions, as in `one-of(1888,1901)..2200`. This
+// notation is no longer a part of the INSDC feature table definition but is
+// found in old records. The Starts and Ends are sorted in ascending order and
+// a side with a single position is written as a plain position.
+type OneOfLocation struct {
+	Starts []int
+	Ends   []int
+}
+
+// OneOf returns the location between one of the start positions and one of
+// the end positions. Will panic if either of the positions are missing or if
+// a start position is not less than all of the end positions.
+func OneOf(starts, ends []int) OneOfLocation {
+	starts, ends = uniqueInts(starts), uniqueInts(ends)
+	if len(starts) == 0 || len(ends) == 0 {
+		panic(fmt.Errorf("one-of location requires at least one start and one end position"))
+	}
+	if ends[0] <= starts[len(starts)-1] {
+		panic(fmt.Errorf("one-of bounds out of range [%d:%d]", starts[len(starts)-1], ends[0]))
+	}
+	return OneOfLocation{starts, ends}
+}
+
+func uniqueInts(p []int) []int {
+	q := append([]int{}, p...)
+	sort.Ints(q)
+	ret := q[:0]
+	for i, v := range q {
+		if i == 0 || v != q[i-1] {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func formatOneOf(p []int, offset int) string {
+	if len(p) == 1 {
+		return strconv.Itoa(p[0] + offset)
+	}
+	ss := make([]string, len(p))
+	for i, v := range p {
+		ss[i] = strconv.Itoa(v + offset)
+	}
+	return fmt.Sprintf("one-of(%s)", strings.Join(ss, ","))
+}
+
+func (oneof OneOfLocation) span() (int, int) {
+	return oneof.Starts[0], oneof.Ends[len(oneof.Ends)-1]
+}
+
+// String satisfies the fmt.Stringer interface.
+func (oneof OneOfLocation) String() string {
+	return formatOneOf(oneof.Starts, 1) + ".." + formatOneOf(oneof.Ends, 0)
+}
+
+// Len returns the total length spanned by the location, which is the length
+// between the outermost positions.
+func (oneof OneOfLocation) Len() int {
+	start, end := oneof.span()
+	return end - start
+}
+
+// Region returns the region between the outermost positions.
+func (oneof OneOfLocation) Region() Region {
+	start, end := oneof.span()
+	return Segment{start, end}
+}
+
+// Complement returns the complement location.
+func (oneof OneOfLocation) Complement() Location {
+	return Complemented{oneof}
+}
+
+// Strand returns the strand of the location.
+func (oneof OneOfLocation) Strand() Strand {
+	return StrandForward
+}
+
+// Reverse returns the reversed location for the given length sequence.
+func (oneof OneOfLocation) Reverse(length int) Location {
+	starts := make([]int, len(oneof.Ends))
+	for i, end := range oneof.Ends {
+		starts[i] = length - end
+	}
+	ends := make([]int, len(oneof.Starts))
+	for i, start := range oneof.Starts {
+		ends[i] = length - start
+	}
+	return OneOf(starts, ends)
+}
+
+// Normalize returns a location normalized for the given length sequence. A
+// location which crosses the end of the sequence is normalized as a Ranged
+// spanning the outermost positions.
+func (oneof OneOfLocation) Normalize(length int) Location {
+	start, end := oneof.span()
+	if 0 <= start && end <= length {
+		return oneof
+	}
+	return Range(start, end).Normalize(length)
+}
+
+// Shift the location beyond the given position i by n. Inserting bases
+// between the outermost positions will yield a Ranged spanning the outermost
+// positions, as the alternative positions can no longer be represented.
+func (oneof OneOfLocation) Shift(i, n int) Location {
+	if n == 0 {
+		return oneof
+	}
+	if n < 0 {
+		return oneof.Expand(i, n)
+	}
+	start, end := oneof.span()
+	if start < i && i < end {
+		return Range(start, end).Shift(i, n)
+	}
+	return oneof.Expand(i, n)
+}
+
+// Expand the location beyond the given position i by n.
+func (oneof OneOfLocation) Expand(i, n int) Location {
+	if n == 0 {
+		return oneof
+	}
+	starts := make([]int, len(oneof.Starts))
+	for j, start := range oneof.Starts {
+		if (0 <= n && i <= start) || (n < 0 && i < start) {
+			start = Max(i, start+n)
+		}
+		starts[j] = start
+	}
+	ends := make([]int, len(oneof.Ends))
+	for j, end := range oneof.Ends {
+		if (0 <= n && i < end) || (n < 0 && i <= end) {
+			end = Max(i, end+n)
+		}
+		ends[j] = end
+	}
+	starts, ends = uniqueInts(starts), uniqueInts(ends)
+	if starts[len(starts)-1] < ends[0] && (len(starts) > 1 || len(ends) > 1) {
+		return OneOfLocation{starts, ends}
+	}
+	start, end := starts[0], ends[len(ends)-1]
+	if start == end {
+		return Between(start)
+	}
+	return Range(start, end)
+}
+
+func parseOneOfPositions(state *pars.State, result *pars.Result) error {
+	state.Push()
+	if err := state.Request(7); err != nil {
+		state.Pop()
+		return err
+	}
+	if !bytes.Equal(state.Buffer(), []byte("one-of(")) {
+		err := pars.NewError("expected `one-of(`", state.Position())
+		state.Pop()
+		return err
+	}
+	state.Advance()
+	p := []int{}
+	for {
+		if err := pars.Int(state, result); err != nil {
+			state.Pop()
+			return err
+		}
+		p = append(p, result.Value.(int))
+		c, err := pars.Next(state)
+		if err != nil {
+			state.Pop()
+			return err
+		}
+		state.Advance()
+		if c == ')' {
+			break
+		}
+		if c != ',' {
+			err := pars.NewError("expected `,` or `)`", state.Position())
+			state.Pop()
+			return err
+		}
+	}
+	result.SetValue(p)
+	state.Drop()
+	return nil
+}
+
+func parseOneOfPosition(state *pars.State, result *pars.Result) error {
+	if err := parseOneOfPositions(state, result); err == nil {
+		return nil
+	}
+	if err := pars.Int(state, result); err != nil {
+		return err
+	}
+	result.SetValue([]int{result.Value.(int)})
+	return nil
+}
+
+func parseOneOf(state *pars.State, result *pars.Result) error {
+	state.Push()
+	if err := parseOneOfPosition(state, result); err != nil {
+		state.Pop()
+		return err
+	}
+	starts := result.Value.([]int)
+	if err := state.Request(2); err != nil {
+		state.Pop()
+		return err
+	}
+	if !bytes.Equal(state.Buffer(), []byte("..")) {
+		err := pars.NewError("expected `..`", state.Position())
+		state.Pop()
+		return err
+	}
+	state.Advance()
+	if err := parseOneOfPosition(state, result); err != nil {
+		state.Pop()
+		return err
+	}
+	ends := result.Value.([]int)
+	if len(starts) == 1 && len(ends) == 1 {
+		err := pars.NewError("expected `one-of(`", state.Position())
+		state.Pop()
+		return err
+	}
+	for i := range starts {
+		starts[i]--
+	}
+	starts, ends = uniqueInts(starts), uniqueInts(ends)
+	if starts[0] < 0 || ends[0] <= starts[len(starts)-1] {
+		state.Pop()
+		return fmt.Errorf("%s..%s is not a valid location: coordinates should be positive and in ascending order", formatOneOf(starts, 1), formatOneOf(ends, 0))
+	}
+	result.SetValue(OneOfLocation{starts, ends})
+	state.Drop()
+	return nil
+}
@@ -0,0 +1,50 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var oneOfParseTests = []struct {
+	in  string
+	out Location
+}{
+	{"one-of(1888,1901)..2200", OneOf([]int{1887, 1900}, []int{2200})},
+	{"100..one-of(200,210,220)", OneOf([]int{99}, []int{200, 210, 220})},
+	{"one-of(1,5)..one-of(10,20)", OneOf([]int{0, 4}, []int{10, 20})},
+	{"complement(one-of(1,5)..10)", OneOf([]int{0, 4}, []int{10}).Complement()},
+	{"join(one-of(1,5)..10,20..30)", Join(OneOf([]int{0, 4}, []int{10}), Range(19, 30))},
+}
+
+func TestOneOfParse(t *testing.T) {
+	for _, tt := range oneOfParseTests {
+		loc, err := AsLocation(tt.in)
+		if err != nil {
+			t.Errorf("failed to parse %q: %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, loc, tt.out)
+		testutils.Equals(t, loc.String(), tt.in)
+	}
+
+	for _, in := range []string{"one-of(1,5", "one-of(1;5)..10", "one-of(5,20)..10", "one-of()..10"} {
+		if _, err := AsLocation(in); err == nil {
+			t.Errorf("expected error while parsing %q", in)
+		}
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	loc := OneOf([]int{1900, 1887}, []int{2200})
+	testutils.Equals(t, loc.Len(), 313)
+	testutils.Equals(t, loc.Region(), Region(Segment{1887, 2200}))
+	testutils.Equals(t, loc.Reverse(3000), Location(OneOf([]int{800}, []int{1100, 1113})))
+	testutils.Equals(t, loc.Normalize(3000), Location(loc))
+	testutils.Equals(t, loc.Shift(100, 10), Location(OneOf([]int{1897, 1910}, []int{2210})))
+	testutils.Equals(t, loc.Shift(1890, 10), Range(1887, 2200).Shift(1890, 10))
+	testutils.Equals(t, loc.Expand(1890, 10), Location(OneOf([]int{1887, 1910}, []int{2210})))
+	testutils.Equals(t, loc.Expand(1880, -20), Location(Range(1880, 2180)))
+	testutils.Equals(t, LocationWithin(loc, 1887, 2200), true)
+	testutils.Equals(t, LocationWithin(loc, 1900, 2200), false)
+}
@@ -0,0 +1,364 @@
+package gts
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PeptideKeys lists the INSDC feature keys which describe a region of the
+// protein encoded by a CDS feature.
+var PeptideKeys = []string{"mat_peptide", "propeptide", "sig_peptide", "transit_peptide"}
+
+var peptideAliases = map[string]string{
+	"mat_peptide":     "mat_peptide",
+	"mature":          "mat_peptide",
+	"mature_peptide":  "mat_peptide",
+	"chain":           "mat_peptide",
+	"propeptide":      "propeptide",
+	"propep":          "propeptide",
+	"pro_peptide":     "propeptide",
+	"sig_peptide":     "sig_peptide",
+	"signal":          "sig_peptide",
+	"signal_peptide":  "sig_peptide",
+	"transit_peptide": "transit_peptide",
+	"transit":         "transit_peptide",
+}
+
+// PeptideKey returns the INSDC feature key for the given peptide feature
+// name. Common aliases such as `signal peptide` or `chain` are accepted and
+// the comparison is case insensitive.
+func PeptideKey(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	key, ok := peptideAliases[name]
+	return key, ok
+}
+
+// CodonStart returns the offset of the first complete codon in the feature
+// as given by the `/codon_start` qualifier. The offset is zero if the
+// qualifier is absent or malformed.
+func CodonStart(f Feature) int {
+	for _, value := range f.Props.Get("codon_start") {
+		if n, err := strconv.Atoi(value); err == nil && 1 <= n && n <= 3 {
+			return n - 1
+		}
+	}
+	return 0
+}
+
+// PeptideLocation returns the location of the residues in the range
+// [start, end) of the protein encoded by the given CDS feature. The leading
+// bases preceding the first complete codon are included if the range starts
+// at the first residue, and the trailing bases of an incomplete last codon
+// are included if the range ends at the last residue.
+func PeptideLocation(cds Feature, start, end int) (Location, error) {
+	n := cds.Loc.Len()
+	offset := CodonStart(cds)
+
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("invalid residue range [%d:%d]", start, end)
+	}
+
+	head, tail := offset+start*3, offset+end*3
+	if tail > n {
+		return nil, fmt.Errorf("residue range %d..%d exceeds the %d residues encoded by %s %s", start+1, end, (n-offset)/3, cds.Key, cds.Loc)
+	}
+
+	if start == 0 {
+		head = 0
+	}
+	if n-tail < 3 {
+		tail = n
+	}
+
+	return TryMapLocation(cds.Loc, head, tail)
+}
+
+// NewPeptide creates a new peptide feature for the residues in the range
+// [start, end) of the protein encoded by the given CDS feature. The `/gene`
+// and `/locus_tag` qualifiers of the CDS are inherited by the new feature.
+func NewPeptide(key string, cds Feature, start, end int, props Props) (Feature, error) {
+	loc, err := PeptideLocation(cds, start, end)
+	if err != nil {
+		return Feature{}, err
+	}
+
+	ret := Props{}
+	for _, name := range []string{"gene", "locus_tag"} {
+		if values := cds.Props.Get(name); len(values) > 0 && !props.Has(name) {
+			ret.Set(name, values...)
+		}
+	}
+	for _, item := range props.Items() {
+		ret.Add(item.Key, item.Value)
+	}
+
+	return NewFeature(key, loc, ret), nil
+}
+
+// IsPeptide tests if the feature is a peptide feature.
+func IsPeptide(f Feature) bool {
+	for _, key := range PeptideKeys {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+type strandedSegment struct {
+	Start, End int
+	Reverse    bool
+}
+
+func splicedSegments(loc Location) []strandedSegment {
+	switch v := loc.(type) {
+	case Ranged:
+		return []strandedSegment{{v.Start, v.End, false}}
+	case Point:
+		return []strandedSegment{{int(v), int(v) + 1, false}}
+	case Ambiguous:
+		return []strandedSegment{{v.Start, v.Start + 1, false}}
+	case Joined:
+		return flattenSplicedSegments(v)
+	case Ordered:
+		return flattenSplicedSegments(v)
+	case Complemented:
+		inner := splicedSegments(v.Location)
+		ret := make([]strandedSegment, len(inner))
+		for i, seg := range inner {
+			seg.Reverse = !seg.Reverse
+			ret[len(ret)-i-1] = seg
+		}
+		return ret
+	default:
+		return nil
+	}
+}
+
+func flattenSplicedSegments(locs []Location) []strandedSegment {
+	ret := []strandedSegment{}
+	for _, loc := range locs {
+		ret = append(ret, splicedSegments(loc)...)
+	}
+	return ret
+}
+
+func splicedOffset(segs []strandedSegment, pos int, reverse bool) (int, bool) {
+	offset := 0
+	for _, seg := range segs {
+		if seg.Reverse == reverse && seg.Start <= pos && pos < seg.End {
+			if reverse {
+				return offset + seg.End - pos - 1, true
+			}
+			return offset + pos - seg.Start, true
+		}
+		offset += seg.End - seg.Start
+	}
+	return 0, false
+}
+
+// splicedRange returns the range [start, end) of the sequence spliced by the
+// parent location which corresponds to the child location.
+func splicedRange(parent, child Location) (int, int, bool) {
+	segs := splicedSegments(child)
+	if len(segs) == 0 {
+		return 0, 0, false
+	}
+
+	first, last := segs[0], segs[len(segs)-1]
+	head, tail := first.Start, last.End-1
+	if first.Reverse {
+		head = first.End - 1
+	}
+	if last.Reverse {
+		tail = last.Start
+	}
+
+	psegs := splicedSegments(parent)
+	start, ok := splicedOffset(psegs, head, first.Reverse)
+	if !ok {
+		return 0, 0, false
+	}
+	end, ok := splicedOffset(psegs, tail, last.Reverse)
+	if !ok || end < start {
+		return 0, 0, false
+	}
+	return start, end + 1, true
+}
+
+func sameQualifier(a, b Feature, name string) bool {
+	u, v := a.Props.Get(name), b.Props.Get(name)
+	if len(u) == 0 || len(v) == 0 {
+		return true
+	}
+	for _, s := range u {
+		for _, t := range v {
+			if s == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PeptideParent returns the CDS feature which the peptide feature belongs to.
+// The parent is the first CDS feature which contains the first and last bases
+// of the peptide on the same strand and does not have a conflicting `/gene`
+// or `/locus_tag` qualifier.
+func PeptideParent(f Feature, ff FeatureSlice) (Feature, bool) {
+	for _, cds := range ff {
+		if cds.Key != "CDS" || !sameQualifier(f, cds, "locus_tag") || !sameQualifier(f, cds, "gene") {
+			continue
+		}
+		if _, _, ok := splicedRange(cds.Loc, f.Loc); ok {
+			return cds, true
+		}
+	}
+	return Feature{}, false
+}
+
+// PeptideIssue describes an inconsistency of a peptide feature with respect
+// to its parent CDS feature.
+type PeptideIssue struct {
+	Peptide Feature
+	CDS     Feature
+	Problem string
+}
+
+// Peptide issue descriptions.
+const (
+	PeptideNoParent  = "not within any CDS"
+	PeptideStructure = "does not follow the CDS location"
+	PeptideFrame5    = "start is not in frame with the CDS"
+	PeptideFrame3    = "end is not in frame with the CDS"
+	PeptideOverlap   = "overlaps another peptide"
+)
+
+type peptideSpan struct {
+	index      int
+	cds        Feature
+	start, end int
+}
+
+func (span peptideSpan) issues(f Feature) []string {
+	ret := []string{}
+	loc, err := TryMapLocation(span.cds.Loc, span.start, span.end)
+	if err != nil || asComplete(loc).String() != asComplete(f.Loc).String() {
+		ret = append(ret, PeptideStructure)
+	}
+	n, offset := span.cds.Loc.Len(), CodonStart(span.cds)
+	if span.start != 0 && (span.start-offset)%3 != 0 {
+		ret = append(ret, PeptideFrame5)
+	}
+	if n-span.end >= 3 && (span.end-offset)%3 != 0 {
+		ret = append(ret, PeptideFrame3)
+	}
+	return ret
+}
+
+func peptideSpans(ff FeatureSlice) ([]peptideSpan, []PeptideIssue) {
+	spans := []peptideSpan{}
+	issues := []PeptideIssue{}
+	for i, f := range ff {
+		if !IsPeptide(f) {
+			continue
+		}
+		cds, ok := PeptideParent(f, ff)
+		if !ok {
+			issues = append(issues, PeptideIssue{f, Feature{}, PeptideNoParent})
+			continue
+		}
+		start, end, _ := splicedRange(cds.Loc, f.Loc)
+		spans = append(spans, peptideSpan{i, cds, start, end})
+	}
+	return spans, issues
+}
+
+func overlappingSpans(spans []peptideSpan, i int) []int {
+	ret := []int{}
+	for j := range spans {
+		a, b := spans[i], spans[j]
+		if i != j && a.cds.Loc.String() == b.cds.Loc.String() && a.start < b.end && b.start < a.end {
+			ret = append(ret, j)
+		}
+	}
+	return ret
+}
+
+// CheckPeptides checks the peptide features in the feature slice for
+// consistency with their parent CDS features. A peptide feature must be
+// contained in a CDS feature following its location, start and end in frame
+// with the CDS, and must not overlap other peptide features of the same CDS.
+func CheckPeptides(ff FeatureSlice) []PeptideIssue {
+	spans, issues := peptideSpans(ff)
+	for i, span := range spans {
+		f := ff[span.index]
+		for _, problem := range span.issues(f) {
+			issues = append(issues, PeptideIssue{f, span.cds, problem})
+		}
+		if len(overlappingSpans(spans, i)) > 0 {
+			issues = append(issues, PeptideIssue{f, span.cds, PeptideOverlap})
+		}
+	}
+	return issues
+}
+
+// FixPeptides attempts to fix the inconsistencies reported by CheckPeptides.
+// The locations of peptide features are recomputed from their parent CDS
+// features with the ends moved inward to the nearest codon boundaries, and a
+// peptide feature which partially overlaps the preceding peptide feature of
+// the same CDS is trimmed to start after it. The fixed feature slice is
+// returned along with the issues which could not be fixed.
+func FixPeptides(ff FeatureSlice) (FeatureSlice, []PeptideIssue) {
+	spans, issues := peptideSpans(ff)
+
+	for i := range spans {
+		span := &spans[i]
+		n, offset := span.cds.Loc.Len(), CodonStart(span.cds)
+		if span.start != 0 {
+			span.start += Mod(offset-span.start, 3)
+		}
+		if n-span.end >= 3 {
+			span.end -= Mod(span.end-offset, 3)
+		}
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	for i := range spans {
+		for j := 0; j < i; j++ {
+			a, b := spans[j], &spans[i]
+			if a.cds.Loc.String() == b.cds.Loc.String() && b.start < a.end && a.end < b.end {
+				b.start = a.end
+			}
+		}
+	}
+
+	ret := make(FeatureSlice, len(ff))
+	copy(ret, ff)
+
+	for i, span := range spans {
+		f := ff[span.index]
+		if span.end <= span.start {
+			issues = append(issues, PeptideIssue{f, span.cds, PeptideOverlap})
+			continue
+		}
+		loc, err := TryMapLocation(span.cds.Loc, span.start, span.end)
+		if err != nil {
+			issues = append(issues, PeptideIssue{f, span.cds, PeptideStructure})
+			continue
+		}
+		f.Loc = loc
+		ret[span.index] = f
+		if len(overlappingSpans(spans, i)) > 0 {
+			issues = append(issues, PeptideIssue{f, span.cds, PeptideOverlap})
+		}
+	}
+
+	return ret, issues
+}
@@ -0,0 +1,185 @@
+package gts
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestPeptideKey(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		ok  bool
+	}{
+		{"sig_peptide", "sig_peptide", true},
+		{"Signal peptide", "sig_peptide", true},
+		{"chain", "mat_peptide", true},
+		{"transit-peptide", "transit_peptide", true},
+		{"PROPEP", "propeptide", true},
+		{"CDS", "", false},
+	}
+
+	for _, tt := range tests {
+		out, ok := PeptideKey(tt.in)
+		testutils.Equals(t, out, tt.out)
+		testutils.Equals(t, ok, tt.ok)
+	}
+}
+
+func TestCodonStart(t *testing.T) {
+	testutils.Equals(t, CodonStart(NewFeature("CDS", Range(0, 9), Props{})), 0)
+	testutils.Equals(t, CodonStart(NewFeature("CDS", Range(0, 9), Props{[]string{"codon_start", "3"}})), 2)
+	testutils.Equals(t, CodonStart(NewFeature("CDS", Range(0, 9), Props{[]string{"codon_start", "4"}})), 0)
+}
+
+func TestPeptideLocation(t *testing.T) {
+	ins := FeatureSlice(testFeatureTable).Filter(Key("CDS"))[0]
+
+	tests := []struct {
+		cds        Feature
+		start, end int
+		out        string
+	}{
+		{ins, 0, 24, "60..131"},
+		{ins, 24, 54, "132..221"},
+		{ins, 89, 110, "327..389"},
+		{
+			NewFeature("CDS", Join(Range(0, 10), Range(20, 31)), Props{}),
+			1, 5,
+			"join(4..10,21..25)",
+		},
+		{
+			NewFeature("CDS", PartialRange(0, 20, PartialBoth), Props{[]string{"codon_start", "2"}}),
+			0, 2,
+			"<1..7",
+		},
+		{
+			NewFeature("CDS", PartialRange(0, 20, PartialBoth), Props{[]string{"codon_start", "2"}}),
+			4, 6,
+			"14..>20",
+		},
+		{
+			NewFeature("CDS", Range(0, 30).Complement(), Props{}),
+			0, 3,
+			"complement(22..30)",
+		},
+	}
+
+	for _, tt := range tests {
+		loc, err := PeptideLocation(tt.cds, tt.start, tt.end)
+		if err != nil {
+			t.Errorf("PeptideLocation(%s, %d, %d): %v", tt.cds.Loc, tt.start, tt.end, err)
+			continue
+		}
+		testutils.Equals(t, loc.String(), tt.out)
+	}
+
+	for _, r := range [][2]int{{-1, 3}, {3, 3}, {100, 112}} {
+		if _, err := PeptideLocation(ins, r[0], r[1]); err == nil {
+			t.Errorf("PeptideLocation(%s, %d, %d) expected error", ins.Loc, r[0], r[1])
+		}
+	}
+}
+
+func TestNewPeptide(t *testing.T) {
+	cds := NewFeature("CDS", Range(0, 30), Props{
+		[]string{"gene", "foo"},
+		[]string{"locus_tag", "FOO_0001"},
+		[]string{"product", "foo protein"},
+	})
+
+	f, err := NewPeptide("sig_peptide", cds, 0, 3, Props{
+		[]string{"locus_tag", "FOO_0002"},
+		[]string{"note", "predicted"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.Equals(t, f.Key, "sig_peptide")
+	testutils.Equals(t, f.Loc.String(), "1..9")
+	testutils.Equals(t, f.Props, Props{
+		[]string{"gene", "foo"},
+		[]string{"locus_tag", "FOO_0002"},
+		[]string{"note", "predicted"},
+	})
+
+	if _, err := NewPeptide("sig_peptide", cds, 8, 12, nil); err == nil {
+		t.Errorf("NewPeptide() expected error")
+	}
+}
+
+func peptideProblems(issues []PeptideIssue) []string {
+	ret := make([]string, len(issues))
+	for i, issue := range issues {
+		ret[i] = fmt.Sprintf("%s %s: %s", issue.Peptide.Key, issue.Peptide.Loc, issue.Problem)
+	}
+	return ret
+}
+
+func TestCheckPeptides(t *testing.T) {
+	gene := Props{[]string{"gene", "a"}}
+	ff := FeatureSlice{
+		NewFeature("CDS", Join(Range(0, 30), Range(40, 70)), gene),
+		NewFeature("sig_peptide", Range(0, 12), gene),
+		NewFeature("mat_peptide", Range(9, 30), gene),
+		NewFeature("mat_peptide", Range(41, 55), gene),
+		NewFeature("mat_peptide", Range(99, 110), gene),
+		NewFeature("CDS", Range(100, 130).Complement(), Props{[]string{"gene", "b"}}),
+		NewFeature("sig_peptide", Range(121, 130).Complement(), Props{[]string{"gene", "b"}}),
+		NewFeature("mat_peptide", Range(100, 121).Complement(), Props{[]string{"gene", "a"}}),
+		NewFeature("CDS", Join(Range(200, 230), Range(240, 270)), Props{}),
+		NewFeature("mat_peptide", Range(224, 245), Props{}),
+	}
+
+	testutils.Equals(t, peptideProblems(CheckPeptides(ff)), []string{
+		"mat_peptide 100..110: not within any CDS",
+		"mat_peptide complement(101..121): not within any CDS",
+		"sig_peptide 1..12: overlaps another peptide",
+		"mat_peptide 10..30: overlaps another peptide",
+		"mat_peptide 42..55: start is not in frame with the CDS",
+		"mat_peptide 225..245: does not follow the CDS location",
+		"mat_peptide 225..245: end is not in frame with the CDS",
+	})
+
+	fixed, issues := FixPeptides(ff)
+	testutils.Equals(t, peptideProblems(issues), []string{
+		"mat_peptide 100..110: not within any CDS",
+		"mat_peptide complement(101..121): not within any CDS",
+	})
+
+	locs := make([]string, len(fixed))
+	for i, f := range fixed {
+		locs[i] = f.Loc.String()
+	}
+	testutils.Equals(t, locs, []string{
+		"join(1..30,41..70)",
+		"1..12",
+		"13..30",
+		"44..55",
+		"100..110",
+		"complement(101..130)",
+		"complement(122..130)",
+		"complement(101..121)",
+		"join(201..230,241..270)",
+		"join(225..230,241..243)",
+	})
+
+	testutils.Equals(t, len(CheckPeptides(fixed)), 2)
+}
+
+func TestFixPeptidesContained(t *testing.T) {
+	ff := FeatureSlice{
+		NewFeature("CDS", Range(0, 60), Props{}),
+		NewFeature("mat_peptide", Range(0, 30), Props{}),
+		NewFeature("mat_peptide", Range(9, 21), Props{}),
+	}
+
+	_, issues := FixPeptides(ff)
+	testutils.Equals(t, peptideProblems(issues), []string{
+		"mat_peptide 1..30: overlaps another peptide",
+		"mat_peptide 10..21: overlaps another peptide",
+	})
+}
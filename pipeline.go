@@ -0,0 +1,116 @@
+package gts
+
+import (
+	"fmt"
+
+	"github.com/go-flip/flip"
+)
+
+// Operation represents a single step of a Pipeline which transforms a
+// sequence into another. The operations below implement the core of the
+// commands of the same name in cmd/gts, so that the commands can be composed
+// programmatically without running the command line interface.
+type Operation func(seq Sequence) (Sequence, error)
+
+// Pipeline represents a list of Operations applied in order. The Apply method
+// of a Pipeline is itself an Operation, so that pipelines may be nested.
+type Pipeline []Operation
+
+// Apply the operations of the pipeline to the given sequence in order. The
+// error of the first operation to fail is returned along with the position of
+// the operation in the pipeline.
+func (p Pipeline) Apply(seq Sequence) (Sequence, error) {
+	for i, op := range p {
+		out, err := op(seq)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %v", i+1, err)
+		}
+		seq = out
+	}
+	return seq, nil
+}
+
+// ClearOp returns an Operation which removes all features except for the
+// source features, as in `gts clear`.
+func ClearOp() Operation {
+	return SelectOp(Key("source"))
+}
+
+// ComplementOp returns an Operation which complements the sequence, as in
+// `gts complement`. The molecule type is guessed from the sequence: amino
+// acid sequences cannot be complemented, and the complement of an RNA
+// sequence is written with uracils.
+func ComplementOp() Operation {
+	return func(seq Sequence) (Sequence, error) {
+		switch GuessMolecule(seq.Bytes()) {
+		case AA:
+			return nil, fmt.Errorf("cannot complement an amino acid sequence")
+		case RNA:
+			return WithBytes(Complement(seq), Transcribe(seq).Bytes()), nil
+		default:
+			return Complement(seq), nil
+		}
+	}
+}
+
+// DeleteOp returns an Operation which deletes the regions found by the
+// locator, as in `gts delete`. If erase is true, the features overlapping the
+// regions are removed instead of being truncated. A region outside of the
+// sequence is reported as an error.
+func DeleteOp(locate Locator, erase bool) Operation {
+	remove := Delete
+	if erase {
+		remove = Erase
+	}
+	return func(seq Sequence) (Sequence, error) {
+		ss := Minimize(locate(seq))
+		flip.Flip(BySegment(ss))
+		for _, s := range ss {
+			loc, err := TryRange(Unpack(s))
+			if err != nil {
+				return nil, fmt.Errorf("invalid region: %v", err)
+			}
+			if s[0] < 0 || Len(seq) < s[1] {
+				return nil, fmt.Errorf("region %s is out of bounds for a sequence of length %d", loc, Len(seq))
+			}
+			seq = remove(seq, s.Head(), s.Len())
+		}
+		return seq, nil
+	}
+}
+
+// RepairOp returns an Operation which merges the features fragmented by
+// other operations, as in `gts repair`.
+func RepairOp() Operation {
+	return func(seq Sequence) (Sequence, error) {
+		return WithFeatures(seq, Repair(seq.Features())), nil
+	}
+}
+
+// ReverseOp returns an Operation which reverses the sequence, as in
+// `gts reverse`.
+func ReverseOp() Operation {
+	return func(seq Sequence) (Sequence, error) {
+		return Reverse(seq), nil
+	}
+}
+
+// RotateOp returns an Operation which rotates the sequence so that the first
+// region found by the locator starts at the origin and marks the sequence as
+// circular, as in `gts rotate`.
+func RotateOp(locate Locator) Operation {
+	return func(seq Sequence) (Sequence, error) {
+		if rr := locate(seq); len(rr) > 0 {
+			seq = Rotate(seq, -rr[0].Head())
+		}
+		return WithTopology(seq, Circular), nil
+	}
+}
+
+// SelectOp returns an Operation which retains the features matching the
+// filter, as in `gts select`.
+func SelectOp(filter Filter) Operation {
+	return func(seq Sequence) (Sequence, error) {
+		return WithFeatures(seq, seq.Features().Filter(filter)), nil
+	}
+}
@@ -0,0 +1,65 @@
+package gts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestPipeline(t *testing.T) {
+	props := Props{}
+	ff := []Feature{
+		NewFeature("source", Range(0, 8), props),
+		NewFeature("gene", Range(2, 4), props),
+	}
+	seq := New(nil, ff, []byte("atgcatgc"))
+
+	locate, err := AsLocator("3..4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := Pipeline{ClearOp(), DeleteOp(locate, false), ReverseOp(), ComplementOp()}
+	out, err := p.Apply(seq)
+	if err != nil {
+		t.Fatalf("p.Apply(seq): %v", err)
+	}
+	testutils.Equals(t, out.Bytes(), []byte("gcatat"))
+	testutils.Equals(t, len(out.Features()), 1)
+	testutils.Equals(t, out.Features()[0].Key, "source")
+
+	in := newSeqWithTest(Linear, ff, []byte("atgcatgc"))
+	out, err = Pipeline{RotateOp(locate), Pipeline{ReverseOp()}.Apply}.Apply(in)
+	if err != nil {
+		t.Fatalf("p.Apply(seq): %v", err)
+	}
+	testutils.Equals(t, out.Bytes(), []byte("tacgtacg"))
+	testutils.Equals(t, out.Info(), Circular)
+
+	out, err = Pipeline{SelectOp(Key("gene")), RepairOp()}.Apply(seq)
+	if err != nil {
+		t.Fatalf("p.Apply(seq): %v", err)
+	}
+	testutils.Equals(t, len(out.Features()), 1)
+	testutils.Equals(t, out.Features()[0].Key, "gene")
+
+	fail := func(seq Sequence) (Sequence, error) {
+		return nil, errors.New("failed")
+	}
+	if _, err := (Pipeline{ReverseOp(), fail}).Apply(seq); err == nil || err.Error() != "step 2: failed" {
+		t.Errorf("expected error from step 2, got %v", err)
+	}
+
+	outside, err := AsLocator("5..12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DeleteOp(outside, false)(seq); err == nil {
+		t.Error("expected error while deleting a region outside of the sequence")
+	}
+
+	if _, err := ComplementOp()(New(nil, nil, []byte("MEFL"))); err == nil {
+		t.Error("expected error while complementing an amino acid sequence")
+	}
+}
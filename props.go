@@ -1,5 +1,7 @@
 package gts
 
+import "sort"
+
 type Props [][]string
 
 func (props Props) Index(key string) int {
@@ -82,3 +84,117 @@ func (props Props) Clone() Props {
 	}
 	return ret
 }
+
+// Sorted returns a copy of the props in which the qualifiers are sorted in the
+// conventional order of FeatureQualifierOrder, followed by the other
+// qualifiers in lexicographical order. The values of each qualifier
+// keep their original order.
+func (props Props) Sorted() Props {
+	return props.SortedFor("")
+}
+
+// SortedFor is like Sorted but uses the conventional qualifier order for a
+// feature with the given key.
+func (props Props) SortedFor(key string) Props {
+	rank := qualifierRank(QualifierOrder(key))
+	ret := props.Clone()
+	sort.SliceStable(ret, func(i, j int) bool {
+		ri, oki := rank[ret[i][0]]
+		rj, okj := rank[ret[j][0]]
+		switch {
+		case oki && okj:
+			return ri < rj
+		case oki != okj:
+			return oki
+		default:
+			return ret[i][0] < ret[j][0]
+		}
+	})
+	return ret
+}
+
+// Dedup returns a copy of the props in which the duplicate values of each
+// qualifier are removed, keeping the first occurrence. Two values of a
+// qualifier are duplicates if the given function returns the same key for
+// them. Values for which the function returns an empty string are always
+// kept.
+func (props Props) Dedup(key func(name, value string) string) Props {
+	ret := make(Props, len(props))
+	for i, prop := range props {
+		name := prop[0]
+		seen := make(map[string]bool)
+		values := []string{name}
+		for _, value := range prop[1:] {
+			k := key(name, value)
+			if k != "" && seen[k] {
+				continue
+			}
+			seen[k] = true
+			values = append(values, value)
+		}
+		ret[i] = values
+	}
+	return ret
+}
+
+// Ordered returns a copy of the props in which the qualifiers with the given
+// names come first in the given order, followed by the other qualifiers in
+// their original order.
+func (props Props) Ordered(names []string) Props {
+	rank := qualifierRank(names)
+	ret := props.Clone()
+	sort.SliceStable(ret, func(i, j int) bool {
+		ri, oki := rank[ret[i][0]]
+		rj, okj := rank[ret[j][0]]
+		switch {
+		case oki && okj:
+			return ri < rj
+		default:
+			return oki && !okj
+		}
+	})
+	return ret
+}
+
+// Conventional orders of qualifiers in INSDC flat files.
+var (
+	SourceQualifierOrder = []string{
+		"organism", "organelle", "mol_type", "submitter_seqid", "strain",
+		"sub_strain", "isolate", "serotype", "serovar", "cultivar",
+		"variety", "ecotype", "specimen_voucher", "culture_collection",
+		"type_material", "host", "lab_host", "isolation_source", "db_xref",
+		"chromosome", "segment", "plasmid", "map", "clone", "tissue_type",
+		"dev_stage", "sex", "country", "lat_lon", "collection_date",
+		"collected_by", "identified_by", "environmental_sample", "note",
+	}
+
+	FeatureQualifierOrder = []string{
+		"gene", "gene_synonym", "allele", "locus_tag", "old_locus_tag",
+		"operon", "standard_name", "pseudo", "pseudogene", "partial",
+		"trans_splicing", "ribosomal_slippage", "exception", "EC_number",
+		"inference", "experiment", "note", "codon_start", "transl_except",
+		"transl_table", "anticodon", "ncRNA_class", "regulatory_class",
+		"rpt_type", "rpt_family", "rpt_unit_range", "rpt_unit_seq",
+		"mobile_element_type", "bound_moiety", "function", "product",
+		"protein_id", "db_xref", "translation",
+	}
+)
+
+// QualifierOrder returns the conventional order of qualifiers for a feature
+// with the given key.
+func QualifierOrder(key string) []string {
+	if key == "source" {
+		return SourceQualifierOrder
+	}
+	return FeatureQualifierOrder
+}
+
+func qualifierRank(names []string) map[string]int {
+	rank := make(map[string]int, len(names))
+	for i, name := range names {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+	return rank
+}
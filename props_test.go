@@ -22,3 +22,51 @@ func TestProps(t *testing.T) {
 	testutils.Equals(t, p.Get("foo") == nil, true)
 	testutils.Equals(t, p.Has("foo"), false)
 }
+
+func TestPropsSorted(t *testing.T) {
+	p := Props{}
+	p.Add("note", "foo", "bar")
+	p.Add("gene", "baz")
+	q := p.Sorted()
+	testutils.Equals(t, q.Keys(), []string{"gene", "note"})
+	testutils.Equals(t, q.Get("note"), []string{"foo", "bar"})
+	testutils.Equals(t, p.Keys(), []string{"note", "gene"})
+
+	p = Props{}
+	p.Add("translation", "MK")
+	p.Add("foo", "bar")
+	p.Add("db_xref", "taxon:1")
+	p.Add("bar", "baz")
+	p.Add("organism", "foo")
+	testutils.Equals(t, p.Sorted().Keys(), []string{"db_xref", "translation", "bar", "foo", "organism"})
+	testutils.Equals(t, p.SortedFor("source").Keys(), []string{"organism", "db_xref", "bar", "foo", "translation"})
+}
+
+func TestPropsDedup(t *testing.T) {
+	p := Props{}
+	p.Add("db_xref", "GeneID:1", "GeneID:2", "GeneID:1")
+	p.Add("note", "foo", "foo", "bar")
+	p.Add("experiment", "x", "x")
+	q := p.Dedup(func(name, value string) string {
+		if name == "experiment" {
+			return ""
+		}
+		return value
+	})
+	testutils.Equals(t, q.Get("db_xref"), []string{"GeneID:1", "GeneID:2"})
+	testutils.Equals(t, q.Get("note"), []string{"foo", "bar"})
+	testutils.Equals(t, q.Get("experiment"), []string{"x", "x"})
+	testutils.Equals(t, p.Get("note"), []string{"foo", "foo", "bar"})
+}
+
+func TestPropsOrdered(t *testing.T) {
+	p := Props{}
+	p.Add("translation", "MK")
+	p.Add("note", "foo")
+	p.Add("product", "bar")
+	p.Add("foo", "bar")
+	p.Add("gene", "baz")
+	q := p.Ordered([]string{"gene", "locus_tag", "product", "translation"})
+	testutils.Equals(t, q.Keys(), []string{"gene", "product", "translation", "note", "foo"})
+	testutils.Equals(t, p.Keys(), []string{"translation", "note", "product", "foo", "gene"})
+}
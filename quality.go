@@ -0,0 +1,112 @@
+package gts
+
+// MeanQuality returns the mean of the given Phred quality scores, or 0 if
+// there are no scores.
+func MeanQuality(q []byte) float64 {
+	if len(q) == 0 {
+		return 0
+	}
+	total := 0
+	for _, v := range q {
+		total += int(v)
+	}
+	return float64(total) / float64(len(q))
+}
+
+// QualityTrim returns the number of bases to keep from the 5' end of a read
+// with the given Phred quality scores by a sliding window. The windows of the
+// given size are examined from the 5' end, and the read is cut within the
+// first window whose mean quality falls below the threshold, keeping the
+// bases of the window preceding the first base below the threshold. A read
+// shorter than the window is examined as a single window.
+func QualityTrim(q []byte, window int, threshold float64) int {
+	if window <= 0 || len(q) == 0 {
+		return len(q)
+	}
+	window = Min(window, len(q))
+	sum := 0
+	for _, v := range q[:window] {
+		sum += int(v)
+	}
+	limit := threshold * float64(window)
+	for i := 0; i+window <= len(q); i++ {
+		if i > 0 {
+			sum += int(q[i+window-1]) - int(q[i-1])
+		}
+		if float64(sum) < limit {
+			for float64(q[i]) >= threshold {
+				i++
+			}
+			return i
+		}
+	}
+	return len(q)
+}
+
+// AdapterPosition returns the position at which the adapter starts within
+// the sequence, or the length of the sequence if the adapter is not found.
+// The adapter is found as in MatchApprox allowing up to the given number of
+// mismatches. An adapter which extends beyond the 3' end of the sequence is
+// found if at least minOverlap bases of the adapter overlap with the
+// sequence, allowing a proportional number of mismatches.
+func AdapterPosition(seq, adapter Sequence, mismatches, minOverlap int) int {
+	p, q := seq.Bytes(), adapter.Bytes()
+	if len(q) == 0 {
+		return len(p)
+	}
+	if hits := MatchApprox(seq, adapter, mismatches); len(hits) > 0 {
+		return hits[0].Segment[0]
+	}
+	for i := Max(len(p)-len(q)+1, 0); i <= len(p)-Max(minOverlap, 1); i++ {
+		n := len(p) - i
+		tail, head := New(nil, nil, p[i:]), New(nil, nil, q[:n])
+		if len(MatchApprox(tail, head, mismatches*n/len(q))) > 0 {
+			return i
+		}
+	}
+	return len(p)
+}
+
+type hasQualityScores interface {
+	QualityScores() []byte
+}
+
+type hasWithQualityScores interface {
+	WithQualityScores(q []byte) Sequence
+}
+
+// QualityScores returns the Phred quality score of each base of the given
+// Sequence if it implements the `QualityScores() []byte` method. If the
+// sequence has no quality scores, or the number of scores does not match the
+// length of the sequence, nil is returned.
+func QualityScores(seq Sequence) []byte {
+	if v, ok := seq.(hasQualityScores); ok {
+		if q := v.QualityScores(); q != nil && len(q) == Len(seq) {
+			return q
+		}
+	}
+	return nil
+}
+
+// WithQualityScores creates a shallow copy of the given Sequence object and
+// swaps the quality scores with the given scores. If the sequence does not
+// implement the `WithQualityScores(q []byte) Sequence` method, the sequence
+// is returned as is.
+func WithQualityScores(seq Sequence, q []byte) Sequence {
+	if v, ok := seq.(hasWithQualityScores); ok {
+		return v.WithQualityScores(q)
+	}
+	return seq
+}
+
+// mapQualityScores sets the quality scores of the given sequence to the
+// scores of the original sequence transformed by the given function, so that
+// the scores follow the bases through the manipulation of the sequence. The
+// function is given a copy of the scores which it may modify in place.
+func mapQualityScores(seq, orig Sequence, f func(q []byte) []byte) Sequence {
+	q := QualityScores(orig)
+	if q == nil {
+		return seq
+	}
+	return WithQualityScores(seq, f(append([]byte{}, q...)))
+}
@@ -0,0 +1,107 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestMeanQuality(t *testing.T) {
+	testutils.Equals(t, MeanQuality(nil), 0.0)
+	testutils.Equals(t, MeanQuality([]byte{10, 20, 30, 40}), 25.0)
+}
+
+var qualityTrimTests = []struct {
+	in        []byte
+	window    int
+	threshold float64
+	out       int
+}{
+	{nil, 4, 20, 0},
+	{[]byte{30, 30, 30, 30, 30, 30}, 4, 20, 6},
+	{[]byte{30, 30, 30, 30, 30, 30}, 0, 20, 6},
+	{[]byte{30, 30, 30, 30, 2, 2, 2, 2}, 4, 20, 4},
+	{[]byte{30, 30, 30, 30, 30, 2, 30, 2, 2}, 4, 20, 5},
+	{[]byte{2, 2, 30, 30, 30, 30}, 4, 20, 0},
+	{[]byte{30, 2}, 4, 20, 1},
+	{[]byte{30, 30}, 4, 20, 2},
+}
+
+func TestQualityTrim(t *testing.T) {
+	for _, tt := range qualityTrimTests {
+		out := QualityTrim(tt.in, tt.window, tt.threshold)
+		if out != tt.out {
+			t.Errorf("QualityTrim(%v, %d, %v) = %d, want %d", tt.in, tt.window, tt.threshold, out, tt.out)
+		}
+	}
+}
+
+var adapterPositionTests = []struct {
+	seq, adapter        string
+	mismatches, overlap int
+	out                 int
+}{
+	{"acgtacgtacgtAGATCGGAAGAGC", "AGATCGGAAGAGC", 0, 3, 12},
+	{"acgtacgtacgtAGATCGGTAGAGCacgt", "AGATCGGAAGAGC", 1, 3, 12},
+	{"acgtacgtacgtAGATCGGTAGAGC", "AGATCGGAAGAGC", 0, 3, 25},
+	{"acgtacgtacgtAGATC", "AGATCGGAAGAGC", 0, 3, 12},
+	{"acgtacgtacgtcAG", "AGATCGGAAGAGC", 0, 3, 15},
+	{"acgtacgtacgtcAGA", "AGATCGGAAGAGC", 0, 3, 13},
+	{"acgtacgtacgt", "", 0, 3, 12},
+	{"acgt", "AGATCGGAAGAGC", 0, 3, 4},
+}
+
+func TestAdapterPosition(t *testing.T) {
+	for _, tt := range adapterPositionTests {
+		seq, adapter := New(nil, nil, []byte(tt.seq)), New(nil, nil, []byte(tt.adapter))
+		out := AdapterPosition(seq, adapter, tt.mismatches, tt.overlap)
+		if out != tt.out {
+			t.Errorf("AdapterPosition(%q, %q, %d, %d) = %d, want %d", tt.seq, tt.adapter, tt.mismatches, tt.overlap, out, tt.out)
+		}
+	}
+}
+
+type qualitySequence struct {
+	data    []byte
+	quality []byte
+}
+
+func (seq qualitySequence) Info() interface{}           { return nil }
+func (seq qualitySequence) Features() FeatureSlice      { return nil }
+func (seq qualitySequence) Bytes() []byte               { return seq.data }
+func (seq qualitySequence) QualityScores() []byte       { return seq.quality }
+func (seq qualitySequence) WithBytes(p []byte) Sequence { return qualitySequence{p, nil} }
+
+func (seq qualitySequence) WithInfo(info interface{}) Sequence { return seq }
+func (seq qualitySequence) WithFeatures(ff []Feature) Sequence { return seq }
+
+func (seq qualitySequence) WithQualityScores(q []byte) Sequence {
+	return qualitySequence{seq.data, q}
+}
+
+func TestQualityScores(t *testing.T) {
+	seq := qualitySequence{[]byte("acgtac"), []byte{1, 2, 3, 4, 5, 6}}
+	plain := New(nil, nil, []byte("gg"))
+
+	testutils.Equals(t, QualityScores(plain), []byte(nil))
+	testutils.Equals(t, QualityScores(qualitySequence{[]byte("acgt"), []byte{1}}), []byte(nil))
+	testutils.Equals(t, WithQualityScores(plain, []byte{1, 2}), plain)
+
+	tests := []struct {
+		in  Sequence
+		out Sequence
+	}{
+		{Slice(seq, 1, 4), qualitySequence{[]byte("cgt"), []byte{2, 3, 4}}},
+		{Reverse(seq), qualitySequence{[]byte("catgca"), []byte{6, 5, 4, 3, 2, 1}}},
+		{Rotate(seq, 2), qualitySequence{[]byte("acacgt"), []byte{5, 6, 1, 2, 3, 4}}},
+		{Delete(seq, 1, 2), qualitySequence{[]byte("atac"), []byte{1, 4, 5, 6}}},
+		{Insert(seq, 2, qualitySequence{[]byte("tt"), []byte{9, 9}}), qualitySequence{[]byte("acttgtac"), []byte{1, 2, 9, 9, 3, 4, 5, 6}}},
+		{Concat(seq, qualitySequence{[]byte("tt"), []byte{9, 9}}), qualitySequence{[]byte("acgtactt"), []byte{1, 2, 3, 4, 5, 6, 9, 9}}},
+		{Concat(seq, plain), qualitySequence{[]byte("acgtacgg"), nil}},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, tt.in.Bytes(), tt.out.Bytes())
+		testutils.Equals(t, QualityScores(tt.in), QualityScores(tt.out))
+	}
+}
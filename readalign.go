@@ -0,0 +1,192 @@
+package gts
+
+import (
+	"bytes"
+)
+
+// AlignedPair represents a column of an alignment between a reference and a
+// read, given as the offsets of the bases in each sequence. The offset of the
+// sequence missing a base in the column is -1.
+type AlignedPair struct {
+	Ref  int
+	Read int
+}
+
+// ReadAlignment represents the local alignment of a read to a reference
+// sequence. If Reverse is true, the reverse complement of the read is
+// aligned, and the offsets in the read are given relative to the reverse
+// complement.
+type ReadAlignment struct {
+	Reverse bool
+	Score   int
+	Pairs   []AlignedPair
+}
+
+// Scores of the read alignment.
+const (
+	readMatchScore    = 1
+	readMismatchScore = -2
+	readGapScore      = -2
+)
+
+// maxReadCells is the maximum number of cells of the alignment matrix of a
+// read. Reads requiring a larger matrix are left unaligned.
+const maxReadCells = 1 << 24
+
+// readSeedHits is the minimum number of k-mers a read must share with the
+// reference on the same diagonal to be aligned.
+const readSeedHits = 2
+
+func reverseComplementBytes(p []byte) []byte {
+	q := replaceBytes(p, []byte("acgturykmbdhv"), []byte("tgcaayrmkvhdb"))
+	for i, j := 0, len(q)-1; i < j; i, j = i+1, j-1 {
+		q[i], q[j] = q[j], q[i]
+	}
+	return q
+}
+
+// readDiagonal returns the most frequent offset between the occurrences of
+// the k-mers of the read in the reference and in the read, and the number of
+// k-mers sharing the offset.
+func readDiagonal(index map[string][]int, read []byte, k int) (int, int) {
+	counts := make(map[int]int)
+	diag, hits := 0, 0
+	for j := 0; j+k <= len(read); j++ {
+		for _, i := range index[string(read[j:j+k])] {
+			d := i - j
+			counts[d]++
+			if counts[d] > hits || (counts[d] == hits && d < diag) {
+				diag, hits = d, counts[d]
+			}
+		}
+	}
+	return diag, hits
+}
+
+func readScore(a, b byte) int {
+	switch {
+	case a == 'n' || b == 'n':
+		return 0
+	case a == b:
+		return readMatchScore
+	default:
+		return readMismatchScore
+	}
+}
+
+// alignLocal computes the local alignment of the read to the window of the
+// reference starting at the given offset.
+func alignLocal(window, read []byte, offset int) (int, []AlignedPair) {
+	n, m := len(read), len(window)
+	w := m + 1
+
+	const (
+		stop byte = iota
+		diag
+		up
+		left
+	)
+
+	trace := make([]byte, (n+1)*w)
+	prev, curr := make([]int, w), make([]int, w)
+	best, bi, bj := 0, 0, 0
+	for i := 1; i <= n; i++ {
+		curr[0] = 0
+		for j := 1; j <= m; j++ {
+			score, dir := 0, stop
+			if s := prev[j-1] + readScore(window[j-1], read[i-1]); s > score {
+				score, dir = s, diag
+			}
+			if s := prev[j] + readGapScore; s > score {
+				score, dir = s, up
+			}
+			if s := curr[j-1] + readGapScore; s > score {
+				score, dir = s, left
+			}
+			curr[j] = score
+			trace[i*w+j] = dir
+			if score > best {
+				best, bi, bj = score, i, j
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	pairs := []AlignedPair{}
+	for i, j := bi, bj; i > 0 && j > 0 && trace[i*w+j] != stop; {
+		switch trace[i*w+j] {
+		case diag:
+			i, j = i-1, j-1
+			pairs = append(pairs, AlignedPair{offset + j, i})
+		case up:
+			i--
+			pairs = append(pairs, AlignedPair{-1, i})
+		default:
+			j--
+			pairs = append(pairs, AlignedPair{offset + j, -1})
+		}
+	}
+
+	for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+	return best, pairs
+}
+
+// AlignRead computes the local alignment of a read, such as a basecalled
+// Sanger read, to a reference sequence in either orientation. The read is
+// placed on the reference by the k-mers shared between the sequences, and
+// aligned to the reference around the placement. The ends of the read which
+// do not agree with the reference, such as low quality bases, are left out
+// of the alignment. If the reference is circular, the read may span the
+// origin, and the offsets in the reference are given modulo its length. The
+// alignment ignores the case of the bases and treats `N` as an unknown base.
+// The second return value is false if the read could not be placed.
+func AlignRead(ref, read []byte, k int, circular bool) (ReadAlignment, bool) {
+	n := len(ref)
+	if n == 0 || len(read) == 0 || k <= 0 {
+		return ReadAlignment{}, false
+	}
+
+	ref, read = bytes.ToLower(ref), bytes.ToLower(read)
+	if circular {
+		ref = append(append([]byte{}, ref...), ref[:Min(n, len(read))]...)
+	}
+
+	index := make(map[string][]int)
+	for i := 0; i+k <= len(ref); i++ {
+		key := string(ref[i : i+k])
+		index[key] = append(index[key], i)
+	}
+
+	forward := read
+	reverse := reverseComplementBytes(read)
+	fdiag, fhits := readDiagonal(index, forward, k)
+	rdiag, rhits := readDiagonal(index, reverse, k)
+
+	rev, diag, hits := false, fdiag, fhits
+	if rhits > fhits {
+		rev, diag, hits, read = true, rdiag, rhits, reverse
+	}
+	if hits < readSeedHits {
+		return ReadAlignment{}, false
+	}
+
+	pad := len(read)/10 + 20
+	start := Max(0, diag-pad)
+	end := Min(len(ref), diag+len(read)+pad)
+	if start >= end || (end-start)*len(read) > maxReadCells {
+		return ReadAlignment{}, false
+	}
+
+	score, pairs := alignLocal(ref[start:end], read, start)
+	if len(pairs) == 0 {
+		return ReadAlignment{}, false
+	}
+	for i := range pairs {
+		if pairs[i].Ref >= 0 {
+			pairs[i].Ref %= n
+		}
+	}
+	return ReadAlignment{rev, score, pairs}, true
+}
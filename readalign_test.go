@@ -0,0 +1,46 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestAlignRead(t *testing.T) {
+	ref := []byte(variantTestRef)
+
+	// A read with a substitution and garbage at the ends.
+	read := []byte("ccccc" + variantTestRef[20:30] + "T" + variantTestRef[31:50] + "ttttt")
+	aln, ok := AlignRead(ref, read, 8, false)
+	testutils.Equals(t, ok, true)
+	testutils.Equals(t, aln.Reverse, false)
+	testutils.Equals(t, aln.Pairs[0], AlignedPair{20, 5})
+	testutils.Equals(t, aln.Pairs[len(aln.Pairs)-1], AlignedPair{49, 34})
+	testutils.Equals(t, len(aln.Pairs), 30)
+
+	// A read with a deletion on the reverse strand.
+	read = reverseComplementBytes([]byte(variantTestRef[10:40] + variantTestRef[41:70]))
+	aln, ok = AlignRead(ref, read, 8, false)
+	testutils.Equals(t, ok, true)
+	testutils.Equals(t, aln.Reverse, true)
+	testutils.Equals(t, aln.Pairs[0], AlignedPair{10, 0})
+	testutils.Equals(t, aln.Pairs[len(aln.Pairs)-1], AlignedPair{69, 58})
+	deleted := 0
+	for _, p := range aln.Pairs {
+		if p.Read < 0 {
+			deleted++
+		}
+	}
+	testutils.Equals(t, deleted, 1)
+
+	// A read spanning the origin of a circular reference.
+	read = []byte(variantTestRef[70:] + variantTestRef[:20])
+	aln, ok = AlignRead(ref, read, 8, true)
+	testutils.Equals(t, ok, true)
+	testutils.Equals(t, aln.Pairs[0], AlignedPair{70, 0})
+	testutils.Equals(t, aln.Pairs[len(aln.Pairs)-1], AlignedPair{19, len(read) - 1})
+
+	// An unrelated read cannot be placed.
+	_, ok = AlignRead(ref, []byte("nnnnnnnnnnnnnnnnnnnn"), 8, false)
+	testutils.Equals(t, ok, false)
+}
@@ -146,11 +146,45 @@ func (rr Regions) Complement() Region {
 	return ret
 }
 
+// spansOrigin tests if the segment a is followed by the segment b across the
+// origin of a sequence of the given length.
+func spansOrigin(a, b Segment, length int) bool {
+	switch {
+	case a[0] < a[1] && b[0] < b[1]:
+		return a[1] == length && b[0] == 0 && b[1] < a[0]
+	case a[1] < a[0] && b[1] < b[0]:
+		return a[1] == 0 && b[0] == length && a[0] < b[1]
+	default:
+		return false
+	}
+}
+
+// locateAcrossOrigin locates the subsequence from the head of segment a to
+// the tail of segment b, which spans the origin of the sequence.
+func locateAcrossOrigin(a, b Segment, seq Sequence) Sequence {
+	if a[0] < a[1] {
+		return Slice(seq, a[0], b[1])
+	}
+	return Reverse(Complement(Slice(seq, b[1], a[0])))
+}
+
 // Locate the subsequence corresponding to the region in the given sequence.
+// Segments which are adjacent across the origin of the sequence are located
+// as a single segment, so that any feature spanning the origin is retained as
+// a contiguous feature.
 func (rr Regions) Locate(seq Sequence) Sequence {
-	seqs := make([]Sequence, len(rr))
-	for i, r := range rr {
-		seqs[i] = r.Locate(seq)
+	seqs := []Sequence{}
+	for i := 0; i < len(rr); i++ {
+		if i+1 < len(rr) {
+			a, aok := rr[i].(Segment)
+			b, bok := rr[i+1].(Segment)
+			if aok && bok && spansOrigin(a, b, Len(seq)) {
+				seqs = append(seqs, locateAcrossOrigin(a, b, seq))
+				i++
+				continue
+			}
+		}
+		seqs = append(seqs, rr[i].Locate(seq))
 	}
 	return Concat(seqs...)
 }
@@ -199,6 +233,9 @@ func flattenRegion(arg Region) []Segment {
 			ss = append(ss, flattenRegion(r)...)
 		}
 		return ss
+	case RemoteRegion:
+		// Regions in remote entries have no local segments.
+		return nil
 	default:
 		s := rr.(Segment)
 		if s[1] < s[0] {
@@ -149,6 +149,7 @@ var regionLocateTests = []struct {
 	{Segment{2, 6}, New(nil, nil, []byte("gcat"))},
 	{Segment{6, 2}, New(nil, nil, []byte("atgc"))},
 	{Regions{Segment{0, 2}, Segment{4, 6}}, New(nil, nil, []byte("atat"))},
+	{Regions{Segment{6, 8}, Segment{0, 2}}, New(nil, nil, []byte("gcat"))},
 }
 
 func TestRegionLocate(t *testing.T) {
@@ -189,6 +190,31 @@ func TestRegionLocate(t *testing.T) {
 	}
 }
 
+func TestRegionLocateOrigin(t *testing.T) {
+	loc := Join(Range(6, 8), Range(0, 2))
+	ff := []Feature{NewFeature("gene", loc, Props{})}
+	seq := New(nil, ff, []byte("atgcatgc"))
+
+	gg := []Feature{NewFeature("gene", Range(0, 4), Props{})}
+	out, exp := loc.Region().Locate(seq), New(nil, gg, []byte("gcat"))
+	if !featuresEqual(out.Features(), exp.Features()) {
+		t.Errorf("%s.Region().Locate(seq).Features() = %v, want %v", loc, out.Features(), exp.Features())
+	}
+	if !bytesEqual(out.Bytes(), exp.Bytes()) {
+		t.Errorf("%s.Region().Locate(seq).Bytes() = %v, want %v", loc, out.Bytes(), exp.Bytes())
+	}
+
+	cmp := loc.Complement()
+	gg = []Feature{NewFeature("gene", Range(0, 4).Complement(), Props{})}
+	out, exp = cmp.Region().Locate(seq), New(nil, gg, []byte("atgc"))
+	if !featuresEqual(out.Features(), exp.Features()) {
+		t.Errorf("%s.Region().Locate(seq).Features() = %v, want %v", cmp, out.Features(), exp.Features())
+	}
+	if !bytesEqual(out.Bytes(), exp.Bytes()) {
+		t.Errorf("%s.Region().Locate(seq).Bytes() = %v, want %v", cmp, out.Bytes(), exp.Bytes())
+	}
+}
+
 var bySegmentTests = [][]Segment{
 	{{3, 13}, {4, 13}, {6, 14}, {6, 16}},
 	{{13, 3}, {13, 4}, {14, 6}, {16, 6}},
@@ -0,0 +1,219 @@
+package gts
+
+import (
+	"fmt"
+
+	"github.com/go-ascii/ascii"
+	"github.com/go-pars/pars"
+)
+
+// RemoteLocation represents a location in another entry identified by its
+// accession, as in `J00194.1:100..202`. The location is not affected by the
+// modifications made to the local sequence.
+type RemoteLocation struct {
+	Accession string
+	Location  Location
+}
+
+// String satisfies the fmt.Stringer interface.
+func (remote RemoteLocation) String() string {
+	return fmt.Sprintf("%s:%s", remote.Accession, remote.Location)
+}
+
+// Len returns the total length spanned by the location.
+func (remote RemoteLocation) Len() int {
+	return remote.Location.Len()
+}
+
+// Region returns the region pointed to by the location, which can only be
+// located with LocateRegion.
+func (remote RemoteLocation) Region() Region {
+	return RemoteRegion{remote.Accession, remote.Location.Region()}
+}
+
+// Complement returns the complement location.
+func (remote RemoteLocation) Complement() Location {
+	return Complemented{remote}
+}
+
+// Strand returns the strand of the location.
+func (remote RemoteLocation) Strand() Strand {
+	return remote.Location.Strand()
+}
+
+// Reverse returns the location as is, as the remote entry is not reversed.
+func (remote RemoteLocation) Reverse(length int) Location {
+	return remote
+}
+
+// Normalize returns the location as is, as the length of the remote entry is
+// not known.
+func (remote RemoteLocation) Normalize(length int) Location {
+	return remote
+}
+
+// Shift returns the location as is, as the remote entry is not modified.
+func (remote RemoteLocation) Shift(i, n int) Location {
+	return remote
+}
+
+// Expand returns the location as is, as the remote entry is not modified.
+func (remote RemoteLocation) Expand(i, n int) Location {
+	return remote
+}
+
+// RemoteRegion represents a region in another entry identified by its
+// accession.
+type RemoteRegion struct {
+	Accession string
+	Region    Region
+}
+
+// Len returns the length spanned by the region.
+func (remote RemoteRegion) Len() int {
+	return remote.Region.Len()
+}
+
+// Head returns the 5' boundary of the region in the remote entry.
+func (remote RemoteRegion) Head() int {
+	return remote.Region.Head()
+}
+
+// Tail returns the 3' boundary of the region in the remote entry.
+func (remote RemoteRegion) Tail() int {
+	return remote.Region.Tail()
+}
+
+// Resize the region using the given Modifier.
+func (remote RemoteRegion) Resize(mod Modifier) Region {
+	return RemoteRegion{remote.Accession, remote.Region.Resize(mod)}
+}
+
+// Complement returns the equivalent region for the complement strand.
+func (remote RemoteRegion) Complement() Region {
+	return RemoteRegion{remote.Accession, remote.Region.Complement()}
+}
+
+// Locate will panic as the remote entry is not available: use LocateRegion to
+// locate the subsequence with a resolver for the remote entry.
+func (remote RemoteRegion) Locate(seq Sequence) Sequence {
+	panic(fmt.Errorf("cannot locate a region in remote entry %s without a resolver", remote.Accession))
+}
+
+// ResolveFunc retrieves the sequence of the entry with the given accession.
+type ResolveFunc func(accession string) (Sequence, error)
+
+// HasRemote tests if the region refers to a remote entry in part or in whole.
+// Such regions cannot be located with Locate: use LocateRegion instead.
+func HasRemote(region Region) bool {
+	switch v := region.(type) {
+	case RemoteRegion:
+		return true
+	case Regions:
+		for _, r := range v {
+			if HasRemote(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LocateRegion locates the subsequence corresponding to the region in the
+// given sequence, where the regions in remote entries are located in the
+// sequences returned by resolve. An error is returned if resolve is nil and
+// the region refers to a remote entry.
+func LocateRegion(region Region, seq Sequence, resolve ResolveFunc) (Sequence, error) {
+	switch v := region.(type) {
+	case RemoteRegion:
+		if resolve == nil {
+			return nil, fmt.Errorf("cannot locate a region in remote entry %s without a resolver", v.Accession)
+		}
+		remote, err := resolve(v.Accession)
+		if err != nil {
+			return nil, fmt.Errorf("while resolving %s: %v", v.Accession, err)
+		}
+		for _, s := range Minimize(v.Region) {
+			if s[1] > Len(remote) {
+				return nil, fmt.Errorf("region %d..%d is out of bounds for %s of length %d", s[0]+1, s[1], v.Accession, Len(remote))
+			}
+		}
+		return LocateRegion(v.Region, remote, nil)
+	case Regions:
+		seqs := []Sequence{}
+		for i := 0; i < len(v); i++ {
+			if i+1 < len(v) {
+				a, aok := v[i].(Segment)
+				b, bok := v[i+1].(Segment)
+				if aok && bok && spansOrigin(a, b, Len(seq)) {
+					seqs = append(seqs, locateAcrossOrigin(a, b, seq))
+					i++
+					continue
+				}
+			}
+			sub, err := LocateRegion(v[i], seq, resolve)
+			if err != nil {
+				return nil, err
+			}
+			seqs = append(seqs, sub)
+		}
+		return Concat(seqs...), nil
+	default:
+		return region.Locate(seq), nil
+	}
+}
+
+func parseAccessionPrefix(state *pars.State, result *pars.Result) error {
+	state.Push()
+	c, err := pars.Next(state)
+	if err != nil {
+		state.Pop()
+		return err
+	}
+	if !ascii.IsLetter(c) {
+		err := pars.NewError("expected accession", state.Position())
+		state.Pop()
+		return err
+	}
+	state.Advance()
+	p := []byte{c}
+	for {
+		c, err = pars.Next(state)
+		if err != nil {
+			state.Pop()
+			return err
+		}
+		if !ascii.IsLetter(c) && !ascii.IsDigit(c) && c != '_' && c != '.' {
+			break
+		}
+		p = append(p, c)
+		state.Advance()
+	}
+	if c != ':' {
+		err := pars.NewError("expected `:`", state.Position())
+		state.Pop()
+		return err
+	}
+	state.Advance()
+	result.SetToken(p)
+	state.Drop()
+	return nil
+}
+
+var parseRemoteLocation = pars.Any(parseRange, parseBetween, parseAmbiguous, parsePoint)
+
+func parseRemote(state *pars.State, result *pars.Result) error {
+	state.Push()
+	if err := parseAccessionPrefix(state, result); err != nil {
+		state.Pop()
+		return err
+	}
+	accession := string(result.Token)
+	if err := parseRemoteLocation(state, result); err != nil {
+		state.Pop()
+		return err
+	}
+	result.SetValue(RemoteLocation{accession, result.Value.(Location)})
+	state.Drop()
+	return nil
+}
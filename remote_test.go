@@ -0,0 +1,130 @@
+package gts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var remoteLocationTests = []struct {
+	in  string
+	out Location
+}{
+	{"J00194.1:100..202", RemoteLocation{"J00194.1", Range(99, 202)}},
+	{"J00194.1:100", RemoteLocation{"J00194.1", Point(99)}},
+	{"AB_000001:<1..>10", RemoteLocation{"AB_000001", PartialRange(0, 10, PartialBoth)}},
+	{"complement(J00194.1:100..202)", RemoteLocation{"J00194.1", Range(99, 202)}.Complement()},
+	{
+		"join(1..100,J00194.1:1..50)",
+		Join(Range(0, 100), RemoteLocation{"J00194.1", Range(0, 50)}),
+	},
+}
+
+func TestRemoteLocation(t *testing.T) {
+	for _, tt := range remoteLocationTests {
+		loc, err := AsLocation(tt.in)
+		if err != nil {
+			t.Errorf("failed to parse %q: %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, loc, tt.out)
+		testutils.Equals(t, loc.String(), tt.in)
+	}
+
+	for _, in := range []string{"J00194.1:", "J00194.1:join(1..2,3..4)"} {
+		if _, err := AsLocation(in); err == nil {
+			t.Errorf("expected error while parsing %q", in)
+		}
+	}
+
+	remote := RemoteLocation{"J00194.1", Range(99, 202)}
+	testutils.Equals(t, remote.Len(), 103)
+	testutils.Equals(t, remote.Shift(0, 10), Location(remote))
+	testutils.Equals(t, remote.Expand(0, -10), Location(remote))
+	testutils.Equals(t, remote.Reverse(1000), Location(remote))
+	testutils.Equals(t, remote.Normalize(100), Location(remote))
+
+	loc := Join(Range(0, 100), remote)
+	testutils.Equals(t, loc.Shift(0, 10), Join(Range(10, 110), remote))
+	testutils.Equals(t, LocationWithin(loc, 0, 100), true)
+	testutils.Equals(t, Minimize(loc.Region()), []Segment{{0, 100}})
+}
+
+func TestLocateRegion(t *testing.T) {
+	seq := New(nil, nil, []byte("aaaaattttt"))
+	remote := New(nil, nil, []byte("ggggcccc"))
+	resolve := func(accession string) (Sequence, error) {
+		if accession != "X00001.1" {
+			return nil, errors.New("not found")
+		}
+		return remote, nil
+	}
+
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"1..5", "aaaaa"},
+		{"join(1..2,X00001.1:3..6)", "aaggcc"},
+		{"complement(X00001.1:1..5)", "gcccc"},
+		{"join(9..10,1..3)", "ttaaa"},
+		{"complement(join(9..10,1..3))", "tttaa"},
+	}
+
+	for _, tt := range tests {
+		loc, err := AsLocation(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := LocateRegion(loc.Region(), seq, resolve)
+		if err != nil {
+			t.Errorf("LocateRegion(%s): %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, string(out.Bytes()), tt.out)
+	}
+
+	for _, in := range []string{"Y00001.1:1..5", "X00001.1:5..10"} {
+		loc, err := AsLocation(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LocateRegion(loc.Region(), seq, resolve); err == nil {
+			t.Errorf("expected error locating %s", in)
+		}
+	}
+
+	loc := RemoteLocation{"X00001.1", Range(0, 5)}
+	if _, err := LocateRegion(loc.Region(), seq, nil); err == nil {
+		t.Error("expected error locating a remote region without a resolver")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic locating a remote region without a resolver")
+		}
+	}()
+	loc.Region().Locate(seq)
+}
+
+func TestHasRemote(t *testing.T) {
+	tests := []struct {
+		in  string
+		out bool
+	}{
+		{"1..5", false},
+		{"join(1..5,7..9)", false},
+		{"X00001.1:1..5", true},
+		{"join(1..2,X00001.1:3..6)", true},
+		{"complement(join(1..2,X00001.1:3..6))", true},
+	}
+
+	for _, tt := range tests {
+		loc, err := AsLocation(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutils.Equals(t, HasRemote(loc.Region()), tt.out)
+	}
+}
@@ -0,0 +1,300 @@
+package gts
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+)
+
+// maxRegexpCache is the number of compiled regular expressions retained by
+// compileRegexp before the cache is reset.
+const maxRegexpCache = 1024
+
+var regexpCache = struct {
+	sync.Mutex
+	m map[string]*regexp.Regexp
+}{m: make(map[string]*regexp.Regexp)}
+
+// compileRegexp compiles the given regular expression, reusing the result of
+// previous compilations of the same expression.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	regexpCache.Lock()
+	defer regexpCache.Unlock()
+	if re, ok := regexpCache.m[expr]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(regexpCache.m) >= maxRegexpCache {
+		regexpCache.m = make(map[string]*regexp.Regexp)
+	}
+	regexpCache.m[expr] = re
+	return re, nil
+}
+
+// SelectorFlags modify how a selector matches the qualifier values.
+type SelectorFlags int
+
+// Available selector flags. In a selector string, the flags of a single
+// qualifier matcher are given by the letters `i`, `x`, and `f` respectively,
+// following a `:` after the qualifier name (e.g. `/gene:ix=abcA`).
+const (
+	// SelectorFoldCase matches the values without regard to case.
+	SelectorFoldCase SelectorFlags = 1 << iota
+
+	// SelectorFullMatch requires the pattern to match the whole value
+	// instead of any part of it.
+	SelectorFullMatch
+
+	// SelectorPlain interprets the pattern as a plain string instead of a
+	// regular expression.
+	SelectorPlain
+)
+
+var selectorFlagLetters = map[byte]SelectorFlags{
+	'i': SelectorFoldCase,
+	'x': SelectorFullMatch,
+	'f': SelectorPlain,
+}
+
+func parseSelectorFlags(s string) (SelectorFlags, error) {
+	flags := SelectorFlags(0)
+	for i := 0; i < len(s); i++ {
+		flag, ok := selectorFlagLetters[s[i]]
+		if !ok {
+			return 0, fmt.Errorf("unknown selector modifier %q in %q", s[i], s)
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
+// valueMatch is a strategy for matching a qualifier value.
+type valueMatch int
+
+const (
+	matchRegexp valueMatch = iota
+	matchContains
+	matchEqual
+	matchEqualFold
+)
+
+// SelectorTerm represents a single qualifier criterion of a selector.
+type SelectorTerm struct {
+	// Name is the qualifier name. If empty, the names and values of every
+	// qualifier will be tested.
+	Name string
+
+	// Pattern is the pattern which one of the qualifier values must match.
+	// If nil, the qualifier only needs to be present.
+	Pattern *regexp.Regexp
+
+	// Flags are the modifiers used to compile the pattern.
+	Flags SelectorFlags
+
+	// When possible, the pattern is matched as a plain string without the
+	// regexp engine.
+	match   valueMatch
+	literal string
+}
+
+// literalRegexp returns the string matched by the given regular expression
+// if it only consists of a case sensitive literal without any anchors.
+func literalRegexp(re *regexp.Regexp) (string, bool) {
+	tree, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	tree = tree.Simplify()
+	switch {
+	case tree.Op == syntax.OpEmptyMatch:
+		return "", true
+	case tree.Op == syntax.OpLiteral && tree.Flags&syntax.FoldCase == 0:
+		return string(tree.Rune), true
+	default:
+		return "", false
+	}
+}
+
+func newSelectorTerm(name string, re *regexp.Regexp) SelectorTerm {
+	term := SelectorTerm{Name: name, Pattern: re}
+	if re != nil {
+		if literal, ok := literalRegexp(re); ok {
+			term.match, term.literal = matchContains, literal
+		}
+	}
+	return term
+}
+
+func compileSelectorTerm(name, query string, flags SelectorFlags) (SelectorTerm, error) {
+	pattern := query
+	if flags&SelectorPlain != 0 {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if flags&SelectorFullMatch != 0 {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	if flags&SelectorFoldCase != 0 {
+		pattern = "(?i)" + pattern
+	}
+	re, err := compileRegexp(pattern)
+	if err != nil {
+		return SelectorTerm{}, err
+	}
+
+	term := newSelectorTerm(name, re)
+	term.Flags = flags
+	if flags&(SelectorPlain|SelectorFullMatch) == SelectorPlain|SelectorFullMatch {
+		term.literal, term.match = query, matchEqual
+		if flags&SelectorFoldCase != 0 {
+			term.match = matchEqualFold
+		}
+	}
+	return term, nil
+}
+
+func (term SelectorTerm) matchValue(v string) bool {
+	switch term.match {
+	case matchContains:
+		return strings.Contains(v, term.literal)
+	case matchEqual:
+		return v == term.literal
+	case matchEqualFold:
+		return strings.EqualFold(v, term.literal)
+	default:
+		return term.Pattern.MatchString(v)
+	}
+}
+
+// Match reports whether the feature satisfies the term.
+func (term SelectorTerm) Match(f Feature) bool {
+	if term.Name == "" {
+		// Each entry of the props starts with the qualifier name, which is
+		// tested along with the values.
+		for _, vv := range f.Props {
+			for _, v := range vv {
+				if term.matchValue(v) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if term.Pattern == nil {
+		return f.Props.Has(term.Name)
+	}
+
+	for _, v := range f.Props.Get(term.Name) {
+		if term.matchValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompiledSelector is the compiled form of a selector string. The regular
+// expressions of the selector are compiled once, so matching a feature does
+// not involve any further parsing or compilation. A CompiledSelector is safe
+// for concurrent use.
+type CompiledSelector struct {
+	Source string // The selector string.
+	Key    string // The feature key, or empty to match any key.
+	Terms  []SelectorTerm
+}
+
+// CompileSelector compiles the given selector string. A selector in GTS is
+// defined as follows:
+//
+//	[feature_key]/qualifier_name[:flags]=regexp[/qualifier_name[:flags]=regexp]...
+//
+// If the qualifier name is omitted, the names and values of every qualifier
+// will be tested. The given flags apply to every qualifier matcher in
+// addition to the flags given in the selector string.
+func CompileSelector(sel string, flags SelectorFlags) (*CompiledSelector, error) {
+	head, tail := shiftSelector(sel)
+	cs := &CompiledSelector{Source: sel, Key: head}
+	for tail != "" {
+		head, tail = shiftSelector(tail)
+		name, query, hasQuery := head, "", false
+		if i := strings.IndexByte(head, '='); i >= 0 {
+			name, query, hasQuery = head[:i], head[i+1:], true
+		}
+
+		termFlags := flags
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			local, err := parseSelectorFlags(name[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			name, termFlags = name[:i], termFlags|local
+		}
+
+		if name != "" && query == "" && !(hasQuery && termFlags&SelectorFullMatch != 0) {
+			cs.Terms = append(cs.Terms, newSelectorTerm(name, nil))
+			continue
+		}
+
+		term, err := compileSelectorTerm(name, query, termFlags)
+		if err != nil {
+			return nil, err
+		}
+		cs.Terms = append(cs.Terms, term)
+	}
+	return cs, nil
+}
+
+// Match reports whether the feature satisfies the selector.
+func (cs *CompiledSelector) Match(f Feature) bool {
+	if cs.Key != "" && f.Key != cs.Key {
+		return false
+	}
+	for _, term := range cs.Terms {
+		if !term.Match(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the selector as a Filter.
+func (cs *CompiledSelector) Filter() Filter {
+	return cs.Match
+}
+
+// String satisfies the fmt.Stringer interface.
+func (cs *CompiledSelector) String() string {
+	return cs.Source
+}
+
+func shiftSelector(s string) (string, string) {
+	esc := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			esc = true
+		case '/':
+			if !esc {
+				return s[:i], s[i+1:]
+			}
+		default:
+			esc = false
+		}
+	}
+	return s, ""
+}
+
+// Selector generates a new Filter which will return true if a given Feature
+// satisfies the criteria specified by the selection string. See
+// CompileSelector for the selector syntax.
+func Selector(sel string) (Filter, error) {
+	cs, err := CompileSelector(sel, 0)
+	if err != nil {
+		return FalseFilter, err
+	}
+	return cs.Filter(), nil
+}
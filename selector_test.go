@@ -0,0 +1,104 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var compileSelectorTests = []struct {
+	sel   string
+	flags SelectorFlags
+	in    Feature
+	out   bool
+}{
+	{"", 0, sampleGeneFeature, true},
+	{"gene", 0, sampleGeneFeature, true},
+	{"CDS", 0, sampleGeneFeature, false},
+	{"gene/locus_tag", 0, sampleGeneFeature, true},
+	{"gene/gene", 0, sampleGeneFeature, false},
+	{"gene/locus_tag=p04", 0, sampleGeneFeature, true},
+	{"gene/locus_tag=p05", 0, sampleGeneFeature, false},
+	{"gene/locus_tag=^phiX174p0[0-9]$", 0, sampleGeneFeature, true},
+	{"gene/locus_tag=^p04$", 0, sampleGeneFeature, false},
+	{"gene/locus_tag=^phiX174p04$", 0, sampleGeneFeature, true},
+	{"gene/locus_tag=(?i)PHIX", 0, sampleGeneFeature, true},
+	{"/locus_tag=PHIX", 0, sampleGeneFeature, false},
+	{"/locus_tag=PHIX", SelectorFoldCase, sampleGeneFeature, true},
+	{"/=phiX174p04", 0, sampleGeneFeature, true},
+	{"/=locus_tag", 0, sampleGeneFeature, true},
+	{"/=^gene$", 0, sampleGeneFeature, false},
+	{"source/mol_type=dna", SelectorFoldCase, sampleSourceFeature, true},
+	{"source/mol_type=Genomic\\/DNA", 0, sampleSourceFeature, false},
+	{"/locus_tag:i=PHIX", 0, sampleGeneFeature, true},
+	{"/locus_tag:x=p04", 0, sampleGeneFeature, false},
+	{"/locus_tag:x=phiX174p0[0-9]", 0, sampleGeneFeature, true},
+	{"/locus_tag:ix=PHIX174P04", 0, sampleGeneFeature, true},
+	{"/locus_tag:f=p0[0-9]", 0, sampleGeneFeature, false},
+	{"/locus_tag:f=174p", 0, sampleGeneFeature, true},
+	{"/locus_tag:fx=phiX174p04", 0, sampleGeneFeature, true},
+	{"/locus_tag:fx=phiX174p0", 0, sampleGeneFeature, false},
+	{"/locus_tag:fix=PHIX174P04", 0, sampleGeneFeature, true},
+	{"/locus_tag=phiX174p0", SelectorFullMatch, sampleGeneFeature, false},
+	{"/locus_tag=phiX174p0.", SelectorFullMatch, sampleGeneFeature, true},
+	{"/locus_tag=phiX174p0.", SelectorFullMatch | SelectorPlain, sampleGeneFeature, false},
+	{"/:i=PHIX174", 0, sampleGeneFeature, true},
+	{"/locus_tag:x=", 0, sampleGeneFeature, false},
+	{"/locus_tag:i", 0, sampleGeneFeature, true},
+}
+
+func TestCompileSelector(t *testing.T) {
+	for _, tt := range compileSelectorTests {
+		cs, err := CompileSelector(tt.sel, tt.flags)
+		if err != nil {
+			t.Errorf("CompileSelector(%q, %d): %v", tt.sel, tt.flags, err)
+			continue
+		}
+		if out := cs.Match(tt.in); out != tt.out {
+			t.Errorf("CompileSelector(%q, %d).Match(%v) = %t, want %t", tt.sel, tt.flags, tt.in, out, tt.out)
+		}
+		if out := cs.Filter()(tt.in); out != tt.out {
+			t.Errorf("CompileSelector(%q, %d).Filter()(%v) = %t, want %t", tt.sel, tt.flags, tt.in, out, tt.out)
+		}
+		testutils.Equals(t, cs.String(), tt.sel)
+	}
+
+	for _, sel := range []string{"gene/locus_tag=(", "gene/locus_tag:q=foo"} {
+		if _, err := CompileSelector(sel, 0); err == nil {
+			t.Errorf("expected error in CompileSelector(%q, 0)", sel)
+		}
+	}
+}
+
+func TestCompileRegexp(t *testing.T) {
+	re, err := compileRegexp("phiX174p0[0-9]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := compileRegexp("phiX174p0[0-9]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if re != again {
+		t.Error("compileRegexp did not reuse the compiled expression")
+	}
+}
+
+func BenchmarkSelector(b *testing.B) {
+	ff := make(FeatureSlice, 0, 10000)
+	for i := 0; i < cap(ff)/2; i++ {
+		ff = append(ff, sampleGeneFeature, sampleCDSFeature)
+	}
+	for _, sel := range []string{"CDS/locus_tag=p05", "CDS/locus_tag=^phiX174p0[0-9]$"} {
+		b.Run(sel, func(b *testing.B) {
+			filter, err := Selector(sel)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ff.Filter(filter)
+			}
+		})
+	}
+}
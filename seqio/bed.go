@@ -0,0 +1,246 @@
+package seqio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// BEDWriter writes the features of a gts.Sequence to an io.Writer in the BED
+// format. If blocks is true, the features are written in BED12 with each
+// segment of a complex location as a block, and in BED6 spanning the whole
+// location otherwise.
+type BEDWriter struct {
+	w      io.Writer
+	blocks bool
+}
+
+// bedName returns the name of a feature: the first of the gene name, locus
+// tag, label, or standard name, or the feature key if none are present.
+func bedName(f gts.Feature) string {
+	for _, name := range gff3NameQualifiers {
+		if values := f.Props.Get(name); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return f.Key
+}
+
+// bedRecords splits the segments of a location, given in the order of
+// transcription, into the runs which do not cross the origin of a circular
+// sequence. The segments of each run are sorted in ascending order.
+func bedRecords(ss []gts.Segment, reverse bool) [][]gts.Segment {
+	runs := [][]gts.Segment{}
+	run := []gts.Segment{}
+	last := gts.Segment{}
+	for i, s := range ss {
+		head, tail := gts.Unpack(s)
+		if tail < head {
+			head, tail = tail, head
+		}
+		s = gts.Segment{head, tail}
+		wraps := s[0] < last[1]
+		if reverse {
+			wraps = s[1] > last[0]
+		}
+		if i > 0 && wraps {
+			runs = append(runs, run)
+			run = []gts.Segment{}
+		}
+		run = append(run, s)
+		last = s
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+
+	for _, run := range runs {
+		sort.Slice(run, func(i, j int) bool {
+			return run[i][0] < run[j][0]
+		})
+	}
+	return runs
+}
+
+func formatBED(seq gts.Sequence, blocks bool) string {
+	b := strings.Builder{}
+	chrom := ID(seq)
+	protein := Molecule(seq) == gts.AA
+
+	for _, f := range seq.Features() {
+		name := strings.ReplaceAll(bedName(f), "\t", " ")
+
+		strand := "+"
+		reverse := gts.CheckStrand(f.Loc) == gts.StrandReverse
+		switch {
+		case protein:
+			strand = "."
+		case reverse:
+			strand = "-"
+		}
+
+		for _, run := range bedRecords(gff3Segments(f.Loc.Region()), reverse) {
+			start, end := run[0][0], run[len(run)-1][1]
+			if !blocks {
+				fmt.Fprintf(&b, "%s\t%d\t%d\t%s\t0\t%s\n", chrom, start, end, name, strand)
+				continue
+			}
+
+			thickStart, thickEnd := start, start
+			if f.Key == "CDS" {
+				thickEnd = end
+			}
+
+			sizes := make([]string, len(run))
+			starts := make([]string, len(run))
+			for i, s := range run {
+				sizes[i] = strconv.Itoa(s[1] - s[0])
+				starts[i] = strconv.Itoa(s[0] - start)
+			}
+
+			fmt.Fprintf(
+				&b, "%s\t%d\t%d\t%s\t0\t%s\t%d\t%d\t0\t%d\t%s,\t%s,\n",
+				chrom, start, end, name, strand, thickStart, thickEnd,
+				len(run), strings.Join(sizes, ","), strings.Join(starts, ","),
+			)
+		}
+	}
+
+	return b.String()
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. The features are written
+// with the identifier of the sequence as the chrom, and the gene name, locus
+// tag, label, standard name, or key of each feature as the name. The strand
+// is given by whether the location is complemented. In BED12, the thick part
+// of CDS features spans the whole feature and is empty for other features.
+// Features spanning the origin of a circular sequence are written as separate
+// records on each side of the origin.
+func (w BEDWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	return io.WriteString(w.w, formatBED(seq, w.blocks))
+}
+
+// bedStrands are the values accepted in the strand column of a BED file.
+var bedStrands = map[string]bool{"+": true, "-": true, ".": true}
+
+// bedBlocks returns the blocks of a BED12 record as segments.
+func bedBlocks(start, end int, count, sizes, starts string) ([]gts.Segment, error) {
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("bad block count %q", count)
+	}
+	sizeList := strings.Split(strings.TrimSuffix(sizes, ","), ",")
+	startList := strings.Split(strings.TrimSuffix(starts, ","), ",")
+	if len(sizeList) != n || len(startList) != n {
+		return nil, fmt.Errorf("expected %d block sizes and starts", n)
+	}
+
+	ss := make([]gts.Segment, n)
+	for i := range ss {
+		size, err := strconv.Atoi(sizeList[i])
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("bad block size %q", sizeList[i])
+		}
+		offset, err := strconv.Atoi(startList[i])
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("bad block start %q", startList[i])
+		}
+		ss[i] = gts.Segment{start + offset, start + offset + size}
+		if ss[i][1] > end {
+			return nil, fmt.Errorf("block %d exceeds the end of the record", i+1)
+		}
+	}
+	return ss, nil
+}
+
+// ReadBED reads the intervals from a BED file or a plain interval list as
+// features with the given key, grouped by the chrom of the sequences they
+// belong to. Each line consists of tab or whitespace separated columns of
+// the chrom, the 0-based start, the end, and optionally the name, the score,
+// and the strand as in BED6. As a shorthand, the strand may also be given in
+// place of the score. The name, unless it is `.`, is read as the
+// /standard_name qualifier, and the intervals on the `-` strand are read as
+// complement locations. The blocks of BED12 records are read as a joined
+// location. Empty lines, comments, and track and browser lines are ignored.
+func ReadBED(r io.Reader, key string) (map[string][]gts.Feature, error) {
+	features := make(map[string][]gts.Feature)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) == 1 {
+			fields = strings.Fields(line)
+		}
+		if fields[0] == "track" || fields[0] == "browser" {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("BED line %d: expected at least 3 fields, got %d", n, len(fields))
+		}
+
+		start, err := strconv.Atoi(fields[1])
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("BED line %d: bad start position %q", n, fields[1])
+		}
+
+		end, err := strconv.Atoi(fields[2])
+		if err != nil || end <= start {
+			return nil, fmt.Errorf("BED line %d: bad end position %q", n, fields[2])
+		}
+
+		props := gts.Props{}
+		if len(fields) > 3 && fields[3] != "." && fields[3] != "" {
+			props.Add("standard_name", fields[3])
+		}
+
+		strand := "."
+		switch {
+		case len(fields) == 5 && bedStrands[fields[4]]:
+			strand = fields[4]
+		case len(fields) > 5:
+			strand = fields[5]
+		}
+		if !bedStrands[strand] {
+			return nil, fmt.Errorf("BED line %d: bad strand %q", n, strand)
+		}
+
+		ss := []gts.Segment{{start, end}}
+		if len(fields) >= 12 {
+			ss, err = bedBlocks(start, end, fields[9], fields[10], fields[11])
+			if err != nil {
+				return nil, fmt.Errorf("BED line %d: %v", n, err)
+			}
+		}
+
+		locs := make([]gts.Location, len(ss))
+		for i, s := range ss {
+			if s[0]+1 == s[1] {
+				locs[i] = gts.Point(s[0])
+			} else {
+				locs[i] = gts.Range(s[0], s[1])
+			}
+		}
+		loc := gts.Join(locs...)
+		if strand == "-" {
+			loc = loc.Complement()
+		}
+
+		chrom := fields[0]
+		features[chrom] = append(features[chrom], gts.NewFeature(key, loc, props))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
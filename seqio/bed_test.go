@@ -0,0 +1,124 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestBEDWriter(t *testing.T) {
+	info := GenBankFields{
+		LocusName: "TEST",
+		Molecule:  gts.DNA,
+		Topology:  gts.Circular,
+		Accession: "TEST",
+		Version:   "TEST.1",
+	}
+	ff := []gts.Feature{
+		gts.NewFeature("source", gts.Range(0, 30), gts.Props{
+			[]string{"organism", "Escherichia coli"},
+		}),
+		gts.NewFeature("gene", gts.Range(0, 20).Complement(), gts.Props{
+			[]string{"locus_tag", "T_01"},
+		}),
+		gts.NewFeature("CDS", gts.Join(gts.Range(0, 4), gts.Range(10, 20)).Complement(), gts.Props{
+			[]string{"locus_tag", "T_01"},
+		}),
+		gts.NewFeature("CDS", gts.Join(gts.Range(25, 30), gts.Range(0, 5)), gts.Props{
+			[]string{"gene", "foo"},
+		}),
+		gts.NewFeature("misc_feature", gts.Between(5), gts.Props{}),
+	}
+	seq := gts.New(info, ff, []byte(strings.Repeat("a", 30)))
+
+	exp12 := strings.Join([]string{
+		"TEST.1\t0\t30\tsource\t0\t+\t0\t0\t0\t1\t30,\t0,",
+		"TEST.1\t0\t20\tT_01\t0\t-\t0\t0\t0\t1\t20,\t0,",
+		"TEST.1\t0\t20\tT_01\t0\t-\t0\t20\t0\t2\t4,10,\t0,10,",
+		"TEST.1\t25\t30\tfoo\t0\t+\t25\t30\t0\t1\t5,\t0,",
+		"TEST.1\t0\t5\tfoo\t0\t+\t0\t5\t0\t1\t5,\t0,",
+		"TEST.1\t5\t5\tmisc_feature\t0\t+\t5\t5\t0\t1\t0,\t0,",
+		"",
+	}, "\n")
+
+	exp6 := strings.Join([]string{
+		"TEST.1\t0\t30\tsource\t0\t+",
+		"TEST.1\t0\t20\tT_01\t0\t-",
+		"TEST.1\t0\t20\tT_01\t0\t-",
+		"TEST.1\t25\t30\tfoo\t0\t+",
+		"TEST.1\t0\t5\tfoo\t0\t+",
+		"TEST.1\t5\t5\tmisc_feature\t0\t+",
+		"",
+	}, "\n")
+
+	b := strings.Builder{}
+	if _, err := NewWriter(&b, BEDFile).WriteSeq(seq); err != nil {
+		t.Fatal(err)
+	}
+	testutils.DiffLine(t, exp12, b.String())
+
+	b.Reset()
+	if _, err := NewWriter(&b, BED6File).WriteSeq(seq); err != nil {
+		t.Fatal(err)
+	}
+	testutils.DiffLine(t, exp6, b.String())
+}
+
+func TestReadBED(t *testing.T) {
+	in := strings.Join([]string{
+		"# comment",
+		"track name=test",
+		"seq1\t0\t20",
+		"seq1\t4\t5\tsite",
+		"seq1\t10\t40\tfoo\t0\t-",
+		"seq2 5 15 bar +",
+		"seq2\t0\t30\tbaz\t0\t+\t0\t30\t0\t2\t10,5,\t0,25,",
+		"",
+	}, "\n")
+
+	out, err := ReadBED(strings.NewReader(in), "misc_feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string][]gts.Feature{
+		"seq1": {
+			gts.NewFeature("misc_feature", gts.Range(0, 20), gts.Props{}),
+			gts.NewFeature("misc_feature", gts.Point(4), gts.Props{
+				[]string{"standard_name", "site"},
+			}),
+			gts.NewFeature("misc_feature", gts.Range(10, 40).Complement(), gts.Props{
+				[]string{"standard_name", "foo"},
+			}),
+		},
+		"seq2": {
+			gts.NewFeature("misc_feature", gts.Range(5, 15), gts.Props{
+				[]string{"standard_name", "bar"},
+			}),
+			gts.NewFeature("misc_feature", gts.Join(gts.Range(0, 10), gts.Range(25, 30)), gts.Props{
+				[]string{"standard_name", "baz"},
+			}),
+		},
+	}
+
+	testutils.Equals(t, out, exp)
+}
+
+func TestReadBEDFail(t *testing.T) {
+	tests := []string{
+		"seq\t0",
+		"seq\t-1\t20",
+		"seq\t20\t10",
+		"seq\t0\t20\tfoo\t0\tx",
+		"seq\t0\t20\tfoo\t0\t+\t0\t20\t0\t2\t10,\t0,",
+		"seq\t0\t20\tfoo\t0\t+\t0\t20\t0\t1\t30,\t0,",
+	}
+
+	for _, in := range tests {
+		if _, err := ReadBED(strings.NewReader(in), "misc_feature"); err == nil {
+			t.Errorf("expected error in ReadBED(%q)", in)
+		}
+	}
+}
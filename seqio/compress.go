@@ -0,0 +1,80 @@
+package seqio
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression represents a compression format of a sequence file.
+type Compression int
+
+// Available compression formats in GTS.
+const (
+	NoCompression Compression = iota
+	GzipCompression
+	Bzip2Compression
+	ZstdCompression
+)
+
+var compressionMagics = []struct {
+	magic       []byte
+	compression Compression
+}{
+	{[]byte{0x1f, 0x8b}, GzipCompression},
+	{[]byte("BZh"), Bzip2Compression},
+	{[]byte{0x28, 0xb5, 0x2f, 0xfd}, ZstdCompression},
+}
+
+// DetectCompression returns the Compression associated to the extension of
+// the given filename.
+func DetectCompression(filename string) Compression {
+	switch filepath.Ext(filename) {
+	case ".gz":
+		return GzipCompression
+	case ".bz2":
+		return Bzip2Compression
+	case ".zst":
+		return ZstdCompression
+	default:
+		return NoCompression
+	}
+}
+
+// trimCompression removes the compression extension from the filename.
+func trimCompression(filename string) string {
+	if DetectCompression(filename) != NoCompression {
+		return filename[:len(filename)-len(filepath.Ext(filename))]
+	}
+	return filename
+}
+
+// Decompress sniffs the leading bytes of the given reader and returns a
+// reader which transparently decompresses gzip, bzip2, and zstd streams.
+// Readers which are not compressed are returned with the buffered leading
+// bytes intact. Concatenated gzip members and zstd frames are read as a
+// single stream.
+func Decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	for _, m := range compressionMagics {
+		p, _ := br.Peek(len(m.magic))
+		if !bytes.Equal(p, m.magic) {
+			continue
+		}
+		switch m.compression {
+		case GzipCompression:
+			return gzip.NewReader(br)
+		case Bzip2Compression:
+			return bzip2.NewReader(br), nil
+		case ZstdCompression:
+			// A single decoder goroutine suffices for reading a stream once.
+			return zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		}
+	}
+	return br, nil
+}
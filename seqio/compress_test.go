@@ -0,0 +1,89 @@
+package seqio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var detectCompressionTests = []struct {
+	in  string
+	out Compression
+}{
+	{"foo.gb", NoCompression},
+	{"foo.gb.gz", GzipCompression},
+	{"foo.gb.bz2", Bzip2Compression},
+	{"foo.gb.zst", ZstdCompression},
+}
+
+func TestDetectCompression(t *testing.T) {
+	for _, tt := range detectCompressionTests {
+		out := DetectCompression(tt.in)
+		if out != tt.out {
+			t.Errorf("DetectCompression(%q) = %v, want %v", tt.in, out, tt.out)
+		}
+	}
+}
+
+func gzipBytes(t *testing.T, p []byte) []byte {
+	b := bytes.Buffer{}
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write(p); err != nil {
+		t.Fatalf("gzip.Writer.Write(p): %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("gzip.Writer.Close(): %v", err)
+	}
+	return b.Bytes()
+}
+
+var bzip2TestRecord = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xad, 0xf7,
+	0x84, 0x4d, 0x00, 0x00, 0x01, 0x49, 0x80, 0x00, 0x10, 0x00, 0x01, 0x29,
+	0x80, 0x84, 0x00, 0x20, 0x00, 0x22, 0x03, 0xd4, 0x7a, 0x84, 0x30, 0x21,
+	0x6e, 0x03, 0x4e, 0x7c, 0x5d, 0xc9, 0x14, 0xe1, 0x42, 0x42, 0xb7, 0xde,
+	0x11, 0x34,
+}
+
+var zstdTestRecord = []byte{
+	0x28, 0xb5, 0x2f, 0xfd, 0x04, 0x58, 0x51, 0x00, 0x00, 0x3e, 0x66, 0x6f,
+	0x6f, 0x0a, 0x61, 0x63, 0x67, 0x74, 0x0a, 0x27, 0x6a, 0x64, 0x30,
+}
+
+func TestAutoScannerDecompress(t *testing.T) {
+	record := []byte(">foo\nacgt\n")
+	gzipped := gzipBytes(t, record)
+
+	tests := [][]byte{
+		record,
+		gzipped,
+		append(append([]byte{}, gzipped...), gzipBytes(t, []byte(">bar\nacgt\n"))...),
+		bzip2TestRecord,
+		zstdTestRecord,
+	}
+
+	for _, in := range tests {
+		scanner := NewAutoScanner(bytes.NewReader(in))
+		if !scanner.Scan() {
+			t.Fatalf("scanner.Scan() = false: %v", scanner.Err())
+		}
+		testutils.Equals(t, scanner.Value(), gts.Sequence(Fasta{"foo", []byte("acgt")}))
+	}
+
+	scanner := NewAutoScanner(bytes.NewReader(tests[2]))
+	n := 0
+	for scanner.Scan() {
+		n++
+	}
+	testutils.Equals(t, n, 2)
+
+	for _, in := range [][]byte{zstdTestRecord[:8], gzipped[:4]} {
+		scanner := NewAutoScanner(bytes.NewReader(in))
+		if scanner.Scan() || scanner.Err() == nil {
+			t.Error("expected error scanning an undecodable stream")
+		}
+	}
+}
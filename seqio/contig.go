@@ -20,3 +20,20 @@ func (contig Contig) String() string {
 	head, tail := gts.Unpack(contig.Region)
 	return fmt.Sprintf("join(%s:%d..%d)", contig.Accession, head+1, tail)
 }
+
+// ResolveContig reconstructs the sequence of a record assembled from another
+// entry, as given by the CONTIG field of a GenBank record or the CO line of an
+// EMBL record, using the given Resolver. The record is returned as is if it
+// is not assembled from another entry or already has a sequence.
+func ResolveContig(seq gts.Sequence, r Resolver) (gts.Sequence, error) {
+	info, ok := seq.Info().(GenBankFields)
+	if !ok || info.Contig.Accession == "" || gts.Len(seq) > 0 {
+		return seq, nil
+	}
+	sub, err := info.Contig.Resolve(r)
+	if err != nil {
+		return nil, fmt.Errorf("while resolving contig %s: %v", info.Contig, err)
+	}
+	info.Contig = Contig{}
+	return gts.WithBytes(gts.WithInfo(seq, info), sub.Bytes()), nil
+}
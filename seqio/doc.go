@@ -0,0 +1,9 @@
+// Package seqio implements the parsing and formatting of sequences in the
+// file formats supported by gts. Sequences are read with a Scanner, which can
+// detect the format of its input automatically, and written with a SeqWriter
+// created by NewWriter, which may be shared by concurrent goroutines.
+// ReadFile and WriteFile cover the common case of reading and writing whole
+// files. Since the metadata of a parsed sequence is format specific, programs
+// exchanging sequences with gts may convert them into the versioned Record
+// schema instead.
+package seqio
@@ -0,0 +1,770 @@
+package seqio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-pars/pars"
+	"github.com/go-wrap/wrap"
+)
+
+// EMBL represents an EMBL sequence record. The metadata are held in the same
+// GenBankFields as a GenBank record, so that a record may be converted
+// between the two formats.
+type EMBL struct {
+	Fields GenBankFields
+	Table  gts.FeatureSlice
+	Data   []byte
+}
+
+// Info returns the metadata of the sequence.
+func (e EMBL) Info() interface{} {
+	return e.Fields
+}
+
+// Features returns the feature table of the sequence.
+func (e EMBL) Features() gts.FeatureSlice {
+	return e.Table
+}
+
+// Len returns the length of the sequence.
+func (e EMBL) Len() int {
+	return len(e.Data)
+}
+
+// Bytes returns the byte representation of the sequence.
+func (e EMBL) Bytes() []byte {
+	return e.Data
+}
+
+// WithInfo creates a shallow copy of the given Sequence object and swaps the
+// metadata with the given value.
+func (e EMBL) WithInfo(info interface{}) gts.Sequence {
+	switch v := info.(type) {
+	case GenBankFields:
+		return EMBL{v, e.Table, e.Data}
+	default:
+		return gts.New(v, e.Features(), e.Bytes())
+	}
+}
+
+// WithFeatures creates a shallow copy of the given Sequence object and swaps
+// the feature table with the given features.
+func (e EMBL) WithFeatures(ff []gts.Feature) gts.Sequence {
+	return EMBL{e.Fields, ff, e.Data}
+}
+
+// WithBytes creates a shallow copy of the given Sequence object and swaps the
+// byte representation with the given byte slice.
+func (e EMBL) WithBytes(p []byte) gts.Sequence {
+	return EMBL{e.Fields, e.Table, p}
+}
+
+// WithTopology creates a shallow copy of the given Sequence object and swaps
+// the topology value with the given value.
+func (e EMBL) WithTopology(t gts.Topology) gts.Sequence {
+	info := e.Fields
+	info.Topology = t
+	return e.WithInfo(info)
+}
+
+// emblDataClasses are the EMBL data classes which are represented as
+// divisions in GenBank.
+var emblDataClasses = []string{"CON", "EST", "GSS", "HTC", "HTG", "PAT", "STS", "TSA"}
+
+// The GenBank divisions and the EMBL taxonomic divisions with differing names.
+var (
+	genbankToEMBLDivision = map[string]string{
+		"BCT": "PRO", "PRI": "HUM", "UNA": "UNC",
+	}
+	emblToGenBankDivision = map[string]string{
+		"PRO": "BCT", "HUM": "PRI", "MUS": "ROD", "FUN": "PLN", "TGN": "SYN", "UNC": "UNA",
+	}
+)
+
+func isEMBLDataClass(s string) bool {
+	for _, class := range emblDataClasses {
+		if s == class {
+			return true
+		}
+	}
+	return false
+}
+
+// emblDivision returns the EMBL data class and taxonomic division for the
+// given GenBank division.
+func emblDivision(division string) (string, string) {
+	switch {
+	case isEMBLDataClass(division):
+		return division, "UNC"
+	case division == "":
+		return "STD", "UNC"
+	}
+	if v, ok := genbankToEMBLDivision[division]; ok {
+		return "STD", v
+	}
+	return "STD", division
+}
+
+// genbankDivision returns the GenBank division for the given EMBL data class
+// and taxonomic division.
+func genbankDivision(class, division string) string {
+	if isEMBLDataClass(class) {
+		return class
+	}
+	if v, ok := emblToGenBankDivision[division]; ok {
+		return v
+	}
+	return division
+}
+
+// emblMoleculeType returns the molecule type of the record for the ID line.
+// The `/mol_type` qualifier of the source feature is used if present.
+func emblMoleculeType(mol gts.Molecule, ff gts.FeatureSlice) string {
+	for _, f := range ff.Filter(gts.Key("source")) {
+		if values := f.Props.Get("mol_type"); len(values) > 0 {
+			return values[0]
+		}
+	}
+	switch mol {
+	case gts.RNA:
+		return "genomic RNA"
+	case gts.AA:
+		return "protein"
+	default:
+		return "genomic DNA"
+	}
+}
+
+func asEMBLMolecule(s string) gts.Molecule {
+	switch {
+	case s == "protein":
+		return gts.AA
+	case strings.Contains(s, "RNA"):
+		return gts.RNA
+	default:
+		return gts.DNA
+	}
+}
+
+// emblSequenceVersion returns the sequence version number of the given
+// accession version.
+func emblSequenceVersion(version string) string {
+	if i := strings.LastIndexByte(version, '.'); i >= 0 {
+		return version[i+1:]
+	}
+	return ""
+}
+
+// emblAuthors converts a list of authors from the GenBank convention to the
+// EMBL convention (i.e. `Sanger,F. and Air,G.M.` to `Sanger F., Air G.M.`).
+func emblAuthors(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return s
+	}
+	s = strings.Replace(s, " and ", ", ", 1)
+	authors := strings.Split(s, ", ")
+	for i, author := range authors {
+		authors[i] = strings.Replace(author, ",", " ", 1)
+	}
+	return strings.Join(authors, ", ")
+}
+
+// genbankAuthors converts a list of authors from the EMBL convention to the
+// GenBank convention (i.e. `Sanger F., Air G.M.` to `Sanger,F. and Air,G.M.`).
+func genbankAuthors(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return s
+	}
+	authors := strings.Split(s, ", ")
+	for i, author := range authors {
+		// The name is followed by the initials and an optional suffix.
+		words := strings.Split(author, " ")
+		j := len(words) - 1
+		for k := j; k > 0; k-- {
+			if strings.Contains(words[k], ".") {
+				j = k
+				break
+			}
+		}
+		if j > 0 {
+			authors[i] = strings.Join(words[:j], " ") + "," + strings.Join(words[j:], " ")
+		}
+	}
+	if n := len(authors); n > 1 {
+		s = strings.Join(authors[:n-1], ", ") + " and " + authors[n-1]
+	} else {
+		s = authors[0]
+	}
+	return wrap.Space(s, 68)
+}
+
+// emblLines formats the given value as lines with the given line code.
+func emblLines(code, value string) string {
+	b := strings.Builder{}
+	for _, line := range strings.Split(value, "\n") {
+		b.WriteString(strings.TrimRight(code+"   "+line, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// emblWrapped formats the given value as lines with the given line code,
+// wrapping the value to fit in a line.
+func emblWrapped(code, value string) string {
+	return emblLines(code, wrap.Space(strings.Join(strings.Fields(value), " "), 75))
+}
+
+func emblReferencePositions(ref Reference, mol gts.Molecule) string {
+	result, err := parseReferenceInfo(mol.Counter()).Parse(pars.FromString(ref.Info))
+	if err != nil {
+		return ""
+	}
+	locs := result.Value.([]gts.Ranged)
+	ss := make([]string, len(locs))
+	for i, loc := range locs {
+		ss[i] = fmt.Sprintf("%d-%d", loc.Start+1, loc.End)
+	}
+	return strings.Join(ss, ", ")
+}
+
+func writeEMBLSequence(b *strings.Builder, p []byte, mol gts.Molecule) {
+	if mol == gts.AA {
+		b.WriteString(fmt.Sprintf("SQ   Sequence %d AA;\n", len(p)))
+	} else {
+		writeEMBLComposition(b, p)
+	}
+
+	for i := 0; i < len(p); i += 60 {
+		end := gts.Min(i+60, len(p))
+		line := strings.Builder{}
+		line.WriteString("    ")
+		for j := i; j < end; j += 10 {
+			line.WriteByte(' ')
+			line.Write(p[j:gts.Min(j+10, end)])
+		}
+		b.WriteString(fmt.Sprintf("%-70s%10d\n", line.String(), end))
+	}
+}
+
+func writeEMBLComposition(b *strings.Builder, p []byte) {
+	counts := [5]int{}
+	for _, c := range p {
+		switch c {
+		case 'a', 'A':
+			counts[0]++
+		case 'c', 'C':
+			counts[1]++
+		case 'g', 'G':
+			counts[2]++
+		case 't', 'T':
+			counts[3]++
+		default:
+			counts[4]++
+		}
+	}
+
+	b.WriteString(fmt.Sprintf(
+		"SQ   Sequence %d BP; %d A; %d C; %d G; %d T; %d other;\n",
+		len(p), counts[0], counts[1], counts[2], counts[3], counts[4],
+	))
+}
+
+// String satisifes the fmt.Stringer interface.
+func (e EMBL) String() string {
+	b := strings.Builder{}
+	f := e.Fields
+
+	length := len(e.Data)
+	if length == 0 {
+		length = f.Contig.Region.Len()
+	}
+
+	unit := "BP"
+	if f.Molecule == gts.AA {
+		unit = "AA"
+	}
+
+	accessions := strings.Fields(f.Accession)
+	primary := f.LocusName
+	if len(accessions) > 0 {
+		primary = accessions[0]
+	}
+
+	id := []string{primary}
+	if sv := emblSequenceVersion(f.Version); sv != "" {
+		id = append(id, "SV "+sv)
+	}
+	class, division := emblDivision(f.Division)
+	id = append(id,
+		f.Topology.String(), emblMoleculeType(f.Molecule, e.Table),
+		class, division, fmt.Sprintf("%d %s.", length, unit),
+	)
+	b.WriteString("ID   " + strings.Join(id, "; ") + "\n")
+	b.WriteString("XX\n")
+
+	if len(accessions) > 0 {
+		b.WriteString(emblWrapped("AC", strings.Join(accessions, "; ")+";"))
+		b.WriteString("XX\n")
+	}
+
+	if projects := f.DBLink.Get("BioProject"); len(projects) > 0 {
+		for _, project := range projects {
+			b.WriteString("PR   Project:" + project + ";\n")
+		}
+		b.WriteString("XX\n")
+	}
+
+	date := strings.ToUpper(f.Date.ToTime().Format("02-Jan-2006"))
+	b.WriteString("DT   " + date + "\n")
+	b.WriteString("XX\n")
+
+	b.WriteString(emblLines("DE", f.Definition))
+	b.WriteString("XX\n")
+
+	b.WriteString(emblWrapped("KW", strings.Join(f.Keywords, "; ")+"."))
+	b.WriteString("XX\n")
+
+	b.WriteString(emblLines("OS", f.Source.Species))
+	b.WriteString(emblWrapped("OC", strings.Join(f.Source.Taxon, "; ")+"."))
+	b.WriteString("XX\n")
+
+	for _, ref := range f.References {
+		b.WriteString(fmt.Sprintf("RN   [%d]\n", ref.Number))
+		if ref.Comment != "" {
+			b.WriteString(emblLines("RC", ref.Comment))
+		}
+		if positions := emblReferencePositions(ref, f.Molecule); positions != "" {
+			b.WriteString(emblWrapped("RP", positions))
+		}
+		keys := make([]string, 0, len(ref.Xref))
+		for key := range ref.Xref {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			b.WriteString(fmt.Sprintf("RX   %s; %s.\n", key, ref.Xref[key]))
+		}
+		if ref.Group != "" {
+			b.WriteString(emblWrapped("RG", ref.Group))
+		}
+		b.WriteString(emblWrapped("RA", emblAuthors(ref.Authors)+";"))
+		title := strings.Join(strings.Fields(ref.Title), " ")
+		if title != "" {
+			title = `"` + title + `"`
+		}
+		b.WriteString(emblWrapped("RT", title+";"))
+		journal := ref.Journal
+		if journal != "" && !strings.HasSuffix(journal, ".") {
+			journal += "."
+		}
+		b.WriteString(emblLines("RL", journal))
+		b.WriteString("XX\n")
+	}
+
+	dbxrefs := 0
+	for _, pair := range f.DBLink {
+		if pair.Key != "BioProject" {
+			b.WriteString(fmt.Sprintf("DR   %s; %s.\n", pair.Key, pair.Value))
+			dbxrefs++
+		}
+	}
+	if dbxrefs > 0 {
+		b.WriteString("XX\n")
+	}
+
+	for _, comment := range f.Comments {
+		b.WriteString(emblLines("CC", comment))
+		b.WriteString("XX\n")
+	}
+
+	if len(e.Table) > 0 {
+		b.WriteString("FH   Key             Location/Qualifiers\n")
+		b.WriteString("FH\n")
+		fmtr := INSDCFormatter{e.Table, "FT   ", 21}
+		fmtr.WriteTo(&b)
+		b.WriteByte('\n')
+		b.WriteString("XX\n")
+	}
+
+	if f.Contig.String() != "" {
+		b.WriteString("CO   " + f.Contig.String() + "\n")
+	}
+
+	if len(e.Data) > 0 {
+		writeEMBLSequence(&b, e.Data, f.Molecule)
+	}
+
+	b.WriteString("//\n")
+
+	return b.String()
+}
+
+// WriteTo satisfies the io.WriterTo interface.
+func (e EMBL) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, e.String())
+	return int64(n), err
+}
+
+// EMBLWriter writes a gts.Sequence to an io.Writer in EMBL format.
+type EMBLWriter struct {
+	w io.Writer
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. A sequence with GenBank
+// metadata is converted to an EMBL record, and a sequence with a plain
+// description is written as an unannotated record as in GenBankWriter.
+func (w EMBLWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	switch v := seq.(type) {
+	case EMBL:
+		n, err := v.WriteTo(w.w)
+		return int(n), err
+	case *EMBL:
+		return w.WriteSeq(*v)
+	default:
+		switch info := v.Info().(type) {
+		case GenBankFields:
+			return w.WriteSeq(EMBL{info, v.Features(), v.Bytes()})
+		case FastaHeader:
+			return w.WriteSeq(gts.WithInfo(v, headerGenBankFields(info, Molecule(v))))
+		case string:
+			return w.WriteSeq(gts.WithInfo(v, headerGenBankFields(ParseFastaHeader(info), Molecule(v))))
+		case fmt.Stringer:
+			return w.WriteSeq(gts.WithInfo(v, headerGenBankFields(ParseFastaHeader(info.String()), Molecule(v))))
+		default:
+			return 0, fmt.Errorf("gts does not know how to format a sequence with metadata of type `%T` as EMBL", info)
+		}
+	}
+}
+
+// parseEMBLIdentification parses the body of an EMBL ID line.
+func parseEMBLIdentification(s string) (GenBankFields, int, error) {
+	fields := strings.Split(strings.TrimSuffix(s, "."), "; ")
+	if len(fields) < 2 {
+		return GenBankFields{}, 0, errors.New("malformed ID line")
+	}
+
+	accession, version := fields[0], ""
+	if sv := fields[1]; strings.HasPrefix(sv, "SV ") {
+		version = accession + "." + strings.TrimPrefix(sv, "SV ")
+		fields = fields[1:]
+	}
+	if len(fields) != 6 {
+		return GenBankFields{}, 0, errors.New("malformed ID line")
+	}
+
+	topology, err := gts.AsTopology(fields[1])
+	if err != nil {
+		return GenBankFields{}, 0, err
+	}
+
+	size := strings.Fields(fields[5])
+	if len(size) != 2 || (size[1] != "BP" && size[1] != "AA") {
+		return GenBankFields{}, 0, fmt.Errorf("malformed sequence length %q", fields[5])
+	}
+	length, err := strconv.Atoi(size[0])
+	if err != nil {
+		return GenBankFields{}, 0, fmt.Errorf("malformed sequence length %q", fields[5])
+	}
+
+	molecule := asEMBLMolecule(fields[2])
+	if size[1] == "AA" {
+		molecule = gts.AA
+	}
+
+	return GenBankFields{
+		LocusName: accession,
+		Molecule:  molecule,
+		Topology:  topology,
+		Division:  genbankDivision(fields[3], fields[4]),
+		Accession: accession,
+		Version:   version,
+	}, length, nil
+}
+
+var emblContigRegexp = regexp.MustCompile(`^join\(([^:]+):(\d+)\.\.(\d+)\)$`)
+
+func parseEMBLContig(s string) (Contig, bool) {
+	m := emblContigRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return Contig{}, false
+	}
+	head, _ := strconv.Atoi(m[2])
+	tail, _ := strconv.Atoi(m[3])
+	return Contig{m[1], gts.Segment{head - 1, tail}}, true
+}
+
+// splitEMBLCrossReference splits the body of an RX or DR line into the
+// database name and the identifier.
+func splitEMBLCrossReference(s string) (string, string, bool) {
+	i := strings.Index(s, "; ")
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], strings.TrimSuffix(s[i+2:], "."), true
+}
+
+// emblLine splits a line into its line code and body.
+func emblLine(line []byte) (string, string) {
+	if len(line) < 2 {
+		return string(line), ""
+	}
+	return string(line[:2]), strings.TrimPrefix(string(line[2:]), "   ")
+}
+
+// appendEMBLLine appends a line to the value of a multi-line field if the
+// line continues the field, and replaces the value otherwise.
+func appendEMBLLine(s *string, body string, cont bool) {
+	if cont {
+		*s += "\n" + body
+		return
+	}
+	*s = body
+}
+
+func parseEMBLSequence(state *pars.State, length int) []byte {
+	p := make([]byte, 0, length)
+	for {
+		line, n := peekLine(state)
+		if n == 0 || !bytes.HasPrefix(line, []byte("     ")) {
+			return p
+		}
+		for _, c := range line {
+			if c != ' ' && (c < '0' || '9' < c) {
+				p = append(p, c)
+			}
+		}
+		skipBytes(state, n)
+	}
+}
+
+// EMBLParser attempts to parse a single EMBL record.
+func EMBLParser(state *pars.State, result *pars.Result) error {
+	return parseEMBL(state, result, nil)
+}
+
+// warningEMBLParser creates an EMBL parser which reports the lines it could
+// not interpret to the given handler.
+func warningEMBLParser(warn WarningHandler) pars.Parser {
+	return func(state *pars.State, result *pars.Result) error {
+		return parseEMBL(state, result, warn)
+	}
+}
+
+func parseEMBL(state *pars.State, result *pars.Result, warn WarningHandler) error {
+	if err := state.Request(1); err != nil {
+		return err
+	}
+	line, n := peekLine(state)
+	if !bytes.HasPrefix(line, []byte("ID   ")) {
+		return pars.NewError("expected `ID`", state.Position())
+	}
+	fields, length, err := parseEMBLIdentification(string(line[5:]))
+	if err != nil {
+		return pars.NewError(err.Error(), state.Position())
+	}
+	skipBytes(state, n)
+
+	state.Clear()
+
+	e := EMBL{Fields: fields}
+	id := fields.ID()
+
+	ignore := func(pos pars.Position, line []byte) {
+		if warn != nil && len(line) > 0 {
+			msg := fmt.Sprintf("ignored malformed line %q", string(line))
+			warn(Warning{pos.Line + 1, id, msg})
+		}
+	}
+
+	accessions, keywords, taxon := []string{}, []string{}, []string{}
+	var ref *Reference
+	var positions, authors, title string
+	sequence := false
+
+	flush := func() {
+		if ref == nil {
+			return
+		}
+		if positions != "" {
+			ss := strings.Split(positions, ",")
+			for i, s := range ss {
+				ss[i] = strings.Replace(strings.TrimSpace(s), "-", " to ", 1)
+			}
+			ref.Info = fmt.Sprintf("(%s %s)", e.Fields.Molecule.Counter(), strings.Join(ss, "; "))
+		}
+		ref.Authors = genbankAuthors(strings.TrimSuffix(authors, ";"))
+		title = strings.Join(strings.Fields(title), " ")
+		title = strings.Trim(strings.TrimSuffix(title, ";"), `"`)
+		ref.Title = wrap.Space(title, 68)
+		ref.Journal = strings.TrimSuffix(ref.Journal, ".")
+		e.Fields.References = append(e.Fields.References, *ref)
+		ref, positions, authors, title = nil, "", "", ""
+	}
+
+	prev := ""
+	for {
+		pos := state.Position()
+		line, n := peekLine(state)
+		if n == 0 {
+			return pars.NewError("expected `//`", pos)
+		}
+
+		code, body := emblLine(line)
+		cont := code == prev
+		prev = code
+
+		switch code {
+		case "//":
+			skipBytes(state, n)
+			flush()
+
+			if len(accessions) > 0 {
+				e.Fields.Accession = strings.Join(accessions, " ")
+			}
+			e.Fields.Keywords = FlatFileSplit(strings.Join(keywords, " "))
+			e.Fields.Source.Taxon = FlatFileSplit(strings.Join(taxon, " "))
+			if sequence && len(e.Data) != length {
+				what := fmt.Sprintf("expected %d bases in sequence, got %d", length, len(e.Data))
+				return pars.NewError(what, pos)
+			}
+
+			result.SetValue(e)
+			return nil
+
+		case "FT":
+			if err := INSDCTableParser("FT")(state, result); err != nil {
+				return err
+			}
+			e.Table = result.Value.([]gts.Feature)
+			continue
+
+		case "SQ":
+			skipBytes(state, n)
+			e.Data = parseEMBLSequence(state, length)
+			sequence = true
+			continue
+		}
+
+		skipBytes(state, n)
+
+		switch code {
+		case "XX", "FH":
+
+		case "AC":
+			for _, s := range strings.Split(body, ";") {
+				if s = strings.TrimSpace(s); s != "" {
+					accessions = append(accessions, s)
+				}
+			}
+
+		case "PR":
+			project := strings.TrimSuffix(body, ";")
+			if !strings.HasPrefix(project, "Project:") {
+				ignore(pos, line)
+				break
+			}
+			e.Fields.DBLink.Set("BioProject", strings.TrimPrefix(project, "Project:"))
+
+		case "DT":
+			date, err := AsDate(strings.SplitN(body, " ", 2)[0])
+			if err != nil {
+				ignore(pos, line)
+				break
+			}
+			e.Fields.Date = date
+
+		case "DE":
+			appendEMBLLine(&e.Fields.Definition, body, cont)
+
+		case "KW":
+			keywords = append(keywords, body)
+
+		case "OS":
+			appendEMBLLine(&e.Fields.Source.Species, body, cont)
+			name := e.Fields.Source.Species
+			if i := strings.LastIndex(name, " ("); i > 0 && strings.HasSuffix(name, ")") {
+				name = name[:i]
+			}
+			e.Fields.Source.Name = name
+
+		case "OC":
+			taxon = append(taxon, body)
+
+		case "RN":
+			flush()
+			number, err := strconv.Atoi(strings.Trim(body, "[]"))
+			if err != nil {
+				ignore(pos, line)
+				break
+			}
+			ref = &Reference{Number: number}
+
+		case "RC", "RP", "RX", "RG", "RA", "RT", "RL":
+			if ref == nil {
+				ignore(pos, line)
+				break
+			}
+			switch code {
+			case "RC":
+				appendEMBLLine(&ref.Comment, body, cont)
+			case "RP":
+				appendEMBLLine(&positions, body, cont)
+			case "RX":
+				db, id, ok := splitEMBLCrossReference(body)
+				if !ok {
+					ignore(pos, line)
+					break
+				}
+				if ref.Xref == nil {
+					ref.Xref = make(map[string]string)
+				}
+				ref.Xref[db] = id
+			case "RG":
+				appendEMBLLine(&ref.Group, body, cont)
+			case "RA":
+				appendEMBLLine(&authors, body, cont)
+			case "RT":
+				appendEMBLLine(&title, body, cont)
+			case "RL":
+				appendEMBLLine(&ref.Journal, body, cont)
+			}
+
+		case "DR":
+			db, id, ok := splitEMBLCrossReference(body)
+			if !ok {
+				ignore(pos, line)
+				break
+			}
+			e.Fields.DBLink.Set(db, id)
+
+		case "CC":
+			if cont {
+				i := len(e.Fields.Comments) - 1
+				e.Fields.Comments[i] += "\n" + body
+				break
+			}
+			e.Fields.Comments = append(e.Fields.Comments, body)
+
+		case "CO":
+			contig, ok := parseEMBLContig(body)
+			if !ok {
+				ignore(pos, line)
+				break
+			}
+			e.Fields.Contig = contig
+
+		default:
+			ignore(pos, line)
+		}
+	}
+}
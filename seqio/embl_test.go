@@ -0,0 +1,222 @@
+package seqio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+	"github.com/go-pars/pars"
+)
+
+func formatEMBLHelper(t *testing.T, seq gts.Sequence, in string) {
+	t.Helper()
+	b := strings.Builder{}
+	n, err := EMBLWriter{&b}.WriteSeq(seq)
+	if int(n) != len([]byte(in)) || err != nil {
+		t.Errorf("f.WriteSeq(seq) = (%d, %v), want %d, nil", n, err, len(in))
+	}
+	testutils.DiffLine(t, in, b.String())
+}
+
+func TestEMBLIO(t *testing.T) {
+	in := testutils.ReadTestfile(t, "NC_001422.embl")
+	state := pars.FromString(in)
+	parser := pars.AsParser(EMBLParser)
+
+	result, err := parser.Parse(state)
+	if err != nil {
+		t.Errorf("parser returned %v\nBuffer:\n%q", err, string(result.Token))
+		return
+	}
+
+	switch seq := result.Value.(type) {
+	case EMBL:
+		formatEMBLHelper(t, &seq, in)
+		cpy := gts.New(seq.Info(), seq.Features(), seq.Bytes())
+		formatEMBLHelper(t, &cpy, in)
+
+	default:
+		t.Errorf("result.Value.(type) = %T, want %T", seq, EMBL{})
+	}
+}
+
+func TestGenBankEMBLConversion(t *testing.T) {
+	gb, err := parseString(GenBankParser, testutils.ReadTestfile(t, "NC_001422.gb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := parseString(EMBLParser, testutils.ReadTestfile(t, "NC_001422.embl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.Equals(t, e.Features(), gb.Features())
+	testutils.Equals(t, e.Bytes(), gb.Bytes())
+
+	in, out := gb.Info().(GenBankFields), e.Info().(GenBankFields)
+	testutils.Equals(t, out.ID(), in.ID())
+	testutils.Equals(t, out.LocusName, in.LocusName)
+	testutils.Equals(t, out.Topology, in.Topology)
+	testutils.Equals(t, out.Division, in.Division)
+	testutils.Equals(t, out.Date, in.Date)
+	testutils.Equals(t, out.Definition, in.Definition)
+	testutils.Equals(t, out.DBLink, in.DBLink)
+	testutils.Equals(t, out.Keywords, in.Keywords)
+	testutils.Equals(t, out.Source, in.Source)
+	testutils.Equals(t, out.Comments, in.Comments)
+	testutils.Equals(t, len(out.References), len(in.References))
+	for i, ref := range out.References {
+		testutils.Equals(t, ref.Authors, in.References[i].Authors)
+		testutils.Equals(t, ref.Title, in.References[i].Title)
+		testutils.Equals(t, ref.Journal, in.References[i].Journal)
+	}
+
+	b := strings.Builder{}
+	if _, err := NewWriter(&b, EMBLFile).WriteSeq(gb); err != nil {
+		t.Fatal(err)
+	}
+	testutils.DiffLine(t, testutils.ReadTestfile(t, "NC_001422.embl"), b.String())
+}
+
+var emblParserTest = `ID   X56734; SV 1; linear; mRNA; STD; PLN; 72 BP.
+XX
+AC   X56734; S46826;
+XX
+DT   12-SEP-1991 (Rel. 29, Created)
+DT   14-NOV-2006 (Rel. 89, Last updated, Version 4)
+XX
+DE   Trifolium repens mRNA for non-cyanogenic beta-glucosidase
+XX
+KW   beta-glucosidase.
+XX
+OS   Trifolium repens (white clover)
+OC   Eukaryota; Viridiplantae; Streptophyta; Embryophyta; Tracheophyta;
+OC   Spermatophyta; Magnoliophyta; eudicotyledons; Trifolium.
+XX
+RN   [1]
+RP   1-72
+RX   DOI; 10.1007/BF00039495.
+RX   PUBMED; 1907511.
+RA   Oxtoby E., Dunn M.A., Pancoro A., Hughes M.A.;
+RT   "Nucleotide and derived amino acid sequence of the cyanogenic
+RT   beta-glucosidase (linamarase) from white clover (Trifolium repens L.)";
+RL   Plant Mol. Biol. 17(2):209-219(1991).
+XX
+DR   MD5; 1e51ca3a5450c43524b9185c236cc5cc.
+XX
+OX   NCBI_TaxID=3899;
+XX
+FH   Key             Location/Qualifiers
+FH
+FT   source          1..72
+FT                   /organism="Trifolium repens"
+FT                   /mol_type="mRNA"
+FT   CDS             14..>72
+FT                   /product="beta-glucosidase"
+XX
+SQ   Sequence 72 BP; 23 A; 10 C; 15 G; 24 T; 0 other;
+     aaacaaacca aatatggatt ttattgtagc catatttgct ctgtttgttg ttagctcatt        60
+     agtgtcagcc gg                                                            72
+//
+`
+
+func TestEMBLParser(t *testing.T) {
+	warnings := []Warning{}
+	parser := warningEMBLParser(func(w Warning) { warnings = append(warnings, w) })
+	seq, err := parseString(parser, emblParserTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := seq.Info().(GenBankFields)
+	testutils.Equals(t, info.ID(), "X56734.1")
+	testutils.Equals(t, info.LocusName, "X56734")
+	testutils.Equals(t, info.Accession, "X56734 S46826")
+	testutils.Equals(t, info.Molecule, gts.RNA)
+	testutils.Equals(t, info.Topology, gts.Linear)
+	testutils.Equals(t, info.Division, "PLN")
+	testutils.Equals(t, info.Date, Date{2006, time.November, 14})
+	testutils.Equals(t, info.Definition, "Trifolium repens mRNA for non-cyanogenic beta-glucosidase")
+	testutils.Equals(t, info.Keywords, []string{"beta-glucosidase"})
+	testutils.Equals(t, info.Source.Species, "Trifolium repens (white clover)")
+	testutils.Equals(t, info.Source.Name, "Trifolium repens")
+	testutils.Equals(t, len(info.Source.Taxon), 9)
+	testutils.Equals(t, info.DBLink, Dictionary{{"MD5", "1e51ca3a5450c43524b9185c236cc5cc"}})
+
+	testutils.Equals(t, len(info.References), 1)
+	ref := info.References[0]
+	testutils.Equals(t, ref.Number, 1)
+	testutils.Equals(t, ref.Info, "(bases 1 to 72)")
+	testutils.Equals(t, ref.Xref, map[string]string{"DOI": "10.1007/BF00039495", "PUBMED": "1907511"})
+	testutils.Equals(t, ref.Authors, "Oxtoby,E., Dunn,M.A., Pancoro,A. and Hughes,M.A.")
+	testutils.Equals(t, ref.Title, "Nucleotide and derived amino acid sequence of the cyanogenic\nbeta-glucosidase (linamarase) from white clover (Trifolium repens\nL.)")
+	testutils.Equals(t, ref.Journal, "Plant Mol. Biol. 17(2):209-219(1991)")
+
+	testutils.Equals(t, len(seq.Features()), 2)
+	testutils.Equals(t, seq.Features()[1].Loc.String(), "14..>72")
+	testutils.Equals(t, len(seq.Bytes()), 72)
+
+	testutils.Equals(t, len(warnings), 1)
+	testutils.Equals(t, warnings[0].Line, 27)
+}
+
+var emblIOFailTests = []string{
+	"",
+	"LOCUS       NC_001422",
+	"ID   X56734; SV 1; linear; mRNA; STD; PLN\n//\n",
+	"ID   X56734; SV 1; linear; mRNA; STD; PLN; 72 XX.\n//\n",
+	"ID   X56734; SV 1; bent; mRNA; STD; PLN; 72 BP.\n//\n",
+	"ID   X56734; SV 1; linear; mRNA; STD; PLN; 72 BP.\nXX\n",
+	"ID   X56734; SV 1; linear; mRNA; STD; PLN; 72 BP.\nSQ   Sequence 4 BP;\n     acgt         4\n//\n",
+}
+
+func TestEMBLIOFail(t *testing.T) {
+	parser := pars.AsParser(EMBLParser)
+	for _, in := range emblIOFailTests {
+		state := pars.FromString(in)
+		if err := parser(state, pars.Void); err == nil {
+			t.Errorf("while parsing`\n%s\n`: expected error", in)
+		}
+	}
+
+	b := bytes.Buffer{}
+	n, err := EMBLWriter{&b}.WriteSeq(gts.New(nil, nil, nil))
+	if n != 0 || err == nil {
+		t.Errorf("formatting an empty Sequence should return an error")
+	}
+}
+
+func TestEMBLDivision(t *testing.T) {
+	tests := []struct {
+		genbank, class, division string
+	}{
+		{"PLN", "STD", "PLN"},
+		{"BCT", "STD", "PRO"},
+		{"PRI", "STD", "HUM"},
+		{"UNA", "STD", "UNC"},
+		{"CON", "CON", "UNC"},
+	}
+	for _, tt := range tests {
+		class, division := emblDivision(tt.genbank)
+		testutils.Equals(t, class, tt.class)
+		testutils.Equals(t, division, tt.division)
+		testutils.Equals(t, genbankDivision(class, division), tt.genbank)
+	}
+}
+
+func TestEMBLAuthors(t *testing.T) {
+	tests := []struct {
+		genbank, embl string
+	}{
+		{"Sanger,F.", "Sanger F."},
+		{"Sanger,F. and Air,G.M.", "Sanger F., Air G.M."},
+		{"Hutchison,C.A. III, van de Vorst,A. and Smith,M.", "Hutchison C.A. III, van de Vorst A., Smith M."},
+	}
+	for _, tt := range tests {
+		testutils.Equals(t, emblAuthors(tt.genbank), tt.embl)
+		testutils.Equals(t, genbankAuthors(tt.embl), tt.genbank)
+	}
+}
@@ -0,0 +1,92 @@
+package seqio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-wrap/wrap"
+)
+
+// EMBOSSFastaWidth is the number of characters per line of the sequence in
+// the FASTA format written by EMBOSS.
+const EMBOSSFastaWidth = 60
+
+// embossFields returns the name, the versioned accession (or the accession
+// if no version is known), and the description of a sequence as they would
+// be read by EMBOSS.
+func embossFields(seq gts.Sequence) (string, string, string, error) {
+	switch info := seq.Info().(type) {
+	case GenBankFields:
+		sv := info.Version
+		if sv == "" {
+			sv = info.Accession
+		}
+		// The definition of a GenBank record is read with the trailing period.
+		desc := info.Definition
+		if desc != "" {
+			desc += "."
+		}
+		return info.LocusName, sv, desc, nil
+	case FastaHeader:
+		name, desc := splitFastaDesc(info.Desc)
+		if info.Name != "" {
+			name = info.Name
+		}
+		if info.Definition != "" {
+			desc = info.Definition
+		}
+		sv := info.Version
+		if sv == "" {
+			sv = info.Accession
+		}
+		return name, sv, desc, nil
+	case string:
+		name, desc := splitFastaDesc(info)
+		return name, "", desc, nil
+	case fmt.Stringer:
+		name, desc := splitFastaDesc(info.String())
+		return name, "", desc, nil
+	default:
+		return "", "", "", fmt.Errorf("gts does not know how to format a sequence with metadata type `%T` as EMBOSS FASTA", info)
+	}
+}
+
+// EMBOSSName returns the name of the sequence as it would be read by EMBOSS,
+// which is the locus name of a GenBank record or the first word of the
+// description line of a FASTA sequence.
+func EMBOSSName(seq gts.Sequence) string {
+	name, _, _, _ := embossFields(seq)
+	return name
+}
+
+// EMBOSSWriter writes a gts.Sequence to an io.Writer in the FASTA format as
+// written by the EMBOSS seqret program.
+type EMBOSSWriter struct {
+	w io.Writer
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. The description line
+// consists of the name of the sequence as given by EMBOSSName, followed by
+// the versioned accession (or the accession if no version is known) unless it
+// is identical to the name, and the description. The sequence is wrapped at
+// EMBOSSFastaWidth characters per line.
+func (w EMBOSSWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	name, sv, desc, err := embossFields(seq)
+	if err != nil {
+		return 0, err
+	}
+
+	words := []string{name}
+	if sv != "" && sv != name {
+		words = append(words, sv)
+	}
+	if desc != "" {
+		words = append(words, desc)
+	}
+
+	header := strings.ReplaceAll(strings.Join(words, " "), "\n", " ")
+	data := wrap.Force(string(seq.Bytes()), EMBOSSFastaWidth)
+	return io.WriteString(w.w, fmt.Sprintf(">%s\n%s\n", header, data))
+}
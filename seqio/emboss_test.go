@@ -0,0 +1,73 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestEMBOSSWriter(t *testing.T) {
+	data := []byte(strings.Repeat("acgt", 20))
+	body := strings.Repeat("acgt", 15) + "\n" + strings.Repeat("acgt", 5) + "\n"
+
+	tests := []struct {
+		info interface{}
+		name string
+		out  string
+	}{
+		{
+			GenBankFields{
+				LocusName:  "NC_001422",
+				Definition: "Coliphage phi-X174, complete genome",
+				Accession:  "NC_001422",
+				Version:    "NC_001422.1",
+			},
+			"NC_001422",
+			">NC_001422 NC_001422.1 Coliphage phi-X174, complete genome.\n",
+		},
+		{
+			GenBankFields{LocusName: "FOO", Accession: "BAR"},
+			"FOO",
+			">FOO BAR\n",
+		},
+		{
+			"NC_001422.1 Coliphage phi-X174, complete genome",
+			"NC_001422.1",
+			">NC_001422.1 Coliphage phi-X174, complete genome\n",
+		},
+		{
+			FastaHeader{
+				Desc:       "sp|P03649|SPIKE_BPPHX Major spike protein G OS=Escherichia phage phiX174",
+				Accession:  "P03649",
+				Version:    "P03649.1",
+				Name:       "SPIKE_BPPHX",
+				Definition: "Major spike protein G",
+			},
+			"SPIKE_BPPHX",
+			">SPIKE_BPPHX P03649.1 Major spike protein G\n",
+		},
+		{
+			"foo",
+			"foo",
+			">foo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		seq := gts.New(tt.info, nil, data)
+		b := strings.Builder{}
+		if _, err := NewWriter(&b, EMBOSSFile).WriteSeq(seq); err != nil {
+			t.Errorf("WriteSeq(%v): %v", tt.info, err)
+			continue
+		}
+		testutils.Equals(t, b.String(), tt.out+body)
+		testutils.Equals(t, EMBOSSName(seq), tt.name)
+	}
+
+	b := strings.Builder{}
+	if _, err := NewWriter(&b, EMBOSSFile).WriteSeq(gts.New(nil, nil, data)); err == nil {
+		t.Error("expected error for sequence without metadata")
+	}
+}
@@ -0,0 +1,64 @@
+package seqio_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/seqio"
+)
+
+func ExampleReadFile() {
+	seqs, err := seqio.ReadFile("testdata/NC_001422.gb")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, seq := range seqs {
+		fmt.Println(seqio.ID(seq), gts.Len(seq))
+	}
+
+	// Output:
+	// NC_001422.1 5386
+}
+
+func ExampleNewWriter() {
+	seqs, err := seqio.ReadFile("testdata/NC_001422.gb")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filter, err := gts.Selector("CDS/locus_tag=^phiX174p01$")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, seq := range seqs {
+		for _, f := range seq.Features().Filter(filter) {
+			seq := gts.New(seqio.ID(seq)+" gene A", nil, f.Loc.Region().Locate(seq).Bytes()[:30])
+			w := seqio.NewWriter(os.Stdout, seqio.FastaFile)
+			if _, err := w.WriteSeq(seq); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	// Output:
+	// >NC_001422.1 gene A
+	// atggttcgttcttattacccttctgaatgt
+}
+
+func ExampleNewRecord() {
+	seqs, err := seqio.ReadAll(strings.NewReader(">foo bar\natgc\n"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rec := seqio.NewRecord(seqs[0])
+	fmt.Println(rec.Schema, rec.ID, rec.Description, rec.Sequence)
+
+	// Output:
+	// 1 foo bar atgc
+}
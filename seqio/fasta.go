@@ -32,26 +32,44 @@ func (f Fasta) Bytes() []byte {
 	return f.Data
 }
 
-// WriteTo satisfies the io.WriterTo interface.
+// DefaultFastaWidth is the number of characters per line of the sequence
+// written in FASTA format unless specified otherwise.
+const DefaultFastaWidth = 70
+
+func formatFasta(desc string, data []byte, width int) string {
+	desc = strings.ReplaceAll(desc, "\n", " ")
+	body := string(data)
+	if width > 0 {
+		body = wrap.Force(body, width)
+	}
+	return fmt.Sprintf(">%s\n%s\n", desc, body)
+}
+
+// WriteTo satisfies the io.WriterTo interface. The sequence is wrapped at
+// DefaultFastaWidth characters per line.
 func (f Fasta) WriteTo(w io.Writer) (int64, error) {
-	desc := strings.ReplaceAll(f.Desc, "\n", " ")
-	data := wrap.Force(string(f.Data), 70)
-	s := fmt.Sprintf(">%s\n%s\n", desc, data)
-	n, err := io.WriteString(w, s)
+	n, err := io.WriteString(w, formatFasta(f.Desc, f.Data, DefaultFastaWidth))
 	return int64(n), err
 }
 
 // FastaWriter writes a gts.Sequence to an io.Writer in FASTA format.
 type FastaWriter struct {
-	w io.Writer
+	w     io.Writer
+	width int
+}
+
+// NewFastaWriter creates a FastaWriter which wraps the sequence at the given
+// number of characters per line. If the width is zero or negative, each
+// sequence is written on a single line.
+func NewFastaWriter(w io.Writer, width int) FastaWriter {
+	return FastaWriter{w, width}
 }
 
 // WriteSeq satisfies the seqio.SeqWriter interface.
 func (w FastaWriter) WriteSeq(seq gts.Sequence) (int, error) {
 	switch v := seq.(type) {
 	case Fasta:
-		n, err := v.WriteTo(w.w)
-		return int(n), err
+		return io.WriteString(w.w, formatFasta(v.Desc, v.Data, w.width))
 	case *Fasta:
 		return w.WriteSeq(*v)
 	default:
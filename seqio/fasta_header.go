@@ -0,0 +1,198 @@
+package seqio
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// FastaHeader represents the metadata of a FASTA sequence parsed from its
+// description line. The description line is retained as is, so that a FASTA
+// sequence with a FastaHeader is written out unchanged.
+type FastaHeader struct {
+	Desc       string
+	Database   string
+	Accession  string
+	Version    string
+	Name       string
+	Definition string
+	Organism   string
+	Molecule   gts.Molecule
+}
+
+// ID returns the ID of the sequence, which is the first word of the
+// description line.
+func (h FastaHeader) ID() string {
+	if fields := strings.Fields(h.Desc); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// String satisfies the fmt.Stringer interface.
+func (h FastaHeader) String() string {
+	return h.Desc
+}
+
+var (
+	fastaAccessionVersion = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)\.(\d+)$`)
+	uniprotAttribute      = regexp.MustCompile(`\s([A-Z]{2})=`)
+)
+
+// ncbiDatabaseTags are the database tags of NCBI FASTA identifiers which are
+// followed by an accession.
+var ncbiDatabaseTags = map[string]bool{
+	"gb": true, "emb": true, "dbj": true, "ref": true, "tpg": true,
+	"tpe": true, "tpd": true, "pir": true, "prf": true, "gpp": true,
+}
+
+func splitFastaDesc(desc string) (string, string) {
+	desc = strings.TrimSpace(desc)
+	if i := strings.IndexAny(desc, " \t"); i >= 0 {
+		return desc[:i], strings.TrimSpace(desc[i+1:])
+	}
+	return desc, ""
+}
+
+// setAccession sets the accession and version from an identifier which may
+// have a version suffix.
+func (h *FastaHeader) setAccession(id string) {
+	if m := fastaAccessionVersion.FindStringSubmatch(id); m != nil {
+		h.Accession, h.Version = m[1], id
+		return
+	}
+	h.Accession = id
+}
+
+// parseUniProtDefinition separates the attributes such as `OS=` and `SV=`
+// from the definition of a UniProt description line.
+func (h *FastaHeader) parseUniProtDefinition() {
+	locs := uniprotAttribute.FindAllStringSubmatchIndex(h.Definition, -1)
+	if len(locs) == 0 {
+		return
+	}
+	s := h.Definition
+	for i, loc := range locs {
+		end := len(s)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		value := strings.TrimSpace(s[loc[1]:end])
+		switch s[loc[2]:loc[3]] {
+		case "OS":
+			h.Organism = value
+		case "SV":
+			h.Version = fmt.Sprintf("%s.%s", h.Accession, value)
+		}
+	}
+	h.Definition = strings.TrimSpace(s[:locs[0][0]])
+}
+
+// ParseFastaHeader parses a FASTA description line following one of the
+// common conventions:
+//
+//	NCBI:    >NC_001422.1 Coliphage phi-X174, complete genome
+//	         >gi|9626372|ref|NC_001422.1| Coliphage phi-X174, complete genome
+//	ENA:     >ENA|J02482|J02482.1 Coliphage phi-X174, complete genome.
+//	UniProt: >sp|P03649|SPIKE_BPPHX Major spike protein G OS=... SV=1
+//
+// The first word of the description line is the identifier and the rest is
+// the definition. An identifier which does not follow any of the conventions
+// is used as the accession, and the version is only set if it is known.
+func ParseFastaHeader(desc string) FastaHeader {
+	id, definition := splitFastaDesc(desc)
+	h := FastaHeader{Desc: desc, Definition: definition}
+
+	parts := strings.Split(strings.TrimSuffix(id, "|"), "|")
+	if len(parts) > 2 && parts[0] == "gi" {
+		parts = parts[2:]
+	}
+
+	switch {
+	case len(parts) == 1:
+		h.setAccession(parts[0])
+	case parts[0] == "sp" || parts[0] == "tr":
+		h.Database, h.Accession = parts[0], parts[1]
+		if len(parts) > 2 {
+			h.Name = parts[2]
+		}
+		h.parseUniProtDefinition()
+	case parts[0] == "ENA":
+		h.Database = parts[0]
+		h.setAccession(parts[1])
+		if len(parts) > 2 {
+			h.setAccession(parts[2])
+		}
+	case ncbiDatabaseTags[parts[0]]:
+		h.Database = parts[0]
+		h.setAccession(parts[1])
+		if len(parts) > 2 {
+			h.Name = parts[2]
+		}
+	default:
+		h.setAccession(id)
+	}
+
+	return h
+}
+
+// FastaHeaderGroups are the names of the capturing groups recognized by
+// ParseFastaHeaderRegexp.
+var FastaHeaderGroups = []string{"database", "accession", "version", "name", "definition", "organism"}
+
+// ParseFastaHeaderRegexp parses a FASTA description line with a regular
+// expression. The named capturing groups listed in FastaHeaderGroups set the
+// respective fields, and the fields without a group are set as given by
+// ParseFastaHeader. If the regular expression does not match, the result of
+// ParseFastaHeader is returned as is.
+func ParseFastaHeaderRegexp(desc string, re *regexp.Regexp) FastaHeader {
+	h := ParseFastaHeader(desc)
+	m := re.FindStringSubmatch(desc)
+	if m == nil {
+		return h
+	}
+	for i, name := range re.SubexpNames() {
+		value := m[i]
+		if value == "" {
+			continue
+		}
+		switch name {
+		case "database":
+			h.Database = value
+		case "accession":
+			h.Accession = value
+		case "version":
+			h.Version = value
+		case "name":
+			h.Name = value
+		case "definition":
+			h.Definition = value
+		case "organism":
+			h.Organism = value
+		}
+	}
+	return h
+}
+
+// HeaderWriter wraps a SeqWriter so that the description line of each FASTA
+// sequence written is parsed with the given regular expression as described
+// in ParseFastaHeaderRegexp.
+type HeaderWriter struct {
+	SeqWriter
+	Regexp *regexp.Regexp
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface.
+func (w HeaderWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	switch info := seq.Info().(type) {
+	case FastaHeader:
+		h := ParseFastaHeaderRegexp(info.Desc, w.Regexp)
+		h.Molecule = info.Molecule
+		seq = gts.WithInfo(seq, h)
+	case string:
+		seq = gts.WithInfo(seq, ParseFastaHeaderRegexp(info, w.Regexp))
+	}
+	return w.SeqWriter.WriteSeq(seq)
+}
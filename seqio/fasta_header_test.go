@@ -0,0 +1,113 @@
+package seqio
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var parseFastaHeaderTests = []struct {
+	in  string
+	out FastaHeader
+}{
+	{"", FastaHeader{}},
+	{"foo bar baz", FastaHeader{
+		Accession:  "foo",
+		Definition: "bar baz",
+	}},
+	{"NC_001422.1 Coliphage phi-X174, complete genome", FastaHeader{
+		Accession:  "NC_001422",
+		Version:    "NC_001422.1",
+		Definition: "Coliphage phi-X174, complete genome",
+	}},
+	{"gi|9626372|ref|NC_001422.1| Coliphage phi-X174, complete genome", FastaHeader{
+		Database:   "ref",
+		Accession:  "NC_001422",
+		Version:    "NC_001422.1",
+		Definition: "Coliphage phi-X174, complete genome",
+	}},
+	{"gb|J02482.1|PHXCG Coliphage phi-X174", FastaHeader{
+		Database:   "gb",
+		Accession:  "J02482",
+		Version:    "J02482.1",
+		Name:       "PHXCG",
+		Definition: "Coliphage phi-X174",
+	}},
+	{"ENA|J02482|J02482.1 Coliphage phi-X174, complete genome.", FastaHeader{
+		Database:   "ENA",
+		Accession:  "J02482",
+		Version:    "J02482.1",
+		Definition: "Coliphage phi-X174, complete genome.",
+	}},
+	{"sp|P03649|SPIKE_BPPHX Major spike protein G OS=Escherichia phage phiX174 OX=10847 GN=G PE=1 SV=1", FastaHeader{
+		Database:   "sp",
+		Accession:  "P03649",
+		Version:    "P03649.1",
+		Name:       "SPIKE_BPPHX",
+		Definition: "Major spike protein G",
+		Organism:   "Escherichia phage phiX174",
+	}},
+	{"tr|A0A0A0|A0A0A0_9CAUD Uncharacterized protein", FastaHeader{
+		Database:   "tr",
+		Accession:  "A0A0A0",
+		Name:       "A0A0A0_9CAUD",
+		Definition: "Uncharacterized protein",
+	}},
+}
+
+func TestParseFastaHeader(t *testing.T) {
+	for _, tt := range parseFastaHeaderTests {
+		out := ParseFastaHeader(tt.in)
+		tt.out.Desc = tt.in
+		testutils.Equals(t, out, tt.out)
+		testutils.Equals(t, out.String(), tt.in)
+	}
+
+	testutils.Equals(t, ParseFastaHeader("sp|P03649|SPIKE_BPPHX Major spike protein G").ID(), "sp|P03649|SPIKE_BPPHX")
+}
+
+func TestParseFastaHeaderRegexp(t *testing.T) {
+	re := regexp.MustCompile(`^(?P<accession>\S+) \[organism=(?P<organism>[^\]]+)\] (?P<definition>.*)$`)
+
+	in := "seq42 [organism=Escherichia coli] pUC19 variant"
+	testutils.Equals(t, ParseFastaHeaderRegexp(in, re), FastaHeader{
+		Desc:       in,
+		Accession:  "seq42",
+		Definition: "pUC19 variant",
+		Organism:   "Escherichia coli",
+	})
+
+	in = "NC_001422.1 Coliphage phi-X174, complete genome"
+	testutils.Equals(t, ParseFastaHeaderRegexp(in, re), ParseFastaHeader(in))
+}
+
+func TestHeaderWriter(t *testing.T) {
+	re := regexp.MustCompile(`^(?P<name>\S+) (?P<accession>\S+) (?P<definition>.*)$`)
+	seq := WithMolecule(Fasta{"foo AB000001 bar baz", []byte("atgcatgcat")}, gts.RNA)
+
+	b := strings.Builder{}
+	w := HeaderWriter{NewWriter(&b, GenBankFile), re}
+	if _, err := w.WriteSeq(seq); err != nil {
+		t.Fatalf("HeaderWriter.WriteSeq(seq): %v", err)
+	}
+	out := b.String()
+	for _, line := range []string{
+		"LOCUS       foo                       10 bp    RNA     linear   UNA 01-JAN-1970\n",
+		"DEFINITION  bar baz.\n",
+		"ACCESSION   AB000001\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("HeaderWriter.WriteSeq(seq) wrote:\n%s\nwant line %q", out, line)
+		}
+	}
+
+	b.Reset()
+	w = HeaderWriter{NewWriter(&b, FastaFile), re}
+	if _, err := w.WriteSeq(seq); err != nil {
+		t.Fatalf("HeaderWriter.WriteSeq(seq): %v", err)
+	}
+	testutils.Equals(t, b.String(), ">foo AB000001 bar baz\natgcatgcat\n")
+}
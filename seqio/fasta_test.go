@@ -33,7 +33,7 @@ func TestFastaIO(t *testing.T) {
 		}
 		t.Run("format from *Fasta", func(t *testing.T) {
 			b := strings.Builder{}
-			n, err := FastaWriter{&b}.WriteSeq(&seq)
+			n, err := NewFastaWriter(&b, DefaultFastaWidth).WriteSeq(&seq)
 			if int(n) != len([]byte(in)) || err != nil {
 				t.Errorf("f.WriteSeq(seq) = (%d, %v), want %d, nil", n, err, len(in))
 				return
@@ -43,7 +43,7 @@ func TestFastaIO(t *testing.T) {
 		})
 		t.Run("format from BasicSequence", func(t *testing.T) {
 			b := strings.Builder{}
-			n, err := FastaWriter{&b}.WriteSeq(gts.Copy(seq))
+			n, err := NewFastaWriter(&b, DefaultFastaWidth).WriteSeq(gts.Copy(seq))
 			if int(n) != len([]byte(in)) || err != nil {
 				t.Errorf("f.WriteSeq(seq) = (%d, %v), want %d, nil", n, err, len(in))
 				return
@@ -58,8 +58,36 @@ func TestFastaIO(t *testing.T) {
 
 func TestFastaIOFail(t *testing.T) {
 	b := bytes.Buffer{}
-	n, err := FastaWriter{&b}.WriteSeq(gts.New(nil, nil, nil))
+	n, err := NewFastaWriter(&b, DefaultFastaWidth).WriteSeq(gts.New(nil, nil, nil))
 	if n != 0 || err == nil {
 		t.Errorf("formatting an empty Sequence should return an error")
 	}
 }
+
+func TestFastaWidth(t *testing.T) {
+	seq := Fasta{"foo", []byte("atgcatgcat")}
+
+	tests := []struct {
+		width int
+		out   string
+	}{
+		{4, ">foo\natgc\natgc\nat\n"},
+		{5, ">foo\natgca\ntgcat\n"},
+		{0, ">foo\natgcatgcat\n"},
+		{-1, ">foo\natgcatgcat\n"},
+	}
+
+	for _, tt := range tests {
+		b := strings.Builder{}
+		if _, err := NewWriterWidth(&b, FastaFile, tt.width).WriteSeq(seq); err != nil {
+			t.Fatal(err)
+		}
+		testutils.Equals(t, b.String(), tt.out)
+
+		b.Reset()
+		if _, err := NewWriterWidth(&b, DefaultFile, tt.width).WriteSeq(seq); err != nil {
+			t.Fatal(err)
+		}
+		testutils.Equals(t, b.String(), tt.out)
+	}
+}
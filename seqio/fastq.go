@@ -0,0 +1,152 @@
+package seqio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-pars/pars"
+)
+
+// FastqOffset is the offset of the ASCII encoding of the Phred quality
+// scores in a FASTQ file.
+const FastqOffset = 33
+
+// Fastq represents a FASTQ format sequence object. The quality holds the
+// Phred quality score of each base without the ASCII offset.
+type Fastq struct {
+	Desc    string
+	Data    []byte
+	Quality []byte
+}
+
+// Info returns the metadata of the sequence.
+func (f Fastq) Info() interface{} {
+	return f.Desc
+}
+
+// Features returns the feature table of the sequence.
+func (f Fastq) Features() gts.FeatureSlice {
+	return nil
+}
+
+// Bytes returns the byte representation of the sequence.
+func (f Fastq) Bytes() []byte {
+	return f.Data
+}
+
+// QualityScores returns the Phred quality score of each base.
+func (f Fastq) QualityScores() []byte {
+	return f.Quality
+}
+
+// WithQualityScores creates a shallow copy of the read with the quality
+// scores swapped with the given scores.
+func (f Fastq) WithQualityScores(q []byte) gts.Sequence {
+	return Fastq{f.Desc, f.Data, q}
+}
+
+// WithInfo creates a shallow copy of the read with the description swapped
+// with the given value. If the value is neither a string nor a fmt.Stringer,
+// a sequence without quality scores is returned instead.
+func (f Fastq) WithInfo(info interface{}) gts.Sequence {
+	switch v := info.(type) {
+	case string:
+		return Fastq{v, f.Data, f.Quality}
+	case fmt.Stringer:
+		return Fastq{v.String(), f.Data, f.Quality}
+	default:
+		return gts.New(info, f.Features(), f.Data)
+	}
+}
+
+// WithFeatures creates a shallow copy of the read with the given features.
+// As a read cannot hold features, a sequence without quality scores is
+// returned if any features are given.
+func (f Fastq) WithFeatures(ff []gts.Feature) gts.Sequence {
+	if len(ff) == 0 {
+		return f
+	}
+	return gts.New(f.Desc, ff, f.Data)
+}
+
+// WithBytes creates a shallow copy of the read with the bases swapped with
+// the given bytes. The quality scores are kept if the number of bases is
+// unchanged, and are otherwise discarded until set with WithQualityScores.
+func (f Fastq) WithBytes(p []byte) gts.Sequence {
+	if len(p) != len(f.Quality) {
+		return Fastq{f.Desc, p, nil}
+	}
+	return Fastq{f.Desc, p, f.Quality}
+}
+
+// Slice returns the read trimmed to the bases from start up to end, along
+// with their quality scores.
+func (f Fastq) Slice(start, end int) Fastq {
+	return Fastq{f.Desc, f.Data[start:end], f.Quality[start:end]}
+}
+
+// WriteTo satisfies the io.WriterTo interface.
+func (f Fastq) WriteTo(w io.Writer) (int64, error) {
+	desc := strings.ReplaceAll(f.Desc, "\n", " ")
+	qual := make([]byte, len(f.Quality))
+	for i, q := range f.Quality {
+		qual[i] = q + FastqOffset
+	}
+	s := fmt.Sprintf("@%s\n%s\n+\n%s\n", desc, f.Data, qual)
+	n, err := io.WriteString(w, s)
+	return int64(n), err
+}
+
+// FastqWriter writes a gts.Sequence to an io.Writer in FASTQ format.
+type FastqWriter struct {
+	w io.Writer
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. Only sequences with
+// quality scores, as given by gts.QualityScores, can be written in FASTQ
+// format.
+func (w FastqWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	if v, ok := seq.(*Fastq); ok {
+		return w.WriteSeq(*v)
+	}
+
+	q := gts.QualityScores(seq)
+	if q == nil {
+		return 0, fmt.Errorf("gts does not know how to format a sequence without quality scores as FASTQ")
+	}
+
+	switch info := seq.Info().(type) {
+	case string:
+		n, err := Fastq{info, seq.Bytes(), q}.WriteTo(w.w)
+		return int(n), err
+	case fmt.Stringer:
+		n, err := Fastq{info.String(), seq.Bytes(), q}.WriteTo(w.w)
+		return int(n), err
+	default:
+		return 0, fmt.Errorf("gts does not know how to format a sequence with metadata type `%T` as FASTQ", info)
+	}
+}
+
+// FastqParser attempts to parse a single FASTQ file entry. The sequence and
+// quality scores are each expected to be on a single line.
+var FastqParser = pars.Seq(
+	'@', pars.Line, pars.Line, '+', pars.Line, pars.Line,
+).Map(func(result *pars.Result) error {
+	desc := string(result.Children[1].Token)
+	data := append([]byte{}, result.Children[2].Token...)
+	qual := result.Children[5].Token
+	if len(qual) != len(data) {
+		return fmt.Errorf("FASTQ record %q has %d bases but %d quality scores", desc, len(data), len(qual))
+	}
+	scores := make([]byte, len(qual))
+	for i, c := range qual {
+		if c < FastqOffset || '~' < c {
+			return fmt.Errorf("FASTQ record %q has an invalid quality score character %q", desc, c)
+		}
+		scores[i] = c - FastqOffset
+	}
+	result.SetValue(Fastq{desc, data, scores})
+	return nil
+})
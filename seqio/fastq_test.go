@@ -0,0 +1,104 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+	"github.com/go-pars/pars"
+)
+
+const fastqTestRecords = `@read1 sample=A
+ACGTACGTAC
++
+IIIIIIII#!
+@read2
+acgt
++read2
+5555
+`
+
+func TestFastqIO(t *testing.T) {
+	scanner := NewAutoScanner(strings.NewReader(fastqTestRecords))
+	seqs := []gts.Sequence{}
+	for scanner.Scan() {
+		seqs = append(seqs, scanner.Value())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner.Err() = %v", err)
+	}
+
+	testutils.Equals(t, seqs, []gts.Sequence{
+		Fastq{"read1 sample=A", []byte("ACGTACGTAC"), []byte{40, 40, 40, 40, 40, 40, 40, 40, 2, 0}},
+		Fastq{"read2", []byte("acgt"), []byte{20, 20, 20, 20}},
+	})
+
+	testutils.Equals(t, ID(seqs[0]), "read1")
+	testutils.Equals(t, seqs[0].(Fastq).Slice(2, 5), Fastq{"read1 sample=A", []byte("GTA"), []byte{40, 40, 40}})
+
+	b := strings.Builder{}
+	w := NewWriter(&b, FastqFile)
+	for _, seq := range seqs {
+		if _, err := w.WriteSeq(seq); err != nil {
+			t.Fatalf("FastqWriter.WriteSeq(seq): %v", err)
+		}
+	}
+	testutils.Equals(t, b.String(), strings.Replace(fastqTestRecords, "+read2", "+", 1))
+
+	b.Reset()
+	fq := seqs[1].(Fastq)
+	if _, err := NewWriter(&b, DefaultFile).WriteSeq(&fq); err != nil {
+		t.Fatalf("AutoWriter.WriteSeq(seq): %v", err)
+	}
+	testutils.Equals(t, b.String(), "@read2\nacgt\n+\n5555\n")
+	if _, err := NewWriter(&b, FastqFile).WriteSeq(Fasta{"foo", []byte("acgt")}); err == nil {
+		t.Error("expected error writing a FASTA sequence as FASTQ")
+	}
+}
+
+func TestFastqIOFail(t *testing.T) {
+	tests := []string{
+		"@read1\nACGT\n+\nIII\n",
+		"@read1\nACGT\n+\nII I\n",
+		">read1\nACGT\n",
+		"@read1\nACGT\nIIII\n",
+	}
+
+	parser := pars.AsParser(FastqParser)
+	for _, in := range tests {
+		if _, err := parser.Parse(pars.FromString(in)); err == nil {
+			t.Errorf("while parsing`\n%s\n`: expected error", in)
+		}
+	}
+}
+
+func TestFastqOperations(t *testing.T) {
+	fq := Fastq{"read1", []byte("aacgt"), []byte{10, 20, 30, 40, 50}}
+
+	seq := gts.Reverse(gts.Complement(fq))
+	testutils.Equals(t, seq, gts.Sequence(Fastq{"read1", []byte("acgtt"), []byte{50, 40, 30, 20, 10}}))
+
+	seq = gts.Slice(seq, 1, 4)
+	testutils.Equals(t, seq, gts.Sequence(Fastq{"read1", []byte("cgt"), []byte{40, 30, 20}}))
+
+	b := strings.Builder{}
+	if _, err := NewWriter(&b, DefaultFile).WriteSeq(gts.WithInfo(seq, "read2")); err != nil {
+		t.Fatalf("AutoWriter.WriteSeq(seq): %v", err)
+	}
+	testutils.Equals(t, b.String(), "@read2\ncgt\n+\nI?5\n")
+
+	b.Reset()
+	seq = gts.WithFeatures(seq, []gts.Feature{gts.NewFeature("misc_feature", gts.Range(0, 1), gts.Props{})})
+	if _, err := NewWriter(&b, DefaultFile).WriteSeq(seq); err != nil {
+		t.Fatalf("AutoWriter.WriteSeq(seq): %v", err)
+	}
+	testutils.Equals(t, b.String(), ">read1\ncgt\n")
+
+	b.Reset()
+	seq = gts.Concat(fq, Fasta{"foo", []byte("acgt")})
+	if _, err := NewWriter(&b, DefaultFile).WriteSeq(seq); err != nil {
+		t.Fatalf("AutoWriter.WriteSeq(seq): %v", err)
+	}
+	testutils.Equals(t, b.String(), ">read1\naacgtacgt\n")
+}
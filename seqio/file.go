@@ -0,0 +1,102 @@
+package seqio
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// ID returns the identifier of the given sequence. For sequences with a
+// textual description such as FASTA sequences, the first word of the
+// description is used. An empty string is returned if the identifier cannot
+// be determined.
+func ID(seq gts.Sequence) string {
+	switch info := seq.Info().(type) {
+	case interface{ ID() string }:
+		return info.ID()
+	case string:
+		if fields := strings.Fields(info); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// WithID returns a shallow copy of the given sequence with the identifier
+// replaced by the given identifier. For GenBank records, the accession,
+// version, and locus name are derived from the identifier. For sequences with
+// a textual description, the first word of the description is replaced. The
+// metadata of any other sequences is replaced with the identifier.
+func WithID(seq gts.Sequence, id string) gts.Sequence {
+	switch info := seq.Info().(type) {
+	case GenBankFields:
+		h := FastaHeader{}
+		h.setAccession(id)
+		info.Accession, info.Version = h.Accession, h.Version
+		info.LocusName = LocusName(id, genbankLocusWidth)
+		return gts.WithInfo(seq, info)
+	case FastaHeader:
+		_, rest := splitFastaDesc(info.Desc)
+		return gts.WithInfo(seq, ParseFastaHeader(strings.TrimSpace(id+" "+rest)))
+	case string:
+		_, rest := splitFastaDesc(info)
+		return gts.WithInfo(seq, strings.TrimSpace(id+" "+rest))
+	default:
+		return gts.WithInfo(seq, id)
+	}
+}
+
+// ReadAll reads all of the sequences from the given reader, automatically
+// detecting the format of the sequences.
+func ReadAll(r io.Reader) ([]gts.Sequence, error) {
+	seqs := []gts.Sequence{}
+	scanner := NewAutoScanner(r)
+	for scanner.Scan() {
+		seqs = append(seqs, scanner.Value())
+	}
+	return seqs, scanner.Err()
+}
+
+// ReadFile reads all of the sequences in the named file.
+func ReadFile(filename string) ([]gts.Sequence, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadAll(f)
+}
+
+// WriteAll writes each of the given sequences with the given SeqWriter.
+func WriteAll(sw SeqWriter, seqs []gts.Sequence) error {
+	for _, seq := range seqs {
+		if _, err := sw.WriteSeq(seq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile writes the given sequences to the named file, creating the file
+// if necessary. The file type is determined from the file extension, and the
+// format of each sequence is detected if the extension is unknown.
+func WriteFile(filename string, seqs []gts.Sequence) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := WriteAll(NewWriter(w, Detect(filename)), seqs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,57 @@
+package seqio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestID(t *testing.T) {
+	testutils.Equals(t, ID(Fasta{"foo bar", nil}), "foo")
+	testutils.Equals(t, ID(Fasta{"", nil}), "")
+	testutils.Equals(t, ID(GenBank{Fields: GenBankFields{LocusName: "foo", Accession: "bar"}}), "bar")
+	testutils.Equals(t, ID(gts.New(nil, nil, nil)), "")
+}
+
+func TestWithID(t *testing.T) {
+	testutils.Equals(t, ID(WithID(Fasta{"foo bar", nil}, "baz")), "baz")
+	testutils.Equals(t, WithID(Fasta{"foo bar", nil}, "baz").Info(), "baz bar")
+	testutils.Equals(t, ID(WithID(gts.New(nil, nil, nil), "baz")), "baz")
+
+	seq := WithID(GenBank{Fields: GenBankFields{LocusName: "foo", Accession: "bar"}}, "NC_000913.3")
+	info := seq.Info().(GenBankFields)
+	testutils.Equals(t, info.LocusName, "NC_000913")
+	testutils.Equals(t, info.Accession, "NC_000913")
+	testutils.Equals(t, info.Version, "NC_000913.3")
+
+	seq = WithID(gts.New(ParseFastaHeader("foo bar"), nil, nil), "baz")
+	testutils.Equals(t, seq.Info().(FastaHeader).Desc, "baz bar")
+}
+
+func TestReadWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gts-seqio-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	in := []gts.Sequence{Fasta{"foo", []byte("atgc")}, Fasta{"bar", []byte("gcta")}}
+	filename := filepath.Join(dir, "out.fasta")
+	if err := WriteFile(filename, in); err != nil {
+		t.Fatalf("WriteFile(%q): %v", filename, err)
+	}
+
+	out, err := ReadFile(filename)
+	if err != nil {
+		t.Fatalf("ReadFile(%q): %v", filename, err)
+	}
+	testutils.Equals(t, out, in)
+
+	if _, err := ReadFile(filepath.Join(dir, "missing.fasta")); err == nil {
+		t.Error("expected error in ReadFile for missing file")
+	}
+}
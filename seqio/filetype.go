@@ -12,11 +12,18 @@ const (
 	FastqFile
 	GenBankFile
 	EMBLFile
+	GFF3File
+	EMBOSSFile
+	BEDFile
+	BED6File
+	GTFFile
 )
 
 // Detect returns the FileType associated to extension of the given filename.
+// The extension of a compression format such as `.gz` is ignored, so that
+// `seq.gb.gz` is detected as a GenBank file.
 func Detect(filename string) FileType {
-	ext := filepath.Ext(filename)
+	ext := filepath.Ext(trimCompression(filename))
 	if ext != "" {
 		ext = ext[1:]
 	}
@@ -28,12 +35,22 @@ func ToFileType(name string) FileType {
 	switch name {
 	case "fasta":
 		return FastaFile
-	case "fastq":
+	case "fastq", "fq":
 		return FastqFile
 	case "gb", "genbank":
 		return GenBankFile
 	case "emb", "embl":
 		return EMBLFile
+	case "gff", "gff3":
+		return GFF3File
+	case "emboss":
+		return EMBOSSFile
+	case "bed", "bed12":
+		return BEDFile
+	case "bed6":
+		return BED6File
+	case "gtf":
+		return GTFFile
 	default:
 		return DefaultFile
 	}
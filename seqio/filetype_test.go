@@ -9,10 +9,22 @@ var detectTests = []struct {
 	{"foo", DefaultFile},
 	{"foo.fasta", FastaFile},
 	{"foo.fastq", FastqFile},
+	{"foo.fq", FastqFile},
 	{"foo.gb", GenBankFile},
 	{"foo.genbank", GenBankFile},
 	{"foo.emb", EMBLFile},
 	{"foo.embl", EMBLFile},
+	{"foo.gff", GFF3File},
+	{"foo.gff3", GFF3File},
+	{"foo.emboss", EMBOSSFile},
+	{"foo.bed", BEDFile},
+	{"foo.bed12", BEDFile},
+	{"foo.bed6", BED6File},
+	{"foo.gtf", GTFFile},
+	{"foo.gb.gz", GenBankFile},
+	{"foo.fasta.bz2", FastaFile},
+	{"foo.fq.zst", FastqFile},
+	{"foo.gz", DefaultFile},
 }
 
 func TestDetect(t *testing.T) {
@@ -0,0 +1,62 @@
+//go:build go1.18
+// +build go1.18
+
+package seqio
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+)
+
+func FuzzGenBank(f *testing.F) {
+	for _, path := range []string{
+		"testdata/NC_001422_part.gb",
+		"testdata/NC_000913.3.min.gb",
+	} {
+		p, err := ioutil.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(p))
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		scanner := NewScanner(GenBankParser, strings.NewReader(s))
+		for scanner.Scan() {
+			seq := scanner.Value()
+			b := strings.Builder{}
+			if _, err := (GenBankWriter{&b, GenBankDialect{}}).WriteSeq(seq); err != nil {
+				continue
+			}
+			_ = NewRecord(seq)
+			if n := gts.Len(seq); n > 0 {
+				_ = gts.Slice(seq, n/2, n)
+				_ = gts.Rotate(seq, n/2)
+			}
+		}
+	})
+}
+
+func FuzzAutoScanner(f *testing.F) {
+	f.Add(">foo\natgc\n")
+	f.Add("@foo\natgc\n+\nIIII\n")
+	f.Add("LOCUS       foo                        4 bp    DNA     linear   UNA 01-JAN-1980\nORIGIN\n        1 atgc\n//\n")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		seqs, err := ReadAll(strings.NewReader(s))
+		if err != nil {
+			return
+		}
+		b := strings.Builder{}
+		for _, seq := range seqs {
+			w, err := DetectWriter(seq, &b)
+			if err != nil {
+				continue
+			}
+			_, _ = w.WriteSeq(seq)
+		}
+	})
+}
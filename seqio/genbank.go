@@ -3,8 +3,11 @@ package seqio
 import (
 	"fmt"
 	"io"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-ascii/ascii"
 	"github.com/go-gts/gts"
@@ -193,8 +196,150 @@ func (gb GenBank) WithTopology(t gts.Topology) gts.Sequence {
 	return gb.WithInfo(info)
 }
 
+// GenBankDialect represents the formatting options of a GenBank record for
+// compatibility with older parsers.
+type GenBankDialect struct {
+	// BaseCount writes the BASE COUNT line before the ORIGIN line.
+	BaseCount bool
+
+	// LegacyLocus writes the LOCUS line in the column layout used prior to
+	// GenBank release 127.
+	LegacyLocus bool
+}
+
+// AsGenBankDialect parses a comma separated list of GenBank dialect options:
+// `base-count` for BaseCount, `legacy-locus` for LegacyLocus, and `legacy`
+// for both.
+func AsGenBankDialect(s string) (GenBankDialect, error) {
+	dialect := GenBankDialect{}
+	for _, option := range strings.Split(s, ",") {
+		switch strings.TrimSpace(option) {
+		case "base-count":
+			dialect.BaseCount = true
+		case "legacy-locus":
+			dialect.LegacyLocus = true
+		case "legacy":
+			dialect.BaseCount = true
+			dialect.LegacyLocus = true
+		case "":
+		default:
+			return GenBankDialect{}, fmt.Errorf("unknown GenBank dialect option %q: expected one of base-count, legacy-locus, legacy", option)
+		}
+	}
+	return dialect, nil
+}
+
+// Maximum widths of the locus name in the LOCUS line.
+const (
+	genbankLocusWidth       = 16
+	genbankLegacyLocusWidth = 10
+)
+
+// LocusName derives a locus name of at most the given width from a string
+// such as an accession or a file name. The directory and extension of a file
+// name and the version of an accession are removed, whitespaces and control
+// characters are replaced with underscores, and the name is truncated to the
+// given width. An empty string is returned if nothing remains.
+func LocusName(s string, width int) string {
+	s = path.Base(filepath.ToSlash(strings.TrimSpace(s)))
+	if i := strings.IndexByte(s, '.'); i > 0 {
+		s = s[:i]
+	}
+	if s == "." || s == "/" {
+		return ""
+	}
+	return genbankLocusName(s, width)
+}
+
+// genbankLocusName replaces the whitespaces and control characters of a
+// locus name with underscores and truncates it to the given width.
+func genbankLocusName(s string, width int) string {
+	p := []byte(s)
+	for i, c := range p {
+		if !isBaseCharacter(c) {
+			p[i] = '_'
+		}
+	}
+	if len(p) > width {
+		p = p[:width]
+	}
+	return string(p)
+}
+
+// locusName returns the locus name to write in a LOCUS line of the given
+// width. A locus name which does not fit in the LOCUS line is sanitized and
+// truncated, and a missing locus name is derived from the accession or
+// version, so that the LOCUS line can be parsed again.
+func (gbf GenBankFields) locusName(width int) string {
+	if gbf.LocusName != "" {
+		return genbankLocusName(gbf.LocusName, width)
+	}
+	for _, s := range []string{gbf.Accession, gbf.Version} {
+		if name := LocusName(s, width); name != "" {
+			return name
+		}
+	}
+	return "UNKNOWN"
+}
+
+// ValidLocusName returns the locus name which is written in the LOCUS line.
+func (gbf GenBankFields) ValidLocusName() string {
+	return gbf.locusName(genbankLocusWidth)
+}
+
+// genbankLegacyLocus formats the LOCUS line in the column layout used prior
+// to GenBank release 127, where the locus name spans 10 columns, the strand
+// and molecule type are split, and a linear topology is left blank.
+func genbankLegacyLocus(gb GenBank, length int, unit, date string) string {
+	strand, mol := "", string(gb.Fields.Molecule)
+	if i := strings.IndexByte(mol, '-'); i >= 0 {
+		strand, mol = mol[:i+1], mol[i+1:]
+	}
+	if gb.Fields.Molecule == gts.AA {
+		mol = ""
+	}
+	topology := ""
+	if gb.Fields.Topology == gts.Circular {
+		topology = "circular"
+	}
+	return fmt.Sprintf(
+		"LOCUS       %-10s%7d %s %3s%-4s  %-10s %-3s      %s",
+		gb.Fields.locusName(genbankLegacyLocusWidth), length, unit, strand, mol, topology, gb.Fields.Division, date,
+	)
+}
+
+// genbankBaseCount formats the BASE COUNT line of the sequence.
+func genbankBaseCount(p []byte) string {
+	counts := [4]int{}
+	others := 0
+	for _, c := range p {
+		switch c {
+		case 'a', 'A':
+			counts[0]++
+		case 'c', 'C':
+			counts[1]++
+		case 'g', 'G':
+			counts[2]++
+		case 't', 'T':
+			counts[3]++
+		default:
+			others++
+		}
+	}
+	s := fmt.Sprintf("BASE COUNT  %7d a%7d c%7d g%7d t", counts[0], counts[1], counts[2], counts[3])
+	if others > 0 {
+		s += fmt.Sprintf("%7d others", others)
+	}
+	return s
+}
+
 // String satisifes the fmt.Stringer interface.
 func (gb GenBank) String() string {
+	return gb.Format(GenBankDialect{})
+}
+
+// Format formats the record following the given dialect.
+func (gb GenBank) Format(dialect GenBankDialect) string {
 	b := strings.Builder{}
 	indent := defaultGenBankIndent
 
@@ -203,11 +348,19 @@ func (gb GenBank) String() string {
 		length = gb.Fields.Contig.Region.Len()
 	}
 
+	unit := "bp"
+	if gb.Fields.Molecule == gts.AA {
+		unit = "aa"
+	}
+
 	date := strings.ToUpper(gb.Fields.Date.ToTime().Format("02-Jan-2006"))
 	locus := fmt.Sprintf(
-		"%-12s%-17s %10d bp %6s     %-9s%s %s", "LOCUS", gb.Fields.LocusName,
-		length, gb.Fields.Molecule, gb.Fields.Topology, gb.Fields.Division, date,
+		"%-12s%-17s %10d %s %6s     %-9s%s %s", "LOCUS", gb.Fields.locusName(genbankLocusWidth),
+		length, unit, gb.Fields.Molecule, gb.Fields.Topology, gb.Fields.Division, date,
 	)
+	if dialect.LegacyLocus {
+		locus = genbankLegacyLocus(gb, length, unit, date)
+	}
 
 	b.WriteString(locus + "\n")
 
@@ -215,8 +368,10 @@ func (gb GenBank) String() string {
 	b.WriteString("DEFINITION  " + definition + ".\n")
 	b.WriteString("ACCESSION   " + gb.Fields.Accession)
 	if seg, ok := gb.Fields.Region.(gts.Segment); ok {
-		loc := gts.Range(gts.Unpack(seg))
-		b.WriteString(fmt.Sprintf(" REGION: %s", loc))
+		// An empty region cannot be written as a range and is omitted.
+		if loc, err := gts.TryRange(gts.Unpack(seg)); err == nil {
+			b.WriteString(fmt.Sprintf(" REGION: %s", loc))
+		}
 	}
 	b.WriteByte('\n')
 	b.WriteString("VERSION     " + gb.Fields.Version + "\n")
@@ -250,7 +405,7 @@ func (gb GenBank) String() string {
 	for _, ref := range gb.Fields.References {
 		b.WriteString(fmt.Sprintf("REFERENCE   %d", ref.Number))
 		if ref.Info != "" {
-			pad := strings.Repeat(" ", 3-len(strconv.Itoa(ref.Number)))
+			pad := strings.Repeat(" ", gts.Max(3-len(strconv.Itoa(ref.Number)), 0))
 			b.WriteString(pad + ref.Info)
 		}
 		b.WriteByte('\n')
@@ -284,16 +439,21 @@ func (gb GenBank) String() string {
 		b.WriteString(extra.String() + "\n")
 	}
 
-	b.WriteString("FEATURES             Location/Qualifiers\n")
-	fmtr := INSDCFormatter{gb.Table, "     ", 21}
-	fmtr.WriteTo(&b)
-	b.WriteByte('\n')
+	if len(gb.Table) > 0 {
+		b.WriteString("FEATURES             Location/Qualifiers\n")
+		fmtr := INSDCFormatter{gb.Table, "     ", 21}
+		fmtr.WriteTo(&b)
+		b.WriteByte('\n')
+	}
 
 	if gb.Fields.Contig.String() != "" {
 		b.WriteString(fmt.Sprintf("CONTIG      %s\n", gb.Fields.Contig))
 	}
 
 	if gb.Origin.Len() > 0 {
+		if dialect.BaseCount && gb.Fields.Molecule != gts.AA {
+			b.WriteString(genbankBaseCount(gb.Origin.Bytes()) + "\n")
+		}
 		b.WriteString("ORIGIN      \n")
 		b.WriteString(gb.Origin.String())
 	}
@@ -309,17 +469,47 @@ func (gb GenBank) WriteTo(w io.Writer) (int64, error) {
 	return int64(n), err
 }
 
-// GenBankWriter writes a gts.Sequence to an io.Writer in GenBank format.
+// GenBankWriter writes a gts.Sequence to an io.Writer in GenBank format
+// following the dialect.
 type GenBankWriter struct {
-	w io.Writer
+	w       io.Writer
+	dialect GenBankDialect
 }
 
-// WriteSeq satisfies the seqio.SeqWriter interface.
+// headerGenBankFields creates the GenBank fields for a FASTA sequence with
+// the given header. The locus name is the entry name if known and the
+// accession otherwise, and the version defaults to the accession. As the
+// record is not yet annotated, the division is set to UNA and the date to the
+// Unix epoch so that the output is reproducible.
+func headerGenBankFields(h FastaHeader, mol gts.Molecule) GenBankFields {
+	locus, version := h.Name, h.Version
+	if locus == "" {
+		locus = h.Accession
+	}
+	if version == "" {
+		version = h.Accession
+	}
+	return GenBankFields{
+		LocusName:  locus,
+		Molecule:   mol,
+		Topology:   gts.Linear,
+		Division:   "UNA",
+		Date:       Date{1970, time.January, 1},
+		Definition: h.Definition,
+		Accession:  h.Accession,
+		Version:    version,
+		Source:     Organism{Species: h.Organism, Name: h.Organism},
+	}
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. A sequence with a plain
+// description is written as an unannotated record with the fields parsed from
+// the description by ParseFastaHeader and the molecule type given by
+// Molecule.
 func (w GenBankWriter) WriteSeq(seq gts.Sequence) (int, error) {
 	switch v := seq.(type) {
 	case GenBank:
-		n, err := v.WriteTo(w.w)
-		return int(n), err
+		return io.WriteString(w.w, v.Format(w.dialect))
 	case *GenBank:
 		return w.WriteSeq(*v)
 	default:
@@ -327,6 +517,12 @@ func (w GenBankWriter) WriteSeq(seq gts.Sequence) (int, error) {
 		case GenBankFields:
 			gb := GenBank{info, v.Features(), NewOrigin(v.Bytes())}
 			return w.WriteSeq(gb)
+		case FastaHeader:
+			return w.WriteSeq(gts.WithInfo(v, headerGenBankFields(info, Molecule(v))))
+		case string:
+			return w.WriteSeq(gts.WithInfo(v, headerGenBankFields(ParseFastaHeader(info), Molecule(v))))
+		case fmt.Stringer:
+			return w.WriteSeq(gts.WithInfo(v, headerGenBankFields(ParseFastaHeader(info.String()), Molecule(v))))
 		default:
 			return 0, fmt.Errorf("gts does not know how to format a sequence with metadata of type `%T` as GenBank", info)
 		}
@@ -369,6 +565,18 @@ func tryAllParsers(pp []pars.Parser) pars.Parser {
 
 // GenBankParser attempts to parse a single GenBank record.
 func GenBankParser(state *pars.State, result *pars.Result) error {
+	return parseGenBank(state, result, nil)
+}
+
+// warningGenBankParser creates a GenBank parser which reports the lines it
+// could not interpret to the given handler.
+func warningGenBankParser(warn WarningHandler) pars.Parser {
+	return func(state *pars.State, result *pars.Result) error {
+		return parseGenBank(state, result, warn)
+	}
+}
+
+func parseGenBank(state *pars.State, result *pars.Result, warn WarningHandler) error {
 	if err := genbankLocusParser(state, result); err != nil {
 		return err
 	}
@@ -383,11 +591,16 @@ func GenBankParser(state *pars.State, result *pars.Result) error {
 	if err != nil {
 		return pars.NewError(err.Error(), state.Position())
 	}
+	division := string(result.Children[5].Token)
 	topology, err := gts.AsTopology(string(result.Children[4].Token))
 	if err != nil {
-		return pars.NewError(err.Error(), state.Position())
+		// The topology of a linear sequence is left blank in the LOCUS
+		// lines prior to GenBank release 127.
+		if division != "" {
+			return pars.NewError(err.Error(), state.Position())
+		}
+		topology, division = gts.Linear, string(result.Children[4].Token)
 	}
-	division := string(result.Children[5].Token)
 	date := result.Children[6].Value.(Date)
 
 	gb := &GenBank{Fields: GenBankFields{
@@ -429,7 +642,12 @@ func GenBankParser(state *pars.State, result *pars.Result) error {
 			if dig(err) != errGenBankExtra {
 				return err
 			}
+			pos := state.Position()
 			pars.Line(state, result)
+			if warn != nil && len(result.Token) > 0 {
+				msg := fmt.Sprintf("ignored malformed line %q", string(result.Token))
+				warn(Warning{pos.Line + 1, locus, msg})
+			}
 			if pars.End(state, result) == nil {
 				return errGenBankField
 			}
@@ -30,6 +30,11 @@ func genbankFieldNameParser(q interface{}, depth int) pars.Parser {
 		}
 		name := string(result.Token)
 		indentLength := depth - len(name)
+		if indentLength < 0 {
+			state.Clear()
+			what := fmt.Sprintf("field name `%s` exceeds indent width", name)
+			return pars.NewError(what, state.Position())
+		}
 		indentParser := pars.String(strings.Repeat(" ", indentLength))
 		paddingParser := pars.Any(indentParser, pars.Dry(pars.EOL))
 		if paddingParser(state, pars.Void) != nil {
@@ -165,7 +170,7 @@ func genbankDBLinkPairParser(gb *GenBank, depth int) pars.Parser {
 		case -1:
 			return pars.NewError("expected `:`", state.Position())
 		default:
-			db, id := s[:i], s[i+2:]
+			db, id := s[:i], strings.TrimPrefix(s[i+1:], " ")
 			gb.Fields.DBLink.Set(db, id)
 			return nil
 		}
@@ -290,7 +295,7 @@ func genbankReferenceParser(gb *GenBank, depth int) pars.Parser {
 
 		ref := Reference{Number: result.Value.(int)}
 
-		paddingLength := 3 - len(strconv.Itoa(ref.Number))
+		paddingLength := gts.Max(3-len(strconv.Itoa(ref.Number)), 0)
 		paddingParser := pars.String(strings.Repeat(" ", paddingLength))
 		paddingParser(state, pars.Void)
 		pars.Line(state, result)
@@ -422,14 +427,14 @@ func slowGenBankOriginParser(length int) pars.Parser {
 			extent += len(prefix)
 
 			for j := 0; j < 60 && i+j < length; j += 10 {
-				if q[extent] != spaceByte {
+				if extent >= len(q) || q[extent] != spaceByte {
 					pos.Byte += extent
 					return pars.NewError("expected whitespace", pos)
 				}
 				extent++
 
 				for k := 0; k < 10 && i+j+k < length; k++ {
-					if !isBaseCharacter(q[extent]) {
+					if extent >= len(q) || !isBaseCharacter(q[extent]) {
 						pos.Byte += extent
 						return pars.NewError("expected character", pos)
 					}
@@ -446,6 +451,37 @@ func slowGenBankOriginParser(length int) pars.Parser {
 	}
 }
 
+// countOriginBases returns the number of bases in the sequence lines of an
+// ORIGIN field starting at the current position, leaving the state where it
+// was.
+func countOriginBases(state *pars.State, result *pars.Result) int {
+	state.Push()
+	defer state.Pop()
+
+	n := 0
+	for {
+		pars.Line(state, result)
+		line := bytes.TrimLeft(result.Token, " ")
+		if len(line) == 0 || !ascii.IsDigit(line[0]) {
+			return n
+		}
+		line = bytes.TrimLeft(line, "0123456789")
+		for _, c := range line {
+			if isBaseCharacter(c) {
+				n++
+			}
+		}
+	}
+}
+
+// locusLengthError reports that the length given in the LOCUS line does not
+// agree with the number of bases in the ORIGIN field.
+func locusLengthError(state *pars.State, length, n int) error {
+	state.Clear()
+	what := fmt.Sprintf("LOCUS line gives a length of %d but ORIGIN has %d bases", length, n)
+	return pars.NewError(what, state.Position())
+}
+
 func makeGenbankOriginParser(length int) genbankSubparser {
 	return func(gb *GenBank, depth int) pars.Parser {
 		fieldNameParser := genbankFieldNameParser("ORIGIN", depth)
@@ -456,16 +492,26 @@ func makeGenbankOriginParser(length int) genbankSubparser {
 			pars.Line(state, result)
 
 			if err := state.Request(toOriginLength(length)); err != nil {
+				if n := countOriginBases(state, result); n != length {
+					return locusLengthError(state, length, n)
+				}
 				return pars.NewError("not enough bytes in state", state.Position())
 			}
 
 			p := state.Buffer()
 			if validateOrigin(p, length, state.Position()) == nil {
 				state.Advance()
+				if n := countOriginBases(state, result); n > 0 {
+					return locusLengthError(state, length, length+n)
+				}
 				gb.Origin = &Origin{p, false}
 				return nil
 			}
 
+			if n := countOriginBases(state, result); n != length {
+				return locusLengthError(state, length, n)
+			}
+
 			parser := slowGenBankOriginParser(length)
 			if err := parser(state, result); err != nil {
 				return err
@@ -15,7 +15,7 @@ import (
 func formatGenBankHelper(t *testing.T, seq gts.Sequence, in string) {
 	t.Helper()
 	b := strings.Builder{}
-	n, err := GenBankWriter{&b}.WriteSeq(seq)
+	n, err := GenBankWriter{&b, GenBankDialect{}}.WriteSeq(seq)
 	if int(n) != len([]byte(in)) || err != nil {
 		t.Errorf("f.WriteSeq(seq) = (%d, %v), want %d, nil", n, err, len(in))
 	}
@@ -246,6 +246,14 @@ var genbankIOFailTests = []string{
 		"LOCUS       TEST_DATA                 20 bp    DNA     linear   UNA 14-MAY-2020\n" +
 		"ORIGIN      \n" +
 		"        1  gagttttatc gcttccatga",
+	"" +
+		"LOCUS       TEST_DATA                 20 bp    DNA     linear   UNA 14-MAY-2020\n" +
+		"ORIGIN      \n" +
+		"        1 gagttttatc\n" +
+		"                     ",
+	"" +
+		"LOCUS       TEST_DATA                 20 bp    DNA     linear   UNA 14-MAY-2020\n" +
+		"ABCDEFGHIJKLMNOP foo",
 	"" +
 		"LOCUS       TEST_DATA                 20 bp    DNA     linear   UNA 14-MAY-2020\n" +
 		"CONTIG      ",
@@ -265,9 +273,140 @@ func TestGenBankIOFail(t *testing.T) {
 	}
 
 	b := bytes.Buffer{}
-	n, err := GenBankWriter{&b}.WriteSeq(gts.New(nil, nil, nil))
+	n, err := GenBankWriter{&b, GenBankDialect{}}.WriteSeq(gts.New(nil, nil, nil))
 	if n != 0 || err == nil {
 		t.Errorf("formatting an empty Sequence should return an error")
 		return
 	}
 }
+
+func TestGenBankDialect(t *testing.T) {
+	in := strings.Join([]string{
+		"LOCUS       TEST                      12 bp ss-DNA     circular PHG 06-JUL-2018",
+		"DEFINITION  Test sequence.",
+		"ACCESSION   TEST",
+		"VERSION     TEST.1",
+		"KEYWORDS    .",
+		"SOURCE      .",
+		"  ORGANISM  .",
+		"            .",
+		"ORIGIN      ",
+		"        1 aaaccgtttn nn",
+		"//",
+		"",
+	}, "\n")
+
+	state := pars.FromString(in)
+	result := pars.Result{}
+	if err := GenBankParser(state, &result); err != nil {
+		t.Fatal(err)
+	}
+	gb := result.Value.(GenBank)
+
+	testutils.Equals(t, gb.Format(GenBankDialect{}), in)
+
+	dialect, err := AsGenBankDialect("legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, dialect, GenBankDialect{BaseCount: true, LegacyLocus: true})
+
+	out := gb.Format(dialect)
+	lines := strings.Split(out, "\n")
+	testutils.Equals(t, lines[0], "LOCUS       TEST           12 bp ss-DNA   circular   PHG      06-JUL-2018")
+	testutils.Equals(t, lines[8], "BASE COUNT        3 a      2 c      1 g      3 t      3 others")
+
+	// The legacy LOCUS line is read back as is.
+	state = pars.FromString(out)
+	if err := GenBankParser(state, &result); err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, result.Value.(GenBank).Fields, gb.Fields)
+
+	gb.Fields.Topology = gts.Linear
+	state = pars.FromString(gb.Format(dialect))
+	if err := GenBankParser(state, &result); err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, result.Value.(GenBank).Fields, gb.Fields)
+
+	if _, err := AsGenBankDialect("base-count,foo"); err == nil {
+		t.Error("expected error for unknown dialect option")
+	}
+}
+
+func TestLocusName(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		out   string
+	}{
+		{"NC_001422.1", 16, "NC_001422"},
+		{"data/pUC19.fasta.gz", 16, "pUC19"},
+		{"my plasmid", 16, "my_plasmid"},
+		{"NZ_ABCD01000001.1", 10, "NZ_ABCD010"},
+		{"", 16, ""},
+		{"/", 16, ""},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, LocusName(tt.in, tt.width), tt.out)
+	}
+}
+
+func TestGenBankLocus(t *testing.T) {
+	in := strings.Join([]string{
+		"LOCUS       TEST                      12 bp    DNA     linear   UNA 01-JAN-1970",
+		"DEFINITION  Test sequence.",
+		"ACCESSION   TEST",
+		"VERSION     TEST.1",
+		"KEYWORDS    .",
+		"SOURCE      .",
+		"  ORGANISM  .",
+		"            .",
+		"ORIGIN      ",
+		"        1 aaaccgtttn nn",
+		"//",
+		"",
+	}, "\n")
+
+	state := pars.FromString(in)
+	result := pars.Result{}
+	if err := GenBankParser(state, &result); err != nil {
+		t.Fatal(err)
+	}
+	gb := result.Value.(GenBank)
+
+	// The length is recomputed from the sequence.
+	gb.Origin = NewOrigin([]byte("aaaccg"))
+	lines := strings.Split(gb.String(), "\n")
+	testutils.Equals(t, lines[0], "LOCUS       TEST                       6 bp    DNA     linear   UNA 01-JAN-1970")
+
+	// A missing locus name is derived from the accession.
+	gb.Fields.LocusName = ""
+	gb.Fields.Accession = "AB000001"
+	lines = strings.Split(gb.String(), "\n")
+	testutils.Equals(t, lines[0], "LOCUS       AB000001                   6 bp    DNA     linear   UNA 01-JAN-1970")
+
+	// A locus name which does not fit is truncated.
+	gb.Fields.LocusName = "a very long locus name"
+	out := gb.String()
+	lines = strings.Split(out, "\n")
+	testutils.Equals(t, lines[0], "LOCUS       a_very_long_locu           6 bp    DNA     linear   UNA 01-JAN-1970")
+
+	state = pars.FromString(out)
+	if err := GenBankParser(state, &result); err != nil {
+		t.Fatal(err)
+	}
+	testutils.Equals(t, result.Value.(GenBank).Fields.LocusName, "a_very_long_locu")
+
+	// A LOCUS line which disagrees with the sequence is an error.
+	for _, length := range []string{"10", "14"} {
+		drift := strings.Replace(in, "  12 bp", "  "+length+" bp", 1)
+		state = pars.FromString(drift)
+		err := GenBankParser(state, &result)
+		if err == nil || !strings.Contains(err.Error(), "ORIGIN has 12 bases") {
+			t.Errorf("expected length mismatch error for LOCUS length %s, got %v", length, err)
+		}
+	}
+}
@@ -0,0 +1,463 @@
+package seqio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-gts/gts"
+)
+
+// GFF3Writer writes the features of a gts.Sequence to an io.Writer in GFF3
+// format. The version directive is written once before the first sequence,
+// and each sequence is introduced by a sequence-region directive.
+type GFF3Writer struct {
+	w       io.Writer
+	mu      *sync.Mutex
+	started *bool
+}
+
+// gff3Escape percent-encodes the characters which may not appear as is in a
+// GFF3 column, along with the given reserved characters.
+func gff3Escape(s, reserved string) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c == 0x7f || c == '%' || strings.IndexByte(reserved, c) >= 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func gff3EscapeSeqID(s string) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case '0' <= c && c <= '9', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+			b.WriteByte(c)
+		case strings.IndexByte(".:^*$@!+_?-|", c) >= 0:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func gff3EscapeAttribute(s string) string {
+	return gff3Escape(strings.ReplaceAll(s, "\n", " "), ";=&,")
+}
+
+// gff3Segments returns the contiguous segments of a location in the order of
+// transcription.
+func gff3Segments(r gts.Region) []gts.Segment {
+	switch v := r.(type) {
+	case gts.Segment:
+		return []gts.Segment{v}
+	case gts.Regions:
+		ss := []gts.Segment{}
+		for _, rr := range v {
+			ss = append(ss, gff3Segments(rr)...)
+		}
+		return ss
+	case gts.RemoteRegion:
+		return nil
+	default:
+		return []gts.Segment{{r.Head(), r.Tail()}}
+	}
+}
+
+// gff3Phases returns the phase of each of the segments of a CDS feature,
+// which is the number of bases to be removed from the 5' end of the segment
+// to reach the first base of the next codon.
+func gff3Phases(ss []gts.Segment, codonStart int) []int {
+	phases := make([]int, len(ss))
+	offset := codonStart - 1
+	length := 0
+	for i, s := range ss {
+		phases[i] = ((offset-length)%3 + 3) % 3
+		length += s.Len()
+	}
+	return phases
+}
+
+// gff3AttributeNames maps the qualifiers written as the reserved attributes.
+var gff3AttributeNames = map[string]string{
+	"db_xref": "Dbxref",
+	"note":    "Note",
+}
+
+// gff3NameQualifiers are the qualifiers whose first value is used as the
+// Name attribute, in the order of preference.
+var gff3NameQualifiers = []string{"gene", "locus_tag", "label", "standard_name"}
+
+// gff3Parent returns the ID of the gene feature sharing the locus tag or gene
+// name with the given feature.
+func gff3Parent(f gts.Feature, genes map[string]string) string {
+	for _, name := range []string{"locus_tag", "gene"} {
+		for _, value := range f.Props.Get(name) {
+			if id, ok := genes[name+"="+value]; ok {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+func formatGFF3(seq gts.Sequence) string {
+	b := strings.Builder{}
+	id := ID(seq)
+	seqid := gff3EscapeSeqID(id)
+	fmt.Fprintf(&b, "##sequence-region %s 1 %d\n", seqid, gts.Len(seq))
+
+	circular := false
+	if info, ok := seq.Info().(GenBankFields); ok {
+		circular = info.Topology == gts.Circular
+	}
+	protein := Molecule(seq) == gts.AA
+
+	ff := seq.Features()
+	ids := make([]string, len(ff))
+	counts := make(map[string]int)
+	genes := make(map[string]string)
+	for i, f := range ff {
+		counts[f.Key]++
+		ids[i] = fmt.Sprintf("%s:%s:%d", id, f.Key, counts[f.Key])
+		if f.Key == "gene" {
+			for _, name := range []string{"locus_tag", "gene"} {
+				for _, value := range f.Props.Get(name) {
+					key := name + "=" + value
+					if _, ok := genes[key]; !ok {
+						genes[key] = ids[i]
+					}
+				}
+			}
+		}
+	}
+
+	for i, f := range ff {
+		attrs := []string{"ID=" + gff3EscapeAttribute(ids[i])}
+		for _, name := range gff3NameQualifiers {
+			if values := f.Props.Get(name); len(values) > 0 {
+				attrs = append(attrs, "Name="+gff3EscapeAttribute(values[0]))
+				break
+			}
+		}
+		if f.Key != "gene" {
+			if parent := gff3Parent(f, genes); parent != "" {
+				attrs = append(attrs, "Parent="+gff3EscapeAttribute(parent))
+			}
+		}
+
+		featureType := f.Key
+		if f.Key == "source" {
+			featureType = "region"
+			if circular {
+				attrs = append(attrs, "Is_circular=true")
+			}
+		}
+
+		for _, key := range f.Props.Keys() {
+			if key == "translation" {
+				continue
+			}
+			name, ok := gff3AttributeNames[key]
+			if !ok {
+				name = gff3EscapeAttribute(key)
+			}
+			values := make([]string, 0, len(f.Props.Get(key)))
+			for _, value := range f.Props.Get(key) {
+				if value == "" {
+					value = "true"
+				}
+				values = append(values, gff3EscapeAttribute(value))
+			}
+			attrs = append(attrs, name+"="+strings.Join(values, ","))
+		}
+		attributes := strings.Join(attrs, ";")
+
+		strand := "+"
+		switch {
+		case protein:
+			strand = "."
+		case gts.CheckStrand(f.Loc) == gts.StrandReverse:
+			strand = "-"
+		}
+
+		segments := gff3Segments(f.Loc.Region())
+		phases := []int(nil)
+		if f.Key == "CDS" {
+			codonStart := 1
+			if values := f.Props.Get("codon_start"); len(values) > 0 {
+				if n, err := strconv.Atoi(values[0]); err == nil {
+					codonStart = n
+				}
+			}
+			phases = gff3Phases(segments, codonStart)
+		}
+
+		for j, s := range segments {
+			head, tail := gts.Unpack(s)
+			if tail < head {
+				head, tail = tail, head
+			}
+			start, end := head+1, tail
+			if head == tail {
+				start, end = gts.Max(head, 1), gts.Max(head, 1)
+			}
+			phase := "."
+			if phases != nil {
+				phase = strconv.Itoa(phases[j])
+			}
+			fmt.Fprintf(
+				&b, "%s\t.\t%s\t%d\t%d\t.\t%s\t%s\t%s\n",
+				seqid, gff3Escape(featureType, "\t"), start, end, strand, phase, attributes,
+			)
+		}
+	}
+
+	return b.String()
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. The features are written
+// with the identifier of the sequence as the seqid. Each feature is given an
+// ID, and the features sharing a locus tag or gene name with a gene feature
+// refer to the gene as its Parent. Each segment of a feature with a complex
+// location is written on its own line sharing the same attributes. The other
+// qualifiers are written as attributes with the same names, except for
+// /db_xref and /note which are written as the Dbxref and Note attributes, and
+// /translation which is omitted.
+func (w GFF3Writer) WriteSeq(seq gts.Sequence) (int, error) {
+	s := formatGFF3(seq)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !*w.started {
+		s = "##gff-version 3\n" + s
+		*w.started = true
+	}
+
+	return io.WriteString(w.w, s)
+}
+
+// gff3QualifierNames maps the reserved attributes read as qualifiers.
+var gff3QualifierNames = map[string]string{
+	"Dbxref": "db_xref",
+	"Note":   "note",
+}
+
+// gff3IgnoredAttributes are the attributes describing the structure of the
+// GFF3 records which have no counterpart in the INSDC qualifiers.
+var gff3IgnoredAttributes = map[string]bool{
+	"ID":          true,
+	"Parent":      true,
+	"Is_circular": true,
+}
+
+func gff3Unescape(s string) string {
+	if t, err := url.PathUnescape(s); err == nil {
+		return t
+	}
+	return s
+}
+
+// gff3Record is a feature being assembled from the lines of a GFF3 file.
+type gff3Record struct {
+	seqid    string
+	key      string
+	reverse  bool
+	segments []gts.Segment
+	phases   []int
+	props    gts.Props
+}
+
+func (r gff3Record) feature() gts.Feature {
+	// The segments are joined in the order of appearance, which preserves
+	// the locations spanning the origin of a circular sequence. The segments
+	// of a feature on the reverse strand may appear in the order of
+	// transcription, in which case they are reversed to follow the INSDC
+	// convention of complement(join(...)).
+	segments := r.segments
+	phases := r.phases
+	if n := len(segments); r.reverse && segments[n-1][0] < segments[0][0] {
+		segments = make([]gts.Segment, n)
+		phases = make([]int, n)
+		for i := range segments {
+			segments[i], phases[i] = r.segments[n-1-i], r.phases[n-1-i]
+		}
+	}
+
+	locs := make([]gts.Location, len(segments))
+	for i, s := range segments {
+		if s[0]+1 == s[1] {
+			locs[i] = gts.Point(s[0])
+		} else {
+			locs[i] = gts.Range(s[0], s[1])
+		}
+	}
+	loc := gts.Join(locs...)
+
+	phase := phases[0]
+	if r.reverse {
+		loc = loc.Complement()
+		phase = phases[len(phases)-1]
+	}
+
+	props := r.props
+	if r.key == "CDS" && !props.Has("codon_start") {
+		if phase > 0 {
+			props = props.Clone()
+			props.Set("codon_start", strconv.Itoa(phase+1))
+		}
+	}
+
+	return gts.NewFeature(r.key, loc, props)
+}
+
+// ReadGFF3 reads the features from a GFF3 file, grouped by the seqid of the
+// sequences they belong to. The feature type is used as the feature key,
+// except for `region` which is read as a source feature. The start, end, and
+// strand are converted into a location, and the lines sharing the same ID are
+// combined into a single feature with a joined location. The phase of the
+// first segment of a CDS feature is converted into /codon_start if absent.
+// The Dbxref and Note attributes are read as the /db_xref and /note
+// qualifiers, and the Name attribute is read as /standard_name unless the
+// feature has a /gene, /locus_tag, /label, or /standard_name qualifier. The
+// ID, Parent, and Is_circular attributes are omitted, and the remaining
+// attributes are read as qualifiers with the same names. Directives and
+// comments are ignored, and reading stops at the `##FASTA` directive.
+func ReadGFF3(r io.Reader) (map[string][]gts.Feature, error) {
+	records := []*gff3Record{}
+	byID := make(map[string]*gff3Record)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "##FASTA") {
+			break
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("GFF3 line %d: expected 9 tab-separated fields, got %d", n, len(fields))
+		}
+
+		start, err := strconv.Atoi(fields[3])
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("GFF3 line %d: bad start position %q", n, fields[3])
+		}
+
+		end, err := strconv.Atoi(fields[4])
+		if err != nil || end < start {
+			return nil, fmt.Errorf("GFF3 line %d: bad end position %q", n, fields[4])
+		}
+
+		switch fields[6] {
+		case "+", "-", ".", "?":
+		default:
+			return nil, fmt.Errorf("GFF3 line %d: bad strand %q", n, fields[6])
+		}
+
+		phase := 0
+		if fields[7] != "." {
+			phase, err = strconv.Atoi(fields[7])
+			if err != nil || phase < 0 || 2 < phase {
+				return nil, fmt.Errorf("GFF3 line %d: bad phase %q", n, fields[7])
+			}
+		}
+
+		seqid := gff3Unescape(fields[0])
+		segment := gts.Segment{start - 1, end}
+
+		id, props := "", gts.Props{}
+		hasName := false
+		names := []string{}
+		for _, field := range strings.Split(fields[8], ";") {
+			if strings.TrimSpace(field) == "" {
+				continue
+			}
+			i := strings.IndexByte(field, '=')
+			if i <= 0 {
+				return nil, fmt.Errorf("GFF3 line %d: expected `<tag>=<value>`, got %q", n, field)
+			}
+			tag := gff3Unescape(field[:i])
+			values := strings.Split(field[i+1:], ",")
+			for j := range values {
+				values[j] = gff3Unescape(values[j])
+			}
+
+			switch {
+			case tag == "ID":
+				id = values[0]
+			case tag == "Name":
+				names = values[:1]
+			case gff3IgnoredAttributes[tag]:
+			default:
+				if name, ok := gff3QualifierNames[tag]; ok {
+					tag = name
+				}
+				for _, name := range gff3NameQualifiers {
+					hasName = hasName || tag == name
+				}
+				for _, value := range values {
+					if value == "true" && IsToggleQualifier(tag) {
+						value = ""
+					}
+					props.Add(tag, value)
+				}
+			}
+		}
+		if len(names) > 0 && !hasName {
+			props.Add("standard_name", names[0])
+		}
+
+		if id != "" {
+			if record, ok := byID[seqid+"\t"+id]; ok {
+				record.segments = append(record.segments, segment)
+				record.phases = append(record.phases, phase)
+				continue
+			}
+		}
+
+		key := fields[2]
+		if key == "region" {
+			key = "source"
+		}
+
+		record := &gff3Record{
+			seqid:    seqid,
+			key:      key,
+			reverse:  fields[6] == "-",
+			segments: []gts.Segment{segment},
+			phases:   []int{phase},
+			props:    props,
+		}
+		records = append(records, record)
+		if id != "" {
+			byID[seqid+"\t"+id] = record
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	features := make(map[string][]gts.Feature)
+	for _, record := range records {
+		features[record.seqid] = append(features[record.seqid], record.feature())
+	}
+
+	return features, nil
+}
@@ -0,0 +1,133 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestGFF3Writer(t *testing.T) {
+	info := GenBankFields{
+		LocusName: "TEST",
+		Molecule:  gts.DNA,
+		Topology:  gts.Circular,
+		Accession: "TEST",
+		Version:   "TEST.1",
+	}
+	ff := []gts.Feature{
+		gts.NewFeature("source", gts.Range(0, 30), gts.Props{
+			[]string{"organism", "Escherichia coli"},
+		}),
+		gts.NewFeature("gene", gts.Range(0, 20).Complement(), gts.Props{
+			[]string{"locus_tag", "T_01"},
+		}),
+		gts.NewFeature("CDS", gts.Join(gts.Range(0, 4), gts.Range(10, 20)).Complement(), gts.Props{
+			[]string{"locus_tag", "T_01"},
+			[]string{"codon_start", "2"},
+			[]string{"note", "a;b=c,d%"},
+			[]string{"pseudo", ""},
+			[]string{"translation", "MK"},
+		}),
+	}
+	seq := gts.New(info, ff, []byte(strings.Repeat("a", 30)))
+
+	exp := strings.Join([]string{
+		"##gff-version 3",
+		"##sequence-region TEST.1 1 30",
+		"TEST.1\t.\tregion\t1\t30\t.\t+\t.\tID=TEST.1:source:1;Is_circular=true;organism=Escherichia coli",
+		"TEST.1\t.\tgene\t1\t20\t.\t-\t.\tID=TEST.1:gene:1;Name=T_01;locus_tag=T_01",
+		"TEST.1\t.\tCDS\t11\t20\t.\t-\t1\tID=TEST.1:CDS:1;Name=T_01;Parent=TEST.1:gene:1;locus_tag=T_01;codon_start=2;Note=a%3Bb%3Dc%2Cd%25;pseudo=true",
+		"TEST.1\t.\tCDS\t1\t4\t.\t-\t0\tID=TEST.1:CDS:1;Name=T_01;Parent=TEST.1:gene:1;locus_tag=T_01;codon_start=2;Note=a%3Bb%3Dc%2Cd%25;pseudo=true",
+		"##sequence-region TEST.1 1 30",
+		"TEST.1\t.\tregion\t1\t30\t.\t+\t.\tID=TEST.1:source:1;Is_circular=true;organism=Escherichia coli",
+		"TEST.1\t.\tgene\t1\t20\t.\t-\t.\tID=TEST.1:gene:1;Name=T_01;locus_tag=T_01",
+		"TEST.1\t.\tCDS\t11\t20\t.\t-\t1\tID=TEST.1:CDS:1;Name=T_01;Parent=TEST.1:gene:1;locus_tag=T_01;codon_start=2;Note=a%3Bb%3Dc%2Cd%25;pseudo=true",
+		"TEST.1\t.\tCDS\t1\t4\t.\t-\t0\tID=TEST.1:CDS:1;Name=T_01;Parent=TEST.1:gene:1;locus_tag=T_01;codon_start=2;Note=a%3Bb%3Dc%2Cd%25;pseudo=true",
+		"",
+	}, "\n")
+
+	b := strings.Builder{}
+	w := NewWriter(&b, GFF3File)
+	for i := 0; i < 2; i++ {
+		if _, err := w.WriteSeq(seq); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testutils.DiffLine(t, exp, b.String())
+}
+
+func TestGFF3Escape(t *testing.T) {
+	testutils.Equals(t, gff3EscapeSeqID("chr 1#a"), "chr%201%23a")
+	testutils.Equals(t, gff3EscapeAttribute("foo\nbar\t;"), "foo bar%09%3B")
+	testutils.Equals(t, gff3Phases([]gts.Segment{{0, 10}, {20, 25}, {30, 40}}, 1), []int{0, 2, 0})
+}
+
+func TestReadGFF3(t *testing.T) {
+	in := strings.Join([]string{
+		"##gff-version 3",
+		"##sequence-region seq%201 1 100",
+		"seq%201\t.\tregion\t1\t100\t.\t+\t.\tID=r1;Is_circular=true;organism=Escherichia coli",
+		"seq%201\t.\tgene\t1\t20\t.\t-\t.\tID=g1;Name=T_01;locus_tag=T_01",
+		"seq%201\t.\tCDS\t11\t20\t.\t-\t1\tID=c1;Parent=g1;locus_tag=T_01;Note=a%3Bb,c;pseudo=true",
+		"seq%201\t.\tCDS\t1\t4\t.\t-\t0\tID=c1;Parent=g1;locus_tag=T_01;Note=a%3Bb,c;pseudo=true",
+		"seq%201\t.\tCDS\t91\t100\t.\t+\t2\tID=c2;Name=foo",
+		"seq%201\t.\tCDS\t1\t5\t.\t+\t1\tID=c2;Name=foo",
+		"# comment",
+		"seq2\t.\tmisc_feature\t5\t5\t.\t.\t.\tDbxref=GeneID:1",
+		"##FASTA",
+		">seq2",
+		"acgt",
+	}, "\n")
+
+	out, err := ReadGFF3(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string][]gts.Feature{
+		"seq 1": {
+			gts.NewFeature("source", gts.Range(0, 100), gts.Props{
+				[]string{"organism", "Escherichia coli"},
+			}),
+			gts.NewFeature("gene", gts.Range(0, 20).Complement(), gts.Props{
+				[]string{"locus_tag", "T_01"},
+			}),
+			gts.NewFeature("CDS", gts.Join(gts.Range(0, 4), gts.Range(10, 20)).Complement(), gts.Props{
+				[]string{"locus_tag", "T_01"},
+				[]string{"note", "a;b", "c"},
+				[]string{"pseudo", ""},
+				[]string{"codon_start", "2"},
+			}),
+			gts.NewFeature("CDS", gts.Join(gts.Range(90, 100), gts.Range(0, 5)), gts.Props{
+				[]string{"standard_name", "foo"},
+				[]string{"codon_start", "3"},
+			}),
+		},
+		"seq2": {
+			gts.NewFeature("misc_feature", gts.Point(4), gts.Props{
+				[]string{"db_xref", "GeneID:1"},
+			}),
+		},
+	}
+
+	testutils.Equals(t, out, exp)
+}
+
+func TestReadGFF3Fail(t *testing.T) {
+	tests := []string{
+		"seq\t.\tgene\t1\t20\t.\t-\t.",
+		"seq\t.\tgene\t0\t20\t.\t-\t.\t",
+		"seq\t.\tgene\t20\t1\t.\t-\t.\t",
+		"seq\t.\tgene\t1\t20\t.\tx\t.\t",
+		"seq\t.\tCDS\t1\t20\t.\t+\t3\t",
+		"seq\t.\tgene\t1\t20\t.\t+\t.\tfoo",
+	}
+
+	for _, in := range tests {
+		if _, err := ReadGFF3(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadGFF3(%q) expected error", in)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package seqio
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// GTFWriter writes the gene and CDS features of a gts.Sequence to an
+// io.Writer in the GTF format.
+type GTFWriter struct {
+	w io.Writer
+}
+
+var gtfReplacer = strings.NewReplacer("\"", "'", "\n", " ", "\t", " ")
+
+// gtfAttributes formats the given pairs of attribute names and values,
+// omitting the attributes with empty values.
+func gtfAttributes(pairs ...string) string {
+	attrs := []string{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			attrs = append(attrs, fmt.Sprintf("%s \"%s\";", pairs[i], gtfReplacer.Replace(pairs[i+1])))
+		}
+	}
+	return strings.Join(attrs, " ")
+}
+
+// gtfFirst returns the first value of the qualifier, or an empty string.
+func gtfFirst(f gts.Feature, name string) string {
+	if values := f.Props.Get(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// gtfGeneID returns the gene ID of a feature, which is the locus tag or the
+// gene name, in the order of preference.
+func gtfGeneID(f gts.Feature) string {
+	for _, name := range []string{"locus_tag", "gene"} {
+		if value := gtfFirst(f, name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func formatGTF(seq gts.Sequence) string {
+	b := strings.Builder{}
+	id := ID(seq)
+	seqname := gtfReplacer.Replace(id)
+	protein := Molecule(seq) == gts.AA
+
+	counts := make(map[string]int)
+	transcripts := make(map[string]int)
+
+	for _, f := range seq.Features() {
+		if f.Key != "gene" && f.Key != "CDS" {
+			continue
+		}
+
+		counts[f.Key]++
+		geneID := gtfGeneID(f)
+		if geneID == "" {
+			geneID = fmt.Sprintf("%s:%s:%d", id, f.Key, counts[f.Key])
+		}
+		geneName := gtfFirst(f, "gene")
+
+		strand := "+"
+		switch {
+		case protein:
+			strand = "."
+		case gts.CheckStrand(f.Loc) == gts.StrandReverse:
+			strand = "-"
+		}
+
+		segments := gff3Segments(f.Loc.Region())
+		starts, ends := make([]int, len(segments)), make([]int, len(segments))
+		for i, s := range segments {
+			head, tail := gts.Unpack(s)
+			if tail < head {
+				head, tail = tail, head
+			}
+			starts[i], ends[i] = head+1, tail
+			if head == tail {
+				starts[i], ends[i] = gts.Max(head, 1), gts.Max(head, 1)
+			}
+		}
+		start, end := starts[0], ends[0]
+		for i := range segments {
+			start, end = gts.Min(start, starts[i]), gts.Max(end, ends[i])
+		}
+
+		line := func(feature string, start, end int, frame, attributes string) {
+			fmt.Fprintf(
+				&b, "%s\t.\t%s\t%d\t%d\t.\t%s\t%s\t%s\n",
+				seqname, feature, start, end, strand, frame, attributes,
+			)
+		}
+
+		if f.Key == "gene" {
+			line("gene", start, end, ".", gtfAttributes("gene_id", geneID, "gene_name", geneName))
+			continue
+		}
+
+		transcripts[geneID]++
+		transcriptID := gtfFirst(f, "transcript_id")
+		if transcriptID == "" {
+			transcriptID = fmt.Sprintf("%s.%d", geneID, transcripts[geneID])
+		}
+		proteinID := gtfFirst(f, "protein_id")
+
+		codonStart := 1
+		if n, err := strconv.Atoi(gtfFirst(f, "codon_start")); err == nil {
+			codonStart = n
+		}
+		frames := gff3Phases(segments, codonStart)
+
+		line("transcript", start, end, ".", gtfAttributes(
+			"gene_id", geneID, "transcript_id", transcriptID, "gene_name", geneName,
+		))
+		for i := range segments {
+			line("exon", starts[i], ends[i], ".", gtfAttributes(
+				"gene_id", geneID, "transcript_id", transcriptID,
+				"exon_number", strconv.Itoa(i+1), "gene_name", geneName,
+			))
+		}
+		for i := range segments {
+			line("CDS", starts[i], ends[i], strconv.Itoa(frames[i]), gtfAttributes(
+				"gene_id", geneID, "transcript_id", transcriptID,
+				"exon_number", strconv.Itoa(i+1), "gene_name", geneName,
+				"protein_id", proteinID,
+			))
+		}
+	}
+
+	return b.String()
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface. Each gene feature is
+// written as a gene line, and each CDS feature is written as a transcript
+// with an exon line and a CDS line for each segment of its location. The
+// gene_id attribute is taken from the locus tag or the gene name of the
+// feature, and the transcript_id attribute is taken from the /transcript_id
+// qualifier or numbered after the gene_id. The other features are omitted.
+func (w GTFWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	return io.WriteString(w.w, formatGTF(seq))
+}
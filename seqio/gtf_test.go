@@ -0,0 +1,55 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestGTFWriter(t *testing.T) {
+	info := GenBankFields{
+		LocusName: "TEST",
+		Molecule:  gts.DNA,
+		Topology:  gts.Linear,
+		Accession: "TEST",
+		Version:   "TEST.1",
+	}
+	ff := []gts.Feature{
+		gts.NewFeature("source", gts.Range(0, 30), gts.Props{
+			[]string{"organism", "Escherichia coli"},
+		}),
+		gts.NewFeature("gene", gts.Range(0, 20).Complement(), gts.Props{
+			[]string{"gene", "fooA"},
+			[]string{"locus_tag", "T_01"},
+		}),
+		gts.NewFeature("CDS", gts.Join(gts.Range(0, 4), gts.Range(10, 20)).Complement(), gts.Props{
+			[]string{"gene", "fooA"},
+			[]string{"locus_tag", "T_01"},
+			[]string{"codon_start", "2"},
+			[]string{"protein_id", "P_01.1"},
+		}),
+		gts.NewFeature("CDS", gts.Range(21, 30), gts.Props{}),
+	}
+	seq := gts.New(info, ff, []byte(strings.Repeat("a", 30)))
+
+	exp := strings.Join([]string{
+		"TEST.1\t.\tgene\t1\t20\t.\t-\t.\tgene_id \"T_01\"; gene_name \"fooA\";",
+		"TEST.1\t.\ttranscript\t1\t20\t.\t-\t.\tgene_id \"T_01\"; transcript_id \"T_01.1\"; gene_name \"fooA\";",
+		"TEST.1\t.\texon\t11\t20\t.\t-\t.\tgene_id \"T_01\"; transcript_id \"T_01.1\"; exon_number \"1\"; gene_name \"fooA\";",
+		"TEST.1\t.\texon\t1\t4\t.\t-\t.\tgene_id \"T_01\"; transcript_id \"T_01.1\"; exon_number \"2\"; gene_name \"fooA\";",
+		"TEST.1\t.\tCDS\t11\t20\t.\t-\t1\tgene_id \"T_01\"; transcript_id \"T_01.1\"; exon_number \"1\"; gene_name \"fooA\"; protein_id \"P_01.1\";",
+		"TEST.1\t.\tCDS\t1\t4\t.\t-\t0\tgene_id \"T_01\"; transcript_id \"T_01.1\"; exon_number \"2\"; gene_name \"fooA\"; protein_id \"P_01.1\";",
+		"TEST.1\t.\ttranscript\t22\t30\t.\t+\t.\tgene_id \"TEST.1:CDS:2\"; transcript_id \"TEST.1:CDS:2.1\";",
+		"TEST.1\t.\texon\t22\t30\t.\t+\t.\tgene_id \"TEST.1:CDS:2\"; transcript_id \"TEST.1:CDS:2.1\"; exon_number \"1\";",
+		"TEST.1\t.\tCDS\t22\t30\t.\t+\t0\tgene_id \"TEST.1:CDS:2\"; transcript_id \"TEST.1:CDS:2.1\"; exon_number \"1\";",
+		"",
+	}, "\n")
+
+	b := strings.Builder{}
+	if _, err := NewWriter(&b, GTFFile).WriteSeq(seq); err != nil {
+		t.Fatal(err)
+	}
+	testutils.DiffLine(t, exp, b.String())
+}
@@ -0,0 +1,65 @@
+package seqio
+
+import (
+	"time"
+)
+
+// HistoryCommentName is the name of the structured comment used to record
+// history entries.
+const HistoryCommentName = "GTS-History"
+
+// HistoryEntry represents a single operation applied to a record.
+type HistoryEntry struct {
+	Command   string    `json:"command"`
+	Version   string    `json:"version"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+}
+
+// Comment formats the HistoryEntry as a structured comment. The timestamp is
+// omitted if it is zero.
+func (e HistoryEntry) Comment() string {
+	fields := Dictionary{{"Command", e.Command}, {"Version", e.Version}}
+	if !e.Timestamp.IsZero() {
+		fields = append(fields, Pair{"Timestamp", e.Timestamp.UTC().Format(time.RFC3339)})
+	}
+	return StructuredComment{HistoryCommentName, fields}.String()
+}
+
+// AsHistoryEntry interprets the given structured comment as a HistoryEntry.
+// The second return value will be false if the comment is not a history
+// entry.
+func AsHistoryEntry(comment string) (HistoryEntry, bool) {
+	sc, ok := AsStructuredComment(comment)
+	if !ok || sc.Name != HistoryCommentName {
+		return HistoryEntry{}, false
+	}
+
+	e := HistoryEntry{}
+	for _, field := range sc.Fields {
+		switch field.Key {
+		case "Command":
+			e.Command = field.Value
+		case "Version":
+			e.Version = field.Value
+		case "Timestamp":
+			t, err := time.Parse(time.RFC3339, field.Value)
+			if err != nil {
+				return HistoryEntry{}, false
+			}
+			e.Timestamp = t
+		}
+	}
+
+	return e, true
+}
+
+// History returns the list of history entries recorded in the comments.
+func History(comments []string) []HistoryEntry {
+	entries := []HistoryEntry{}
+	for _, comment := range comments {
+		if e, ok := AsHistoryEntry(comment); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
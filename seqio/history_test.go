@@ -0,0 +1,46 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var historyEntryTests = []HistoryEntry{
+	{"gts select CDS", "0.27.0", time.Date(2021, time.May, 1, 12, 0, 0, 0, time.UTC)},
+	{"gts extract", "0.27.0", time.Time{}},
+}
+
+func TestHistoryEntry(t *testing.T) {
+	for _, in := range historyEntryTests {
+		out, ok := AsHistoryEntry(in.Comment())
+		if !ok {
+			t.Errorf("AsHistoryEntry(%q) failed", in.Comment())
+			continue
+		}
+		testutils.Equals(t, out, in)
+	}
+
+	for _, in := range []string{
+		"",
+		"Ribosomal protein",
+		strings.Join([]string{"##GTS-History-START##", "Command :: gts"}, "\n"),
+		strings.Join([]string{"##GTS-History-START##", "Command gts", "##GTS-History-END##"}, "\n"),
+		strings.Join([]string{"##GTS-History-START##", "Timestamp :: yesterday", "##GTS-History-END##"}, "\n"),
+	} {
+		if _, ok := AsHistoryEntry(in); ok {
+			t.Errorf("AsHistoryEntry(%q) expected to fail", in)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	comments := []string{
+		"This is not a history entry.",
+		historyEntryTests[0].Comment(),
+		historyEntryTests[1].Comment(),
+	}
+	testutils.Equals(t, History(comments), historyEntryTests)
+}
@@ -60,6 +60,55 @@ func (qf QualifierFormatter) WriteTo(w io.Writer) (int64, error) {
 	return int64(n), err
 }
 
+// insdcQualifierWidth is the number of columns available to a qualifier in
+// a feature table.
+const insdcQualifierWidth = 58
+
+// WrapQualifier rewraps the value of a qualifier so that each line of the
+// qualifier, including its name, fits within the width of a feature table.
+// The lines of the value are first joined with a space, or without one for
+// the `/translation` qualifier, and the value is then broken at the last
+// space within the width, or at the width if there is no such space.
+func WrapQualifier(name, value string) string {
+	if GetQualifierType(name) == ToggleQualifier {
+		return value
+	}
+
+	sep := " "
+	if name == "translation" {
+		sep = ""
+	}
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	value = strings.Join(lines, sep)
+
+	// The name and the quotes are kept in the first and last lines.
+	empty := QualifierIO{name, ""}.String()
+	head, tail := len(empty), 0
+	if GetQualifierType(name) != LiteralQualifier {
+		head, tail = head-1, 1
+	}
+
+	s := QualifierIO{name, value}.String()
+	b := strings.Builder{}
+	for lower := head; len(s) > insdcQualifierWidth; lower = 1 {
+		i := strings.LastIndexByte(s[:insdcQualifierWidth+1], ' ')
+		if i < lower {
+			b.WriteString(s[:insdcQualifierWidth] + "\n")
+			s = s[insdcQualifierWidth:]
+		} else {
+			b.WriteString(s[:i] + "\n")
+			s = s[i+1:]
+		}
+	}
+	b.WriteString(s)
+
+	s = b.String()
+	return s[head : len(s)-tail]
+}
+
 // Names of qualifiers.
 var (
 	QuotedQualifierNames = []string{
@@ -98,6 +147,12 @@ var (
 	}
 )
 
+// Conventional orders of qualifiers in INSDC flat files.
+var (
+	SourceQualifierOrder  = gts.SourceQualifierOrder
+	FeatureQualifierOrder = gts.FeatureQualifierOrder
+)
+
 func init() {
 	sort.Strings(QuotedQualifierNames)
 	sort.Strings(LiteralQualifierNames)
@@ -181,141 +236,16 @@ func GetQualifierType(name string) QualifierType {
 	}
 }
 
-func qualifierNameParser(prefix string) pars.Parser {
-	p := []byte(prefix + "/")
-	word := pars.Word(ascii.IsSnake)
-	return func(state *pars.State, result *pars.Result) error {
-		if err := state.Request(len(p)); err != nil {
-			return err
-		}
-		if !bytes.Equal(state.Buffer(), p) {
-			return pars.NewError(fmt.Sprintf("expected %q", prefix+"/"), state.Position())
-		}
-		state.Advance()
-		return word(state, result)
-	}
-}
-
-func quotedQualifierParser(prefix string) pars.Parser {
-	quoted := pars.Quoted('"')
-	p := append([]byte{'\n'}, []byte(prefix)...)
-	return func(state *pars.State, result *pars.Result) error {
-		state.Push()
-		c, err := pars.Next(state)
-		if err != nil {
-			state.Pop()
-			return err
-		}
-		if c != '=' {
-			state.Pop()
-			return pars.NewError("expected `=`", state.Position())
-		}
-		state.Advance()
-		if err := quoted(state, result); err != nil {
-			state.Pop()
-			return err
-		}
-		state.Drop()
-		pars.EOL(state, pars.Void)
-		token := result.Token
-		i := bytes.Index(token, p)
-		for i >= 0 {
-			n := copy(token[i+1:], token[i+len(p):])
-			token = token[:i+1+n]
-			i = bytes.Index(token, p)
-		}
-		result.SetToken(token)
-		return nil
-	}
-}
-
-func literalQualifierValueParser(prefix string) pars.Parser {
-	prefixParser := pars.String(prefix)
-	return func(state *pars.State, result *pars.Result) error {
-		pars.Line(state, result)
-		p := result.Token
-
-		state.Push()
-		for prefixParser(state, result) == nil {
-			c, err := pars.Next(state)
-			if err != nil {
-				result.SetToken(p)
-				state.Pop()
-				return err
-			}
-			if c == '/' {
-				result.SetToken(p)
-				state.Pop()
-				return nil
-			}
-			pars.Line(state, result)
-			p = append(p, '\n')
-			p = append(p, result.Token...)
-			state.Drop()
-			state.Push()
-		}
-		result.SetToken(p)
-		state.Drop()
-		return nil
-	}
-}
-
-func literalQualifierParser(prefix string) pars.Parser {
-	valueParser := literalQualifierValueParser(prefix)
-	return func(state *pars.State, result *pars.Result) error {
-		state.Push()
-		c, err := pars.Next(state)
-		if err != nil {
-			state.Pop()
-			return err
-		}
-		if c != '=' {
-			state.Pop()
-			return pars.NewError("expected `=`", state.Position())
-		}
-		state.Advance()
-
-		valueParser(state, result)
-
-		state.Drop()
-		return nil
-	}
-}
-
 // QualifierParser attempts to match a single qualifier name-value pair.
 func QualifierParser(prefix string) pars.Parser {
-	nameParser := qualifierNameParser(prefix)
-
-	quotedParser := quotedQualifierParser(prefix)
-	literalParser := literalQualifierParser(prefix)
-	toggleParser := pars.EOL
-
-	valueParsers := []pars.Parser{quotedParser, literalParser, toggleParser}
-
+	indent := []byte(prefix)
 	return func(state *pars.State, result *pars.Result) error {
-		if err := nameParser(state, result); err != nil {
-			return err
+		t := featureTableTokenizer{indent: indent}
+		name, ok := t.qualifier(state)
+		if !ok {
+			return pars.NewError(fmt.Sprintf("expected qualifier with prefix %q", prefix), state.Position())
 		}
-		name := string(result.Token)
-
-		switch qtype := GetQualifierType(name); qtype {
-		case UnknownQualifier:
-			switch {
-			case quotedParser(state, result) == nil:
-				RegisterQuotedQualifier(name)
-			case literalParser(state, result) == nil:
-				RegisterLiteralQualifier(name)
-			case toggleParser(state, result) == nil:
-				RegisterToggleQualifier(name)
-			}
-		default:
-			if err := valueParsers[qtype](state, result); err != nil {
-				return err
-			}
-		}
-
-		value := string(result.Token)
-		result.SetValue(QualifierIO{name, value})
+		result.SetValue(QualifierIO{name, string(t.buf)})
 		return nil
 	}
 }
@@ -371,41 +301,377 @@ type keyline struct {
 	loc gts.Location
 }
 
-func featureKeylineParser(prefix string, depth int) pars.Parser {
-	word := pars.Word(ascii.IsSnake).Error(errFeatureKey)
-	p := []byte(prefix)
-	return func(state *pars.State, result *pars.Result) error {
-		if err := state.Request(len(p)); err != nil {
-			return err
+// peekLine returns the next line in the state excluding the line terminator,
+// along with the number of bytes up to and including the terminator. The state
+// is not advanced.
+func peekLine(state *pars.State) ([]byte, int) {
+	n := 128
+	for {
+		err := state.Request(n)
+		p := state.Buffer()
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line := p[:i]
+			if len(line) > 0 && line[len(line)-1] == '\r' {
+				line = line[:len(line)-1]
+			}
+			return line, i + 1
 		}
-		if !bytes.Equal(state.Buffer(), p) {
-			return pars.NewError(fmt.Sprintf("expected %q", prefix), state.Position())
+		if err != nil {
+			return p, len(p)
 		}
-		state.Advance()
-		if err := word(state, result); err != nil {
-			return err
+		n *= 2
+	}
+}
+
+// isSnake is a lookup table for ascii.IsSnake.
+var isSnake = func() (table [256]bool) {
+	for i := range table {
+		table[i] = ascii.IsSnake(byte(i))
+	}
+	return table
+}()
+
+// skipBytes advances the state by n bytes.
+func skipBytes(state *pars.State, n int) {
+	state.Request(n)
+	state.Advance()
+}
+
+// parseDigits returns the number given by the leading digits of p and the
+// remainder of p.
+func parseDigits(p []byte) (int, []byte, bool) {
+	i, n := 0, 0
+	for ; i < len(p) && '0' <= p[i] && p[i] <= '9'; i++ {
+		if n > (int(^uint(0)>>1)-9)/10 {
+			return 0, p, false
 		}
-		key := string(result.Token)
-		for i := 0; i < depth-len(prefix+key); i++ {
-			c, err := pars.Next(state)
-			if err != nil {
-				return err
+		n = n*10 + int(p[i]-'0')
+	}
+	return n, p[i:], i > 0
+}
+
+// simpleLocation interprets the given bytes as a location if it is a plain
+// range or the complement of one, which make up most of the locations in a
+// feature table, without going through gts.ParseLocation.
+func simpleLocation(p []byte) (gts.Location, bool) {
+	complement := bytes.HasPrefix(p, []byte("complement(")) && bytes.HasSuffix(p, []byte(")"))
+	if complement {
+		p = p[len("complement(") : len(p)-1]
+	}
+	start, p, ok := parseDigits(p)
+	if !ok || !bytes.HasPrefix(p, []byte("..")) {
+		return nil, false
+	}
+	end, p, ok := parseDigits(p[2:])
+	if !ok || len(p) > 0 || start < 1 || end < start {
+		return nil, false
+	}
+	if complement {
+		return gts.Range(start-1, end).Complement(), true
+	}
+	return gts.Range(start-1, end), true
+}
+
+// featureTableTokenizer reads the keylines and qualifiers of an INSDC feature
+// table a line at a time, reusing a single buffer for the qualifier values.
+type featureTableTokenizer struct {
+	prefix []byte // The prefix of the keylines.
+	indent []byte // The prefix of the qualifier lines.
+	depth  int
+
+	// The qualifier values of a feature are read into a single buffer and
+	// converted to a single string shared by the values.
+	buf   []byte
+	spans []qualifierSpan
+
+	// The props of the features are carved out of shared slabs so that each
+	// qualifier does not need an allocation of its own.
+	slab [][]string
+	strs []string
+
+	// The qualifier names and types seen so far, so that the name strings
+	// are shared and the types are only searched for once per table.
+	names map[string]qualifierName
+	keys  map[string]string
+}
+
+type qualifierSpan struct {
+	name       string
+	start, end int
+}
+
+type qualifierName struct {
+	name  string
+	qtype QualifierType
+}
+
+func newFeatureTableTokenizer(prefix string, pre, depth int) *featureTableTokenizer {
+	return &featureTableTokenizer{
+		prefix: []byte(prefix + strings.Repeat(" ", pre)),
+		indent: []byte(prefix + strings.Repeat(" ", depth)),
+		depth:  len(prefix) + depth,
+		buf:    make([]byte, 0, 4096),
+		names:  make(map[string]qualifierName),
+		keys:   make(map[string]string),
+	}
+}
+
+func (t *featureTableTokenizer) keyline(state *pars.State, result *pars.Result) error {
+	kl, err := t.readKeyline(state, result)
+	if err != nil {
+		return err
+	}
+	result.SetValue(kl)
+	return nil
+}
+
+// key returns the feature key for the given bytes, sharing the strings of
+// the keys seen before.
+func (t *featureTableTokenizer) key(p []byte) string {
+	if key, ok := t.keys[string(p)]; ok {
+		return key
+	}
+	key := string(p)
+	if t.keys != nil {
+		t.keys[key] = key
+	}
+	return key
+}
+
+func (t *featureTableTokenizer) readKeyline(state *pars.State, result *pars.Result) (keyline, error) {
+	line, n := peekLine(state)
+	if !bytes.HasPrefix(line, t.prefix) {
+		return keyline{}, pars.NewError(fmt.Sprintf("expected %q", t.prefix), state.Position())
+	}
+	i := len(t.prefix)
+	j := i
+	for j < len(line) && isSnake[line[j]] {
+		j++
+	}
+	if i == j {
+		return keyline{}, errFeatureKey
+	}
+	key := t.key(line[i:j])
+	for ; j < t.depth; j++ {
+		if j >= len(line) || line[j] != ' ' {
+			return keyline{}, pars.NewError("wanted indent", state.Position())
+		}
+	}
+
+	if loc, ok := simpleLocation(line[j:]); ok {
+		skipBytes(state, n)
+		return keyline{0, key, 0, loc}, nil
+	}
+
+	state.Push()
+	skipBytes(state, j)
+	if err := gts.ParseLocation(state, result); err != nil {
+		state.Pop()
+		return keyline{}, err
+	}
+	loc := result.Value.(gts.Location)
+	if err := pars.EOL(state, result); err != nil {
+		state.Pop()
+		return keyline{}, err
+	}
+	state.Drop()
+	return keyline{0, key, 0, loc}, nil
+}
+
+// quoted reads a quoted qualifier value starting at the given remainder of
+// the current line of length n. Continuation lines are joined with a newline
+// after removing the qualifier indentation. Both backslash escapes and
+// doubled quotes are retained verbatim in the value. The value is appended to
+// the buffer of the tokenizer, which is left as is if the value is not
+// terminated.
+func (t *featureTableTokenizer) quoted(state *pars.State, line []byte, n int) bool {
+	buf := t.buf
+	state.Push()
+	for {
+		for len(line) > 0 {
+			i := bytes.IndexAny(line, "\\\"")
+			if i < 0 {
+				buf = append(buf, line...)
+				break
 			}
-			if c != ' ' {
-				return pars.NewError("wanted indent", state.Position())
+			buf = append(buf, line[:i]...)
+			c := line[i]
+			line = line[i+1:]
+			switch {
+			case len(line) > 0 && (c == '\\' || line[0] == '"'):
+				buf = append(buf, c, line[0])
+				line = line[1:]
+			case c == '"':
+				skipBytes(state, n)
+				state.Drop()
+				t.buf = buf
+				return true
+			default:
+				buf = append(buf, c)
 			}
-			state.Advance()
 		}
-		if err := gts.ParseLocation(state, result); err != nil {
-			return err
+		skipBytes(state, n)
+		line, n = peekLine(state)
+		if n == 0 {
+			state.Pop()
+			return false
 		}
-		loc := result.Value.(gts.Location)
-		if err := pars.EOL(state, result); err != nil {
-			return err
+		buf = append(buf, '\n')
+		line = bytes.TrimPrefix(line, t.indent)
+	}
+}
+
+// literal reads a literal qualifier value starting at the given remainder of
+// the current line of length n. The value continues onto the following lines
+// with the qualifier indentation which do not start a new qualifier. The
+// value is appended to the buffer of the tokenizer.
+func (t *featureTableTokenizer) literal(state *pars.State, line []byte, n int) {
+	buf := append(t.buf, line...)
+	skipBytes(state, n)
+	for {
+		line, n = peekLine(state)
+		if !bytes.HasPrefix(line, t.indent) || n <= len(t.indent) {
+			break
 		}
-		result.SetValue(keyline{0, key, 0, loc})
-		return nil
+		line = line[len(t.indent):]
+		if len(line) > 0 && line[0] == '/' {
+			break
+		}
+		buf = append(buf, '\n')
+		buf = append(buf, line...)
+		skipBytes(state, n)
+	}
+	t.buf = buf
+}
+
+// name returns the qualifier name and type for the given bytes.
+func (t *featureTableTokenizer) name(p []byte) (string, QualifierType) {
+	if q, ok := t.names[string(p)]; ok {
+		return q.name, q.qtype
 	}
+	name := string(p)
+	qtype := GetQualifierType(name)
+	if t.names != nil && qtype != UnknownQualifier {
+		t.names[name] = qualifierName{name, qtype}
+	}
+	return name, qtype
+}
+
+// qualifier reads a single qualifier and returns its name. The value of the
+// qualifier is appended to the buffer of the tokenizer.
+func (t *featureTableTokenizer) qualifier(state *pars.State) (string, bool) {
+	line, n := peekLine(state)
+	if !bytes.HasPrefix(line, t.indent) {
+		return "", false
+	}
+	line = line[len(t.indent):]
+	if len(line) < 2 || line[0] != '/' {
+		return "", false
+	}
+	i := 1
+	for i < len(line) && isSnake[line[i]] {
+		i++
+	}
+	if i == 1 {
+		return "", false
+	}
+	name, qtype := t.name(line[1:i])
+	line = line[i:]
+
+	if len(line) == 0 {
+		switch qtype {
+		case UnknownQualifier:
+			RegisterToggleQualifier(name)
+		case ToggleQualifier:
+		default:
+			return "", false
+		}
+		skipBytes(state, n)
+		return name, true
+	}
+
+	if line[0] != '=' || qtype == ToggleQualifier {
+		return "", false
+	}
+	line = line[1:]
+
+	if qtype != LiteralQualifier && len(line) > 0 && line[0] == '"' {
+		if t.quoted(state, line[1:], n) {
+			if qtype == UnknownQualifier {
+				RegisterQuotedQualifier(name)
+			}
+			return name, true
+		}
+	}
+	if qtype == QuotedQualifier {
+		return "", false
+	}
+
+	t.literal(state, line, n)
+	if qtype == UnknownQualifier {
+		RegisterLiteralQualifier(name)
+	}
+	return name, true
+}
+
+// props reads the qualifiers of a feature. The values share the string of a
+// single buffer and the name-value pairs are carved out of shared slabs so
+// that each qualifier does not need an allocation of its own.
+func (t *featureTableTokenizer) props(state *pars.State) gts.Props {
+	t.buf, t.spans = t.buf[:0], t.spans[:0]
+	for {
+		start := len(t.buf)
+		name, ok := t.qualifier(state)
+		if !ok {
+			break
+		}
+		t.spans = append(t.spans, qualifierSpan{name, start, len(t.buf)})
+	}
+	if len(t.spans) == 0 {
+		return gts.Props{}
+	}
+
+	s := string(t.buf)
+	props := t.carveProps(len(t.spans))
+	for i, span := range t.spans {
+		if j := props.Index(span.name); j >= 0 {
+			props[j] = append(props[j], s[span.start:span.end])
+			continue
+		}
+		n := 1
+		for _, other := range t.spans[i+1:] {
+			if other.name == span.name {
+				n++
+			}
+		}
+		prop := t.carveStrings(n + 1)
+		prop = append(prop, span.name, s[span.start:span.end])
+		props = append(props, prop)
+	}
+	return props
+}
+
+func (t *featureTableTokenizer) carveProps(n int) gts.Props {
+	if len(t.slab) < n {
+		t.slab = make([][]string, max(n, 256))
+	}
+	props := t.slab[:0:n]
+	t.slab = t.slab[n:]
+	return props
+}
+
+func (t *featureTableTokenizer) carveStrings(n int) []string {
+	if len(t.strs) < n {
+		t.strs = make([]string, max(n, 1024))
+	}
+	strs := t.strs[:0:n]
+	t.strs = t.strs[n:]
+	return strs
+}
+
+func featureKeylineParser(prefix string, depth int) pars.Parser {
+	t := newFeatureTableTokenizer(prefix, 0, depth-len(prefix))
+	return t.keyline
 }
 
 // INSDCTableParser attempts to match an INSDC feature table.
@@ -432,46 +698,15 @@ func INSDCTableParser(prefix string) pars.Parser {
 		pre, key, pst, loc := tmp.pre, tmp.key, tmp.pst, tmp.loc
 		depth := pre + len(key) + pst
 
-		keylineParser := featureKeylineParser(prefix+strings.Repeat(" ", pre), depth)
-
-		qualifierParser := QualifierParser(prefix + strings.Repeat(" ", depth))
-		qualifiersParser := pars.Many(qualifierParser)
+		t := newFeatureTableTokenizer(prefix, pre, depth)
+		ff := []gts.Feature{gts.NewFeature(key, loc, t.props(state))}
 
-		// Does not return error by definition.
-		qualifiersParser(state, result)
-
-		props := gts.Props{}
-		order := make(map[string]int)
-
-		for _, child := range result.Children {
-			name, value := child.Value.(QualifierIO).Unpack()
-			props.Add(name, value)
-			if _, ok := order[name]; name != "translation" && !ok {
-				order[name] = len(order)
-			}
-		}
-
-		ff := []gts.Feature{gts.NewFeature(key, loc, props)}
-
-		for keylineParser(state, result) == nil {
-			tmp := result.Value.(keyline)
-			key, loc := tmp.key, tmp.loc
-
-			// Does not return error by definition.
-			qualifiersParser(state, result)
-
-			props := gts.Props{}
-			order := make(map[string]int)
-
-			for _, child := range result.Children {
-				name, value := child.Value.(QualifierIO).Unpack()
-				props.Add(name, value)
-				if _, ok := order[name]; name != "translation" && !ok {
-					order[name] = len(order)
-				}
+		for {
+			tmp, err := t.readKeyline(state, result)
+			if err != nil {
+				break
 			}
-
-			ff = append(ff, gts.NewFeature(key, loc, props))
+			ff = append(ff, gts.NewFeature(tmp.key, tmp.loc, t.props(state)))
 		}
 
 		result.SetValue(ff)
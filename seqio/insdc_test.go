@@ -1,6 +1,9 @@
 package seqio
 
 import (
+	"fmt"
+	"math/rand"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -221,6 +224,7 @@ var qualifierTests = []string{
 	"                     /site_type=\"other\"",
 	"                     /coded_by=\"NM_000207.3:60..392\"",
 	"                     /mutated",
+	"                     /note=\"contains \"\"doubled\"\" quotes\"",
 }
 
 func TestQualifierIO(t *testing.T) {
@@ -350,6 +354,43 @@ var featureIOTests = []string{
                      /db_xref="HGNC:HGNC:34694"`,
 }
 
+func TestWrapQualifier(t *testing.T) {
+	tests := []struct {
+		name, in, out string
+	}{
+		{"note", "short note", "short note"},
+		{
+			"note",
+			"a very long note that is broken across lines\nfor testing purposes only",
+			"a very long note that is broken across lines for\ntesting purposes only",
+		},
+		{
+			"inference",
+			"COORDINATES:alignment:blastn:2.2.31+:GenBank:NC_000913.3:NC_000913.3",
+			"COORDINATES:alignment:blastn:2.2.31+:GenBank:N\nC_000913.3:NC_000913.3",
+		},
+		{
+			"translation",
+			"MKVLAAGIVGLLLAAGCSSSKEETPAQ\nKAPEQAETAPAATPAPAASAPAAEEAPKAETSAPAQSG",
+			"MKVLAAGIVGLLLAAGCSSSKEETPAQKAPEQAETAPAATPAPA\nASAPAAEEAPKAETSAPAQSG",
+		},
+		{"codon_start", "1", "1"},
+		{"pseudo", "", ""},
+	}
+
+	for _, tt := range tests {
+		out := WrapQualifier(tt.name, tt.in)
+		if out != tt.out {
+			t.Errorf("WrapQualifier(%q, %q) = %q, want %q", tt.name, tt.in, out, tt.out)
+		}
+		for _, line := range strings.Split(QualifierIO{tt.name, out}.String(), "\n") {
+			if len(line) > 58 {
+				t.Errorf("WrapQualifier(%q, %q): line %q exceeds 58 columns", tt.name, tt.in, line)
+			}
+		}
+	}
+}
+
 func TestFeatureKeylineParser(t *testing.T) {
 	parser := pars.Exact(featureKeylineParser("     ", 21))
 	for _, in := range []string{
@@ -397,3 +438,138 @@ func TestFeatureIO(t *testing.T) {
 		t.Error("while parsing empty string: expected error")
 	}
 }
+
+// generateFeatureTable generates a feature table resembling that of a RefSeq
+// bacterial genome with n genes, each annotated with a gene and a CDS feature
+// carrying the qualifiers found in a typical RefSeq record.
+func generateFeatureTable(n int) string {
+	const aminoAcids = "ACDEFGHIKLMNPQRSTVWY"
+	const indent = "                     "
+
+	r := rand.New(rand.NewSource(1))
+	b := strings.Builder{}
+
+	qualifier := func(name, value string) {
+		value = WrapQualifier(name, value)
+		b.WriteString(QualifierIO{name, value}.Format(indent).String())
+		b.WriteByte('\n')
+	}
+
+	start := 1
+	for i := 0; i < n; i++ {
+		aa := 100 + r.Intn(500)
+		end := start + aa*3 + 2
+		loc := fmt.Sprintf("%d..%d", start, end)
+		if r.Intn(2) == 0 {
+			loc = fmt.Sprintf("complement(%s)", loc)
+		}
+		gene := fmt.Sprintf("gen%c", 'A'+r.Intn(26))
+		tag := fmt.Sprintf("b%04d", i+1)
+		synonyms := fmt.Sprintf("ECK%04d; %s1; %s2", i+1, gene, gene)
+
+		b.WriteString(fmt.Sprintf("     gene            %s\n", loc))
+		qualifier("gene", gene)
+		qualifier("locus_tag", tag)
+		qualifier("gene_synonym", synonyms)
+		qualifier("db_xref", fmt.Sprintf("EcoGene:EG%05d", r.Intn(100000)))
+		qualifier("db_xref", fmt.Sprintf("GeneID:%d", 944000+i))
+
+		p := make([]byte, aa)
+		p[0] = 'M'
+		for j := 1; j < aa; j++ {
+			p[j] = aminoAcids[r.Intn(len(aminoAcids))]
+		}
+
+		b.WriteString(fmt.Sprintf("     CDS             %s\n", loc))
+		qualifier("gene", gene)
+		qualifier("locus_tag", tag)
+		qualifier("gene_synonym", synonyms)
+		qualifier("EC_number", fmt.Sprintf("%d.%d.%d.%d", 1+r.Intn(6), 1+r.Intn(20), 1+r.Intn(20), 1+r.Intn(200)))
+		qualifier("codon_start", "1")
+		qualifier("transl_table", "11")
+		qualifier("product", "fused aspartate kinase/homoserine dehydrogenase 1 family protein")
+		qualifier("protein_id", fmt.Sprintf("NP_%06d.1", 414000+i))
+		qualifier("db_xref", fmt.Sprintf("UniProtKB/Swiss-Prot:P%05d", r.Intn(100000)))
+		qualifier("db_xref", fmt.Sprintf("ASAP:ABE-%07d", r.Intn(10000000)))
+		qualifier("translation", string(p))
+
+		start = end + 1 + r.Intn(200)
+	}
+
+	genes := b.String()
+	b.Reset()
+	b.WriteString(fmt.Sprintf("     source          1..%d\n", start))
+	qualifier("organism", "Escherichia coli str. K-12 substr. MG1655")
+	qualifier("mol_type", "genomic DNA")
+	qualifier("strain", "K-12")
+	qualifier("sub_strain", "MG1655")
+	qualifier("db_xref", "taxon:511145")
+	b.WriteString(genes)
+
+	return b.String()
+}
+
+func TestSimpleLocation(t *testing.T) {
+	for _, in := range []string{"1..10", "5..5", "complement(337..2799)"} {
+		exp, err := gts.AsLocation(in)
+		if err != nil {
+			t.Fatalf("gts.AsLocation(%q): %v", in, err)
+		}
+		out, ok := simpleLocation([]byte(in))
+		if !ok || !reflect.DeepEqual(out, exp) {
+			t.Errorf("simpleLocation(%q) = %v, %t, want %v, true", in, out, ok, exp)
+		}
+	}
+
+	// Anything other than a plain range is left to gts.ParseLocation.
+	for _, in := range []string{
+		"<1..10", "1..>10", "10..1", "0..10", "1..10 ", "1",
+		"complement(1..10", "join(1..10,20..30)", "J00194.1:100..202",
+	} {
+		if out, ok := simpleLocation([]byte(in)); ok {
+			t.Errorf("simpleLocation(%q) = %v, true, want false", in, out)
+		}
+	}
+}
+
+func TestGenerateFeatureTable(t *testing.T) {
+	in := generateFeatureTable(10)
+	parser := INSDCTableParser("")
+	result := pars.Result{}
+	if err := parser(pars.FromString(in), &result); err != nil {
+		t.Fatalf("while parsing generated feature table: %v", err)
+	}
+	ff := result.Value.([]gts.Feature)
+	if len(ff) != 21 {
+		t.Errorf("len(ff) = %d, want %d", len(ff), 21)
+	}
+	for _, f := range ff[1:] {
+		if f.Key == "CDS" && len(f.Props.Get("translation")) != 1 {
+			t.Errorf("f.Props.Get(%q) = %v, want a single value", "translation", f.Props.Get("translation"))
+		}
+	}
+}
+
+// BenchmarkINSDCTableParser measures the throughput of the feature table
+// parser on a table of the size of an Escherichia coli genome.
+func BenchmarkINSDCTableParser(b *testing.B) {
+	in := generateFeatureTable(4300)
+	parser := INSDCTableParser("")
+	b.SetBytes(int64(len(in)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := parser(pars.FromString(in), pars.Void); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkQualifierParser(b *testing.B) {
+	in := strings.Join(qualifierTests, "\n") + "\n"
+	parser := pars.Many(QualifierParser("                     "))
+	b.SetBytes(int64(len(in)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parser(pars.FromString(in), pars.Void)
+	}
+}
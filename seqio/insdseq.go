@@ -0,0 +1,273 @@
+package seqio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/gts"
+	"github.com/go-pars/pars"
+)
+
+type insdXrefXML struct {
+	DBName string `xml:"INSDXref_dbname"`
+	ID     string `xml:"INSDXref_id"`
+}
+
+type insdQualifierXML struct {
+	Name  string  `xml:"INSDQualifier_name"`
+	Value *string `xml:"INSDQualifier_value"`
+}
+
+type insdFeatureXML struct {
+	Key        string             `xml:"INSDFeature_key"`
+	Location   string             `xml:"INSDFeature_location"`
+	Qualifiers []insdQualifierXML `xml:"INSDFeature_quals>INSDQualifier"`
+}
+
+type insdReferenceXML struct {
+	Reference  string        `xml:"INSDReference_reference"`
+	Position   string        `xml:"INSDReference_position"`
+	Authors    []string      `xml:"INSDReference_authors>INSDAuthor"`
+	Consortium string        `xml:"INSDReference_consortium"`
+	Title      string        `xml:"INSDReference_title"`
+	Journal    string        `xml:"INSDReference_journal"`
+	Xrefs      []insdXrefXML `xml:"INSDReference_xref>INSDXref"`
+	Pubmed     string        `xml:"INSDReference_pubmed"`
+	Remark     string        `xml:"INSDReference_remark"`
+}
+
+type insdSeqXML struct {
+	Locus               string             `xml:"INSDSeq_locus"`
+	Length              int                `xml:"INSDSeq_length"`
+	Strandedness        string             `xml:"INSDSeq_strandedness"`
+	Moltype             string             `xml:"INSDSeq_moltype"`
+	Topology            string             `xml:"INSDSeq_topology"`
+	Division            string             `xml:"INSDSeq_division"`
+	UpdateDate          string             `xml:"INSDSeq_update-date"`
+	CreateDate          string             `xml:"INSDSeq_create-date"`
+	Definition          string             `xml:"INSDSeq_definition"`
+	PrimaryAccession    string             `xml:"INSDSeq_primary-accession"`
+	AccessionVersion    string             `xml:"INSDSeq_accession-version"`
+	SecondaryAccessions []string           `xml:"INSDSeq_secondary-accessions>INSDSecondary-no"`
+	Keywords            []string           `xml:"INSDSeq_keywords>INSDKeyword"`
+	Source              string             `xml:"INSDSeq_source"`
+	Organism            string             `xml:"INSDSeq_organism"`
+	Taxonomy            string             `xml:"INSDSeq_taxonomy"`
+	References          []insdReferenceXML `xml:"INSDSeq_references>INSDReference"`
+	Comment             string             `xml:"INSDSeq_comment"`
+	Features            []insdFeatureXML   `xml:"INSDSeq_feature-table>INSDFeature"`
+	Sequence            string             `xml:"INSDSeq_sequence"`
+	Contig              string             `xml:"INSDSeq_contig"`
+	Xrefs               []insdXrefXML      `xml:"INSDSeq_xrefs>INSDXref"`
+}
+
+// insdMolecule returns the molecule type of an INSDSeq record.
+func insdMolecule(moltype, strandedness string) gts.Molecule {
+	switch {
+	case moltype == "AA":
+		return gts.AA
+	case strings.Contains(moltype, "RNA"):
+		return gts.RNA
+	case strandedness == "single":
+		return gts.SingleStrandDNA
+	case strandedness == "double":
+		return gts.DoubleStrandDNA
+	default:
+		return gts.DNA
+	}
+}
+
+// insdReferenceInfo formats the position of a reference in the same form as
+// the REFERENCE field of a GenBank record (e.g. `(bases 1 to 100)`).
+func insdReferenceInfo(position string, molecule gts.Molecule) string {
+	position = strings.TrimSpace(position)
+	if position == "" {
+		return "(sites)"
+	}
+	ranges := []string{}
+	for _, s := range strings.FieldsFunc(position, func(r rune) bool { return r == ';' || r == ',' }) {
+		s = strings.TrimSpace(s)
+		i := strings.Index(s, "..")
+		if i < 0 {
+			return "(sites)"
+		}
+		ranges = append(ranges, fmt.Sprintf("%s to %s", s[:i], s[i+2:]))
+	}
+	return fmt.Sprintf("(%s %s)", molecule.Counter(), strings.Join(ranges, "; "))
+}
+
+// insdAuthors joins the authors of a reference in the same form as the
+// AUTHORS field of a GenBank record.
+func insdAuthors(authors []string) string {
+	switch len(authors) {
+	case 0:
+		return ""
+	case 1:
+		return authors[0]
+	default:
+		return strings.Join(authors[:len(authors)-1], ", ") + " and " + authors[len(authors)-1]
+	}
+}
+
+var insdContigPattern = regexp.MustCompile(`^join\(([^:]+):(\d+)\.\.(\d+)\)$`)
+
+func (x insdSeqXML) genbank() (GenBank, error) {
+	molecule := insdMolecule(x.Moltype, x.Strandedness)
+
+	topology := gts.Linear
+	if x.Topology == "circular" {
+		topology = gts.Circular
+	}
+
+	date := Date{}
+	for _, s := range []string{x.UpdateDate, x.CreateDate} {
+		if s != "" {
+			d, err := AsDate(s)
+			if err != nil {
+				return GenBank{}, err
+			}
+			date = d
+			break
+		}
+	}
+
+	accession := strings.Join(append([]string{x.PrimaryAccession}, x.SecondaryAccessions...), " ")
+
+	fields := GenBankFields{
+		LocusName:  x.Locus,
+		Molecule:   molecule,
+		Topology:   topology,
+		Division:   x.Division,
+		Date:       date,
+		Definition: strings.TrimSuffix(x.Definition, "."),
+		Accession:  accession,
+		Version:    x.AccessionVersion,
+		Keywords:   x.Keywords,
+		Source: Organism{
+			Species: x.Source,
+			Name:    x.Organism,
+			Taxon:   FlatFileSplit(x.Taxonomy),
+		},
+	}
+
+	dblinks := map[string][]string{}
+	dbnames := []string{}
+	for _, xref := range x.Xrefs {
+		if _, ok := dblinks[xref.DBName]; !ok {
+			dbnames = append(dbnames, xref.DBName)
+		}
+		dblinks[xref.DBName] = append(dblinks[xref.DBName], xref.ID)
+	}
+	for _, db := range dbnames {
+		fields.DBLink.Set(db, strings.Join(dblinks[db], ", "))
+	}
+
+	for i, r := range x.References {
+		number, err := strconv.Atoi(r.Reference)
+		if err != nil {
+			number = i + 1
+		}
+		ref := Reference{
+			Number:  number,
+			Info:    insdReferenceInfo(r.Position, molecule),
+			Authors: insdAuthors(r.Authors),
+			Group:   r.Consortium,
+			Title:   r.Title,
+			Journal: r.Journal,
+			Comment: r.Remark,
+		}
+		if r.Pubmed != "" || len(r.Xrefs) > 0 {
+			ref.Xref = make(map[string]string)
+			for _, xref := range r.Xrefs {
+				ref.Xref[strings.ToUpper(xref.DBName)] = xref.ID
+			}
+			if r.Pubmed != "" {
+				ref.Xref["PUBMED"] = r.Pubmed
+			}
+		}
+		fields.References = append(fields.References, ref)
+	}
+
+	if x.Comment != "" {
+		fields.Comments = []string{strings.ReplaceAll(x.Comment, "~", "\n")}
+	}
+
+	if m := insdContigPattern.FindStringSubmatch(x.Contig); m != nil {
+		head, _ := strconv.Atoi(m[2])
+		tail, _ := strconv.Atoi(m[3])
+		fields.Contig = Contig{m[1], gts.Segment{head - 1, tail}}
+	}
+
+	ff := gts.FeatureSlice{}
+	for _, f := range x.Features {
+		loc, err := gts.AsLocation(f.Location)
+		if err != nil {
+			return GenBank{}, fmt.Errorf("in feature %s: %v", f.Key, err)
+		}
+		props := gts.Props{}
+		for _, q := range f.Qualifiers {
+			value := ""
+			if q.Value != nil {
+				value = *q.Value
+			}
+			props.Add(q.Name, value)
+		}
+		ff = append(ff, gts.NewFeature(f.Key, loc, props))
+	}
+
+	seq := strings.Join(strings.Fields(x.Sequence), "")
+	if seq != "" && x.Length != 0 && len(seq) != x.Length {
+		return GenBank{}, fmt.Errorf("expected sequence of length %d, got %d", x.Length, len(seq))
+	}
+
+	return GenBank{fields, ff, NewOrigin([]byte(strings.ToLower(seq)))}, nil
+}
+
+var insdseqHeaderParser = pars.Seq(
+	pars.Spaces,
+	pars.Maybe(pars.Seq("<?xml", pars.Until("?>"), "?>", pars.Spaces)),
+	pars.Maybe(pars.Seq("<!DOCTYPE", pars.Until('>'), '>', pars.Spaces)),
+	pars.Maybe(pars.Seq("<INSDSet", pars.Until('>'), '>', pars.Spaces)),
+)
+
+var insdseqTrailerParser = pars.Seq(
+	pars.Spaces,
+	pars.Maybe(pars.Seq("</INSDSet>", pars.Spaces)),
+)
+
+// INSDSeqParser attempts to parse a single INSDSeq XML entry, as returned by
+// the NCBI E-utilities with `rettype=gb&retmode=xml`. The entry is read into
+// the same fields as a GenBank record.
+func INSDSeqParser(state *pars.State, result *pars.Result) error {
+	if err := insdseqHeaderParser(state, pars.Void); err != nil {
+		return err
+	}
+	if err := pars.String("<INSDSeq>")(state, pars.Void); err != nil {
+		return err
+	}
+	pos := state.Position()
+	if err := pars.Until("</INSDSeq>")(state, result); err != nil {
+		return err
+	}
+	body := string(result.Token)
+	if err := pars.String("</INSDSeq>")(state, pars.Void); err != nil {
+		return err
+	}
+	state.Clear()
+	insdseqTrailerParser(state, pars.Void)
+
+	x := insdSeqXML{}
+	if err := xml.Unmarshal([]byte("<INSDSeq>"+body+"</INSDSeq>"), &x); err != nil {
+		return pars.NewError(err.Error(), pos)
+	}
+	gb, err := x.genbank()
+	if err != nil {
+		return pars.NewError(err.Error(), pos)
+	}
+
+	result.SetValue(gb)
+	return nil
+}
@@ -0,0 +1,172 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+const insdseqTestXML = `<?xml version="1.0" encoding="UTF-8"  ?>
+<!DOCTYPE INSDSet PUBLIC "-//NCBI//INSD INSDSeq/EN" "https://www.ncbi.nlm.nih.gov/dtd/INSD_INSDSeq.dtd">
+<INSDSet>
+  <INSDSeq>
+    <INSDSeq_locus>TEST</INSDSeq_locus>
+    <INSDSeq_length>20</INSDSeq_length>
+    <INSDSeq_strandedness>single</INSDSeq_strandedness>
+    <INSDSeq_moltype>DNA</INSDSeq_moltype>
+    <INSDSeq_topology>circular</INSDSeq_topology>
+    <INSDSeq_division>PHG</INSDSeq_division>
+    <INSDSeq_update-date>06-JUL-2018</INSDSeq_update-date>
+    <INSDSeq_create-date>01-JAN-1990</INSDSeq_create-date>
+    <INSDSeq_definition>Test sequence, complete genome</INSDSeq_definition>
+    <INSDSeq_primary-accession>TEST</INSDSeq_primary-accession>
+    <INSDSeq_accession-version>TEST.1</INSDSeq_accession-version>
+    <INSDSeq_keywords>
+      <INSDKeyword>RefSeq</INSDKeyword>
+    </INSDSeq_keywords>
+    <INSDSeq_source>Escherichia virus phiX174</INSDSeq_source>
+    <INSDSeq_organism>Escherichia virus phiX174</INSDSeq_organism>
+    <INSDSeq_taxonomy>Viruses; Microviridae</INSDSeq_taxonomy>
+    <INSDSeq_references>
+      <INSDReference>
+        <INSDReference_reference>1</INSDReference_reference>
+        <INSDReference_position>1..20</INSDReference_position>
+        <INSDReference_authors>
+          <INSDAuthor>Sanger,F.</INSDAuthor>
+          <INSDAuthor>Coulson,A.R.</INSDAuthor>
+          <INSDAuthor>Smith,M.</INSDAuthor>
+        </INSDReference_authors>
+        <INSDReference_title>The nucleotide sequence of bacteriophage phiX174</INSDReference_title>
+        <INSDReference_journal>J. Mol. Biol. 125 (2), 225-246 (1978)</INSDReference_journal>
+        <INSDReference_pubmed>731693</INSDReference_pubmed>
+      </INSDReference>
+      <INSDReference>
+        <INSDReference_reference>2</INSDReference_reference>
+        <INSDReference_consortium>NCBI Genome Project</INSDReference_consortium>
+        <INSDReference_title>Direct Submission</INSDReference_title>
+        <INSDReference_journal>Submitted (06-JUL-2018)</INSDReference_journal>
+      </INSDReference>
+    </INSDSeq_references>
+    <INSDSeq_comment>PROVISIONAL REFSEQ.~The reference sequence is identical.</INSDSeq_comment>
+    <INSDSeq_feature-table>
+      <INSDFeature>
+        <INSDFeature_key>source</INSDFeature_key>
+        <INSDFeature_location>1..20</INSDFeature_location>
+        <INSDFeature_quals>
+          <INSDQualifier>
+            <INSDQualifier_name>organism</INSDQualifier_name>
+            <INSDQualifier_value>Escherichia virus phiX174</INSDQualifier_value>
+          </INSDQualifier>
+        </INSDFeature_quals>
+      </INSDFeature>
+      <INSDFeature>
+        <INSDFeature_key>CDS</INSDFeature_key>
+        <INSDFeature_location>join(18..20,1..9)</INSDFeature_location>
+        <INSDFeature_quals>
+          <INSDQualifier>
+            <INSDQualifier_name>locus_tag</INSDQualifier_name>
+            <INSDQualifier_value>T_01</INSDQualifier_value>
+          </INSDQualifier>
+          <INSDQualifier>
+            <INSDQualifier_name>pseudo</INSDQualifier_name>
+          </INSDQualifier>
+        </INSDFeature_quals>
+      </INSDFeature>
+    </INSDSeq_feature-table>
+    <INSDSeq_sequence>atgaaacccgggttttaaat</INSDSeq_sequence>
+    <INSDSeq_xrefs>
+      <INSDXref>
+        <INSDXref_dbname>BioProject</INSDXref_dbname>
+        <INSDXref_id>PRJNA14015</INSDXref_id>
+      </INSDXref>
+    </INSDSeq_xrefs>
+  </INSDSeq>
+  <INSDSeq>
+    <INSDSeq_locus>TEST2</INSDSeq_locus>
+    <INSDSeq_length>4</INSDSeq_length>
+    <INSDSeq_moltype>AA</INSDSeq_moltype>
+    <INSDSeq_topology>linear</INSDSeq_topology>
+    <INSDSeq_division>PHG</INSDSeq_division>
+    <INSDSeq_update-date>06-JUL-2018</INSDSeq_update-date>
+    <INSDSeq_definition>Test protein</INSDSeq_definition>
+    <INSDSeq_primary-accession>TEST2</INSDSeq_primary-accession>
+    <INSDSeq_accession-version>TEST2.1</INSDSeq_accession-version>
+    <INSDSeq_sequence>MKRS</INSDSeq_sequence>
+  </INSDSeq>
+</INSDSet>
+`
+
+func TestINSDSeqParser(t *testing.T) {
+	scanner := NewAutoScanner(strings.NewReader(insdseqTestXML))
+	seqs := []gts.Sequence{}
+	for scanner.Scan() {
+		seqs = append(seqs, scanner.Value())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seqs) != 2 {
+		t.Fatalf("expected 2 sequences, got %d", len(seqs))
+	}
+
+	info := seqs[0].Info().(GenBankFields)
+	testutils.Equals(t, info.LocusName, "TEST")
+	testutils.Equals(t, info.Molecule, gts.SingleStrandDNA)
+	testutils.Equals(t, info.Topology, gts.Circular)
+	testutils.Equals(t, info.Date, Date{2018, 7, 6})
+	testutils.Equals(t, info.Definition, "Test sequence, complete genome")
+	testutils.Equals(t, info.Version, "TEST.1")
+	testutils.Equals(t, info.DBLink.Get("BioProject"), []string{"PRJNA14015"})
+	testutils.Equals(t, info.Keywords, []string{"RefSeq"})
+	testutils.Equals(t, info.Source, Organism{"Escherichia virus phiX174", "Escherichia virus phiX174", []string{"Viruses", "Microviridae"}})
+	testutils.Equals(t, info.References, []Reference{
+		{
+			Number:  1,
+			Info:    "(bases 1 to 20)",
+			Authors: "Sanger,F., Coulson,A.R. and Smith,M.",
+			Title:   "The nucleotide sequence of bacteriophage phiX174",
+			Journal: "J. Mol. Biol. 125 (2), 225-246 (1978)",
+			Xref:    map[string]string{"PUBMED": "731693"},
+		},
+		{
+			Number:  2,
+			Info:    "(sites)",
+			Group:   "NCBI Genome Project",
+			Title:   "Direct Submission",
+			Journal: "Submitted (06-JUL-2018)",
+		},
+	})
+	testutils.Equals(t, info.Comments, []string{"PROVISIONAL REFSEQ.\nThe reference sequence is identical."})
+
+	testutils.Equals(t, seqs[0].Features(), gts.FeatureSlice{
+		gts.NewFeature("source", gts.Range(0, 20), gts.Props{
+			[]string{"organism", "Escherichia virus phiX174"},
+		}),
+		gts.NewFeature("CDS", gts.Join(gts.Range(17, 20), gts.Range(0, 9)), gts.Props{
+			[]string{"locus_tag", "T_01"},
+			[]string{"pseudo", ""},
+		}),
+	})
+	testutils.Equals(t, string(seqs[0].Bytes()), "atgaaacccgggttttaaat")
+
+	info = seqs[1].Info().(GenBankFields)
+	testutils.Equals(t, info.Molecule, gts.AA)
+	testutils.Equals(t, string(seqs[1].Bytes()), "mkrs")
+}
+
+func TestINSDSeqParserFail(t *testing.T) {
+	tests := []string{
+		"<INSDSeq><INSDSeq_length>5</INSDSeq_length><INSDSeq_sequence>acgt</INSDSeq_sequence></INSDSeq>",
+		"<INSDSeq><INSDSeq_update-date>foo</INSDSeq_update-date></INSDSeq>",
+		"<INSDSeq><INSDSeq_feature-table><INSDFeature><INSDFeature_key>CDS</INSDFeature_key><INSDFeature_location>foo</INSDFeature_location></INSDFeature></INSDSeq_feature-table></INSDSeq>",
+		"<INSDSeq><INSDSeq_locus>TEST</INSDSeq_locus>",
+	}
+	for _, in := range tests {
+		scanner := NewAutoScanner(strings.NewReader(in))
+		if scanner.Scan() {
+			t.Errorf("expected error for %q", in)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package seqio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-gts/gts"
+)
+
+// Limits represents the limits imposed on the sequence records read by a
+// Scanner, so that malformed or unexpectedly large inputs are reported as
+// errors instead of exhausting the memory. A limit of zero means no limit.
+type Limits struct {
+	// RecordSize is the maximum size of a record in bytes.
+	RecordSize int
+
+	// FeatureCount is the maximum number of features in a record.
+	FeatureCount int
+
+	// QualifierLength is the maximum length of a qualifier value in bytes.
+	QualifierLength int
+}
+
+// Check the given sequence against the feature count and qualifier length
+// limits. The record size limit is enforced by the Scanner while reading.
+func (limits Limits) Check(seq gts.Sequence) error {
+	ff := seq.Features()
+	if limits.FeatureCount > 0 && len(ff) > limits.FeatureCount {
+		return fmt.Errorf("record %q has %d features, exceeding the maximum of %d", ID(seq), len(ff), limits.FeatureCount)
+	}
+
+	if limits.QualifierLength > 0 {
+		for _, f := range ff {
+			for _, item := range f.Props.Items() {
+				if n := len(item.Value); n > limits.QualifierLength {
+					return fmt.Errorf("record %q: /%s qualifier of %s %s is %d bytes long, exceeding the maximum of %d", ID(seq), item.Key, f.Key, f.Loc, n, limits.QualifierLength)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// limitSlack is the number of bytes the parser state may read ahead of the
+// record being parsed.
+const limitSlack = 4096
+
+var errRecordSize = errors.New("record size limit exceeded")
+
+// limitReader counts the bytes read for the current record and fails once
+// the record size limit is exceeded by more than the read ahead, so that the
+// parser stops reading a record which is certainly too large.
+type limitReader struct {
+	r        io.Reader
+	n, max   int
+	exceeded bool
+}
+
+// reset starts counting the bytes of a new record, of which the given number
+// of bytes have already been read ahead.
+func (lr *limitReader) reset(n int) {
+	lr.n = n
+}
+
+func (lr *limitReader) Read(p []byte) (int, error) {
+	if lr.exceeded {
+		return 0, errRecordSize
+	}
+	n, err := lr.r.Read(p)
+	lr.n += n
+	if lr.max > 0 && lr.n > lr.max+limitSlack {
+		lr.exceeded = true
+		return n, errRecordSize
+	}
+	return n, err
+}
@@ -0,0 +1,41 @@
+package seqio
+
+import "github.com/go-gts/gts"
+
+// Molecule returns the molecule type of the sequence. The molecule type
+// recorded in the metadata of the sequence is returned if present, and is
+// otherwise inferred from the sequence content with gts.GuessMolecule.
+func Molecule(seq gts.Sequence) gts.Molecule {
+	switch info := seq.Info().(type) {
+	case GenBankFields:
+		if info.Molecule != "" {
+			return info.Molecule
+		}
+	case FastaHeader:
+		if info.Molecule != "" {
+			return info.Molecule
+		}
+	}
+	return gts.GuessMolecule(seq.Bytes())
+}
+
+// WithMolecule returns a sequence with the molecule type set to the given
+// value. The molecule type of a GenBank sequence is set in its fields, and a
+// sequence with a plain description is given a FastaHeader with the molecule
+// type set.
+func WithMolecule(seq gts.Sequence, mol gts.Molecule) gts.Sequence {
+	switch info := seq.Info().(type) {
+	case GenBankFields:
+		info.Molecule = mol
+		return gts.WithInfo(seq, info)
+	case FastaHeader:
+		info.Molecule = mol
+		return gts.WithInfo(seq, info)
+	case string:
+		h := ParseFastaHeader(info)
+		h.Molecule = mol
+		return gts.WithInfo(seq, h)
+	default:
+		return seq
+	}
+}
@@ -0,0 +1,68 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestMolecule(t *testing.T) {
+	dna := Fasta{"foo bar", []byte("atgcatgcat")}
+	testutils.Equals(t, Molecule(dna), gts.DNA)
+
+	rna := Fasta{"foo bar", []byte("augcaugcau")}
+	testutils.Equals(t, Molecule(rna), gts.RNA)
+
+	seq := WithMolecule(dna, gts.RNA)
+	testutils.Equals(t, Molecule(seq), gts.RNA)
+	testutils.Equals(t, ID(seq), "foo")
+	testutils.Equals(t, Molecule(WithMolecule(seq, gts.AA)), gts.AA)
+
+	gb := gts.New(GenBankFields{Molecule: gts.RNA}, nil, []byte("atgcatgcat"))
+	testutils.Equals(t, Molecule(gb), gts.RNA)
+	testutils.Equals(t, Molecule(WithMolecule(gb, gts.DNA)), gts.DNA)
+
+	gb = gts.New(GenBankFields{}, nil, []byte("MKVLAAGIV"))
+	testutils.Equals(t, Molecule(gb), gts.AA)
+}
+
+func TestGenBankWriterFasta(t *testing.T) {
+	seqs := []gts.Sequence{
+		Fasta{"foo bar baz", []byte("atgcatgcat")},
+		gts.New("foo bar baz", nil, []byte("atgcatgcat")),
+	}
+
+	for _, seq := range seqs {
+		b := strings.Builder{}
+		if _, err := (GenBankWriter{&b, GenBankDialect{}}).WriteSeq(seq); err != nil {
+			t.Fatalf("GenBankWriter.WriteSeq(%q): %v", seq.Info(), err)
+		}
+		out := b.String()
+		locus := "LOCUS       foo                       10 bp    DNA     linear   UNA 01-JAN-1970"
+		if !strings.HasPrefix(out, locus+"\n") {
+			t.Errorf("GenBankWriter.WriteSeq(%q) wrote:\n%s\nwant LOCUS line:\n%s", seq.Info(), out, locus)
+		}
+		if !strings.Contains(out, "DEFINITION  bar baz.\n") {
+			t.Errorf("GenBankWriter.WriteSeq(%q) wrote:\n%s\nwant definition %q", seq.Info(), out, "bar baz")
+		}
+	}
+
+	b := strings.Builder{}
+	seq := Fasta{"foo", []byte("atgcatgcat")}
+	w := MoleculeWriter{NewWriter(&b, GenBankFile), gts.RNA}
+	if _, err := w.WriteSeq(seq); err != nil {
+		t.Fatalf("MoleculeWriter.WriteSeq(seq): %v", err)
+	}
+	if !strings.Contains(b.String(), " RNA ") {
+		t.Errorf("MoleculeWriter.WriteSeq(seq) wrote:\n%s\nwant molecule type RNA", b.String())
+	}
+
+	b.Reset()
+	w = MoleculeWriter{NewWriter(&b, FastaFile), gts.RNA}
+	if _, err := w.WriteSeq(seq); err != nil {
+		t.Fatalf("MoleculeWriter.WriteSeq(seq): %v", err)
+	}
+	testutils.Equals(t, b.String(), ">foo\natgcatgcat\n")
+}
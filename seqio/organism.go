@@ -1,8 +1,37 @@
 package seqio
 
+import "strings"
+
 // Organism represents an organism of a record.
 type Organism struct {
 	Species string
 	Name    string
 	Taxon   []string
 }
+
+// Lineage returns the taxonomic lineage of the organism from the root of the
+// taxonomy down to the organism name itself.
+func (o Organism) Lineage() []string {
+	lineage := make([]string, 0, len(o.Taxon)+1)
+	for _, taxon := range o.Taxon {
+		if taxon = strings.TrimSpace(taxon); taxon != "" {
+			lineage = append(lineage, taxon)
+		}
+	}
+	if o.Name != "" {
+		lineage = append(lineage, o.Name)
+	}
+	return lineage
+}
+
+// InClade tests if the organism belongs to the clade of the given name by
+// comparing the name against the lineage of the organism. The comparison is
+// case insensitive.
+func (o Organism) InClade(name string) bool {
+	for _, taxon := range o.Lineage() {
+		if strings.EqualFold(taxon, name) {
+			return true
+		}
+	}
+	return false
+}
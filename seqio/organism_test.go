@@ -0,0 +1,36 @@
+package seqio
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestOrganism(t *testing.T) {
+	o := Organism{
+		Species: "Escherichia coli str. K-12 substr. MG1655",
+		Name:    "Escherichia coli str. K-12 substr. MG1655",
+		Taxon: []string{
+			"Bacteria", "Proteobacteria", "Gammaproteobacteria",
+			"Enterobacterales", "Enterobacteriaceae", "Escherichia",
+		},
+	}
+
+	testutils.Equals(t, o.Lineage(), []string{
+		"Bacteria", "Proteobacteria", "Gammaproteobacteria",
+		"Enterobacterales", "Enterobacteriaceae", "Escherichia",
+		"Escherichia coli str. K-12 substr. MG1655",
+	})
+
+	for _, name := range []string{"Bacteria", "gammaproteobacteria", "Escherichia"} {
+		if !o.InClade(name) {
+			t.Errorf("o.InClade(%q) = false, want true", name)
+		}
+	}
+
+	if o.InClade("Firmicutes") {
+		t.Errorf("o.InClade(%q) = true, want false", "Firmicutes")
+	}
+
+	testutils.Equals(t, Organism{}.Lineage(), []string{})
+}
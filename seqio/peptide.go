@@ -0,0 +1,79 @@
+package seqio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// PeptideRecord represents a row of a peptide table, describing a peptide
+// feature in protein coordinates of the CDS feature identified by CDS.
+// Start and End are zero-based and half-open.
+type PeptideRecord struct {
+	CDS   string
+	Key   string
+	Start int
+	End   int
+	Props gts.Props
+}
+
+// ReadPeptideTable reads a tab-separated peptide table. Each row consists of
+// the CDS identifier, the feature key, the one-based inclusive start and end
+// residues, and any number of qualifiers in `<name>=<value>` form. Blank lines
+// and lines starting with `#` are ignored. The feature key may be any of the
+// names accepted by gts.PeptideKey.
+func ReadPeptideTable(r io.Reader) ([]PeptideRecord, error) {
+	records := []PeptideRecord{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("peptide table line %d: expected at least 4 fields", n)
+		}
+
+		key, ok := gts.PeptideKey(fields[1])
+		if !ok {
+			return nil, fmt.Errorf("peptide table line %d: unknown peptide feature %q", n, fields[1])
+		}
+
+		start, err := strconv.Atoi(fields[2])
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("peptide table line %d: bad start residue %q", n, fields[2])
+		}
+
+		end, err := strconv.Atoi(fields[3])
+		if err != nil || end < start {
+			return nil, fmt.Errorf("peptide table line %d: bad end residue %q", n, fields[3])
+		}
+
+		props := gts.Props{}
+		for _, field := range fields[4:] {
+			if field == "" {
+				continue
+			}
+			i := strings.IndexByte(field, '=')
+			if i <= 0 {
+				return nil, fmt.Errorf("peptide table line %d: expected `<name>=<value>`, got %q", n, field)
+			}
+			props.Add(field[:i], field[i+1:])
+		}
+
+		records = append(records, PeptideRecord{fields[0], key, start - 1, end, props})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
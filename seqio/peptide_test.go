@@ -0,0 +1,47 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestReadPeptideTable(t *testing.T) {
+	in := strings.Join([]string{
+		"# cds\tkey\tstart\tend\tqualifiers",
+		"NP_000198.1\tsignal peptide\t1\t24",
+		"",
+		"NP_000198.1\tmat_peptide\t25\t54\tproduct=insulin B chain\tnote=",
+	}, "\n")
+
+	records, err := ReadPeptideTable(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.Equals(t, records, []PeptideRecord{
+		{"NP_000198.1", "sig_peptide", 0, 24, gts.Props{}},
+		{"NP_000198.1", "mat_peptide", 24, 54, gts.Props{
+			[]string{"product", "insulin B chain"},
+			[]string{"note", ""},
+		}},
+	})
+}
+
+func TestReadPeptideTableFail(t *testing.T) {
+	tests := []string{
+		"NP_000198.1\tsig_peptide\t1",
+		"NP_000198.1\tgene\t1\t24",
+		"NP_000198.1\tsig_peptide\t0\t24",
+		"NP_000198.1\tsig_peptide\t24\t1",
+		"NP_000198.1\tsig_peptide\t1\t24\tproduct",
+	}
+
+	for _, in := range tests {
+		if _, err := ReadPeptideTable(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadPeptideTable(%q) expected error", in)
+		}
+	}
+}
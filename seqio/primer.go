@@ -0,0 +1,73 @@
+package seqio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// Primer represents an entry of a primer library.
+type Primer struct {
+	Name string
+	Seq  string
+}
+
+// ReadPrimerLibrary reads a primer library in either FASTA or tabular form.
+// A FASTA library uses the first word of each description as the primer
+// name. A tabular library consists of rows with the primer name and the
+// primer sequence delimited by a tab, where blank lines and lines starting
+// with `#` are ignored.
+func ReadPrimerLibrary(r io.Reader) ([]Primer, error) {
+	p, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte(">")) {
+		primers := []Primer{}
+		scanner := NewScanner(FastaParser, bytes.NewReader(p))
+		for scanner.Scan() {
+			seq := scanner.Value()
+			desc, _ := seq.Info().(string)
+			name := desc
+			if fields := strings.Fields(desc); len(fields) > 0 {
+				name = fields[0]
+			}
+			primers = append(primers, Primer{name, string(seq.Bytes())})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("primer library: %v", err)
+		}
+		return primers, nil
+	}
+
+	primers := []Primer{}
+	scanner := bufio.NewScanner(bytes.NewReader(p))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("primer library line %d: expected at least 2 fields", n)
+		}
+
+		name, seq := strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1])
+		if seq == "" {
+			return nil, fmt.Errorf("primer library line %d: empty primer sequence", n)
+		}
+
+		primers = append(primers, Primer{name, seq})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return primers, nil
+}
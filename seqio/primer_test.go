@@ -0,0 +1,53 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestReadPrimerLibrary(t *testing.T) {
+	exp := []Primer{
+		{"M13F", "GTAAAACGACGGCCAGT"},
+		{"M13R", "CAGGAAACAGCTATGAC"},
+	}
+
+	tests := []string{
+		strings.Join([]string{
+			"# name\tsequence",
+			"M13F\tGTAAAACGACGGCCAGT\t-20",
+			"",
+			"M13R\tCAGGAAACAGCTATGAC",
+		}, "\n"),
+		strings.Join([]string{
+			">M13F (-20) forward",
+			"GTAAAACGACGGCCAGT",
+			">M13R reverse",
+			"CAGGAAACAGCTATGAC",
+			"",
+		}, "\n"),
+	}
+
+	for _, in := range tests {
+		primers, err := ReadPrimerLibrary(strings.NewReader(in))
+		if err != nil {
+			t.Errorf("ReadPrimerLibrary(%q): %v", in, err)
+			continue
+		}
+		testutils.Equals(t, primers, exp)
+	}
+}
+
+func TestReadPrimerLibraryFail(t *testing.T) {
+	tests := []string{
+		"M13F",
+		"M13F\t",
+	}
+
+	for _, in := range tests {
+		if _, err := ReadPrimerLibrary(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadPrimerLibrary(%q) expected error", in)
+		}
+	}
+}
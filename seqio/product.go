@@ -0,0 +1,135 @@
+package seqio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultProductFallback is the product name given to products which are
+// deemed uninformative by a ProductCurator.
+const DefaultProductFallback = "hypothetical protein"
+
+// DefaultBannedProductTerms is the list of terms which are considered
+// uninformative when found in a product name.
+var DefaultBannedProductTerms = []string{
+	"conserved protein",
+	"predicted protein",
+	"putative protein",
+	"uncharacterized protein",
+	"unknown protein",
+}
+
+// ProductCurator normalizes product names against a set of rules. A product
+// name is curated in the following order: redundant whitespaces and trailing
+// periods are removed, the name is mapped with the synonym table, names
+// containing any of the banned terms are replaced with the fallback name, and
+// the first letter is lowercased unless it is part of an acronym.
+type ProductCurator struct {
+	Synonyms map[string]string
+	Banned   []string
+	Fallback string
+	KeepCase bool
+
+	banned []*regexp.Regexp
+}
+
+// NewProductCurator creates a new ProductCurator with the default banned
+// terms and fallback name.
+func NewProductCurator() *ProductCurator {
+	banned := make([]string, len(DefaultBannedProductTerms))
+	copy(banned, DefaultBannedProductTerms)
+	return &ProductCurator{
+		Synonyms: make(map[string]string),
+		Banned:   banned,
+		Fallback: DefaultProductFallback,
+	}
+}
+
+// AddSynonym adds a synonym to the synonym table. The synonym is matched case
+// insensitively against the whole product name.
+func (c *ProductCurator) AddSynonym(from, to string) {
+	if c.Synonyms == nil {
+		c.Synonyms = make(map[string]string)
+	}
+	c.Synonyms[strings.ToLower(normalizeProductSpaces(from))] = to
+}
+
+// ReadSynonyms reads a synonym table where each line contains a product name
+// and the name it should be replaced with, delimited by a tab character.
+// Empty lines and lines starting with a `#` are ignored.
+func (c *ProductCurator) ReadSynonyms(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			return fmt.Errorf("synonym table line %d: expected 2 tab-delimited fields, got %d", n, len(fields))
+		}
+		c.AddSynonym(fields[0], fields[1])
+	}
+	return scanner.Err()
+}
+
+func normalizeProductSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isAcronym(word string) bool {
+	if len([]rune(word)) == 1 {
+		return true
+	}
+	for i, r := range word {
+		if i > 0 && (unicode.IsUpper(r) || unicode.IsDigit(r)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ProductCurator) isBanned(name string) bool {
+	if len(c.banned) != len(c.Banned) {
+		c.banned = make([]*regexp.Regexp, len(c.Banned))
+		for i, term := range c.Banned {
+			pattern := `(?i)\b` + regexp.QuoteMeta(normalizeProductSpaces(term)) + `\b`
+			c.banned[i] = regexp.MustCompile(pattern)
+		}
+	}
+	for _, re := range c.banned {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Curate returns the curated form of the given product name.
+func (c *ProductCurator) Curate(name string) string {
+	name = normalizeProductSpaces(name)
+	name = strings.TrimRight(name, ".")
+
+	if to, ok := c.Synonyms[strings.ToLower(name)]; ok {
+		name = normalizeProductSpaces(to)
+	}
+
+	if name == "" || c.isBanned(name) {
+		return c.Fallback
+	}
+
+	if !c.KeepCase {
+		word := strings.SplitN(name, " ", 2)[0]
+		if !isAcronym(word) {
+			r := []rune(name)
+			r[0] = unicode.ToLower(r[0])
+			name = string(r)
+		}
+	}
+
+	return name
+}
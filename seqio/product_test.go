@@ -0,0 +1,64 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+)
+
+var productCuratorTests = []struct {
+	in, out string
+}{
+	{"DNA polymerase III subunit alpha", "DNA polymerase III subunit alpha"},
+	{"Ribosomal protein L2", "ribosomal protein L2"},
+	{"  chaperonin   GroEL. ", "chaperonin GroEL"},
+	{"RecA", "RecA"},
+	{"G", "G"},
+	{"Putative protein", "hypothetical protein"},
+	{"uncharacterized protein YbaB", "hypothetical protein"},
+	{"putative proteinase", "putative proteinase"},
+	{"", "hypothetical protein"},
+	{"Heat Shock Protein 70", "heat shock protein 70"},
+	{"hsp70", "heat shock protein 70"},
+	{"obsolete name", "hypothetical protein"},
+}
+
+func TestProductCurator(t *testing.T) {
+	c := NewProductCurator()
+	table := strings.Join([]string{
+		"# synonyms",
+		"",
+		"HSP70\theat shock protein 70",
+		"heat shock protein 70\theat shock protein 70",
+		"obsolete  name\t",
+	}, "\n")
+	if err := c.ReadSynonyms(strings.NewReader(table)); err != nil {
+		t.Fatalf("c.ReadSynonyms(): %v", err)
+	}
+
+	for _, tt := range productCuratorTests {
+		out := c.Curate(tt.in)
+		if out != tt.out {
+			t.Errorf("c.Curate(%q) = %q, want %q", tt.in, out, tt.out)
+		}
+	}
+
+	c.KeepCase = true
+	if out := c.Curate("Ribosomal protein L2"); out != "Ribosomal protein L2" {
+		t.Errorf("c.Curate(%q) = %q, want %q", "Ribosomal protein L2", out, "Ribosomal protein L2")
+	}
+
+	c.Banned = append(c.Banned, "DUF")
+	if out := c.Curate("DUF1234 domain-containing protein"); out != "DUF1234 domain-containing protein" {
+		t.Errorf("c.Curate() matched a partial word")
+	}
+	if out := c.Curate("DUF domain protein"); out != c.Fallback {
+		t.Errorf("c.Curate(%q) = %q, want %q", "DUF domain protein", out, c.Fallback)
+	}
+}
+
+func TestProductCuratorReadSynonymsFail(t *testing.T) {
+	c := NewProductCurator()
+	if err := c.ReadSynonyms(strings.NewReader("foo\tbar\tbaz")); err == nil {
+		t.Error("expected error in c.ReadSynonyms()")
+	}
+}
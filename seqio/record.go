@@ -0,0 +1,236 @@
+package seqio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gts/gts"
+)
+
+// RecordSchemaVersion is the version of the Record schema produced by this
+// package. The version is incremented whenever a change to the schema would
+// alter the interpretation of previously serialized records.
+const RecordSchemaVersion = 1
+
+// RecordQualifier represents a single qualifier of a RecordFeature.
+type RecordQualifier struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// RecordFeature represents a feature of a Record.
+type RecordFeature struct {
+	Key        string            `json:"key"`
+	Location   string            `json:"location"`
+	Qualifiers []RecordQualifier `json:"qualifiers,omitempty"`
+}
+
+// Record is a format independent representation of a sequence record with a
+// versioned schema. Programs embedding gts as a library should use Record to
+// exchange sequences instead of relying on the format specific metadata
+// returned by the Info method of a gts.Sequence, whose structure may change
+// between releases. Serialized records from older schema versions can be
+// upgraded with MigrateRecord.
+type Record struct {
+	Schema      int             `json:"schema"`
+	ID          string          `json:"id"`
+	Description string          `json:"description,omitempty"`
+	Molecule    string          `json:"molecule,omitempty"`
+	Topology    string          `json:"topology,omitempty"`
+	Division    string          `json:"division,omitempty"`
+	Date        string          `json:"date,omitempty"`
+	Accession   string          `json:"accession,omitempty"`
+	Version     string          `json:"version,omitempty"`
+	Keywords    []string        `json:"keywords,omitempty"`
+	Organism    string          `json:"organism,omitempty"`
+	Lineage     []string        `json:"lineage,omitempty"`
+	Comments    []string        `json:"comments,omitempty"`
+	Features    []RecordFeature `json:"features,omitempty"`
+	Sequence    string          `json:"sequence"`
+}
+
+const recordDateLayout = "2006-01-02"
+
+// NewRecord creates a Record from the given sequence. Metadata which are not
+// representable in the schema are discarded.
+func NewRecord(seq gts.Sequence) Record {
+	rec := Record{Schema: RecordSchemaVersion, Sequence: string(seq.Bytes())}
+
+	switch info := seq.Info().(type) {
+	case GenBankFields:
+		rec.ID = info.ID()
+		rec.Description = info.Definition
+		rec.Molecule = string(info.Molecule)
+		rec.Topology = info.Topology.String()
+		rec.Division = info.Division
+		if info.Date != (Date{}) {
+			rec.Date = info.Date.ToTime().Format(recordDateLayout)
+		}
+		rec.Accession = info.Accession
+		rec.Version = info.Version
+		rec.Keywords = info.Keywords
+		rec.Organism = info.Source.Species
+		rec.Lineage = info.Source.Lineage()
+		rec.Comments = info.Comments
+	case string:
+		rec = fastaHeaderRecord(rec, ParseFastaHeader(info))
+	case FastaHeader:
+		rec = fastaHeaderRecord(rec, info)
+	case interface{ ID() string }:
+		rec.ID = info.ID()
+	case fmt.Stringer:
+		rec.Description = info.String()
+	}
+
+	for _, f := range seq.Features() {
+		feature := RecordFeature{Key: f.Key, Location: f.Loc.String()}
+		for _, item := range f.Props.Items() {
+			feature.Qualifiers = append(feature.Qualifiers, RecordQualifier{item.Key, item.Value})
+		}
+		rec.Features = append(rec.Features, feature)
+	}
+
+	return rec
+}
+
+// fastaHeaderRecord sets the fields of the Record from a FASTA header. The
+// accession and version are only set if the version is known, so that an
+// arbitrary identifier is not mistaken for an accession.
+func fastaHeaderRecord(rec Record, h FastaHeader) Record {
+	fields := strings.SplitN(h.Desc, " ", 2)
+	rec.ID = fields[0]
+	if len(fields) > 1 {
+		rec.Description = fields[1]
+	}
+	if h.Version != "" {
+		rec.Accession, rec.Version = h.Accession, h.Version
+	}
+	rec.Organism = h.Organism
+	if h.Molecule != "" {
+		rec.Molecule = string(h.Molecule)
+	}
+	return rec
+}
+
+// Seq converts the Record into a gts.Sequence. A Record without features
+// or a molecule type is converted into a FASTA sequence, and any other Record
+// is converted into a GenBank sequence.
+func (rec Record) Seq() (gts.Sequence, error) {
+	if len(rec.Features) == 0 && rec.Molecule == "" {
+		desc := rec.ID
+		if rec.Description != "" {
+			desc += " " + rec.Description
+		}
+		return Fasta{desc, []byte(rec.Sequence)}, nil
+	}
+
+	fields := GenBankFields{
+		LocusName:  rec.ID,
+		Division:   rec.Division,
+		Definition: rec.Description,
+		Accession:  rec.Accession,
+		Version:    rec.Version,
+		Keywords:   rec.Keywords,
+		Comments:   rec.Comments,
+	}
+
+	if rec.Accession != "" {
+		fields.LocusName = rec.Accession
+	}
+
+	if rec.Molecule != "" {
+		mol, err := gts.AsMolecule(rec.Molecule)
+		if err != nil {
+			return nil, err
+		}
+		fields.Molecule = mol
+	}
+
+	if rec.Topology != "" {
+		t, err := gts.AsTopology(rec.Topology)
+		if err != nil {
+			return nil, err
+		}
+		fields.Topology = t
+	}
+
+	if rec.Date != "" {
+		t, err := time.Parse(recordDateLayout, rec.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid record date %q: %v", rec.Date, err)
+		}
+		fields.Date = FromTime(t)
+	}
+
+	if rec.Organism != "" || len(rec.Lineage) > 0 {
+		fields.Source.Species = rec.Organism
+		fields.Source.Name = rec.Organism
+		if n := len(rec.Lineage); n > 0 {
+			fields.Source.Name = rec.Lineage[n-1]
+			fields.Source.Taxon = rec.Lineage[:n-1]
+		}
+	}
+
+	ff := make(gts.FeatureSlice, len(rec.Features))
+	for i, feature := range rec.Features {
+		loc, err := gts.AsLocation(feature.Location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid location for feature %d: %v", i+1, err)
+		}
+		props := gts.Props{}
+		for _, q := range feature.Qualifiers {
+			props.Add(q.Name, q.Value)
+		}
+		ff[i] = gts.NewFeature(feature.Key, loc, props)
+	}
+
+	return GenBank{fields, ff, NewOrigin([]byte(rec.Sequence))}, nil
+}
+
+// recordMigrations holds the functions upgrading a serialized record of the
+// schema version given by the key to the next version. A migration must be
+// added here whenever RecordSchemaVersion is incremented.
+var recordMigrations = map[int]func(map[string]interface{}) error{}
+
+// MigrateRecord decodes a JSON serialized Record of any supported schema
+// version, upgrading it to the current version if necessary. An error is
+// returned if the record lacks a schema version or was produced by a newer
+// version of the schema.
+func MigrateRecord(p []byte) (Record, error) {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(p, &m); err != nil {
+		return Record{}, err
+	}
+
+	v, ok := m["schema"].(float64)
+	if !ok {
+		return Record{}, fmt.Errorf("record does not have a schema version")
+	}
+
+	version := int(v)
+	if version > RecordSchemaVersion {
+		return Record{}, fmt.Errorf("record schema version %d is newer than the supported version %d", version, RecordSchemaVersion)
+	}
+
+	for ; version < RecordSchemaVersion; version++ {
+		migrate, ok := recordMigrations[version]
+		if !ok {
+			return Record{}, fmt.Errorf("record schema version %d is not supported", version)
+		}
+		if err := migrate(m); err != nil {
+			return Record{}, fmt.Errorf("failed to migrate record from schema version %d: %v", version, err)
+		}
+		m["schema"] = version + 1
+	}
+
+	q, err := json.Marshal(m)
+	if err != nil {
+		return Record{}, err
+	}
+
+	rec := Record{}
+	err = json.Unmarshal(q, &rec)
+	return rec, err
+}
@@ -0,0 +1,116 @@
+package seqio
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestRecordGenBank(t *testing.T) {
+	f, err := os.Open("testdata/NC_001422.gb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := NewAutoScanner(f)
+	if !scanner.Scan() {
+		t.Fatalf("failed to scan testdata: %v", scanner.Err())
+	}
+	seq := scanner.Value()
+
+	rec := NewRecord(seq)
+	testutils.Equals(t, rec.Schema, RecordSchemaVersion)
+	testutils.Equals(t, rec.ID, "NC_001422.1")
+	testutils.Equals(t, rec.Molecule, "ss-DNA")
+	testutils.Equals(t, rec.Topology, "circular")
+	testutils.Equals(t, rec.Date, "2018-07-06")
+	testutils.Equals(t, len(rec.Features), len(seq.Features()))
+	testutils.Equals(t, rec.Sequence, string(seq.Bytes()))
+
+	p, err := json.Marshal(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := MigrateRecord(p)
+	if err != nil {
+		t.Fatalf("MigrateRecord(): %v", err)
+	}
+	testutils.Equals(t, out, rec)
+
+	res, err := out.Seq()
+	if err != nil {
+		t.Fatalf("Record.Seq(): %v", err)
+	}
+	testutils.Equals(t, NewRecord(res), rec)
+}
+
+func TestRecordFasta(t *testing.T) {
+	seq := Fasta{"foo bar baz", []byte("atgc")}
+	rec := NewRecord(seq)
+	testutils.Equals(t, rec, Record{
+		Schema:      RecordSchemaVersion,
+		ID:          "foo",
+		Description: "bar baz",
+		Sequence:    "atgc",
+	})
+
+	res, err := rec.Seq()
+	if err != nil {
+		t.Fatalf("Record.Seq(): %v", err)
+	}
+	testutils.Equals(t, res, seq)
+}
+
+func TestMigrateRecordFail(t *testing.T) {
+	tests := []string{
+		`{`,
+		`{"id": "foo", "sequence": "atgc"}`,
+		`{"schema": 1000, "id": "foo", "sequence": "atgc"}`,
+		`{"schema": 0, "id": "foo", "sequence": "atgc"}`,
+	}
+
+	for _, in := range tests {
+		if _, err := MigrateRecord([]byte(in)); err == nil {
+			t.Errorf("expected error in MigrateRecord(%q)", in)
+		}
+	}
+}
+
+func TestRecordSeqFail(t *testing.T) {
+	tests := []Record{
+		{Molecule: "XNA"},
+		{Molecule: "DNA", Topology: "knotted"},
+		{Molecule: "DNA", Date: "27-AUG-2018"},
+		{Features: []RecordFeature{{Key: "CDS", Location: "foo"}}},
+	}
+
+	for _, rec := range tests {
+		if _, err := rec.Seq(); err == nil {
+			t.Errorf("expected error in %#v.Seq()", rec)
+		}
+	}
+}
+
+func TestRecordFastaHeader(t *testing.T) {
+	seq := Fasta{"sp|P03649|SPIKE_BPPHX Major spike protein G OS=Escherichia phage phiX174 SV=1", []byte("MFQTF")}
+	rec := NewRecord(seq)
+	testutils.Equals(t, rec, Record{
+		Schema:      RecordSchemaVersion,
+		ID:          "sp|P03649|SPIKE_BPPHX",
+		Description: "Major spike protein G OS=Escherichia phage phiX174 SV=1",
+		Accession:   "P03649",
+		Version:     "P03649.1",
+		Organism:    "Escherichia phage phiX174",
+		Sequence:    "MFQTF",
+	})
+
+	res, err := rec.Seq()
+	if err != nil {
+		t.Fatalf("Record.Seq(): %v", err)
+	}
+	testutils.Equals(t, res, seq)
+}
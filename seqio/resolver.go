@@ -0,0 +1,170 @@
+package seqio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// ErrNotResolved is returned by a Resolver when the accession could not be
+// found. A ChainResolver will only try the next Resolver on this error.
+var ErrNotResolved = errors.New("accession could not be resolved")
+
+// Resolver is the interface for resolving an accession into a Sequence.
+type Resolver interface {
+	Resolve(accession string) (gts.Sequence, error)
+}
+
+// ResolverFunc is a function which satisfies the Resolver interface.
+type ResolverFunc func(accession string) (gts.Sequence, error)
+
+// Resolve satisfies the Resolver interface.
+func (f ResolverFunc) Resolve(accession string) (gts.Sequence, error) {
+	return f(accession)
+}
+
+// ChainResolver tries each Resolver in order and returns the first sequence
+// that was resolved.
+type ChainResolver []Resolver
+
+// Resolve satisfies the Resolver interface.
+func (rr ChainResolver) Resolve(accession string) (gts.Sequence, error) {
+	for _, r := range rr {
+		seq, err := r.Resolve(accession)
+		if !errors.Is(err, ErrNotResolved) {
+			return seq, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotResolved, accession)
+}
+
+func scanFirst(r io.Reader) (gts.Sequence, error) {
+	scanner := NewAutoScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("no sequence found")
+	}
+	return scanner.Value(), nil
+}
+
+// DirResolverExts is the list of file extensions searched by DirResolver.
+var DirResolverExts = []string{".gb", ".gbk", ".genbank", ".fasta", ".fa", ".fna", ".fas"}
+
+// DirResolver resolves accessions by searching for files named after the
+// accession in the directory. If a file named after the versioned accession
+// is not found, the accession without the version will be tried.
+type DirResolver string
+
+// Resolve satisfies the Resolver interface.
+func (dir DirResolver) Resolve(accession string) (gts.Sequence, error) {
+	names := []string{accession}
+	if i := strings.LastIndexByte(accession, '.'); i > 0 {
+		names = append(names, accession[:i])
+	}
+
+	for _, name := range names {
+		if strings.ContainsAny(name, `/\`) {
+			break
+		}
+		for _, ext := range DirResolverExts {
+			f, err := os.Open(filepath.Join(string(dir), name+ext))
+			if err != nil {
+				continue
+			}
+			seq, err := scanFirst(f)
+			f.Close()
+			if err != nil {
+				return nil, fmt.Errorf("while reading %q: %v", f.Name(), err)
+			}
+			return seq, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotResolved, accession)
+}
+
+// HTTPClient is the interface for sending HTTP requests, which is satisfied
+// by *http.Client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPResolver resolves accessions by requesting the URL generated from the
+// template where `{accession}` is replaced with the accession. If Client is
+// nil, http.DefaultClient will be used.
+type HTTPResolver struct {
+	Template string
+	Client   HTTPClient
+}
+
+// EntrezURL is the URL template for retrieving GenBank records using the NCBI
+// Entrez E-utilities.
+const EntrezURL = "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/efetch.fcgi?db=nuccore&id={accession}&rettype=gbwithparts&retmode=text"
+
+// NewEntrezResolver creates a new HTTPResolver for the NCBI Entrez
+// E-utilities.
+func NewEntrezResolver() HTTPResolver {
+	return HTTPResolver{EntrezURL, nil}
+}
+
+// URL returns the URL for the given accession.
+func (r HTTPResolver) URL(accession string) string {
+	return strings.ReplaceAll(r.Template, "{accession}", url.QueryEscape(accession))
+}
+
+// Resolve satisfies the Resolver interface.
+func (r HTTPResolver) Resolve(accession string) (gts.Sequence, error) {
+	var client HTTPClient = http.DefaultClient
+	if r.Client != nil {
+		client = r.Client
+	}
+
+	req, err := http.NewRequest(http.MethodGet, r.URL(accession), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotResolved, accession)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("while requesting %s: %s", r.URL(accession), resp.Status)
+	}
+
+	seq, err := scanFirst(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("while reading %s: %v", r.URL(accession), err)
+	}
+	return seq, nil
+}
+
+// Resolve the sequence referenced by the contig using the given Resolver.
+func (contig Contig) Resolve(r Resolver) (gts.Sequence, error) {
+	if contig.Accession == "" {
+		return nil, errors.New("contig has no accession")
+	}
+	seq, err := r.Resolve(contig.Accession)
+	if err != nil {
+		return nil, err
+	}
+	head, tail := gts.Unpack(contig.Region)
+	if head < 0 || tail > gts.Len(seq) || tail < head {
+		return nil, fmt.Errorf("contig region %s is out of bounds for %s of length %d", contig, contig.Accession, gts.Len(seq))
+	}
+	return gts.Slice(seq, head, tail), nil
+}
@@ -0,0 +1,169 @@
+package seqio
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestDirResolver(t *testing.T) {
+	r := DirResolver("testdata")
+
+	seq, err := r.Resolve("NC_001422.1")
+	if err != nil {
+		t.Fatalf("r.Resolve(%q): %v", "NC_001422.1", err)
+	}
+	testutils.Equals(t, gts.Len(seq), 5386)
+
+	for _, acc := range []string{"NC_000000.1", "../testdata/NC_001422"} {
+		if _, err := r.Resolve(acc); !errors.Is(err, ErrNotResolved) {
+			t.Errorf("r.Resolve(%q) = %v, want ErrNotResolved", acc, err)
+		}
+	}
+}
+
+func TestHTTPResolver(t *testing.T) {
+	p, err := ioutil.ReadFile(filepath.Join("testdata", "NC_001422_part.fasta"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("id") {
+		case "NC_001422.1":
+			w.Write(p)
+		case "broken":
+			http.Error(w, "oops", http.StatusInternalServerError)
+		case "empty":
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer ts.Close()
+
+	r := HTTPResolver{ts.URL + "/?id={accession}", ts.Client()}
+
+	seq, err := r.Resolve("NC_001422.1")
+	if err != nil {
+		t.Fatalf("r.Resolve(%q): %v", "NC_001422.1", err)
+	}
+	testutils.Equals(t, gts.Len(seq), 133)
+
+	if _, err := r.Resolve("missing"); !errors.Is(err, ErrNotResolved) {
+		t.Errorf("r.Resolve(%q) = %v, want ErrNotResolved", "missing", err)
+	}
+
+	for _, acc := range []string{"broken", "empty"} {
+		if _, err := r.Resolve(acc); err == nil || errors.Is(err, ErrNotResolved) {
+			t.Errorf("r.Resolve(%q) = %v, want other error", acc, err)
+		}
+	}
+
+	testutils.Equals(t, strings.HasPrefix(NewEntrezResolver().URL("A B"), "https://eutils.ncbi.nlm.nih.gov/"), true)
+	testutils.Equals(t, strings.Contains(NewEntrezResolver().URL("A B"), "id=A+B&"), true)
+}
+
+func TestChainResolver(t *testing.T) {
+	fail := ResolverFunc(func(accession string) (gts.Sequence, error) {
+		return nil, errors.New("fail")
+	})
+	miss := ResolverFunc(func(accession string) (gts.Sequence, error) {
+		return nil, ErrNotResolved
+	})
+	hit := ResolverFunc(func(accession string) (gts.Sequence, error) {
+		return gts.New(accession, nil, []byte("atgc")), nil
+	})
+
+	seq, err := ChainResolver{miss, hit, fail}.Resolve("foo")
+	if err != nil {
+		t.Fatalf("ChainResolver.Resolve(): %v", err)
+	}
+	testutils.Equals(t, seq.Info(), "foo")
+
+	if _, err := (ChainResolver{miss, fail, hit}).Resolve("foo"); err == nil || errors.Is(err, ErrNotResolved) {
+		t.Errorf("ChainResolver.Resolve() = %v, want fail", err)
+	}
+
+	if _, err := (ChainResolver{miss}).Resolve("foo"); !errors.Is(err, ErrNotResolved) {
+		t.Errorf("ChainResolver.Resolve() = %v, want ErrNotResolved", err)
+	}
+}
+
+func TestContigResolve(t *testing.T) {
+	r := ResolverFunc(func(accession string) (gts.Sequence, error) {
+		if accession != "NC_000000.1" {
+			return nil, ErrNotResolved
+		}
+		return gts.New(nil, nil, []byte("atgcatgcat")), nil
+	})
+
+	seq, err := Contig{"NC_000000.1", gts.Segment{2, 6}}.Resolve(r)
+	if err != nil {
+		t.Fatalf("contig.Resolve(): %v", err)
+	}
+	testutils.Equals(t, string(seq.Bytes()), "gcat")
+
+	for _, contig := range []Contig{
+		{},
+		{"NC_000000.2", gts.Segment{2, 6}},
+		{"NC_000000.1", gts.Segment{2, 20}},
+	} {
+		if _, err := contig.Resolve(r); err == nil {
+			t.Errorf("%v.Resolve() expected error", contig)
+		}
+	}
+}
+
+func TestResolveContig(t *testing.T) {
+	r := ResolverFunc(func(accession string) (gts.Sequence, error) {
+		if accession != "U00096.3" {
+			return nil, ErrNotResolved
+		}
+		return gts.New(nil, nil, []byte(strings.Repeat("acgt", 1160413))), nil
+	})
+
+	in := testutils.ReadTestfile(t, "NC_000913.3.min.gb")
+
+	s := NewAutoScanner(strings.NewReader(in))
+	if !s.Scan() {
+		t.Fatalf("Scan failed: %v", s.Err())
+	}
+	seq := s.Value()
+	testutils.Equals(t, gts.Len(seq), 0)
+
+	out, err := ResolveContig(seq, r)
+	if err != nil {
+		t.Fatalf("ResolveContig(): %v", err)
+	}
+	testutils.Equals(t, gts.Len(out), 4641652)
+	testutils.Equals(t, out.Info().(GenBankFields).Contig, Contig{})
+	testutils.Equals(t, len(out.Features()), len(seq.Features()))
+
+	same, err := ResolveContig(out, r)
+	if err != nil {
+		t.Fatalf("ResolveContig(): %v", err)
+	}
+	testutils.Equals(t, gts.Len(same), 4641652)
+
+	s = NewAutoScanner(strings.NewReader(in))
+	s.SetResolver(r)
+	if !s.Scan() {
+		t.Fatalf("Scan failed: %v", s.Err())
+	}
+	testutils.Equals(t, gts.Len(s.Value()), 4641652)
+
+	s = NewAutoScanner(strings.NewReader(in))
+	s.SetResolver(ResolverFunc(func(accession string) (gts.Sequence, error) {
+		return nil, ErrNotResolved
+	}))
+	if s.Scan() || s.Err() == nil {
+		t.Errorf("expected error in Scan with an unresolvable contig")
+	}
+}
@@ -1,6 +1,8 @@
 package seqio
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/go-gts/gts"
@@ -9,48 +11,151 @@ import (
 
 var sequenceParsers = []pars.Parser{
 	GenBankParser,
+	EMBLParser,
 	FastaParser,
+	FastqParser,
+	INSDSeqParser,
 }
 
 // Scanner represents a sequence file scanner.
 type Scanner struct {
-	p   pars.Parser
-	s   *pars.State
-	res pars.Result
-	err error
+	p      pars.Parser
+	s      *pars.State
+	res    pars.Result
+	err    error
+	warn   WarningHandler
+	lr     *limitReader
+	limits Limits
+	r      Resolver
 }
 
 // NewScanner creates a new sequence scanner.
 func NewScanner(p pars.Parser, r io.Reader) *Scanner {
-	return &Scanner{p, pars.NewState(r), pars.Result{}, nil}
+	if _, ok := r.(*pars.State); ok {
+		return &Scanner{p, pars.NewState(r), pars.Result{}, nil, nil, nil, Limits{}, nil}
+	}
+	lr := &limitReader{r: r}
+	return &Scanner{p, pars.NewState(lr), pars.Result{}, nil, nil, lr, Limits{}, nil}
 }
 
 // NewAutoScanner creates a new sequence scanner which will automatically
 // detect the sequence format from a list of known parsers on the first scan.
+// Compressed streams are decompressed transparently as described in
+// Decompress, and the scanner will report the error if the stream could not
+// be decompressed.
 func NewAutoScanner(r io.Reader) *Scanner {
+	r, err := Decompress(r)
+	if err != nil {
+		s := NewScanner(nil, bytes.NewReader(nil))
+		s.err = err
+		return s
+	}
 	return NewScanner(nil, r)
 }
 
+// SetWarningHandler sets the handler which will receive the warnings for the
+// sequences scanned afterwards. Warnings are reported for the suspicious
+// contents of each sequence as described in SequenceWarnings, and for the
+// lines of a GenBank or EMBL record which could not be interpreted if the
+// format is detected automatically by the scanner.
+func (s *Scanner) SetWarningHandler(warn WarningHandler) {
+	s.warn = warn
+}
+
+// SetLimits sets the limits imposed on the sequences scanned afterwards. The
+// size of a record is measured in the bytes read from the input, which may
+// run ahead of the record by a few kilobytes, so a record slightly larger than
+// the size limit may be accepted.
+func (s *Scanner) SetLimits(limits Limits) {
+	s.limits = limits
+	if s.lr != nil {
+		s.lr.max = limits.RecordSize
+	}
+}
+
+// SetResolver sets the Resolver used to reconstruct the sequences of records
+// assembled from other entries, as described by ResolveContig. The records
+// are left as is if no Resolver is set.
+func (s *Scanner) SetResolver(r Resolver) {
+	s.r = r
+}
+
+func (s *Scanner) parsers() []pars.Parser {
+	if s.warn == nil {
+		return sequenceParsers
+	}
+	return []pars.Parser{warningGenBankParser(s.warn), warningEMBLParser(s.warn), FastaParser, FastqParser, INSDSeqParser}
+}
+
+func (s *Scanner) check(line int) {
+	if s.warn == nil {
+		return
+	}
+	seq := s.Value()
+	for _, msg := range SequenceWarnings(seq) {
+		s.warn(Warning{line + 1, ID(seq), msg})
+	}
+}
+
 // Scan advances the scanner using the given parser. If the parser is not yet
-// specified, the first scan will match one of the known parsers.
+// specified, the first scan will match one of the known parsers. Scanning
+// stops with an error if a sequence exceeds the limits set by SetLimits.
 func (s *Scanner) Scan() bool {
 	if s.err != nil {
 		return false
 	}
 
+	line := s.s.Position().Line
+	if s.lr != nil {
+		s.lr.reset(len(s.s.Dump()))
+	}
+
+	if !s.scan(line) {
+		if s.lr != nil && s.lr.exceeded {
+			s.err = fmt.Errorf("line %d: record exceeds the maximum size of %d bytes", line+1, s.limits.RecordSize)
+		}
+		return false
+	}
+
+	if err := s.limits.Check(s.Value()); err != nil {
+		s.err = fmt.Errorf("line %d: %v", line+1, err)
+		return false
+	}
+
+	if s.r != nil {
+		seq, err := ResolveContig(s.Value(), s.r)
+		if err != nil {
+			s.err = fmt.Errorf("line %d: %v", line+1, err)
+			return false
+		}
+		s.res.Value = seq
+	}
+
+	return true
+}
+
+func (s *Scanner) scan(line int) bool {
 	if s.p == nil {
+		parsers := s.parsers()
 		errs := make([]struct {
 			err error
 			pos pars.Position
-		}, len(sequenceParsers))
-		for i, p := range sequenceParsers {
+		}, len(parsers))
+		for i, p := range parsers {
 			s.s.Push()
 			s.res, errs[i].err = p.Parse(s.s)
 			if errs[i].err == nil {
 				s.s.Drop()
 				s.p = p
+				s.check(line)
 				return true
 			}
+			// A parser which has cleared the state has recognized the
+			// format, so the error is reported as is.
+			if !s.s.Pushed() {
+				s.err = errs[i].err
+				return false
+			}
 			errs[i].pos = s.s.Position()
 			s.s.Pop()
 		}
@@ -67,7 +172,11 @@ func (s *Scanner) Scan() bool {
 	}
 
 	s.res, s.err = s.p.Parse(s.s)
-	return s.err == nil
+	if s.err != nil {
+		return false
+	}
+	s.check(line)
+	return true
 }
 
 // Value returns the most recently scanned sequence value.
@@ -107,3 +107,79 @@ func TestAutoScannerFail(t *testing.T) {
 		return
 	}
 }
+
+func TestScannerWarnings(t *testing.T) {
+	in := "" +
+		"LOCUS       TEST_DATA                 20 bp    DNA     linear   UNA 14-MAY-2020\n" +
+		"DEFINITION  Test data.\n" +
+		"  foo bar\n" +
+		"FEATURES             Location/Qualifiers\n" +
+		"     promoter        1..10\n" +
+		"     gene            11..30\n" +
+		"                     /label=foo\n" +
+		"ORIGIN      \n" +
+		"        1 gagttttatc gcttccatga\n" +
+		"//\n" +
+		"LOCUS       TEST_DATA                 20 bp    DNA     linear   UNA 14-MAY-2020\n" +
+		"FEATURES             Location/Qualifiers\n" +
+		"     terminator      11..20\n" +
+		"ORIGIN      \n" +
+		"        1 gagttttatc gcttccatga\n" +
+		"//\n"
+
+	warnings := []Warning{}
+	s := NewAutoScanner(strings.NewReader(in))
+	s.SetWarningHandler(func(w Warning) { warnings = append(warnings, w) })
+
+	n := 0
+	for s.Scan() {
+		n++
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	testutils.Equals(t, n, 2)
+	testutils.Equals(t, warnings, []Warning{
+		{3, "TEST_DATA", `ignored malformed line "  foo bar"`},
+		{1, "TEST_DATA", "feature key promoter at 1..10 is deprecated"},
+		{1, "TEST_DATA", "gene feature at 11..30 extends beyond the sequence of length 20"},
+		{1, "TEST_DATA", "qualifier /label of gene feature at 11..30 is deprecated"},
+		{11, "TEST_DATA", "feature key terminator at 11..20 is deprecated"},
+	})
+	testutils.Equals(t, warnings[0].String(), `line 3: TEST_DATA: ignored malformed line "  foo bar"`)
+}
+
+func TestScannerLimits(t *testing.T) {
+	in := testutils.ReadTestfile(t, "NC_001422.gb")
+
+	tests := []struct {
+		limits Limits
+		ok     bool
+	}{
+		{Limits{}, true},
+		{Limits{RecordSize: 1 << 20, FeatureCount: 100, QualifierLength: 1000}, true},
+		{Limits{RecordSize: 8192}, false},
+		{Limits{FeatureCount: 5}, false},
+		{Limits{QualifierLength: 100}, false},
+	}
+
+	for _, tt := range tests {
+		s := NewAutoScanner(strings.NewReader(in + in))
+		s.SetLimits(tt.limits)
+
+		n := 0
+		for s.Scan() {
+			n++
+		}
+
+		switch err := s.Err(); {
+		case tt.ok && err != nil:
+			t.Errorf("Scan with %+v failed: %v", tt.limits, err)
+		case tt.ok && n != 2:
+			t.Errorf("Scan with %+v: scanned %d records, want 2", tt.limits, n)
+		case !tt.ok && err == nil:
+			t.Errorf("expected error in Scan with %+v", tt.limits)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package seqio
+
+import "github.com/go-gts/gts"
+
+// ChecksumCommentName is the name of the structured comment used to embed
+// the checksum of a record.
+const ChecksumCommentName = "GTS-Checksum"
+
+// Stamp embeds the checksum of the canonicalized sequence and features into
+// the COMMENT field of the record, replacing any existing checksum. The
+// second return value will be false if the metadata of the sequence cannot
+// hold comments.
+func Stamp(seq gts.Sequence) (gts.Sequence, bool) {
+	info, ok := seq.Info().(GenBankFields)
+	if !ok {
+		return seq, false
+	}
+
+	sc := StructuredComment{ChecksumCommentName, Dictionary{{"SHA256", gts.Checksum(seq)}}}
+
+	comments := make([]string, len(info.Comments))
+	copy(comments, info.Comments)
+	if i := FindStructuredComment(comments, ChecksumCommentName); i >= 0 {
+		comments[i] = sc.String()
+	} else {
+		comments = append(comments, sc.String())
+	}
+	info.Comments = comments
+
+	return gts.WithInfo(seq, info), true
+}
+
+// Stamped returns the checksum embedded in the COMMENT field of the record.
+// The second return value will be false if no checksum is embedded.
+func Stamped(seq gts.Sequence) (string, bool) {
+	info, ok := seq.Info().(GenBankFields)
+	if !ok {
+		return "", false
+	}
+	i := FindStructuredComment(info.Comments, ChecksumCommentName)
+	if i < 0 {
+		return "", false
+	}
+	sc, _ := AsStructuredComment(info.Comments[i])
+	values := sc.Fields.Get("SHA256")
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
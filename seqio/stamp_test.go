@@ -0,0 +1,44 @@
+package seqio
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestStamp(t *testing.T) {
+	ff := []gts.Feature{{Key: "source", Loc: gts.Range(0, 6), Props: gts.Props{}}}
+	info := GenBankFields{Comments: []string{"plain comment"}}
+	seq := gts.New(info, ff, []byte("atgatg"))
+
+	if _, ok := Stamped(seq); ok {
+		t.Error("Stamped() found a checksum in an unstamped record")
+	}
+
+	stamped, ok := Stamp(seq)
+	if !ok {
+		t.Fatal("Stamp() failed for GenBankFields")
+	}
+	sum, ok := Stamped(stamped)
+	if !ok {
+		t.Fatal("Stamped() did not find the checksum")
+	}
+	testutils.Equals(t, sum, gts.Checksum(seq))
+	testutils.Equals(t, len(seq.Info().(GenBankFields).Comments), 1)
+
+	modified := gts.WithBytes(stamped, []byte("atgatc"))
+	restamped, _ := Stamp(modified)
+	comments := restamped.Info().(GenBankFields).Comments
+	testutils.Equals(t, len(comments), 2)
+	sum, _ = Stamped(restamped)
+	testutils.Equals(t, sum, gts.Checksum(modified))
+
+	plain := gts.New("fasta", ff, []byte("atgatg"))
+	if _, ok := Stamp(plain); ok {
+		t.Error("Stamp() succeeded for a string metadata")
+	}
+	if _, ok := Stamped(plain); ok {
+		t.Error("Stamped() succeeded for a string metadata")
+	}
+}
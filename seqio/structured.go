@@ -0,0 +1,75 @@
+package seqio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// StructuredComment represents a structured comment of the form:
+//
+//	##<Name>-START##
+//	<Key> :: <Value>
+//	##<Name>-END##
+type StructuredComment struct {
+	Name   string
+	Fields Dictionary
+}
+
+// String satisfies the fmt.Stringer interface.
+func (sc StructuredComment) String() string {
+	width := 0
+	for _, field := range sc.Fields {
+		width = gts.Max(width, len(field.Key))
+	}
+	lines := []string{fmt.Sprintf("##%s-START##", sc.Name)}
+	for _, field := range sc.Fields {
+		lines = append(lines, fmt.Sprintf("%-*s :: %s", width, field.Key, field.Value))
+	}
+	lines = append(lines, fmt.Sprintf("##%s-END##", sc.Name))
+	return strings.Join(lines, "\n")
+}
+
+// AsStructuredComment interprets the given comment as a StructuredComment.
+// The second return value will be false if the comment is not a structured
+// comment.
+func AsStructuredComment(comment string) (StructuredComment, bool) {
+	lines := strings.Split(strings.TrimSpace(comment), "\n")
+	if len(lines) < 2 {
+		return StructuredComment{}, false
+	}
+
+	head := strings.TrimSpace(lines[0])
+	tail := strings.TrimSpace(lines[len(lines)-1])
+	if !strings.HasPrefix(head, "##") || !strings.HasSuffix(head, "-START##") {
+		return StructuredComment{}, false
+	}
+	name := head[2 : len(head)-len("-START##")]
+	if tail != fmt.Sprintf("##%s-END##", name) {
+		return StructuredComment{}, false
+	}
+
+	fields := Dictionary{}
+	for _, line := range lines[1 : len(lines)-1] {
+		i := strings.Index(line, "::")
+		if i < 0 {
+			return StructuredComment{}, false
+		}
+		key, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+2:])
+		fields = append(fields, Pair{key, value})
+	}
+
+	return StructuredComment{name, fields}, true
+}
+
+// FindStructuredComment returns the index of the first structured comment
+// with the given name, or -1 if none is found.
+func FindStructuredComment(comments []string, name string) int {
+	for i, comment := range comments {
+		if sc, ok := AsStructuredComment(comment); ok && sc.Name == name {
+			return i
+		}
+	}
+	return -1
+}
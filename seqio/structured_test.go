@@ -0,0 +1,58 @@
+package seqio
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var structuredCommentTests = []struct {
+	in  StructuredComment
+	out string
+}{
+	{
+		StructuredComment{"Assembly-Data", Dictionary{{"Assembly Method", "SPAdes v. 3.13"}, {"Sequencing Technology", "Illumina"}}},
+		"##Assembly-Data-START##\n" +
+			"Assembly Method       :: SPAdes v. 3.13\n" +
+			"Sequencing Technology :: Illumina\n" +
+			"##Assembly-Data-END##",
+	},
+	{
+		StructuredComment{"Empty", Dictionary{}},
+		"##Empty-START##\n##Empty-END##",
+	},
+}
+
+func TestStructuredComment(t *testing.T) {
+	for _, tt := range structuredCommentTests {
+		out := tt.in.String()
+		testutils.Equals(t, out, tt.out)
+		in, ok := AsStructuredComment(out)
+		if !ok {
+			t.Errorf("AsStructuredComment(%q) failed", out)
+		}
+		testutils.Equals(t, in, tt.in)
+	}
+
+	for _, in := range []string{
+		"",
+		"##Foo-START##",
+		"Foo-START\nFoo-END",
+		"##Foo-START##\n##Bar-END##",
+		"##Foo-START##\nfoo\n##Foo-END##",
+	} {
+		if _, ok := AsStructuredComment(in); ok {
+			t.Errorf("AsStructuredComment(%q) expected to fail", in)
+		}
+	}
+}
+
+func TestFindStructuredComment(t *testing.T) {
+	comments := []string{
+		"plain comment",
+		structuredCommentTests[0].out,
+		structuredCommentTests[1].out,
+	}
+	testutils.Equals(t, FindStructuredComment(comments, "Empty"), 2)
+	testutils.Equals(t, FindStructuredComment(comments, "Missing"), -1)
+}
@@ -0,0 +1,177 @@
+package seqio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-gts/gts"
+)
+
+// Taxon represents a single node in the NCBI taxonomy.
+type Taxon struct {
+	ID     int
+	Parent int
+	Rank   string
+	Name   string
+}
+
+// Taxonomy represents a taxonomy tree loaded from a NCBI taxdump.
+type Taxonomy struct {
+	nodes map[int]Taxon
+	names map[string]int
+}
+
+func splitDmpLine(line string) []string {
+	line = strings.TrimSuffix(line, "\t|")
+	fields := strings.Split(line, "\t|\t")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// ReadTaxonomy reads the contents of the `nodes.dmp` and `names.dmp` files
+// of a NCBI taxdump to construct a Taxonomy.
+func ReadTaxonomy(nodes, names io.Reader) (*Taxonomy, error) {
+	tax := &Taxonomy{make(map[int]Taxon), make(map[string]int)}
+
+	scanner := bufio.NewScanner(nodes)
+	for n := 1; scanner.Scan(); n++ {
+		fields := splitDmpLine(scanner.Text())
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("nodes.dmp line %d: expected at least 3 fields", n)
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("nodes.dmp line %d: bad taxonomy ID %q", n, fields[0])
+		}
+		parent, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("nodes.dmp line %d: bad parent taxonomy ID %q", n, fields[1])
+		}
+		tax.nodes[id] = Taxon{id, parent, fields[2], ""}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	scanner = bufio.NewScanner(names)
+	for n := 1; scanner.Scan(); n++ {
+		fields := splitDmpLine(scanner.Text())
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("names.dmp line %d: expected 4 fields", n)
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("names.dmp line %d: bad taxonomy ID %q", n, fields[0])
+		}
+		name, class := fields[1], fields[3]
+		if class == "scientific name" {
+			node := tax.nodes[id]
+			node.Name = name
+			tax.nodes[id] = node
+			tax.names[strings.ToLower(name)] = id
+			continue
+		}
+		key := strings.ToLower(name)
+		if _, ok := tax.names[key]; !ok {
+			tax.names[key] = id
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tax, nil
+}
+
+// LoadTaxonomy loads the NCBI taxdump contained in the given directory.
+func LoadTaxonomy(dir string) (*Taxonomy, error) {
+	nodes, err := os.Open(filepath.Join(dir, "nodes.dmp"))
+	if err != nil {
+		return nil, err
+	}
+	defer nodes.Close()
+
+	names, err := os.Open(filepath.Join(dir, "names.dmp"))
+	if err != nil {
+		return nil, err
+	}
+	defer names.Close()
+
+	return ReadTaxonomy(nodes, names)
+}
+
+// Get returns the Taxon with the given taxonomy ID.
+func (tax *Taxonomy) Get(id int) (Taxon, bool) {
+	node, ok := tax.nodes[id]
+	return node, ok
+}
+
+// Name returns the scientific name of the given taxonomy ID.
+func (tax *Taxonomy) Name(id int) string {
+	return tax.nodes[id].Name
+}
+
+// TaxID returns the taxonomy ID associated to the given name. Scientific
+// names take precedence over other name classes such as synonyms. The lookup
+// is case insensitive.
+func (tax *Taxonomy) TaxID(name string) (int, bool) {
+	id, ok := tax.names[strings.ToLower(name)]
+	return id, ok
+}
+
+// Lineage returns the list of taxa from the root of the taxonomy down to the
+// given taxonomy ID.
+func (tax *Taxonomy) Lineage(id int) []Taxon {
+	lineage := []Taxon{}
+	for {
+		node, ok := tax.nodes[id]
+		if !ok {
+			break
+		}
+		lineage = append(lineage, node)
+		if node.Parent == node.ID {
+			break
+		}
+		id = node.Parent
+	}
+	for l, r := 0, len(lineage)-1; l < r; l, r = l+1, r-1 {
+		lineage[l], lineage[r] = lineage[r], lineage[l]
+	}
+	return lineage
+}
+
+// InClade tests if the given taxonomy ID is a descendant of (or identical to)
+// the clade taxonomy ID.
+func (tax *Taxonomy) InClade(id, clade int) bool {
+	for _, node := range tax.Lineage(id) {
+		if node.ID == clade {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceTaxID returns the taxonomy ID of the sequence specified in the
+// `/db_xref="taxon:<id>"` qualifier of the source feature.
+func SourceTaxID(seq gts.Sequence) (int, bool) {
+	for _, f := range seq.Features() {
+		if f.Key != "source" {
+			continue
+		}
+		for _, xref := range f.Props.Get("db_xref") {
+			if strings.HasPrefix(xref, "taxon:") {
+				if id, err := strconv.Atoi(xref[6:]); err == nil {
+					return id, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,122 @@
+package seqio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts"
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+const testNodesDmp = `1	|	1	|	no rank	|
+2	|	131567	|	superkingdom	|
+131567	|	1	|	no rank	|
+1224	|	2	|	phylum	|
+1236	|	1224	|	class	|
+91347	|	1236	|	order	|
+543	|	91347	|	family	|
+561	|	543	|	genus	|
+562	|	561	|	species	|
+`
+
+const testNamesDmp = `1	|	root	|		|	scientific name	|
+2	|	Bacteria	|	Bacteria <bacteria>	|	scientific name	|
+2	|	eubacteria	|		|	genbank common name	|
+131567	|	cellular organisms	|		|	scientific name	|
+1224	|	Proteobacteria	|		|	scientific name	|
+1236	|	Gammaproteobacteria	|		|	scientific name	|
+91347	|	Enterobacterales	|		|	scientific name	|
+543	|	Enterobacteriaceae	|		|	scientific name	|
+561	|	Escherichia	|		|	scientific name	|
+562	|	Escherichia coli	|		|	scientific name	|
+562	|	Bacillus coli	|		|	synonym	|
+`
+
+func testTaxonomy(t *testing.T) *Taxonomy {
+	t.Helper()
+	tax, err := ReadTaxonomy(strings.NewReader(testNodesDmp), strings.NewReader(testNamesDmp))
+	if err != nil {
+		t.Fatalf("ReadTaxonomy returned error: %v", err)
+	}
+	return tax
+}
+
+func TestTaxonomy(t *testing.T) {
+	tax := testTaxonomy(t)
+
+	if name := tax.Name(562); name != "Escherichia coli" {
+		t.Errorf("tax.Name(562) = %q, want %q", name, "Escherichia coli")
+	}
+
+	for _, name := range []string{"Escherichia coli", "escherichia COLI", "Bacillus coli"} {
+		id, ok := tax.TaxID(name)
+		if !ok || id != 562 {
+			t.Errorf("tax.TaxID(%q) = (%d, %v), want (562, true)", name, id, ok)
+		}
+	}
+
+	if _, ok := tax.TaxID("Homo sapiens"); ok {
+		t.Errorf("tax.TaxID(%q) returned true", "Homo sapiens")
+	}
+
+	node, ok := tax.Get(561)
+	testutils.Equals(t, node, Taxon{561, 543, "genus", "Escherichia"})
+	if !ok {
+		t.Error("tax.Get(561) returned false")
+	}
+
+	lineage := tax.Lineage(562)
+	names := make([]string, len(lineage))
+	for i, node := range lineage {
+		names[i] = node.Name
+	}
+	testutils.Equals(t, names, []string{
+		"root", "cellular organisms", "Bacteria", "Proteobacteria",
+		"Gammaproteobacteria", "Enterobacterales", "Enterobacteriaceae",
+		"Escherichia", "Escherichia coli",
+	})
+
+	if !tax.InClade(562, 1224) {
+		t.Error("tax.InClade(562, 1224) = false, want true")
+	}
+	if !tax.InClade(562, 562) {
+		t.Error("tax.InClade(562, 562) = false, want true")
+	}
+	if tax.InClade(1224, 562) {
+		t.Error("tax.InClade(1224, 562) = true, want false")
+	}
+}
+
+func TestTaxonomyFail(t *testing.T) {
+	tests := []struct {
+		nodes, names string
+	}{
+		{"1\t|", ""},
+		{"a\t|\t1\t|\tno rank\t|", ""},
+		{"1\t|\ta\t|\tno rank\t|", ""},
+		{"1\t|\t1\t|\tno rank\t|", "1\t|\troot\t|"},
+		{"1\t|\t1\t|\tno rank\t|", "a\t|\troot\t|\t\t|\tscientific name\t|"},
+	}
+	for _, tt := range tests {
+		_, err := ReadTaxonomy(strings.NewReader(tt.nodes), strings.NewReader(tt.names))
+		if err == nil {
+			t.Errorf("ReadTaxonomy(%q, %q) expected error", tt.nodes, tt.names)
+		}
+	}
+}
+
+func TestSourceTaxID(t *testing.T) {
+	props := gts.Props{}
+	props.Add("organism", "Escherichia coli")
+	props.Add("db_xref", "taxon:562")
+	ff := []gts.Feature{gts.NewFeature("source", gts.Range(0, 10), props)}
+
+	id, ok := SourceTaxID(gts.New(nil, ff, nil))
+	if !ok || id != 562 {
+		t.Errorf("SourceTaxID(seq) = (%d, %v), want (562, true)", id, ok)
+	}
+
+	if _, ok := SourceTaxID(gts.New(nil, nil, nil)); ok {
+		t.Error("SourceTaxID(seq) returned true for a sequence without features")
+	}
+}
@@ -0,0 +1,79 @@
+package seqio
+
+import (
+	"fmt"
+
+	"github.com/go-gts/gts"
+)
+
+// Warning represents a non-fatal problem encountered while reading a
+// sequence. The sequence is still returned by the scanner, but may not
+// faithfully represent the input.
+type Warning struct {
+	Line    int    // The line number (1-based) at which the problem was found.
+	ID      string // The identifier of the sequence, if known.
+	Message string
+}
+
+// String satisfies the fmt.Stringer interface.
+func (w Warning) String() string {
+	if w.ID == "" {
+		return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", w.Line, w.ID, w.Message)
+}
+
+// WarningHandler is a function that receives the warnings reported by a
+// Scanner.
+type WarningHandler func(w Warning)
+
+// DeprecatedFeatureKeys is the set of feature keys deprecated by the
+// INSDC feature table definition. Most of these were replaced by the
+// `regulatory` feature with a `/regulatory_class` qualifier.
+var DeprecatedFeatureKeys = map[string]bool{
+	"-10_signal":   true,
+	"-35_signal":   true,
+	"attenuator":   true,
+	"CAAT_signal":  true,
+	"enhancer":     true,
+	"GC_signal":    true,
+	"misc_signal":  true,
+	"polyA_signal": true,
+	"promoter":     true,
+	"RBS":          true,
+	"TATA_signal":  true,
+	"terminator":   true,
+}
+
+// DeprecatedQualifiers is the set of qualifier names deprecated by the INSDC
+// feature table definition.
+var DeprecatedQualifiers = map[string]bool{
+	"cons_splice":   true,
+	"insertion_seq": true,
+	"label":         true,
+	"specific_host": true,
+	"transposon":    true,
+	"usedin":        true,
+}
+
+// SequenceWarnings returns messages describing the suspicious contents of the
+// given sequence: feature locations which extend beyond the sequence, and
+// deprecated feature keys and qualifiers.
+func SequenceWarnings(seq gts.Sequence) []string {
+	ret := []string{}
+	n := gts.Len(seq)
+	for _, f := range seq.Features() {
+		if !gts.LocationWithin(f.Loc, 0, n) {
+			ret = append(ret, fmt.Sprintf("%s feature at %s extends beyond the sequence of length %d", f.Key, f.Loc, n))
+		}
+		if DeprecatedFeatureKeys[f.Key] {
+			ret = append(ret, fmt.Sprintf("feature key %s at %s is deprecated", f.Key, f.Loc))
+		}
+		for _, name := range f.Props.Keys() {
+			if DeprecatedQualifiers[name] {
+				ret = append(ret, fmt.Sprintf("qualifier /%s of %s feature at %s is deprecated", name, f.Key, f.Loc))
+			}
+		}
+	}
+	return ret
+}
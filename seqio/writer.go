@@ -3,55 +3,165 @@ package seqio
 import (
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/go-gts/gts"
 )
 
+// SeqWriter is the interface implemented by types that can format and write
+// a gts.Sequence. The SeqWriter implementations in this package write each
+// sequence with a single call to the Write method of the underlying
+// io.Writer, and hold no state between calls other than whether a GFF3Writer
+// has written its version directive.
 type SeqWriter interface {
 	WriteSeq(seq gts.Sequence) (int, error)
 }
 
-type AutoWriter struct {
+// syncWriter serializes the calls to the underlying io.Writer.
+type syncWriter struct {
+	mu *sync.Mutex
 	w  io.Writer
-	sw SeqWriter
 }
 
+func (w syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// AutoWriter writes a gts.Sequence in the format best suited for its
+// underlying type or metadata.
+type AutoWriter struct {
+	w       io.Writer
+	width   int
+	dialect GenBankDialect
+}
+
+// NewWriter creates a SeqWriter which writes sequences to the given writer in
+// the given file type. If the file type is DefaultFile, an AutoWriter is
+// returned. The SeqWriter may be reused for any number of sequences and is
+// safe for concurrent use: sequences written concurrently will not be
+// interleaved in the output, although the order in which they appear is
+// unspecified. The given writer must not be written to by other means while
+// the SeqWriter is in use.
 func NewWriter(w io.Writer, filetype FileType) SeqWriter {
+	return NewWriterWidth(w, filetype, DefaultFastaWidth)
+}
+
+// NewWriterWidth creates a SeqWriter as in NewWriter, wrapping the sequences
+// written in FASTA format at the given number of characters per line. If the
+// width is zero or negative, the sequences are not wrapped.
+func NewWriterWidth(w io.Writer, filetype FileType, width int) SeqWriter {
+	return NewWriterDialect(w, filetype, width, GenBankDialect{})
+}
+
+// NewWriterDialect creates a SeqWriter as in NewWriterWidth, formatting the
+// sequences written in GenBank format following the given dialect.
+func NewWriterDialect(w io.Writer, filetype FileType, width int, dialect GenBankDialect) SeqWriter {
+	w = syncWriter{&sync.Mutex{}, w}
 	switch filetype {
 	case FastaFile:
-		return FastaWriter{w}
+		return FastaWriter{w, width}
+	case FastqFile:
+		return FastqWriter{w}
 	case GenBankFile:
-		return GenBankWriter{w}
+		return GenBankWriter{w, dialect}
+	case EMBLFile:
+		return EMBLWriter{w}
+	case GFF3File:
+		return GFF3Writer{w, &sync.Mutex{}, new(bool)}
+	case EMBOSSFile:
+		return EMBOSSWriter{w}
+	case BEDFile:
+		return BEDWriter{w, true}
+	case BED6File:
+		return BEDWriter{w, false}
+	case GTFFile:
+		return GTFWriter{w}
 	default:
-		return AutoWriter{w, nil}
+		return AutoWriter{w, width, dialect}
 	}
 }
 
-func detectWriter(seq gts.Sequence, w io.Writer) (SeqWriter, error) {
-	switch seq.(type) {
+// DetectWriter returns the SeqWriter best suited for writing the given
+// sequence to the given writer.
+func DetectWriter(seq gts.Sequence, w io.Writer) (SeqWriter, error) {
+	return detectWriter(seq, w, DefaultFastaWidth, GenBankDialect{})
+}
+
+func detectWriter(seq gts.Sequence, w io.Writer, width int, dialect GenBankDialect) (SeqWriter, error) {
+	switch v := seq.(type) {
 	case GenBank, *GenBank:
-		return GenBankWriter{w}, nil
+		return GenBankWriter{w, dialect}, nil
+	case EMBL, *EMBL:
+		return EMBLWriter{w}, nil
 	case Fasta, *Fasta:
-		return FastaWriter{w}, nil
+		return FastaWriter{w, width}, nil
+	case *Fastq:
+		return detectWriter(*v, w, width, dialect)
 	default:
+		if gts.QualityScores(seq) != nil {
+			return FastqWriter{w}, nil
+		}
 		switch info := seq.Info().(type) {
 		case GenBankFields:
-			return GenBankWriter{w}, nil
+			return GenBankWriter{w, dialect}, nil
 		case string, fmt.Stringer:
-			return FastaWriter{w}, nil
+			return FastaWriter{w, width}, nil
 		default:
 			return nil, fmt.Errorf("gts does not know how to format a sequence with metadata type `%T`", info)
 		}
 	}
 }
 
+// WriteSeq satisfies the seqio.SeqWriter interface. The format is detected
+// for each sequence.
 func (w AutoWriter) WriteSeq(seq gts.Sequence) (int, error) {
-	if w.sw == nil {
-		sw, err := detectWriter(seq, w.w)
-		if err != nil {
-			return 0, err
-		}
-		w.sw = sw
+	sw, err := detectWriter(seq, w.w, w.width, w.dialect)
+	if err != nil {
+		return 0, err
+	}
+	return sw.WriteSeq(seq)
+}
+
+// DeterministicWriter wraps a SeqWriter so that equivalent sequences are
+// always formatted identically. See gts.Deterministic for details.
+type DeterministicWriter struct {
+	SeqWriter
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface.
+func (w DeterministicWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	return w.SeqWriter.WriteSeq(gts.Deterministic(seq))
+}
+
+// MoleculeWriter wraps a SeqWriter so that the molecule type of each
+// sequence written is set to the given Molecule. See WithMolecule for
+// details.
+type MoleculeWriter struct {
+	SeqWriter
+	Molecule gts.Molecule
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface.
+func (w MoleculeWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	return w.SeqWriter.WriteSeq(WithMolecule(seq, w.Molecule))
+}
+
+// HistoryWriter wraps a SeqWriter so that the given history entry is
+// appended to the comments of each GenBank sequence written.
+type HistoryWriter struct {
+	SeqWriter
+	Entry HistoryEntry
+}
+
+// WriteSeq satisfies the seqio.SeqWriter interface.
+func (w HistoryWriter) WriteSeq(seq gts.Sequence) (int, error) {
+	if info, ok := seq.Info().(GenBankFields); ok {
+		comments := make([]string, len(info.Comments), len(info.Comments)+1)
+		copy(comments, info.Comments)
+		info.Comments = append(comments, w.Entry.Comment())
+		seq = gts.WithInfo(seq, info)
 	}
-	return w.sw.WriteSeq(seq)
+	return w.SeqWriter.WriteSeq(seq)
 }
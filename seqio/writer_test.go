@@ -2,6 +2,7 @@ package seqio
 
 import (
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-gts/gts"
@@ -46,6 +47,36 @@ func TestWriter(t *testing.T) {
 	}
 }
 
+func TestWriterConcurrent(t *testing.T) {
+	for _, tt := range writerTests {
+		in := testutils.ReadTestfile(t, tt.filename)
+		scanner := NewAutoScanner(strings.NewReader(in))
+		if !scanner.Scan() {
+			t.Errorf("failed to scan test file %s", tt.filename)
+		}
+
+		seq := scanner.Value()
+
+		w := &strings.Builder{}
+		sw := NewWriter(w, tt.filetype)
+
+		n := 16
+		wg := sync.WaitGroup{}
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func() {
+				defer wg.Done()
+				if _, err := sw.WriteSeq(seq); err != nil {
+					t.Errorf("writer.WriteSeq(seq): %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		testutils.Equals(t, w.String(), strings.Repeat(in, n))
+	}
+}
+
 func TestWriterFail(t *testing.T) {
 	w := &strings.Builder{}
 	n, err := NewWriter(w, DefaultFile).WriteSeq(gts.New(nil, nil, nil))
@@ -0,0 +1,97 @@
+package seqio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Xref represents a cross-reference to an external database as found in the
+// `/db_xref` qualifier or the DBLINK field of a record.
+type Xref struct {
+	DB string
+	ID string
+}
+
+// AsXref interprets the given string as a Xref. The database name and the
+// identifier are delimited by the first colon.
+func AsXref(s string) (Xref, error) {
+	i := strings.IndexByte(s, ':')
+	if i <= 0 || i == len(s)-1 {
+		return Xref{}, fmt.Errorf("cannot interpret %q as a cross-reference: expected `<database>:<identifier>`", s)
+	}
+	return Xref{strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])}, nil
+}
+
+// String satisfies the fmt.Stringer interface.
+func (x Xref) String() string {
+	return fmt.Sprintf("%s:%s", x.DB, x.ID)
+}
+
+// URL returns the URL for the cross-reference using the template registered
+// for the database. If no template is registered for the database, the
+// second return value will be false.
+func (x Xref) URL() (string, bool) {
+	template, ok := LookupXrefTemplate(x.DB)
+	if !ok {
+		return "", false
+	}
+	return strings.ReplaceAll(template, "{id}", x.ID), true
+}
+
+// XrefTemplates maps database names to URL templates. The string `{id}` in a
+// template will be replaced by the identifier of the cross-reference.
+var XrefTemplates = map[string]string{
+	"ASAP":                  "https://asap.genetics.wisc.edu/asap/feature_info.php?FeatureID={id}",
+	"Assembly":              "https://www.ncbi.nlm.nih.gov/assembly/{id}",
+	"BioProject":            "https://www.ncbi.nlm.nih.gov/bioproject/{id}",
+	"BioSample":             "https://www.ncbi.nlm.nih.gov/biosample/{id}",
+	"CCDS":                  "https://www.ncbi.nlm.nih.gov/CCDS/CcdsBrowse.cgi?REQUEST=CCDS&DATA={id}",
+	"dbSNP":                 "https://www.ncbi.nlm.nih.gov/snp/rs{id}",
+	"EcoGene":               "https://ecocyc.org/gene?orgid=ECOLI&id={id}",
+	"Ensembl":               "https://www.ensembl.org/id/{id}",
+	"GeneID":                "https://www.ncbi.nlm.nih.gov/gene/{id}",
+	"GI":                    "https://www.ncbi.nlm.nih.gov/protein/{id}",
+	"GO":                    "https://amigo.geneontology.org/amigo/term/GO:{id}",
+	"HGNC":                  "https://www.genenames.org/data/gene-symbol-report/#!/hgnc_id/{id}",
+	"InterPro":              "https://www.ebi.ac.uk/interpro/entry/InterPro/{id}",
+	"MIM":                   "https://www.omim.org/entry/{id}",
+	"PDB":                   "https://www.rcsb.org/structure/{id}",
+	"PFAM":                  "https://www.ebi.ac.uk/interpro/entry/pfam/{id}",
+	"Sequence Read Archive": "https://www.ncbi.nlm.nih.gov/sra/{id}",
+	"taxon":                 "https://www.ncbi.nlm.nih.gov/Taxonomy/Browser/wwwtax.cgi?id={id}",
+	"UniProtKB/Swiss-Prot":  "https://www.uniprot.org/uniprot/{id}",
+	"UniProtKB/TrEMBL":      "https://www.uniprot.org/uniprot/{id}",
+}
+
+// RegisterXrefTemplate registers the URL template for the given database
+// name, overriding any existing template.
+func RegisterXrefTemplate(db, template string) {
+	XrefTemplates[db] = template
+}
+
+// LookupXrefTemplate returns the URL template for the given database name.
+// If an exact match is not found, the database name is compared case
+// insensitively.
+func LookupXrefTemplate(db string) (string, bool) {
+	if template, ok := XrefTemplates[db]; ok {
+		return template, true
+	}
+	for name, template := range XrefTemplates {
+		if strings.EqualFold(name, db) {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// XrefDatabases returns the sorted list of database names with a registered
+// URL template.
+func XrefDatabases() []string {
+	names := make([]string, 0, len(XrefTemplates))
+	for name := range XrefTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
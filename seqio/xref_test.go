@@ -0,0 +1,77 @@
+package seqio
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var asXrefTests = []struct {
+	in  string
+	out Xref
+}{
+	{"GeneID:3630", Xref{"GeneID", "3630"}},
+	{"HGNC:HGNC:6081", Xref{"HGNC", "HGNC:6081"}},
+	{"UniProtKB/Swiss-Prot:P01308", Xref{"UniProtKB/Swiss-Prot", "P01308"}},
+	{"BioProject: PRJNA224116", Xref{"BioProject", "PRJNA224116"}},
+}
+
+func TestAsXref(t *testing.T) {
+	for _, tt := range asXrefTests {
+		out, err := AsXref(tt.in)
+		if err != nil {
+			t.Errorf("AsXref(%q) returned error: %v", tt.in, err)
+		}
+		testutils.Equals(t, out, tt.out)
+	}
+
+	for _, in := range []string{"", "GeneID", ":3630", "GeneID:"} {
+		if _, err := AsXref(in); err == nil {
+			t.Errorf("AsXref(%q) expected error", in)
+		}
+	}
+}
+
+func TestXrefString(t *testing.T) {
+	in := Xref{"GeneID", "3630"}
+	if out := in.String(); out != "GeneID:3630" {
+		t.Errorf("xref.String() = %q, want %q", out, "GeneID:3630")
+	}
+}
+
+var xrefURLTests = []struct {
+	in  Xref
+	out string
+	ok  bool
+}{
+	{Xref{"GeneID", "3630"}, "https://www.ncbi.nlm.nih.gov/gene/3630", true},
+	{Xref{"geneid", "3630"}, "https://www.ncbi.nlm.nih.gov/gene/3630", true},
+	{Xref{"GO", "0005515"}, "https://amigo.geneontology.org/amigo/term/GO:0005515", true},
+	{Xref{"InterPro", "IPR004825"}, "https://www.ebi.ac.uk/interpro/entry/InterPro/IPR004825", true},
+	{Xref{"Unknown", "1"}, "", false},
+}
+
+func TestXrefURL(t *testing.T) {
+	for _, tt := range xrefURLTests {
+		out, ok := tt.in.URL()
+		if out != tt.out || ok != tt.ok {
+			t.Errorf("%v.URL() = (%q, %v), want (%q, %v)", tt.in, out, ok, tt.out, tt.ok)
+		}
+	}
+}
+
+func TestRegisterXrefTemplate(t *testing.T) {
+	RegisterXrefTemplate("LIMS", "https://lims.example.com/{id}")
+	defer delete(XrefTemplates, "LIMS")
+
+	out, ok := Xref{"LIMS", "pX001"}.URL()
+	if !ok || out != "https://lims.example.com/pX001" {
+		t.Errorf("URL() = (%q, %v), want (%q, true)", out, ok, "https://lims.example.com/pX001")
+	}
+
+	dbs := XrefDatabases()
+	if !sort.StringsAreSorted(dbs) {
+		t.Errorf("XrefDatabases() is not sorted: %v", dbs)
+	}
+}
@@ -141,6 +141,19 @@ func WithFeatures(seq Sequence, ff []Feature) Sequence {
 	}
 }
 
+// Deterministic creates a shallow copy of the given Sequence object with the
+// features sorted as in SortFeatures and the qualifiers of each feature
+// sorted as in Props.SortedFor, so that equivalent sequences are always
+// formatted identically.
+func Deterministic(seq Sequence) Sequence {
+	ff := make(FeatureSlice, len(seq.Features()))
+	for i, f := range seq.Features() {
+		ff[i] = Feature{f.Key, f.Loc, f.Props.SortedFor(f.Key)}
+	}
+	SortFeatures(ff)
+	return WithFeatures(seq, ff)
+}
+
 // WithBytes creates a shallow copy of the given Sequence object and swaps the
 // byte representation with the given byte slice. If the sequence implements the
 // `WithBytes(p []info) Sequence` method, it will be called instead.
@@ -161,17 +174,46 @@ func insert(p []byte, pos int, q []byte) []byte {
 // a region containing the point of insertion, the location will be split at
 // the positions before and after the guest sequence.
 func Insert(host Sequence, index int, guest Sequence) Sequence {
+	host, _ = InsertReport(host, index, guest)
+	return host
+}
+
+// InsertReport is identical to Insert except that the features of the host
+// sequence whose locations were split by the insertion are also returned.
+func InsertReport(host Sequence, index int, guest Sequence) (Sequence, []Invalidation) {
+	return InsertEmbed(host, index, guest, FalseFilter)
+}
+
+// InsertEmbed inserts a sequence at the given index as in Insert, except that
+// the locations of the host features matching the filter will be extended by
+// the length of the guest sequence as in Embed instead of being split. The
+// features of the host sequence whose locations were split by the insertion
+// are also returned.
+func InsertEmbed(host Sequence, index int, guest Sequence, embed Filter) (Sequence, []Invalidation) {
+	orig := host
 	info := host.Info()
 	info = tryShift(info, index, Len(guest))
 	host = WithInfo(host, info)
 
 	var ff FeatureSlice
+	var invs []Invalidation
 	for _, f := range host.Features() {
-		f.Loc = f.Loc.Shift(index, Len(guest))
-		ff = ff.Insert(f)
+		if embed(f) {
+			ff = ff.Insert(f.mapLocation(func(loc Location) Location {
+				return loc.Expand(index, Len(guest))
+			}))
+			continue
+		}
+		g := f.mapLocation(func(loc Location) Location {
+			return loc.Shift(index, Len(guest))
+		})
+		invs = append(invs, invalidateShift(f, g.Loc, index)...)
+		ff = ff.Insert(g)
 	}
 	for _, f := range guest.Features() {
-		f.Loc = f.Loc.Expand(0, index)
+		f = f.mapLocation(func(loc Location) Location {
+			return loc.Expand(0, index)
+		})
 		ff = ff.Insert(f)
 	}
 	host = WithFeatures(host, ff)
@@ -179,24 +221,41 @@ func Insert(host Sequence, index int, guest Sequence) Sequence {
 	p := insert(host.Bytes(), index, guest.Bytes())
 	host = WithBytes(host, p)
 
-	return host
+	return insertQualityScores(host, orig, index, guest), invs
+}
+
+// insertQualityScores sets the quality scores of the host sequence after the
+// insertion of the guest sequence, if both of the sequences have scores.
+func insertQualityScores(host, orig Sequence, index int, guest Sequence) Sequence {
+	q := QualityScores(guest)
+	if q == nil {
+		return host
+	}
+	return mapQualityScores(host, orig, func(p []byte) []byte {
+		return insert(p, index, append([]byte{}, q...))
+	})
 }
 
 // Embed a sequence at the given index. For any feature whose location covers
 // a region containing the point of insertion, the location will be extended
 // by the length of the guest Sequence.
 func Embed(host Sequence, index int, guest Sequence) Sequence {
+	orig := host
 	info := host.Info()
 	info = tryExpand(info, index, Len(guest))
 	host = WithInfo(host, info)
 
 	var ff FeatureSlice
 	for _, f := range host.Features() {
-		f.Loc = f.Loc.Expand(index, Len(guest))
+		f = f.mapLocation(func(loc Location) Location {
+			return loc.Expand(index, Len(guest))
+		})
 		ff = ff.Insert(f)
 	}
 	for _, f := range guest.Features() {
-		f.Loc = f.Loc.Expand(0, index)
+		f = f.mapLocation(func(loc Location) Location {
+			return loc.Expand(0, index)
+		})
 		ff = ff.Insert(f)
 	}
 	host = WithFeatures(host, ff)
@@ -204,7 +263,7 @@ func Embed(host Sequence, index int, guest Sequence) Sequence {
 	p := insert(host.Bytes(), index, guest.Bytes())
 	host = WithBytes(host, p)
 
-	return host
+	return insertQualityScores(host, orig, index, guest)
 }
 
 // Delete a region of the sequence at the given offset and length. Any
@@ -213,13 +272,25 @@ func Embed(host Sequence, index int, guest Sequence) Sequence {
 // shortened as a result, the location will be described as a offset in
 // between the bases where the deletion occurred.
 func Delete(seq Sequence, offset, length int) Sequence {
+	seq, _ = DeleteReport(seq, offset, length)
+	return seq
+}
+
+// DeleteReport is identical to Delete except that the features whose
+// locations were shortened by the deletion are also returned.
+func DeleteReport(seq Sequence, offset, length int) (Sequence, []Invalidation) {
+	orig := seq
 	info := seq.Info()
 	info = tryExpand(info, offset, -length)
 	seq = WithInfo(seq, info)
 
-	ff := seq.Features()
-	for i, f := range ff {
-		ff[i].Loc = f.Loc.Expand(offset, -length)
+	ff := make(FeatureSlice, len(seq.Features()))
+	var invs []Invalidation
+	for i, f := range seq.Features() {
+		ff[i] = f.mapLocation(func(loc Location) Location {
+			return loc.Expand(offset, -length)
+		})
+		invs = append(invs, invalidateExpand(f, ff[i].Loc, offset, length)...)
 	}
 	seq = WithFeatures(seq, ff)
 
@@ -229,7 +300,10 @@ func Delete(seq Sequence, offset, length int) Sequence {
 	copy(p[offset:], q[offset+length:])
 	seq = WithBytes(seq, p)
 
-	return seq
+	seq = mapQualityScores(seq, orig, func(q []byte) []byte {
+		return append(q[:offset], q[offset+length:]...)
+	})
+	return seq, invs
 }
 
 // Erase a region of the sequence at the given offset and length. Any
@@ -237,10 +311,66 @@ func Delete(seq Sequence, offset, length int) Sequence {
 // shortened by the length of deletion. If the entirety of the feature is
 // shortened as a result, the location will be removed from the sequence.
 func Erase(seq Sequence, offset, length int) Sequence {
+	seq, _ = EraseReport(seq, offset, length)
+	return seq
+}
+
+// EraseReport is identical to Erase except that the features which were
+// removed or whose locations were shortened by the deletion are also
+// returned.
+func EraseReport(seq Sequence, offset, length int) (Sequence, []Invalidation) {
 	f := Or(Key("source"), Not(Within(offset, offset+length)))
+	var invs []Invalidation
+	for _, g := range seq.Features() {
+		if !f(g) {
+			invs = append(invs, Invalidation{g, nil, FeatureRemoved})
+		}
+	}
 	ff := seq.Features().Filter(f)
 	seq = WithFeatures(seq, ff)
-	return Delete(seq, offset, length)
+	seq, rest := DeleteReport(seq, offset, length)
+	return seq, append(invs, rest...)
+}
+
+// trimCollapsed removes the parts of a joined location which have collapsed
+// in between bases by slicing, marking the outermost remaining parts as
+// partial if the parts outside of them were removed.
+func trimCollapsed(loc Location) Location {
+	switch v := loc.(type) {
+	case Complemented:
+		return Complemented{trimCollapsed(v.Location)}
+
+	case Joined:
+		locs := []Location{}
+		partial5, partial3 := false, false
+		for _, l := range v {
+			if _, ok := l.(Between); ok {
+				if len(locs) == 0 {
+					partial5 = true
+				} else {
+					partial3 = true
+				}
+				continue
+			}
+			locs = append(locs, l)
+			partial3 = false
+		}
+		if len(locs) == 0 {
+			return loc
+		}
+		if r, ok := locs[0].(Ranged); ok && partial5 {
+			r.Partial.Partial5 = true
+			locs[0] = r
+		}
+		if r, ok := locs[len(locs)-1].(Ranged); ok && partial3 {
+			r.Partial.Partial3 = true
+			locs[len(locs)-1] = r
+		}
+		return Join(locs...)
+
+	default:
+		return loc
+	}
 }
 
 // Slice returns a subsequence of the given sequence starting at start and up
@@ -256,6 +386,8 @@ func Slice(seq Sequence, start, end int) Sequence {
 		end += seqlen
 	}
 
+	start, end = Min(Max(start, 0), seqlen), Min(Max(end, 0), seqlen)
+
 	if end < start {
 		length := seqlen - start + end
 		seq = Rotate(seq, -start)
@@ -268,57 +400,109 @@ func Slice(seq Sequence, start, end int) Sequence {
 	ff := seq.Features().Filter(Overlap(start, end))
 
 	for i, f := range ff {
-		loc := f.Loc.Expand(end, end-seqlen).Expand(0, -start)
+		ff[i] = f.mapLocation(func(loc Location) Location {
+			return trimCollapsed(loc.Expand(end, end-seqlen).Expand(0, -start))
+		})
 		if f.Key == "source" {
-			loc = asComplete(loc)
+			ff[i].Loc = asComplete(ff[i].Loc)
 		}
-		ff[i].Loc = loc
 	}
 
 	p := make([]byte, end-start)
 	copy(p, seq.Bytes()[start:end])
 
+	orig := seq
 	seq = WithInfo(seq, info)
 	seq = WithFeatures(seq, ff)
 	seq = WithBytes(seq, p)
 	seq = WithTopology(seq, Linear)
 
-	return seq
+	return mapQualityScores(seq, orig, func(q []byte) []byte {
+		return q[start:end]
+	})
+}
+
+// Joiner concatenates Sequences added one at a time, so that the Sequences
+// need not be held in memory all at once. The zero value is ready to use.
+type Joiner struct {
+	head Sequence
+	n    int
+	ff   FeatureSlice
+	p    []byte
+	q    []byte
+}
+
+// Add appends the given Sequence to the joined Sequence. The features of the
+// Sequence are shifted to the position where the Sequence is appended.
+func (j *Joiner) Add(seq Sequence) {
+	j.n++
+	if j.head == nil {
+		j.head = seq
+		j.ff, j.p = seq.Features(), seq.Bytes()
+		j.q = append([]byte{}, QualityScores(seq)...)
+		return
+	}
+
+	// The quality scores are kept only if all of the sequences have them.
+	if j.q != nil {
+		if QualityScores(seq) == nil {
+			j.q = nil
+		} else {
+			j.q = append(j.q, QualityScores(seq)...)
+		}
+	}
+
+	for _, f := range seq.Features() {
+		n := len(j.p)
+		f = f.mapLocation(func(loc Location) Location {
+			return loc.Expand(0, n)
+		})
+		j.ff = j.ff.Insert(f)
+	}
+	j.p = append(j.p, seq.Bytes()...)
 }
 
-// Concat takes the given Sequences and concatenates them into a single
-// Sequence.
-func Concat(ss ...Sequence) Sequence {
-	switch len(ss) {
+// Sequence returns the Sequence joined so far. The metadata is inherited
+// from the first Sequence added.
+func (j *Joiner) Sequence() Sequence {
+	switch j.n {
 	case 0:
 		return New(nil, nil, nil)
 	case 1:
-		return ss[0]
+		return j.head
 	default:
-		head, tail := ss[0], ss[1:]
-		ff, p := head.Features(), head.Bytes()
+		seq := WithFeatures(j.head, j.ff)
+		seq = WithBytes(seq, j.p)
 
-		for _, seq := range tail {
-			for _, f := range seq.Features() {
-				f.Loc = f.Loc.Expand(0, len(p))
-				ff = ff.Insert(f)
-			}
-			p = append(p, seq.Bytes()...)
+		if j.q == nil {
+			return seq
 		}
+		return mapQualityScores(seq, j.head, func([]byte) []byte {
+			return j.q
+		})
+	}
+}
 
-		head = WithFeatures(head, ff)
-		head = WithBytes(head, p)
-
-		return head
+// Concat takes the given Sequences and concatenates them into a single
+// Sequence.
+func Concat(ss ...Sequence) Sequence {
+	j := Joiner{}
+	for _, seq := range ss {
+		j.Add(seq)
 	}
+	return j.Sequence()
 }
 
 // Reverse returns a Sequence object with the byte representation in the
 // reversed order. The feature locations will be reversed accordingly.
 func Reverse(seq Sequence) Sequence {
+	orig := seq
 	var ff FeatureSlice
 	for _, f := range seq.Features() {
-		ff = ff.Insert(Feature{f.Key, f.Loc.Reverse(Len(seq)), f.Props.Clone()})
+		f = Feature{f.Key, f.Loc, f.Props.Clone()}
+		ff = ff.Insert(f.mapLocation(func(loc Location) Location {
+			return loc.Reverse(Len(seq))
+		}))
 	}
 	seq = WithFeatures(seq, ff)
 
@@ -327,7 +511,10 @@ func Reverse(seq Sequence) Sequence {
 	flip.Bytes(p)
 	seq = WithBytes(seq, p)
 
-	return seq
+	return mapQualityScores(seq, orig, func(q []byte) []byte {
+		flip.Bytes(q)
+		return q
+	})
 }
 
 // Rotate returns a Sequence object whose coordinates are shifted by the given
@@ -341,7 +528,9 @@ func Rotate(seq Sequence, n int) Sequence {
 
 	var ff FeatureSlice
 	for _, f := range seq.Features() {
-		f.Loc = f.Loc.Expand(0, n).Normalize(Len(seq))
+		f = f.mapLocation(func(loc Location) Location {
+			return loc.Expand(0, n).Normalize(Len(seq))
+		})
 		ff = ff.Insert(f)
 	}
 
@@ -349,10 +538,13 @@ func Rotate(seq Sequence, n int) Sequence {
 	p := seq.Bytes()
 	p = append(p[m:], p[:m]...)
 
+	orig := seq
 	seq = WithFeatures(seq, ff)
 	seq = WithBytes(seq, p)
 
-	return seq
+	return mapQualityScores(seq, orig, func(q []byte) []byte {
+		return append(q[m:], q[:m]...)
+	})
 }
 
 func bytesIndexAll(s, sep []byte) []int {
@@ -290,6 +290,50 @@ func TestSlice(t *testing.T) {
 			t.Errorf("Slice(in, %d, %d).Bytes() = %v, want %v", -6, -2, out.Bytes(), exp.Bytes())
 		}
 	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		gg := []Feature{
+			NewFeature("source", Range(0, 6), props),
+			NewFeature("gene", Range(1, 3), props),
+		}
+		out, exp := Slice(in, 2, 20), New(info, gg, p[2:])
+		if !reflect.DeepEqual(out.Info(), exp.Info()) {
+			t.Errorf("Slice(in, %d, %d).Info() = %v, want %v", 2, 20, out.Info(), exp.Info())
+		}
+		if !featuresEqual(out.Features(), exp.Features()) {
+			t.Errorf("Slice(in, %d, %d).Features() = %v, want %v", 2, 20, out.Features(), exp.Features())
+		}
+		if !bytesEqual(out.Bytes(), exp.Bytes()) {
+			t.Errorf("Slice(in, %d, %d).Bytes() = %v, want %v", 2, 20, out.Bytes(), exp.Bytes())
+		}
+	})
+}
+
+func TestSliceOrigin(t *testing.T) {
+	p := []byte("atgcatgc")
+	ff := []Feature{
+		NewFeature("gene", Join(Range(6, 8), Range(0, 2)), Props{}),
+		NewFeature("gene", Join(Range(6, 8), Range(0, 2)).Complement(), Props{}),
+	}
+	in := New(nil, ff, p)
+
+	gg := []Feature{
+		NewFeature("gene", PartialRange(0, 2, Partial5), Props{}),
+		NewFeature("gene", PartialRange(0, 2, Partial5).Complement(), Props{}),
+	}
+	out, exp := Slice(in, 0, 4), New(nil, gg, p[:4])
+	if !featuresEqual(out.Features(), exp.Features()) {
+		t.Errorf("Slice(in, %d, %d).Features() = %v, want %v", 0, 4, out.Features(), exp.Features())
+	}
+
+	gg = []Feature{
+		NewFeature("gene", PartialRange(2, 4, Partial3), Props{}),
+		NewFeature("gene", PartialRange(2, 4, Partial3).Complement(), Props{}),
+	}
+	out, exp = Slice(in, 4, 8), New(nil, gg, p[4:])
+	if !featuresEqual(out.Features(), exp.Features()) {
+		t.Errorf("Slice(in, %d, %d).Features() = %v, want %v", 4, 8, out.Features(), exp.Features())
+	}
 }
 
 func TestConcat(t *testing.T) {
@@ -392,6 +436,32 @@ func TestRotate(t *testing.T) {
 	}
 }
 
+func TestRotateOrigin(t *testing.T) {
+	p, q := []byte("aattggcc"), []byte("ccaattgg")
+	ff := []Feature{
+		NewFeature("gene", Join(Range(6, 8), Range(0, 2)), Props{}),
+		NewFeature("gene", Join(Range(6, 8), Range(0, 2)).Complement(), Props{}),
+	}
+	gg := []Feature{
+		NewFeature("gene", Range(0, 4), Props{}),
+		NewFeature("gene", Range(0, 4).Complement(), Props{}),
+	}
+
+	in, exp := New(nil, ff, p), New(nil, gg, q)
+	out := Rotate(in, 2)
+	if !featuresEqual(out.Features(), exp.Features()) {
+		t.Errorf("Rotate(in, 2).Features() = %v, want %v", out.Features(), exp.Features())
+	}
+	if !bytesEqual(out.Bytes(), exp.Bytes()) {
+		t.Errorf("Rotate(in, 2).Bytes() = %v, want %v", out.Bytes(), exp.Bytes())
+	}
+
+	out = Rotate(out, -2)
+	if !featuresEqual(out.Features(), in.Features()) {
+		t.Errorf("Rotate(Rotate(in, 2), -2).Features() = %v, want %v", out.Features(), in.Features())
+	}
+}
+
 func TestSeqWith(t *testing.T) {
 	p := []byte(strings.Repeat("atgc", 100))
 	props := Props{}
@@ -482,3 +552,48 @@ func TestSearch(t *testing.T) {
 		}
 	}
 }
+
+func TestDeterministic(t *testing.T) {
+	props := Props{}
+	props.Add("note", "foo")
+	props.Add("gene", "bar")
+	ff := []Feature{
+		{"gene", Range(3, 6), props},
+		{"CDS", Range(0, 3), Props{}},
+		{"gene", Range(0, 3), Props{}},
+		{"source", Range(0, 6), Props{}},
+	}
+	seq := Deterministic(New(nil, ff, []byte("atgatg")))
+	out := seq.Features()
+	testutils.Equals(t, out[0].Key, "source")
+	testutils.Equals(t, out[1].Key, "gene")
+	testutils.Equals(t, out[1].Loc, Location(Range(0, 3)))
+	testutils.Equals(t, out[2].Key, "CDS")
+	testutils.Equals(t, out[3].Props.Keys(), []string{"gene", "note"})
+	testutils.Equals(t, ff[0].Props.Keys(), []string{"note", "gene"})
+}
+
+func TestJoiner(t *testing.T) {
+	j := Joiner{}
+	if out, exp := j.Sequence(), New(nil, nil, nil); !Equal(out, exp) {
+		t.Errorf("j.Sequence() = %v, want %v", out, exp)
+	}
+
+	p := []byte("atgcatgc")
+	f := NewFeature("gene", Range(2, 4), Props{})
+	seqs := []Sequence{
+		New("info", []Feature{f}, p),
+		New(nil, []Feature{f}, []byte("aaaa")),
+		New(nil, nil, p),
+	}
+	for _, seq := range seqs {
+		j.Add(seq)
+	}
+
+	out, exp := j.Sequence(), Concat(seqs...)
+	if !Equal(out, exp) {
+		t.Errorf("j.Sequence() = %v, want %v", out, exp)
+	}
+	testutils.Equals(t, string(out.Bytes()), "atgcatgcaaaaatgcatgc")
+	testutils.Equals(t, len(out.Features()), 2)
+}
@@ -0,0 +1,156 @@
+package gts
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Sketch represents a MinHash sketch of the k-mers contained in a sequence.
+// Only the smallest hash values (bottom-s) of the canonical k-mers are kept,
+// enabling rapid estimation of the Jaccard index between two sequences
+// without the need for alignment.
+type Sketch struct {
+	K      int      `json:"k"`
+	Size   int      `json:"size"`
+	Hashes []uint64 `json:"hashes"`
+}
+
+var canonicalComplement = [256]byte{
+	'a': 't', 'c': 'g', 'g': 'c', 't': 'a',
+	'A': 't', 'C': 'g', 'G': 'c', 'T': 'a',
+}
+
+func isCanonicalBase(c byte) bool {
+	return canonicalComplement[c] != 0
+}
+
+// forEachCanonicalKmer calls fn with the starting position and the canonical
+// form of each k-mer in p, skipping k-mers containing bases other than A, C,
+// G, or T. The slice passed to fn is reused between calls.
+func forEachCanonicalKmer(p []byte, k int, fn func(i int, q []byte)) {
+	if k <= 0 {
+		return
+	}
+
+	fwd, rev := make([]byte, k), make([]byte, k)
+
+	run := 0
+	for i := range p {
+		if !isCanonicalBase(p[i]) {
+			run = 0
+			continue
+		}
+		run++
+		if run < k {
+			continue
+		}
+
+		kmer := p[i+1-k : i+1]
+		for j, c := range kmer {
+			fwd[j] = canonicalComplement[canonicalComplement[c]]
+			rev[k-1-j] = canonicalComplement[c]
+		}
+
+		q := fwd
+		if string(rev) < string(fwd) {
+			q = rev
+		}
+		fn(i+1-k, q)
+	}
+}
+
+func hashKmer(p []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(p)
+	return h.Sum64()
+}
+
+// NewSketch computes the MinHash sketch for the given sequence with k-mers of
+// length k, keeping at most size hash values. Each k-mer is hashed in its
+// canonical form (the lexicographically smaller of itself and its reverse
+// complement) so that the sketch is independent of strand. K-mers containing
+// bases other than A, C, G, or T are skipped.
+func NewSketch(seq Sequence, k, size int) Sketch {
+	set := make(map[uint64]struct{})
+	forEachCanonicalKmer(seq.Bytes(), k, func(i int, q []byte) {
+		set[hashKmer(q)] = struct{}{}
+	})
+
+	hashes := make([]uint64, 0, len(set))
+	for h := range set {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+	if len(hashes) > size {
+		hashes = hashes[:size]
+	}
+
+	return Sketch{k, size, hashes}
+}
+
+// Compatible tests if the two sketches can be compared with each other.
+func (s Sketch) Compatible(t Sketch) error {
+	if s.K != t.K {
+		return errors.New("sketches have different k-mer sizes")
+	}
+	return nil
+}
+
+// Shared returns the number of hash values shared between the two sketches
+// within the bottom-s union of both sketches, and the size of the union.
+func (s Sketch) Shared(t Sketch) (int, int) {
+	size := Min(s.Size, t.Size)
+	shared, total := 0, 0
+	i, j := 0, 0
+	for total < size && i < len(s.Hashes) && j < len(t.Hashes) {
+		switch a, b := s.Hashes[i], t.Hashes[j]; {
+		case a < b:
+			i++
+		case b < a:
+			j++
+		default:
+			shared++
+			i++
+			j++
+		}
+		total++
+	}
+	for total < size && i < len(s.Hashes) {
+		i++
+		total++
+	}
+	for total < size && j < len(t.Hashes) {
+		j++
+		total++
+	}
+	return shared, total
+}
+
+// Jaccard estimates the Jaccard index between the k-mer sets represented by
+// the two sketches.
+func (s Sketch) Jaccard(t Sketch) float64 {
+	shared, total := s.Shared(t)
+	if total == 0 {
+		return 0
+	}
+	return float64(shared) / float64(total)
+}
+
+// Distance estimates the Mash distance between the sequences represented by
+// the two sketches. The Mash distance approximates the per-base mutation rate
+// between two sequences assuming a Poisson model of mutation.
+func (s Sketch) Distance(t Sketch) float64 {
+	return MashDistance(s.Jaccard(t), s.K)
+}
+
+// MashDistance converts a Jaccard index into the Mash distance for k-mers of
+// length k. A Jaccard index of zero results in a distance of one.
+func MashDistance(j float64, k int) float64 {
+	if j <= 0 {
+		return 1
+	}
+	d := -math.Log(2*j/(1+j)) / float64(k)
+	return math.Min(1, math.Max(0, d))
+}
@@ -0,0 +1,86 @@
+package gts
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSketchSelf(t *testing.T) {
+	seq := New(nil, nil, []byte("atgcgtacgttagcatgcatgcgatcgatcgtagctagctagcatcga"))
+	s := NewSketch(seq, 5, 100)
+	if len(s.Hashes) == 0 {
+		t.Fatal("NewSketch(seq, 5, 100) returned an empty sketch")
+	}
+	if j := s.Jaccard(s); j != 1 {
+		t.Errorf("s.Jaccard(s) = %f, want 1", j)
+	}
+	if d := s.Distance(s); d != 0 {
+		t.Errorf("s.Distance(s) = %f, want 0", d)
+	}
+}
+
+func TestSketchStrand(t *testing.T) {
+	seq := New(nil, nil, []byte("atgcgtacgttagcatgcatgcgatcgatcgtagctagctagcatcga"))
+	cmp := Reverse(Complement(seq))
+	s, u := NewSketch(seq, 7, 100), NewSketch(cmp, 7, 100)
+	if j := s.Jaccard(u); j != 1 {
+		t.Errorf("s.Jaccard(u) = %f, want 1", j)
+	}
+}
+
+func TestSketchCase(t *testing.T) {
+	s := NewSketch(New(nil, nil, []byte("ATGCGTACGTTAGCATGCA")), 5, 100)
+	u := NewSketch(New(nil, nil, []byte("atgcgtacgttagcatgca")), 5, 100)
+	if j := s.Jaccard(u); j != 1 {
+		t.Errorf("s.Jaccard(u) = %f, want 1", j)
+	}
+}
+
+func TestSketchSkipAmbiguous(t *testing.T) {
+	s := NewSketch(New(nil, nil, []byte("atgcnatgc")), 5, 100)
+	if len(s.Hashes) != 0 {
+		t.Errorf("len(s.Hashes) = %d, want 0", len(s.Hashes))
+	}
+}
+
+func TestSketchSize(t *testing.T) {
+	seq := New(nil, nil, []byte("atgcgtacgttagcatgcatgcgatcgatcgtagctagctagcatcga"))
+	s := NewSketch(seq, 5, 10)
+	if len(s.Hashes) != 10 {
+		t.Errorf("len(s.Hashes) = %d, want 10", len(s.Hashes))
+	}
+	for i := 1; i < len(s.Hashes); i++ {
+		if s.Hashes[i] <= s.Hashes[i-1] {
+			t.Errorf("s.Hashes is not sorted: %v", s.Hashes)
+		}
+	}
+}
+
+func TestSketchDisjoint(t *testing.T) {
+	s := NewSketch(New(nil, nil, []byte("aaaaaaaaaa")), 5, 100)
+	u := NewSketch(New(nil, nil, []byte("acacacacac")), 5, 100)
+	if j := s.Jaccard(u); j != 0 {
+		t.Errorf("s.Jaccard(u) = %f, want 0", j)
+	}
+	if d := s.Distance(u); d != 1 {
+		t.Errorf("s.Distance(u) = %f, want 1", d)
+	}
+}
+
+func TestSketchCompatible(t *testing.T) {
+	seq := New(nil, nil, []byte("atgcgtacgttagcatgca"))
+	if err := NewSketch(seq, 5, 10).Compatible(NewSketch(seq, 5, 20)); err != nil {
+		t.Errorf("Compatible returned %v, want nil", err)
+	}
+	if err := NewSketch(seq, 5, 10).Compatible(NewSketch(seq, 7, 10)); err == nil {
+		t.Error("Compatible returned nil, want error")
+	}
+}
+
+func TestMashDistance(t *testing.T) {
+	j, k := 0.5, 21
+	exp := -math.Log(2*j/(1+j)) / float64(k)
+	if out := MashDistance(j, k); math.Abs(out-exp) > 1e-12 {
+		t.Errorf("MashDistance(%f, %d) = %f, want %f", j, k, out, exp)
+	}
+}
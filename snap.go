@@ -0,0 +1,238 @@
+package gts
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ExtendLocation returns the location with the 5' and 3' ends, in the
+// orientation of the location, extended by the given number of bases. Only
+// ranges, points, and complemented or joined locations thereof are extended
+// and any other locations are returned as is.
+func ExtendLocation(loc Location, n5, n3 int) Location {
+	switch v := loc.(type) {
+	case Ranged:
+		return PartialRange(v.Start-n5, v.End+n3, v.Partial)
+	case Point:
+		if n5 == 0 && n3 == 0 {
+			return v
+		}
+		return Range(int(v)-n5, int(v)+1+n3)
+	case Complemented:
+		return ExtendLocation(v.Location, n3, n5).Complement()
+	case Joined:
+		if len(v) == 0 {
+			return v
+		}
+		ll := append(Joined{}, v...)
+		ll[0] = ExtendLocation(ll[0], n5, 0)
+		ll[len(ll)-1] = ExtendLocation(ll[len(ll)-1], 0, n3)
+		return ll
+	default:
+		return loc
+	}
+}
+
+// flankingBases returns the number of bases available upstream and
+// downstream of the location in the given length sequence.
+func flankingBases(loc Location, length int) (int, int) {
+	r := loc.Region()
+	if loc.Strand() == StrandReverse {
+		return length - r.Head(), r.Tail()
+	}
+	return r.Head(), length - r.Tail()
+}
+
+// SnapStop returns the location of the CDS feature with the 3' end extended
+// to the nearest in-frame stop codon within the given number of bases
+// downstream, and whether the location was changed. Features which already
+// end with a stop codon, have a partial 3' end, or refer to a remote entry are
+// left unchanged.
+func SnapStop(f Feature, seq Sequence, table CodonTable, limit int) (Location, bool) {
+	if LocationPartial(f.Loc).Partial3 || HasRemote(f.Loc.Region()) {
+		return f.Loc, false
+	}
+
+	p := f.Loc.Region().Locate(seq).Bytes()
+	offset := CodonStart(f)
+	if offset > len(p) {
+		return f.Loc, false
+	}
+
+	r := (len(p) - offset) % 3
+	if r == 0 && len(p)-offset >= 3 && table.IsStop(p[len(p)-3:]) {
+		return f.Loc, false
+	}
+
+	_, n := flankingBases(f.Loc, Len(seq))
+	n = Min(n, limit)
+	q := ExtendLocation(f.Loc, 0, n).Region().Locate(seq).Bytes()
+
+	for i := len(p) - r; i+3 <= len(q); i += 3 {
+		if table.IsStop(q[i : i+3]) {
+			return ExtendLocation(f.Loc, 0, i+3-len(p)), true
+		}
+	}
+
+	return f.Loc, false
+}
+
+// SnapStart returns the location of the CDS feature with the 5' end moved to
+// the nearest in-frame start codon within the given number of bases, and
+// whether the location was changed. The search in either direction stops at
+// an in-frame stop codon, and the upstream start codon is preferred if both
+// are equally distant. Features which already begin with a start codon, have
+// a partial 5' end, have a /codon_start other than 1, or refer to a remote
+// entry are left unchanged.
+func SnapStart(f Feature, seq Sequence, table CodonTable, window int) (Location, bool) {
+	if CodonStart(f) != 0 || LocationPartial(f.Loc).Partial5 || HasRemote(f.Loc.Region()) {
+		return f.Loc, false
+	}
+
+	p := f.Loc.Region().Locate(seq).Bytes()
+	if len(p) < 3 || table.IsStart(p[:3]) {
+		return f.Loc, false
+	}
+
+	n, _ := flankingBases(f.Loc, Len(seq))
+	n = Min(n, window)
+	n -= n % 3
+	q := ExtendLocation(f.Loc, n, 0).Region().Locate(seq).Bytes()
+
+	up := 0
+	for d := 3; d <= n; d += 3 {
+		codon := q[n-d : n-d+3]
+		if table.IsStop(codon) {
+			break
+		}
+		if table.IsStart(codon) {
+			up = d
+			break
+		}
+	}
+
+	down := 0
+	for d := 3; d <= window && d+3 <= len(p); d += 3 {
+		codon := p[d : d+3]
+		if table.IsStop(codon) {
+			break
+		}
+		if table.IsStart(codon) {
+			down = d
+			break
+		}
+	}
+
+	switch {
+	case up > 0 && (down == 0 || up <= down):
+		return ExtendLocation(f.Loc, up, 0), true
+	case down > 0:
+		return MapLocation(f.Loc, down, f.Loc.Len()), true
+	default:
+		return f.Loc, false
+	}
+}
+
+// ShineDalgarno is the consensus Shine-Dalgarno sequence used by RBSScore.
+const ShineDalgarno = "AGGAGG"
+
+// The ribosome binding site of a start codon is searched within the bases
+// from rbsUpstream to rbsSpacer bases upstream of the start codon.
+const (
+	rbsUpstream = 20
+	rbsSpacer   = 4
+)
+
+// RBSScore returns the length of the longest substring of the Shine-Dalgarno
+// consensus sequence found in the given nucleotide sequence. The comparison
+// is case insensitive and U is regarded as T.
+func RBSScore(p []byte) int {
+	q := bytes.ToUpper(p)
+	q = bytes.ReplaceAll(q, []byte("U"), []byte("T"))
+	for n := len(ShineDalgarno); n > 0; n-- {
+		for i := 0; i+n <= len(ShineDalgarno); i++ {
+			if bytes.Contains(q, []byte(ShineDalgarno[i:i+n])) {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// StartCandidate represents a candidate start codon of a CDS feature. Shift is
+// the change in the length of the feature if the start codon is chosen:
+// positive for upstream start codons and negative for downstream ones. RBS is
+// the RBSScore of the region upstream of the start codon.
+type StartCandidate struct {
+	Loc   Location
+	Codon string
+	Shift int
+	RBS   int
+}
+
+// StartCandidates returns the in-frame start codons of the CDS feature within
+// the given number of bases, ordered from upstream to downstream. The search
+// in either direction stops at an in-frame stop codon, and the annotated
+// start codon is included if it is a start codon. The RBS score of each
+// candidate is computed from the bases 20 to 4 bases upstream of the start
+// codon. Features which have a partial 5' end, have a /codon_start other
+// than 1, or refer to a remote entry have no candidates.
+func StartCandidates(f Feature, seq Sequence, table CodonTable, window int) []StartCandidate {
+	if CodonStart(f) != 0 || LocationPartial(f.Loc).Partial5 || HasRemote(f.Loc.Region()) {
+		return nil
+	}
+
+	p := f.Loc.Region().Locate(seq).Bytes()
+	if len(p) < 3 {
+		return nil
+	}
+
+	flank, _ := flankingBases(f.Loc, Len(seq))
+	n := Min(flank, window)
+	n -= n % 3
+	m := Min(flank, n+rbsUpstream)
+	q := ExtendLocation(f.Loc, m, 0).Region().Locate(seq).Bytes()
+
+	candidate := func(loc Location, shift int) StartCandidate {
+		i := m - shift
+		head, tail := Max(0, i-rbsUpstream), Max(0, i-rbsSpacer)
+		return StartCandidate{
+			Loc:   loc,
+			Codon: strings.ToUpper(string(q[i : i+3])),
+			Shift: shift,
+			RBS:   RBSScore(q[head:tail]),
+		}
+	}
+
+	upstream := []StartCandidate{}
+	for d := 3; d <= n; d += 3 {
+		codon := q[m-d : m-d+3]
+		if table.IsStop(codon) {
+			break
+		}
+		if table.IsStart(codon) {
+			upstream = append(upstream, candidate(ExtendLocation(f.Loc, d, 0), d))
+		}
+	}
+
+	cc := make([]StartCandidate, 0, len(upstream)+1)
+	for i := len(upstream) - 1; i >= 0; i-- {
+		cc = append(cc, upstream[i])
+	}
+
+	if table.IsStart(p[:3]) {
+		cc = append(cc, candidate(f.Loc, 0))
+	}
+
+	for d := 3; d <= window && d+3 <= len(p); d += 3 {
+		codon := p[d : d+3]
+		if table.IsStop(codon) {
+			break
+		}
+		if table.IsStart(codon) {
+			cc = append(cc, candidate(MapLocation(f.Loc, d, f.Loc.Len()), -d))
+		}
+	}
+
+	return cc
+}
@@ -0,0 +1,138 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+var extendLocationTests = []struct {
+	in     Location
+	n5, n3 int
+	out    Location
+}{
+	{Range(10, 20), 3, 6, Range(7, 26)},
+	{PartialRange(10, 20, Partial3), 3, 0, PartialRange(7, 20, Partial3)},
+	{Range(10, 20).Complement(), 3, 6, Range(4, 23).Complement()},
+	{Point(10), 0, 0, Point(10)},
+	{Point(10), 1, 2, Range(9, 13)},
+	{Join(Range(10, 20), Range(30, 40)), 3, 6, Join(Range(7, 20), Range(30, 46))},
+	{Join(Range(30, 40).Complement(), Range(10, 20).Complement()), 3, 6, Join(Range(30, 43).Complement(), Range(4, 20).Complement())},
+	{Between(10), 3, 6, Between(10)},
+}
+
+func TestExtendLocation(t *testing.T) {
+	for _, tt := range extendLocationTests {
+		out := ExtendLocation(tt.in, tt.n5, tt.n3)
+		testutils.Equals(t, out.String(), tt.out.String())
+	}
+}
+
+func TestSnap(t *testing.T) {
+	table := CodonTables[1].WithStarts("ATG")
+	testutils.Equals(t, table.IsStart([]byte("ctg")), false)
+	testutils.Equals(t, table.IsStart([]byte("aug")), true)
+
+	fwd := New(nil, nil, []byte("cccatgaaactgaaaaaataaccc"))
+	rev := Reverse(Complement(fwd))
+
+	tests := []struct {
+		seq   Sequence
+		loc   Location
+		start Location
+		stop  Location
+	}{
+		{fwd, Range(9, 18), Range(3, 18), Range(9, 21)},
+		{rev, Range(6, 15).Complement(), Range(6, 21).Complement(), Range(3, 15).Complement()},
+		{fwd, Range(0, 18), Range(3, 18), Range(0, 21)},
+		{fwd, Range(3, 21), Range(3, 21), Range(3, 21)},
+		{fwd, PartialRange(9, 18, PartialBoth), PartialRange(9, 18, PartialBoth), PartialRange(9, 18, PartialBoth)},
+		{fwd, Range(9, 16), Range(3, 16), Range(9, 21)},
+	}
+
+	for _, tt := range tests {
+		f := NewFeature("CDS", tt.loc, Props{})
+
+		loc, ok := SnapStart(f, tt.seq, table, 6)
+		testutils.Equals(t, loc.String(), tt.start.String())
+		testutils.Equals(t, ok, tt.loc.String() != tt.start.String())
+
+		loc, ok = SnapStop(f, tt.seq, table, 6)
+		testutils.Equals(t, loc.String(), tt.stop.String())
+		testutils.Equals(t, ok, tt.loc.String() != tt.stop.String())
+	}
+
+	blocked := New(nil, nil, []byte("atgtaaaaaaaaaaataa"))
+	f := NewFeature("CDS", Range(6, 18), Props{})
+	loc, ok := SnapStart(f, blocked, table, 6)
+	testutils.Equals(t, loc.String(), Range(6, 18).String())
+	testutils.Equals(t, ok, false)
+
+	f = NewFeature("CDS", Range(9, 18), Props{})
+	loc, ok = SnapStop(f, fwd, table, 2)
+	testutils.Equals(t, loc.String(), Range(9, 18).String())
+	testutils.Equals(t, ok, false)
+}
+
+func TestRBSScore(t *testing.T) {
+	tests := []struct {
+		in  string
+		out int
+	}{
+		{"", 0},
+		{"ccc", 0},
+		{"aggagg", 6},
+		{"ttGGAGtt", 4},
+		{"uaggu", 3},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, RBSScore([]byte(tt.in)), tt.out)
+	}
+}
+
+func TestStartCandidates(t *testing.T) {
+	table := CodonTables[1].WithStarts("ATG")
+
+	fwd := New(nil, nil, []byte("cccaggaggcccatgaaaatgaaaatgaaataa"))
+	rev := Reverse(Complement(fwd))
+
+	type candidate struct {
+		loc   string
+		codon string
+		shift int
+		rbs   int
+	}
+
+	tests := []struct {
+		seq Sequence
+		loc Location
+		out []candidate
+	}{
+		{fwd, Range(18, 33), []candidate{
+			{Range(12, 33).String(), "ATG", 6, 5},
+			{Range(18, 33).String(), "ATG", 0, 6},
+			{Range(24, 33).String(), "ATG", -6, 5},
+		}},
+		{rev, Range(0, 15).Complement(), []candidate{
+			{Range(0, 21).Complement().String(), "ATG", 6, 5},
+			{Range(0, 15).Complement().String(), "ATG", 0, 6},
+			{Range(0, 9).Complement().String(), "ATG", -6, 5},
+		}},
+		{fwd, Range(15, 33), []candidate{
+			{Range(12, 33).String(), "ATG", 3, 5},
+			{Range(18, 33).String(), "ATG", -3, 6},
+			{Range(24, 33).String(), "ATG", -9, 5},
+		}},
+		{fwd, PartialRange(18, 33, Partial5), []candidate{}},
+	}
+
+	for _, tt := range tests {
+		f := NewFeature("CDS", tt.loc, Props{})
+		out := []candidate{}
+		for _, c := range StartCandidates(f, tt.seq, table, 9) {
+			out = append(out, candidate{c.Loc.String(), c.Codon, c.Shift, c.RBS})
+		}
+		testutils.Equals(t, out, tt.out)
+	}
+}
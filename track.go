@@ -0,0 +1,57 @@
+package gts
+
+// SlidingWindows returns the windows of the given size starting at every
+// multiple of step within a sequence of length n. The last window is
+// truncated to fit the sequence.
+func SlidingWindows(n, window, step int) []Segment {
+	segments := []Segment{}
+	if window <= 0 || step <= 0 {
+		return segments
+	}
+	for start := 0; start < n; start += step {
+		segments = append(segments, Segment{start, Min(start+window, n)})
+	}
+	return segments
+}
+
+// FeatureDepth returns the number of the given features covering each base
+// of a sequence of length n. Overlapping segments within a single feature are
+// only counted once.
+func FeatureDepth(ff FeatureSlice, n int) []int {
+	diff := make([]int, n+1)
+	for _, f := range ff {
+		for _, segment := range Minimize(f.Loc.Region()) {
+			start, end := Max(0, segment[0]), Min(n, segment[1])
+			if start < end {
+				diff[start]++
+				diff[end]--
+			}
+		}
+	}
+
+	depth := make([]int, n)
+	sum := 0
+	for i := range depth {
+		sum += diff[i]
+		depth[i] = sum
+	}
+	return depth
+}
+
+// FeatureCount returns the number of the given features overlapping each of
+// the given windows.
+func FeatureCount(ff FeatureSlice, windows []Segment) []int {
+	counts := make([]int, len(windows))
+	for _, f := range ff {
+		segments := Minimize(f.Loc.Region())
+		for i, window := range windows {
+			for _, segment := range segments {
+				if segment[0] < window[1] && window[0] < segment[1] {
+					counts[i]++
+					break
+				}
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,25 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestSlidingWindows(t *testing.T) {
+	testutils.Equals(t, SlidingWindows(10, 4, 4), []Segment{{0, 4}, {4, 8}, {8, 10}})
+	testutils.Equals(t, SlidingWindows(6, 4, 2), []Segment{{0, 4}, {2, 6}, {4, 6}})
+	testutils.Equals(t, SlidingWindows(0, 4, 4), []Segment{})
+	testutils.Equals(t, SlidingWindows(10, 0, 4), []Segment{})
+}
+
+func TestFeatureDepth(t *testing.T) {
+	ff := FeatureSlice{
+		NewFeature("CDS", Range(1, 4), Props{}),
+		NewFeature("CDS", Range(3, 6).Complement(), Props{}),
+		NewFeature("CDS", Join(Range(0, 2), Range(1, 3)), Props{}),
+	}
+	testutils.Equals(t, FeatureDepth(ff, 8), []int{1, 2, 2, 2, 1, 1, 0, 0})
+	testutils.Equals(t, FeatureDepth(nil, 3), []int{0, 0, 0})
+	testutils.Equals(t, FeatureCount(ff, []Segment{{0, 2}, {2, 4}, {6, 8}}), []int{2, 3, 0})
+}
@@ -0,0 +1,305 @@
+package gts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FeatureEffect summarizes the difference between the feature tables of a
+// sequence before and after an edit.
+type FeatureEffect struct {
+	Added     int // Features which did not exist before the edit.
+	Removed   int // Features which no longer exist after the edit.
+	Relocated int // Features whose location was changed by the edit.
+	Modified  int // Features whose qualifiers were changed by the edit.
+}
+
+func featureSignature(f Feature, loc, props bool) string {
+	b := strings.Builder{}
+	b.WriteString(f.Key)
+	if loc {
+		b.WriteByte(0)
+		b.WriteString(f.Loc.String())
+	}
+	if props {
+		for _, vv := range f.Props {
+			b.WriteByte(0)
+			b.WriteString(strings.Join(vv, "\x01"))
+		}
+	}
+	return b.String()
+}
+
+// matchFeatures removes the pairs of features from a and b whose signatures
+// are identical and returns the number of pairs.
+func matchFeatures(a, b FeatureSlice, loc, props bool) (FeatureSlice, FeatureSlice, int) {
+	counts := make(map[string]int)
+	for _, f := range a {
+		counts[featureSignature(f, loc, props)]++
+	}
+
+	n, rest := 0, FeatureSlice{}
+	for _, f := range b {
+		sig := featureSignature(f, loc, props)
+		if counts[sig] > 0 {
+			counts[sig]--
+			n++
+		} else {
+			rest = append(rest, f)
+		}
+	}
+
+	left := FeatureSlice{}
+	for _, f := range a {
+		sig := featureSignature(f, loc, props)
+		if counts[sig] > 0 {
+			counts[sig]--
+			left = append(left, f)
+		}
+	}
+
+	return left, rest, n
+}
+
+// DiffFeatures computes the FeatureEffect of changing the feature table from
+// the features before to the features after. A feature is regarded as
+// modified if a feature with the same key and location exists on both sides,
+// and as relocated if a feature with the same key and qualifiers exists on
+// both sides.
+func DiffFeatures(before, after FeatureSlice) FeatureEffect {
+	before, after, _ = matchFeatures(before, after, true, true)
+	before, after, modified := matchFeatures(before, after, true, false)
+	before, after, relocated := matchFeatures(before, after, false, true)
+	return FeatureEffect{
+		Added:     len(after),
+		Removed:   len(before),
+		Relocated: relocated,
+		Modified:  modified,
+	}
+}
+
+// String satisfies the fmt.Stringer interface.
+func (fe FeatureEffect) String() string {
+	parts := []string{}
+	for _, part := range []struct {
+		n    int
+		verb string
+	}{
+		{fe.Added, "added"},
+		{fe.Removed, "removed"},
+		{fe.Relocated, "relocated"},
+		{fe.Modified, "modified"},
+	} {
+		if part.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.n, part.verb))
+		}
+	}
+	if len(parts) == 0 {
+		return "no features changed"
+	}
+	return fmt.Sprintf("features: %s", strings.Join(parts, ", "))
+}
+
+// Effect describes the exact effect of an edit applied in a transaction.
+type Effect struct {
+	Operation string // The name of the operation.
+	Offset    int    // The position of the edit in the sequence before the edit.
+	Deleted   int    // The number of bases removed from the sequence.
+	Inserted  int    // The number of bases added to the sequence.
+	Features  FeatureEffect
+}
+
+// String satisfies the fmt.Stringer interface.
+func (e Effect) String() string {
+	var s string
+	switch {
+	case e.Operation == "rotate":
+		s = fmt.Sprintf("rotate by %d bp", e.Offset)
+	case e.Deleted > 0 && e.Inserted > 0:
+		s = fmt.Sprintf("%s %d bp at %d with %d bp", e.Operation, e.Deleted, e.Offset+1, e.Inserted)
+	case e.Deleted > 0:
+		s = fmt.Sprintf("%s %d bp at %d", e.Operation, e.Deleted, e.Offset+1)
+	case e.Inserted > 0:
+		s = fmt.Sprintf("%s %d bp at %d", e.Operation, e.Inserted, e.Offset+1)
+	default:
+		s = e.Operation
+	}
+	return fmt.Sprintf("%s (%s)", s, e.Features)
+}
+
+// txnOp is an operation recorded in a transaction along with the means to
+// revert it. The byte representation is reverted by the inverse operation
+// and the metadata and features are restored as they were.
+type txnOp struct {
+	effect  Effect
+	info    interface{}
+	ff      FeatureSlice
+	inverse func(p []byte) []byte
+}
+
+// Txn is a transaction of edits on a Sequence. Each edit is recorded with
+// its inverse operation, so that the edits may be undone one by one or rolled
+// back altogether, and with its Effect, so that the edits may be previewed
+// before being committed. A Txn is not safe for concurrent use.
+type Txn struct {
+	seq Sequence
+	ops []txnOp
+}
+
+// Begin starts a transaction on the given Sequence.
+func Begin(seq Sequence) *Txn {
+	return &Txn{seq: seq}
+}
+
+// Sequence returns the Sequence with the edits of the transaction applied.
+func (txn *Txn) Sequence() Sequence {
+	return txn.seq
+}
+
+// Len returns the number of edits in the transaction.
+func (txn *Txn) Len() int {
+	return len(txn.ops)
+}
+
+// Effects returns the effects of the edits in the transaction in the order
+// in which they were applied.
+func (txn *Txn) Effects() []Effect {
+	ee := make([]Effect, len(txn.ops))
+	for i, op := range txn.ops {
+		ee[i] = op.effect
+	}
+	return ee
+}
+
+// bytes returns the byte representation of the current sequence without any
+// spare capacity, so that the edits never write into the byte slice shared
+// with the previous states of the sequence.
+func (txn *Txn) bytes() []byte {
+	p := txn.seq.Bytes()
+	return p[:len(p):len(p)]
+}
+
+func (txn *Txn) apply(effect Effect, seq Sequence, inverse func(p []byte) []byte) Effect {
+	effect.Features = DiffFeatures(txn.seq.Features(), seq.Features())
+	txn.ops = append(txn.ops, txnOp{effect, txn.seq.Info(), txn.seq.Features(), inverse})
+	txn.seq = seq
+	return effect
+}
+
+// replaceInverse returns the inverse operation of replacing the given bytes
+// at the offset with n bytes.
+func replaceInverse(offset, n int, removed []byte) func(p []byte) []byte {
+	return func(p []byte) []byte {
+		q := make([]byte, 0, len(p)-n+len(removed))
+		q = append(q, p[:offset]...)
+		q = append(q, removed...)
+		return append(q, p[offset+n:]...)
+	}
+}
+
+func (txn *Txn) checkRange(offset, length int) {
+	if offset < 0 || length < 0 || Len(txn.seq) < offset+length {
+		panic(fmt.Errorf("region [%d:%d] out of range for sequence of length %d", offset, offset+length, Len(txn.seq)))
+	}
+}
+
+// Insert the guest Sequence at the given index as with the Insert function.
+func (txn *Txn) Insert(index int, guest Sequence) Effect {
+	txn.checkRange(index, 0)
+	host := WithBytes(txn.seq, txn.bytes())
+	seq := Insert(host, index, guest)
+	effect := Effect{Operation: "insert", Offset: index, Inserted: Len(guest)}
+	return txn.apply(effect, seq, replaceInverse(index, Len(guest), nil))
+}
+
+// Delete the region at the given offset and length as with the Delete
+// function.
+func (txn *Txn) Delete(offset, length int) Effect {
+	txn.checkRange(offset, length)
+	removed := make([]byte, length)
+	copy(removed, txn.seq.Bytes()[offset:])
+	seq := Delete(txn.seq, offset, length)
+	effect := Effect{Operation: "delete", Offset: offset, Deleted: length}
+	return txn.apply(effect, seq, replaceInverse(offset, 0, removed))
+}
+
+// Replace the region at the given offset and length with the guest Sequence.
+// Any features with a location covering the replaced region will be
+// shortened and then extended to cover the guest sequence as with the Delete
+// and Embed functions.
+func (txn *Txn) Replace(offset, length int, guest Sequence) Effect {
+	txn.checkRange(offset, length)
+	removed := make([]byte, length)
+	copy(removed, txn.seq.Bytes()[offset:])
+	seq := Delete(txn.seq, offset, length)
+	seq = Embed(WithBytes(seq, seq.Bytes()[:Len(seq):Len(seq)]), offset, guest)
+	effect := Effect{Operation: "replace", Offset: offset, Deleted: length, Inserted: Len(guest)}
+	return txn.apply(effect, seq, replaceInverse(offset, Len(guest), removed))
+}
+
+// Rotate the sequence by the given amount as with the Rotate function.
+func (txn *Txn) Rotate(n int) Effect {
+	length := Len(txn.seq)
+	seq := txn.seq
+	if length > 0 {
+		seq = Rotate(seq, n)
+	}
+	effect := Effect{Operation: "rotate", Offset: n}
+	return txn.apply(effect, seq, func(p []byte) []byte {
+		if len(p) == 0 {
+			return p
+		}
+		m := ((n % len(p)) + len(p)) % len(p)
+		q := make([]byte, 0, len(p))
+		q = append(q, p[m:]...)
+		return append(q, p[:m]...)
+	})
+}
+
+// ReverseComplement reverse complements the sequence.
+func (txn *Txn) ReverseComplement() Effect {
+	seq := Reverse(Complement(txn.seq))
+	effect := Effect{Operation: "reverse complement"}
+	return txn.apply(effect, seq, func(p []byte) []byte {
+		return Reverse(Complement(New(nil, nil, p))).Bytes()
+	})
+}
+
+// WithFeatures replaces the feature table of the sequence.
+func (txn *Txn) WithFeatures(ff FeatureSlice) Effect {
+	seq := WithFeatures(txn.seq, ff)
+	effect := Effect{Operation: "edit features"}
+	return txn.apply(effect, seq, func(p []byte) []byte {
+		return p
+	})
+}
+
+// Undo reverts the last edit of the transaction, returning false if there
+// are no edits to revert.
+func (txn *Txn) Undo() bool {
+	if len(txn.ops) == 0 {
+		return false
+	}
+	op := txn.ops[len(txn.ops)-1]
+	txn.ops = txn.ops[:len(txn.ops)-1]
+	seq := WithBytes(txn.seq, op.inverse(txn.seq.Bytes()))
+	seq = WithFeatures(seq, op.ff)
+	txn.seq = WithInfo(seq, op.info)
+	return true
+}
+
+// Rollback reverts every edit of the transaction and returns the sequence
+// as it was when the transaction began.
+func (txn *Txn) Rollback() Sequence {
+	for txn.Undo() {
+	}
+	return txn.seq
+}
+
+// Commit discards the record of the edits in the transaction and returns the
+// edited sequence. The transaction may continue to be used, in which case
+// the committed state becomes the state to roll back to.
+func (txn *Txn) Commit() Sequence {
+	txn.ops = nil
+	return txn.seq
+}
@@ -0,0 +1,125 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func sampleTxnSequence() Sequence {
+	p := make([]byte, 400, 1000)
+	for i := range p {
+		p[i] = "atgc"[i%4]
+	}
+	ff := FeatureSlice{
+		NewFeature("source", Range(0, len(p)), Props{[]string{"mol_type", "Genomic DNA"}}),
+		sampleGeneFeature,
+		sampleCDSFeature,
+	}
+	return New(nil, ff, p)
+}
+
+func TestTxn(t *testing.T) {
+	guest := New(nil, nil, []byte("nnnn"))
+
+	edits := []struct {
+		name   string
+		apply  func(txn *Txn) Effect
+		effect Effect
+	}{
+		{"insert", func(txn *Txn) Effect { return txn.Insert(60, guest) }, Effect{
+			Operation: "insert", Offset: 60, Inserted: 4,
+			Features: FeatureEffect{Relocated: 3},
+		}},
+		{"delete", func(txn *Txn) Effect { return txn.Delete(300, 10) }, Effect{
+			Operation: "delete", Offset: 300, Deleted: 10,
+			Features: FeatureEffect{Relocated: 2},
+		}},
+		{"replace", func(txn *Txn) Effect { return txn.Replace(0, 2, guest) }, Effect{
+			Operation: "replace", Offset: 0, Deleted: 2, Inserted: 4,
+			Features: FeatureEffect{Relocated: 3},
+		}},
+		{"rotate", func(txn *Txn) Effect { return txn.Rotate(-7) }, Effect{
+			Operation: "rotate", Offset: -7,
+			Features: FeatureEffect{Relocated: 3},
+		}},
+		{"reverse complement", func(txn *Txn) Effect { return txn.ReverseComplement() }, Effect{
+			Operation: "reverse complement",
+			Features:  FeatureEffect{Relocated: 3},
+		}},
+		{"edit features", func(txn *Txn) Effect {
+			ff := txn.Sequence().Features()
+			gg := FeatureSlice{ff[0], ff[1], ff[2]}
+			gg[1].Props = Props{[]string{"locus_tag", "foo"}}
+			return txn.WithFeatures(gg[:2])
+		}, Effect{
+			Operation: "edit features",
+			Features:  FeatureEffect{Removed: 1, Modified: 1},
+		}},
+	}
+
+	in := sampleTxnSequence()
+	txn := Begin(in)
+	states := []Sequence{in}
+	for _, edit := range edits {
+		effect := edit.apply(txn)
+		testutils.Equals(t, effect, edit.effect)
+		states = append(states, txn.Sequence())
+	}
+
+	testutils.Equals(t, txn.Len(), len(edits))
+	ee := txn.Effects()
+	for i, edit := range edits {
+		testutils.Equals(t, ee[i], edit.effect)
+	}
+
+	for i := len(edits) - 1; i >= 0; i-- {
+		if !txn.Undo() {
+			t.Fatalf("Undo() = false after %q", edits[i].name)
+		}
+		if !Equal(txn.Sequence(), states[i]) {
+			t.Errorf("Undo() of %q did not restore the previous state", edits[i].name)
+		}
+	}
+	if txn.Undo() {
+		t.Error("Undo() = true for an empty transaction")
+	}
+
+	for _, edit := range edits {
+		edit.apply(txn)
+	}
+	out := txn.Rollback()
+	if !Equal(out, in) {
+		t.Error("Rollback() did not restore the original sequence")
+	}
+	if string(in.Bytes()) != string(sampleTxnSequence().Bytes()) {
+		t.Error("transaction modified the original sequence")
+	}
+
+	txn.Delete(0, 4)
+	committed := txn.Commit()
+	testutils.Equals(t, txn.Len(), 0)
+	txn.Insert(0, guest)
+	if !Equal(txn.Rollback(), committed) {
+		t.Error("Rollback() did not restore the committed sequence")
+	}
+
+	testutils.Panics(t, func() { txn.Delete(390, 20) })
+}
+
+func TestEffectString(t *testing.T) {
+	tests := []struct {
+		in  Effect
+		out string
+	}{
+		{Effect{Operation: "insert", Offset: 9, Inserted: 4}, "insert 4 bp at 10 (no features changed)"},
+		{Effect{Operation: "delete", Offset: 0, Deleted: 3, Features: FeatureEffect{Removed: 1, Relocated: 2}}, "delete 3 bp at 1 (features: 1 removed, 2 relocated)"},
+		{Effect{Operation: "replace", Offset: 4, Deleted: 2, Inserted: 3}, "replace 2 bp at 5 with 3 bp (no features changed)"},
+		{Effect{Operation: "rotate", Offset: -7, Features: FeatureEffect{Relocated: 1}}, "rotate by -7 bp (features: 1 relocated)"},
+		{Effect{Operation: "edit features", Features: FeatureEffect{Added: 1, Modified: 2}}, "edit features (features: 1 added, 2 modified)"},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, tt.in.String(), tt.out)
+	}
+}
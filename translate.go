@@ -0,0 +1,313 @@
+package gts
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CodonTable represents a genetic code as defined by the NCBI. The amino
+// acids and start codon flags are listed for the 64 codons in the order of
+// TTT, TTC, TTA, TTG, TCT, ..., GGG.
+type CodonTable struct {
+	ID     int
+	Name   string
+	AAs    string
+	Starts string
+}
+
+func baseIndex(c byte) int {
+	switch c {
+	case 'T', 't', 'U', 'u':
+		return 0
+	case 'C', 'c':
+		return 1
+	case 'A', 'a':
+		return 2
+	case 'G', 'g':
+		return 3
+	default:
+		return -1
+	}
+}
+
+func codonIndex(p []byte) int {
+	if len(p) != 3 {
+		return -1
+	}
+	index := 0
+	for _, c := range p {
+		i := baseIndex(c)
+		if i < 0 {
+			return -1
+		}
+		index = index*4 + i
+	}
+	return index
+}
+
+// Codon returns the amino acid encoded by the given codon. Codons containing
+// bases other than A, C, G, T, or U are translated as `X`.
+func (table CodonTable) Codon(p []byte) byte {
+	if i := codonIndex(p); i >= 0 {
+		return table.AAs[i]
+	}
+	return 'X'
+}
+
+// IsStart tests if the given codon is a start codon.
+func (table CodonTable) IsStart(p []byte) bool {
+	i := codonIndex(p)
+	return i >= 0 && table.Starts[i] == 'M'
+}
+
+// WithStarts returns a copy of the genetic code in which only the given
+// codons are start codons. Codons which cannot be interpreted are ignored.
+func (table CodonTable) WithStarts(codons ...string) CodonTable {
+	starts := []byte(strings.Repeat("-", 64))
+	for _, codon := range codons {
+		if i := codonIndex([]byte(codon)); i >= 0 {
+			starts[i] = 'M'
+		}
+	}
+	table.Starts = string(starts)
+	return table
+}
+
+// IsStop tests if the given codon is a stop codon.
+func (table CodonTable) IsStop(p []byte) bool {
+	return table.Codon(p) == '*'
+}
+
+// Translate the given nucleotide sequence codon by codon. Trailing bases
+// which do not form a complete codon are ignored.
+func (table CodonTable) Translate(p []byte) []byte {
+	q := make([]byte, len(p)/3)
+	for i := range q {
+		q[i] = table.Codon(p[i*3 : i*3+3])
+	}
+	return q
+}
+
+// CodonTables lists the genetic codes by their NCBI translation table ID.
+var CodonTables = map[int]CodonTable{
+	1: {
+		1, "Standard",
+		"FFLLSSSSYY**CC*WLLLLPPPPHHQQRRRRIIIMTTTTNNKKSSRRVVVVAAAADDEEGGGG",
+		"---M------**--*----M---------------M----------------------------",
+	},
+	2: {
+		2, "Vertebrate Mitochondrial",
+		"FFLLSSSSYY**CCWWLLLLPPPPHHQQRRRRIIMMTTTTNNKKSS**VVVVAAAADDEEGGGG",
+		"----------**--------------------MMMM----------**---M------------",
+	},
+	3: {
+		3, "Yeast Mitochondrial",
+		"FFLLSSSSYY**CCWWTTTTPPPPHHQQRRRRIIMMTTTTNNKKSSRRVVVVAAAADDEEGGGG",
+		"----------**----------------------MM---------------M------------",
+	},
+	4: {
+		4, "Mold, Protozoan, and Coelenterate Mitochondrial and Mycoplasma/Spiroplasma",
+		"FFLLSSSSYY**CCWWLLLLPPPPHHQQRRRRIIIMTTTTNNKKSSRRVVVVAAAADDEEGGGG",
+		"--MM------**-------M------------MMMM---------------M------------",
+	},
+	5: {
+		5, "Invertebrate Mitochondrial",
+		"FFLLSSSSYY**CCWWLLLLPPPPHHQQRRRRIIMMTTTTNNKKSSSSVVVVAAAADDEEGGGG",
+		"---M------**--------------------MMMM---------------M------------",
+	},
+	6: {
+		6, "Ciliate, Dasycladacean and Hexamita Nuclear",
+		"FFLLSSSSYYQQCC*WLLLLPPPPHHQQRRRRIIIMTTTTNNKKSSRRVVVVAAAADDEEGGGG",
+		"--------------*--------------------M----------------------------",
+	},
+	9: {
+		9, "Echinoderm and Flatworm Mitochondrial",
+		"FFLLSSSSYY**CCWWLLLLPPPPHHQQRRRRIIIMTTTTNNNKSSSSVVVVAAAADDEEGGGG",
+		"----------**-----------------------M---------------M------------",
+	},
+	11: {
+		11, "Bacterial, Archaeal and Plant Plastid",
+		"FFLLSSSSYY**CC*WLLLLPPPPHHQQRRRRIIIMTTTTNNKKSSRRVVVVAAAADDEEGGGG",
+		"---M------**--*----M------------MMMM---------------M------------",
+	},
+}
+
+// LookupCodonTable returns the genetic code for the given translation table
+// ID.
+func LookupCodonTable(id int) (CodonTable, error) {
+	table, ok := CodonTables[id]
+	if !ok {
+		ids := make([]int, 0, len(CodonTables))
+		for id := range CodonTables {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		return CodonTable{}, fmt.Errorf("unknown translation table %d: available tables are %v", id, ids)
+	}
+	return table, nil
+}
+
+// IsPseudo tests if the feature is flagged with a `/pseudo` or `/pseudogene`
+// qualifier. Such features are not expected to encode a functional product.
+func IsPseudo(f Feature) bool {
+	return f.Props.Has("pseudo") || f.Props.Has("pseudogene")
+}
+
+// LocationPartial returns the partiality of the 5' and 3' ends of the
+// location in the orientation of the location.
+func LocationPartial(loc Location) Partial {
+	switch v := loc.(type) {
+	case Ranged:
+		return v.Partial
+	case Complemented:
+		p := LocationPartial(v.Location)
+		return Partial{p.Partial3, p.Partial5}
+	case Joined:
+		if len(v) == 0 {
+			return Complete
+		}
+		return Partial{LocationPartial(v[0]).Partial5, LocationPartial(v[len(v)-1]).Partial3}
+	case Ordered:
+		if len(v) == 0 {
+			return Complete
+		}
+		return Partial{LocationPartial(v[0]).Partial5, LocationPartial(v[len(v)-1]).Partial3}
+	default:
+		return Complete
+	}
+}
+
+// TranslationTable returns the genetic code for the feature as given by the
+// `/transl_table` qualifier. The genetic code with the given default ID is
+// returned if the qualifier is absent.
+func TranslationTable(f Feature, id int) (CodonTable, error) {
+	if values := f.Props.Get("transl_table"); len(values) > 0 {
+		n, err := strconv.Atoi(values[0])
+		if err != nil {
+			return CodonTable{}, fmt.Errorf("cannot interpret %q as a translation table", values[0])
+		}
+		id = n
+	}
+	return LookupCodonTable(id)
+}
+
+// TranslateCDS returns the protein encoded by the CDS feature in the given
+// sequence. Translation starts at the offset given by `/codon_start` and
+// the first codon is translated as methionine if it is an alternative start
+// codon and the 5' end is complete. Codons designated by `/transl_except`
+// qualifiers are translated as the given amino acids, and the qualifiers are
+// ignored if any of them cannot be applied. A terminal stop codon is not
+// included. Regions in remote entries are located using resolve, and an
+// error is returned if they cannot be located.
+func TranslateCDS(f Feature, seq Sequence, table CodonTable, resolve ResolveFunc) ([]byte, error) {
+	sub, err := LocateRegion(f.Loc.Region(), seq, resolve)
+	if err != nil {
+		return nil, err
+	}
+	p := sub.Bytes()
+	exceptions, _ := translExceptCodons(f, len(p))
+	offset := CodonStart(f)
+	if offset > len(p) {
+		offset = len(p)
+	}
+	p = p[offset:]
+
+	q := table.Translate(p)
+	if len(q) > 0 && offset == 0 && !LocationPartial(f.Loc).Partial5 && table.IsStart(p[:3]) {
+		q[0] = 'M'
+	}
+	for i, c := range exceptions {
+		if i < len(q) {
+			q[i] = c
+		}
+	}
+	if len(q) > 0 && q[len(q)-1] == '*' {
+		q = q[:len(q)-1]
+	}
+	return q, nil
+}
+
+// TranslationValue returns the value of a `/translation` qualifier for the
+// given protein sequence, broken into lines as they would appear in a
+// GenBank flat file.
+func TranslationValue(p []byte) string {
+	lines := []string{}
+	for width := 44; len(p) > width; width = 58 {
+		lines = append(lines, string(p[:width]))
+		p = p[width:]
+	}
+	lines = append(lines, string(p))
+	return strings.Join(lines, "\n")
+}
+
+// Translation problem constants.
+const (
+	TranslationLength       = "length is not a multiple of three"
+	TranslationNoStart      = "missing start codon"
+	TranslationNoStop       = "missing stop codon"
+	TranslationInternalStop = "internal stop codon"
+	TranslationMismatch     = "translation differs from /translation"
+	TranslationPseudo       = "pseudo feature has /translation"
+	TranslationException    = "/transl_except cannot be applied"
+)
+
+// CheckTranslation checks the translation of the CDS feature against the
+// given sequence. A CDS with complete ends must span a whole number of
+// codons, begin with a start codon, and end with a stop codon. Stop codons
+// must not appear elsewhere and the `/translation` qualifier, if present,
+// must match the translated sequence. Codons designated by `/transl_except`
+// qualifiers are translated as the given amino acids, so that selenocysteine,
+// pyrrolysine, and programmed readthrough codons are not reported as internal
+// stop codons. A trailing incomplete codon designated as a stop codon, as in
+// stop codons completed by the addition of a poly(A) tail, satisfies the
+// length and stop codon requirements. Features flagged as pseudo are checked
+// as any other feature: use IsPseudo to exempt them if desired. Regions in
+// remote entries are located using resolve, and an error is returned if they
+// cannot be located.
+func CheckTranslation(f Feature, seq Sequence, table CodonTable, resolve ResolveFunc) ([]string, error) {
+	sub, err := LocateRegion(f.Loc.Region(), seq, resolve)
+	if err != nil {
+		return nil, err
+	}
+	problems := []string{}
+	partial := LocationPartial(f.Loc)
+	p := sub.Bytes()
+	offset := CodonStart(f)
+
+	exceptions, err := translExceptCodons(f, len(p))
+	if err != nil {
+		problems = append(problems, TranslationException)
+	}
+	terminated := exceptions[(len(p)-offset+2)/3-1] == '*'
+
+	if !partial.Partial5 && !partial.Partial3 && !terminated && (len(p)-offset)%3 != 0 {
+		problems = append(problems, TranslationLength)
+	}
+
+	if len(p) >= offset+3 {
+		if !partial.Partial5 && !table.IsStart(p[offset:offset+3]) {
+			problems = append(problems, TranslationNoStart)
+		}
+		n := offset + (len(p)-offset)/3*3
+		if !partial.Partial3 && !terminated && !table.IsStop(p[n-3:n]) {
+			problems = append(problems, TranslationNoStop)
+		}
+	}
+
+	q, err := TranslateCDS(f, seq, table, resolve)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(q, '*') >= 0 {
+		problems = append(problems, TranslationInternalStop)
+	}
+
+	if values := f.Props.Get("translation"); len(values) > 0 && strings.Join(strings.Fields(values[0]), "") != string(q) {
+		problems = append(problems, TranslationMismatch)
+	}
+
+	return problems, nil
+}
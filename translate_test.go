@@ -0,0 +1,142 @@
+package gts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestCodonTable(t *testing.T) {
+	for id, table := range CodonTables {
+		testutils.Equals(t, table.ID, id)
+		testutils.Equals(t, len(table.AAs), 64)
+		testutils.Equals(t, len(table.Starts), 64)
+	}
+
+	std, err := LookupCodonTable(1)
+	if err != nil {
+		t.Fatalf("LookupCodonTable(1): %v", err)
+	}
+	testutils.Equals(t, string(std.Translate([]byte("ATGuuuTAAgg"))), "MF*")
+	testutils.Equals(t, std.Codon([]byte("anc")), byte('X'))
+	testutils.Equals(t, std.IsStart([]byte("atg")), true)
+	testutils.Equals(t, std.IsStart([]byte("gtg")), false)
+	testutils.Equals(t, std.IsStop([]byte("tga")), true)
+
+	mito, _ := LookupCodonTable(2)
+	testutils.Equals(t, mito.Codon([]byte("tga")), byte('W'))
+	testutils.Equals(t, mito.IsStop([]byte("aga")), true)
+
+	if _, err := LookupCodonTable(7); err == nil {
+		t.Errorf("LookupCodonTable(7) expected error")
+	}
+}
+
+func TestIsPseudo(t *testing.T) {
+	testutils.Equals(t, IsPseudo(NewFeature("CDS", Range(0, 9), Props{})), false)
+	testutils.Equals(t, IsPseudo(NewFeature("CDS", Range(0, 9), Props{[]string{"pseudo"}})), true)
+	testutils.Equals(t, IsPseudo(NewFeature("CDS", Range(0, 9), Props{[]string{"pseudogene", "unitary"}})), true)
+}
+
+func TestLocationPartial(t *testing.T) {
+	tests := []struct {
+		in  Location
+		out Partial
+	}{
+		{Range(0, 9), Complete},
+		{PartialRange(0, 9, Partial5), Partial5},
+		{PartialRange(0, 9, Partial5).Complement(), Partial3},
+		{Join(PartialRange(0, 3, Partial5), PartialRange(6, 9, Partial3)), PartialBoth},
+		{Join(PartialRange(0, 3, Partial5), Range(6, 9)).Complement(), Partial3},
+		{Point(3), Complete},
+	}
+
+	for _, tt := range tests {
+		testutils.Equals(t, LocationPartial(tt.in), tt.out)
+	}
+}
+
+func TestTranslationTable(t *testing.T) {
+	table, err := TranslationTable(NewFeature("CDS", Range(0, 9), Props{}), 11)
+	testutils.Equals(t, err, nil)
+	testutils.Equals(t, table.ID, 11)
+
+	table, err = TranslationTable(NewFeature("CDS", Range(0, 9), Props{[]string{"transl_table", "2"}}), 11)
+	testutils.Equals(t, err, nil)
+	testutils.Equals(t, table.ID, 2)
+
+	if _, err := TranslationTable(NewFeature("CDS", Range(0, 9), Props{[]string{"transl_table", "x"}}), 1); err == nil {
+		t.Errorf("TranslationTable expected error for malformed /transl_table")
+	}
+}
+
+func TestTranslateCDS(t *testing.T) {
+	seq := New(nil, nil, []byte("ccatgaaatttgggtaaccgtgaaataacc"))
+	std, bac := CodonTables[1], CodonTables[11]
+
+	tests := []struct {
+		in    Feature
+		table CodonTable
+		out   string
+		probs []string
+	}{
+		{NewFeature("CDS", Range(2, 17), Props{}), std, "MKFG", []string{}},
+		{NewFeature("CDS", Range(2, 17), Props{[]string{"translation", "MKFG"}}), std, "MKFG", []string{}},
+		{NewFeature("CDS", Range(2, 17), Props{[]string{"translation", "MK\nFG"}}), std, "MKFG", []string{}},
+		{NewFeature("CDS", Range(2, 17), Props{[]string{"translation", "MKFW"}}), std, "MKFG", []string{TranslationMismatch}},
+		{NewFeature("CDS", Range(19, 28), Props{}), std, "VK", []string{TranslationNoStart}},
+		{NewFeature("CDS", Range(19, 28), Props{}), bac, "MK", []string{}},
+		{NewFeature("CDS", PartialRange(20, 28, Partial5), Props{[]string{"codon_start", "3"}}), std, "K", []string{}},
+		{NewFeature("CDS", Range(2, 16), Props{}), std, "MKFG", []string{TranslationLength, TranslationNoStop}},
+		{NewFeature("CDS", PartialRange(2, 14, Partial3), Props{}), std, "MKFG", []string{}},
+		{NewFeature("CDS", Range(5, 17), Props{}), std, "KFG", []string{TranslationNoStart}},
+		{NewFeature("CDS", Range(2, 23), Props{}), std, "MKFG*P", []string{TranslationInternalStop}},
+		{NewFeature("CDS", Range(2, 17).Complement(), Props{}), std, "LPKFH", []string{TranslationNoStart, TranslationNoStop}},
+		{NewFeature("CDS", Range(2, 23), Props{[]string{"pseudo"}}), std, "MKFG*P", []string{TranslationInternalStop}},
+		{NewFeature("CDS", Range(2, 23), Props{[]string{"transl_except", "(pos:15..17,aa:Sec)"}}), std, "MKFGUP", []string{}},
+		{NewFeature("CDS", Range(2, 23), Props{[]string{"transl_except", "(pos:15..17,aa:OTHER)"}}), std, "MKFGXP", []string{}},
+		{NewFeature("CDS", Range(2, 23), Props{[]string{"transl_except", "(pos:4..6,aa:Sec)"}}), std, "MKFG*P", []string{TranslationException, TranslationInternalStop}},
+		{NewFeature("CDS", Range(2, 16), Props{[]string{"transl_except", "(pos:15..16,aa:TERM)"}}), std, "MKFG", []string{}},
+		{NewFeature("CDS", Range(2, 16), Props{[]string{"transl_except", "(pos:16,aa:TERM)"}}), std, "MKFG", []string{TranslationException, TranslationLength, TranslationNoStop}},
+	}
+
+	for _, tt := range tests {
+		q, err := TranslateCDS(tt.in, seq, tt.table, nil)
+		testutils.Equals(t, err, nil)
+		testutils.Equals(t, string(q), tt.out)
+		probs, err := CheckTranslation(tt.in, seq, tt.table, nil)
+		testutils.Equals(t, err, nil)
+		testutils.Equals(t, probs, tt.probs)
+	}
+}
+
+func TestTranslateCDSRemote(t *testing.T) {
+	seq := New(nil, nil, []byte("ccatgaaatttgggtaaccgtgaaataacc"))
+	remote := New(nil, nil, []byte("tttgggtaa"))
+	loc := Join(Range(2, 8), RemoteLocation{"J00194.1", Range(0, 9)})
+	f := NewFeature("CDS", loc, Props{})
+
+	if _, err := TranslateCDS(f, seq, CodonTables[1], nil); err == nil {
+		t.Errorf("TranslateCDS expected error without a resolver")
+	}
+	if _, err := CheckTranslation(f, seq, CodonTables[1], nil); err == nil {
+		t.Errorf("CheckTranslation expected error without a resolver")
+	}
+
+	resolve := func(accession string) (Sequence, error) {
+		return remote, nil
+	}
+	q, err := TranslateCDS(f, seq, CodonTables[1], resolve)
+	testutils.Equals(t, err, nil)
+	testutils.Equals(t, string(q), "MKFG")
+	probs, err := CheckTranslation(f, seq, CodonTables[1], resolve)
+	testutils.Equals(t, err, nil)
+	testutils.Equals(t, probs, []string{})
+}
+
+func TestTranslationValue(t *testing.T) {
+	p := []byte(strings.Repeat("M", 110))
+	testutils.Equals(t, TranslationValue(p[:10]), strings.Repeat("M", 10))
+	testutils.Equals(t, TranslationValue(p), strings.Repeat("M", 44)+"\n"+strings.Repeat("M", 58)+"\n"+strings.Repeat("M", 8))
+}
@@ -0,0 +1,99 @@
+package gts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TranslException represents the value of a `/transl_except` qualifier, which
+// describes a codon translated as an amino acid other than the one given by
+// the genetic code. Such exceptions include selenocysteine and pyrrolysine
+// encoded by stop codons, programmed stop codon readthrough, and stop codons
+// completed by the addition of a poly(A) tail.
+type TranslException struct {
+	Location  Location
+	AminoAcid string
+}
+
+// translExceptCodes maps the amino acid abbreviations allowed in a
+// `/transl_except` qualifier to their one letter codes.
+var translExceptCodes = map[string]byte{
+	"Ala": 'A', "Arg": 'R', "Asn": 'N', "Asp": 'D', "Cys": 'C',
+	"Gln": 'Q', "Glu": 'E', "Gly": 'G', "His": 'H', "Ile": 'I',
+	"Leu": 'L', "Lys": 'K', "Met": 'M', "Phe": 'F', "Pro": 'P',
+	"Ser": 'S', "Thr": 'T', "Trp": 'W', "Tyr": 'Y', "Val": 'V',
+	"Sec": 'U', "Pyl": 'O', "Asx": 'B', "Glx": 'Z', "Xle": 'J',
+	"fMet": 'M', "TERM": '*', "OTHER": 'X',
+}
+
+// AsTranslException interprets the given string as a TranslException. The
+// string should be formatted as `(pos:<location>,aa:<amino_acid>)`.
+func AsTranslException(s string) (TranslException, error) {
+	v := strings.TrimSpace(s)
+	if !strings.HasPrefix(v, "(pos:") || !strings.HasSuffix(v, ")") {
+		return TranslException{}, fmt.Errorf("cannot interpret %q as a translation exception: expected `(pos:<location>,aa:<amino_acid>)`", s)
+	}
+	v = v[5 : len(v)-1]
+
+	i := strings.LastIndex(v, ",aa:")
+	if i < 0 {
+		return TranslException{}, fmt.Errorf("cannot interpret %q as a translation exception: missing `aa` field", s)
+	}
+
+	loc, err := AsLocation(v[:i])
+	if err != nil {
+		return TranslException{}, fmt.Errorf("cannot interpret %q as a translation exception: %v", s, err)
+	}
+
+	aa := v[i+4:]
+	if _, ok := translExceptCodes[aa]; !ok {
+		return TranslException{}, fmt.Errorf("cannot interpret %q as a translation exception: unknown amino acid %q", s, aa)
+	}
+
+	return TranslException{loc, aa}, nil
+}
+
+// String satisfies the fmt.Stringer interface.
+func (e TranslException) String() string {
+	return fmt.Sprintf("(pos:%s,aa:%s)", e.Location, e.AminoAcid)
+}
+
+// Code returns the one letter code of the amino acid of the exception, where
+// `*` denotes a stop and `X` denotes any other amino acid.
+func (e TranslException) Code() byte {
+	if c, ok := translExceptCodes[e.AminoAcid]; ok {
+		return c
+	}
+	return 'X'
+}
+
+// translExceptCodons returns the one letter codes of the `/transl_except`
+// qualifiers of the CDS feature in the given sequence, keyed by the index of
+// the codon counted from the offset given by `/codon_start`. An error is
+// returned if an exception cannot be interpreted or does not designate a
+// codon within the feature. An exception designating the trailing bases of
+// the feature is keyed by the index following the last complete codon.
+func translExceptCodons(f Feature, length int) (map[int]byte, error) {
+	values := f.Props.Get("transl_except")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	offset := CodonStart(f)
+	codons := make(map[int]byte, len(values))
+	for _, value := range values {
+		e, err := AsTranslException(value)
+		if err != nil {
+			return nil, err
+		}
+		start, end, ok := splicedRange(f.Loc, e.Location)
+		if !ok || start < offset || (start-offset)%3 != 0 {
+			return nil, fmt.Errorf("translation exception %s is not a codon within %s %s", e.Location, f.Key, f.Loc)
+		}
+		if end-start != 3 && !(end == length && end-start < 3) {
+			return nil, fmt.Errorf("translation exception %s is not a codon within %s %s", e.Location, f.Key, f.Loc)
+		}
+		codons[(start-offset)/3] = e.Code()
+	}
+	return codons, nil
+}
@@ -0,0 +1,38 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+func TestTranslException(t *testing.T) {
+	tests := []struct {
+		in   string
+		out  TranslException
+		code byte
+	}{
+		{"(pos:213..215,aa:Trp)", TranslException{Range(212, 215), "Trp"}, 'W'},
+		{"(pos:complement(4156..4158),aa:Sec)", TranslException{Range(4155, 4158).Complement(), "Sec"}, 'U'},
+		{"(pos:1017,aa:TERM)", TranslException{Point(1016), "TERM"}, '*'},
+		{"(pos:2000..2001,aa:OTHER)", TranslException{Range(1999, 2001), "OTHER"}, 'X'},
+	}
+
+	for _, tt := range tests {
+		e, err := AsTranslException(tt.in)
+		if err != nil {
+			t.Errorf("AsTranslException(%q): %v", tt.in, err)
+			continue
+		}
+		testutils.Equals(t, e.Location.String(), tt.out.Location.String())
+		testutils.Equals(t, e.AminoAcid, tt.out.AminoAcid)
+		testutils.Equals(t, e.String(), tt.in)
+		testutils.Equals(t, e.Code(), tt.code)
+	}
+
+	for _, in := range []string{"", "pos:213..215,aa:Trp", "(pos:213..215)", "(pos:foo,aa:Trp)", "(pos:213..215,aa:Foo)"} {
+		if _, err := AsTranslException(in); err == nil {
+			t.Errorf("expected error in AsTranslException(%q)", in)
+		}
+	}
+}
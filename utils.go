@@ -40,3 +40,8 @@ func Max(i, j int) int {
 	}
 	return j
 }
+
+// Mod returns the non-negative remainder of x divided by n.
+func Mod(x, n int) int {
+	return (x%n + n) % n
+}
@@ -77,3 +77,22 @@ func TestMax(t *testing.T) {
 		}
 	}
 }
+
+var modTests = []struct {
+	x, n int
+	out  int
+}{
+	{7, 3, 1},
+	{-7, 3, 2},
+	{6, 3, 0},
+	{-6, 3, 0},
+}
+
+func TestMod(t *testing.T) {
+	for _, tt := range modTests {
+		out := Mod(tt.x, tt.n)
+		if out != tt.out {
+			t.Errorf("Mod(%d, %d) = %d, want %d", tt.x, tt.n, out, tt.out)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package gts
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Variant represents a difference between a reference sequence and a query
+// sequence. Pos and Query are the offsets of the variant in the reference and
+// query sequences, and Ref and Alt are the bases of the reference replaced by
+// the bases of the query. Either of Ref or Alt is empty for an insertion or a
+// deletion.
+type Variant struct {
+	Pos   int
+	Query int
+	Ref   []byte
+	Alt   []byte
+}
+
+// Variant types.
+const (
+	VariantSNV       = "SNV"
+	VariantMNV       = "MNV"
+	VariantInsertion = "insertion"
+	VariantDeletion  = "deletion"
+	VariantComplex   = "complex"
+)
+
+// Type returns the type of the variant: a single or multiple nucleotide
+// variant for substitutions, an insertion, a deletion, or a complex variant
+// replacing bases with a different number of bases.
+func (v Variant) Type() string {
+	switch {
+	case len(v.Ref) == 0:
+		return VariantInsertion
+	case len(v.Alt) == 0:
+		return VariantDeletion
+	case len(v.Ref) != len(v.Alt):
+		return VariantComplex
+	case len(v.Ref) == 1:
+		return VariantSNV
+	default:
+		return VariantMNV
+	}
+}
+
+// Location returns the location of the variant in the reference sequence,
+// which is the position between two bases for an insertion.
+func (v Variant) Location() Location {
+	switch len(v.Ref) {
+	case 0:
+		return Between(v.Pos)
+	case 1:
+		return Point(v.Pos)
+	default:
+		return Range(v.Pos, v.Pos+len(v.Ref))
+	}
+}
+
+// maxVariantCells is the maximum number of cells of the alignment matrix
+// used to resolve the differences between a pair of anchors. Larger gaps are
+// reported as a single variant.
+const maxVariantCells = 1 << 22
+
+type variantAnchor struct {
+	ref, query int
+}
+
+// uniqueKmers returns the offsets of the k-mers which occur exactly once in
+// the given sequence.
+func uniqueKmers(p []byte, k int) map[string]int {
+	index := make(map[string]int)
+	for i := 0; i+k <= len(p); i++ {
+		key := string(p[i : i+k])
+		if _, ok := index[key]; ok {
+			index[key] = -1
+		} else {
+			index[key] = i
+		}
+	}
+	return index
+}
+
+// variantAnchors returns the longest collinear chain of k-mers occurring
+// exactly once in each of the sequences.
+func variantAnchors(ref, query []byte, k int) []variantAnchor {
+	refIndex := uniqueKmers(ref, k)
+	queryIndex := uniqueKmers(query, k)
+
+	aa := []variantAnchor{}
+	for j := 0; j+k <= len(query); j++ {
+		key := string(query[j : j+k])
+		if queryIndex[key] < 0 {
+			continue
+		}
+		if i, ok := refIndex[key]; ok && i >= 0 {
+			aa = append(aa, variantAnchor{i, j})
+		}
+	}
+
+	// Find the longest chain increasing in both sequences.
+	tails, prev := []int{}, make([]int, len(aa))
+	for n, a := range aa {
+		l := sort.Search(len(tails), func(m int) bool {
+			return aa[tails[m]].ref >= a.ref
+		})
+		prev[n] = -1
+		if l > 0 {
+			prev[n] = tails[l-1]
+		}
+		if l == len(tails) {
+			tails = append(tails, n)
+		} else {
+			tails[l] = n
+		}
+	}
+
+	chain := make([]variantAnchor, len(tails))
+	for i, n := len(tails)-1, -1; i >= 0; i-- {
+		if n < 0 {
+			n = tails[len(tails)-1]
+		}
+		chain[i] = aa[n]
+		n = prev[n]
+	}
+	return chain
+}
+
+// alignVariants computes the variants between the given pieces of the
+// reference and query sequences starting at the given offsets using the edit
+// distance, preferring substitutions over insertions and deletions.
+func alignVariants(ref, query []byte, pos, qpos int) []Variant {
+	n, m := len(ref), len(query)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	if n == 0 || m == 0 || n*m > maxVariantCells {
+		return []Variant{{pos, qpos, ref, query}}
+	}
+
+	w := m + 1
+	dp := make([]int, (n+1)*w)
+	for i := 0; i <= n; i++ {
+		dp[i*w] = i
+	}
+	for j := 0; j <= m; j++ {
+		dp[j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := dp[(i-1)*w+j-1]
+			if ref[i-1] != query[j-1] {
+				cost++
+			}
+			cost = Min(cost, dp[(i-1)*w+j]+1)
+			cost = Min(cost, dp[i*w+j-1]+1)
+			dp[i*w+j] = cost
+		}
+	}
+
+	// Trace back the alignment, marking the matching pairs of bases.
+	matches := []variantAnchor{{n, m}}
+	for i, j := n, m; i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && ref[i-1] == query[j-1] && dp[i*w+j] == dp[(i-1)*w+j-1]:
+			i, j = i-1, j-1
+			matches = append(matches, variantAnchor{i, j})
+		case i > 0 && j > 0 && dp[i*w+j] == dp[(i-1)*w+j-1]+1:
+			i, j = i-1, j-1
+		case i > 0 && dp[i*w+j] == dp[(i-1)*w+j]+1:
+			i--
+		default:
+			j--
+		}
+	}
+
+	vv := []Variant{}
+	x, y := 0, 0
+	for k := len(matches) - 1; k >= 0; k-- {
+		a := matches[k]
+		if a.ref > x || a.query > y {
+			vv = append(vv, Variant{pos + x, qpos + y, ref[x:a.ref], query[y:a.query]})
+		}
+		x, y = a.ref+1, a.query+1
+	}
+	return vv
+}
+
+// normalizeVariant shifts an insertion or a deletion to the leftmost
+// equivalent position which does not precede the given lower bound.
+func normalizeVariant(v Variant, ref []byte, lower int) Variant {
+	switch {
+	case len(v.Ref) == 0 && len(v.Alt) > 0:
+		alt := append([]byte{}, v.Alt...)
+		for v.Pos > lower && v.Query > 0 && ref[v.Pos-1] == alt[len(alt)-1] {
+			alt = append([]byte{ref[v.Pos-1]}, alt[:len(alt)-1]...)
+			v.Pos, v.Query = v.Pos-1, v.Query-1
+		}
+		v.Alt = alt
+	case len(v.Alt) == 0 && len(v.Ref) > 0:
+		for v.Pos > lower && v.Query > 0 && ref[v.Pos-1] == ref[v.Pos+len(v.Ref)-1] {
+			v.Pos, v.Query = v.Pos-1, v.Query-1
+		}
+		v.Ref = ref[v.Pos : v.Pos+len(v.Ref)]
+	}
+	return v
+}
+
+// variantRotation returns the offset of the query sequence corresponding to
+// the origin of a circular reference sequence, which is given by the first
+// k-mer of the reference occurring exactly once in each sequence.
+func variantRotation(ref, query []byte, k int) int {
+	refIndex := uniqueKmers(ref, k)
+	queryIndex := uniqueKmers(query, k)
+	for i := 0; i+k <= len(ref); i++ {
+		key := string(ref[i : i+k])
+		if j, ok := queryIndex[key]; ok && j >= 0 && refIndex[key] == i {
+			return ((j-i)%len(query) + len(query)) % len(query)
+		}
+	}
+	return 0
+}
+
+// FindVariants computes the variants of the query sequence against the
+// reference sequence. The sequences are anchored by the longest collinear
+// chain of k-mers occurring exactly once in each sequence, and the bases in
+// between the anchors are aligned to find the substitutions, insertions, and
+// deletions. Insertions and deletions are shifted to the leftmost equivalent
+// position. If the reference is circular, the query is first rotated so that
+// the origins of the sequences agree, and the offsets in the query are given
+// relative to the origin of the query. The comparison ignores the case of
+// the bases.
+func FindVariants(ref, query []byte, k int, circular bool) []Variant {
+	ref, query = bytes.ToLower(ref), bytes.ToLower(query)
+
+	rotation := 0
+	if circular && k > 0 {
+		rotation = variantRotation(ref, query, k)
+		query = append(append([]byte{}, query[rotation:]...), query[:rotation]...)
+	}
+
+	chain := []variantAnchor{}
+	if k > 0 {
+		chain = variantAnchors(ref, query, k)
+	}
+	chain = append(chain, variantAnchor{len(ref), len(query)})
+
+	vv := []Variant{}
+	x, y := 0, 0
+	for i, a := range chain {
+		// Skip the anchors overlapping the previous anchor, leaving the
+		// bases to be compared up to the next anchor.
+		if a.ref < x || a.query < y {
+			continue
+		}
+		if a.ref-x == a.query-y && bytes.Equal(ref[x:a.ref], query[y:a.query]) {
+			x, y = a.ref, a.query
+		} else {
+			vv = append(vv, alignVariants(ref[x:a.ref], query[y:a.query], x, y)...)
+		}
+		// Skip over the k-mer, except for the terminal anchor.
+		if i < len(chain)-1 {
+			x, y = a.ref+k, a.query+k
+		}
+	}
+
+	lower := 0
+	for i, v := range vv {
+		v = normalizeVariant(v, ref, lower)
+		v.Query = (v.Query + rotation) % Max(1, len(query))
+		vv[i] = v
+		lower = v.Pos + len(v.Ref) + 1
+	}
+	return vv
+}
@@ -0,0 +1,62 @@
+package gts
+
+import (
+	"testing"
+
+	"github.com/go-gts/gts/internal/testutils"
+)
+
+const variantTestRef = "acgtacgatcgatcgtagctagctagctgatcgatcgtacgtagctagctgatcgtagctgatcgtacgatgctagcttttgatcgtagc"
+
+var variantTypeTests = []struct {
+	in  Variant
+	typ string
+	loc Location
+}{
+	{Variant{4, 4, []byte("a"), []byte("t")}, VariantSNV, Point(4)},
+	{Variant{4, 4, []byte("ac"), []byte("tg")}, VariantMNV, Range(4, 6)},
+	{Variant{4, 4, nil, []byte("tg")}, VariantInsertion, Between(4)},
+	{Variant{4, 4, []byte("ac"), nil}, VariantDeletion, Range(4, 6)},
+	{Variant{4, 4, []byte("ac"), []byte("t")}, VariantComplex, Range(4, 6)},
+}
+
+func TestVariantType(t *testing.T) {
+	for _, tt := range variantTypeTests {
+		testutils.Equals(t, tt.in.Type(), tt.typ)
+		testutils.Equals(t, tt.in.Location(), tt.loc)
+	}
+}
+
+func TestFindVariants(t *testing.T) {
+	ref := []byte(variantTestRef)
+	query := []byte(variantTestRef[:10] + "T" + variantTestRef[11:40] + variantTestRef[43:60] + "ggg" + variantTestRef[60:80] + "a" + variantTestRef[81:])
+
+	vv := []Variant{
+		{10, 10, []byte("g"), []byte("t")},
+		{40, 40, []byte("gta"), []byte{}},
+		{60, 57, []byte{}, []byte("ggg")},
+		{80, 80, []byte("t"), []byte("a")},
+	}
+	testutils.Equals(t, FindVariants(ref, query, 8, false), vv)
+	testutils.Equals(t, FindVariants(ref, ref, 8, false), []Variant{})
+
+	// Without anchors, the whole sequences are aligned.
+	testutils.Equals(t, FindVariants(ref, query, 0, false), vv)
+
+	// The query of a circular reference may start anywhere.
+	rotated := append(append([]byte{}, query[30:]...), query[:30]...)
+	out := FindVariants(ref, rotated, 8, true)
+	testutils.Equals(t, len(out), len(vv))
+	for i, v := range out {
+		testutils.Equals(t, v.Pos, vv[i].Pos)
+		testutils.Equals(t, v.Query, (vv[i].Query-30+len(query))%len(query))
+	}
+
+	// Deletions in a repeat are shifted to the leftmost position.
+	testutils.Equals(t, FindVariants([]byte("ccgaaaatcc"), []byte("ccgaaatcc"), 0, false), []Variant{
+		{3, 3, []byte("a"), []byte{}},
+	})
+	testutils.Equals(t, FindVariants([]byte("ccgacacatcc"), []byte("ccgacacacatcc"), 0, false), []Variant{
+		{3, 3, []byte{}, []byte("ac")},
+	})
+}